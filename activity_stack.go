@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ActivityStackEntry is one activity record in the task back-stack
+type ActivityStackEntry struct {
+	PackageName string `json:"packageName"`
+	Activity    string `json:"activity"`
+	TaskID      int    `json:"taskId"`
+	Resumed     bool   `json:"resumed"`
+}
+
+// GetActivityStack returns the activity back-stack, top to bottom, as reported by
+// `dumpsys activity activities`
+func (a *App) GetActivityStack(deviceId string) ([]ActivityStackEntry, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	cmd := a.newAdbCommand(ctx, "-s", deviceId, "shell", "dumpsys", "activity", "activities")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run dumpsys activity: %w", err)
+	}
+
+	entries := parseActivityStack(string(output))
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no activities found in dumpsys output")
+	}
+	return entries, nil
+}
+
+var (
+	// "* Hist #1: ActivityRecord{a1b2c3 u0 com.example/.DetailActivity t123}" (Android 10+ prefixes "*",
+	// Android 12+ may pad "Hist  #1", Android 14 appends " f}" flags after the task id)
+	activityHistLine = regexp.MustCompile(`Hist\s*#\d+:\s*ActivityRecord\{\S+\s+u\d+\s+([^/\s]+)/(\S+)\s+t(-?\d+)`)
+	// "mResumedActivity: ActivityRecord{...}" (<= 9), "ResumedActivity: ActivityRecord{...}" (10/11),
+	// "topResumedActivity=ActivityRecord{...}" (10+). The name is anchored so that
+	// "mLastResumedActivity: ..." (the previous, no longer resumed activity) doesn't match.
+	activityResumedLine = regexp.MustCompile(`(?:^|\s)(?:mResumedActivity|ResumedActivity|topResumedActivity)[:=]\s*ActivityRecord\{\S+\s+u\d+\s+([^/\s]+)/(\S+)\s+t(-?\d+)`)
+)
+
+// parseActivityStack extracts the "Hist #N" records in display order. The surrounding
+// task/stack headers changed shape on nearly every release (TaskRecord, Task, RootTask),
+// so entries are taken from the ActivityRecord lines themselves, which carry the task id.
+func parseActivityStack(output string) []ActivityStackEntry {
+	var entries []ActivityStackEntry
+	resumed := make(map[string]bool)
+
+	for _, line := range strings.Split(output, "\n") {
+		if m := activityResumedLine.FindStringSubmatch(line); m != nil {
+			resumed[m[1]+"/"+m[2]+"#"+m[3]] = true
+			continue
+		}
+		m := activityHistLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		taskID, _ := strconv.Atoi(m[3])
+		entries = append(entries, ActivityStackEntry{
+			PackageName: m[1],
+			Activity:    m[2],
+			TaskID:      taskID,
+		})
+	}
+
+	for i := range entries {
+		e := &entries[i]
+		e.Resumed = resumed[e.PackageName+"/"+e.Activity+"#"+strconv.Itoa(e.TaskID)]
+	}
+	return entries
+}
+
+// ForegroundActivity is the activity the user currently sees
+type ForegroundActivity struct {
+	PackageName string `json:"packageName"`
+	Activity    string `json:"activity"`
+	DisplayID   int    `json:"displayId"`
+}
+
+var (
+	activityDisplayHeader = regexp.MustCompile(`^\s*Display #(\d+)`)
+	topFocusedDisplayLine = regexp.MustCompile(`mTopFocusedDisplayId=(-?\d+)`)
+	// "mCurrentFocus=Window{ab3a179 u0 com.example.app/com.example.app.MainActivity}"
+	currentFocusActivityLine = regexp.MustCompile(`mCurrentFocus=Window\{\S+\s+u\d+\s+([^/\s}]+)/([^\s}]+)\}`)
+)
+
+// GetForegroundApp returns the resumed activity of the focused display. Devices
+// with several displays (foldables, cars, desktop mode) resume one activity per
+// display, so the focused display is looked up in window manager state first.
+func (a *App) GetForegroundApp(deviceId string) (ForegroundActivity, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return ForegroundActivity{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "shell",
+		"dumpsys activity activities; dumpsys window | grep -E 'mTopFocusedDisplayId|mCurrentFocus'")
+	if err != nil && len(out) == 0 {
+		return ForegroundActivity{}, fmt.Errorf("failed to run dumpsys activity: %w", err)
+	}
+
+	fg, ok := parseForegroundActivity(string(out))
+	if !ok {
+		return ForegroundActivity{}, fmt.Errorf("no activity is resumed (the screen may be off or locked)")
+	}
+	return fg, nil
+}
+
+// parseForegroundActivity picks the resumed activity of the focused display from
+// `dumpsys activity activities` followed by the grep'd window lines. Without a focused
+// display id the first resumed activity wins; without any, the focused window is used.
+func parseForegroundActivity(output string) (ForegroundActivity, bool) {
+	focusedDisplay := -1
+	if m := topFocusedDisplayLine.FindStringSubmatch(output); m != nil {
+		focusedDisplay, _ = strconv.Atoi(m[1])
+	}
+
+	var first *ForegroundActivity
+	display := 0
+	for _, line := range strings.Split(output, "\n") {
+		if m := activityDisplayHeader.FindStringSubmatch(line); m != nil {
+			display, _ = strconv.Atoi(m[1])
+			continue
+		}
+		m := activityResumedLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		fg := ForegroundActivity{PackageName: m[1], Activity: m[2], DisplayID: display}
+		if display == focusedDisplay {
+			return fg, true
+		}
+		if first == nil {
+			first = &fg
+		}
+	}
+	if first != nil {
+		return *first, true
+	}
+
+	if m := currentFocusActivityLine.FindStringSubmatch(output); m != nil {
+		return ForegroundActivity{PackageName: m[1], Activity: m[2], DisplayID: max(focusedDisplay, 0)}, true
+	}
+	return ForegroundActivity{}, false
+}
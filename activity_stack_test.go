@@ -0,0 +1,145 @@
+package main
+
+import "testing"
+
+// Android 9: Stack/TaskRecord headers, mResumedActivity
+const dumpsysActivitiesPie = `ACTIVITY MANAGER ACTIVITIES (dumpsys activity activities)
+Display #0 (activities from top to bottom):
+  Stack #1: type=standard mode=fullscreen
+    Task id #245
+      * TaskRecord{8a1b2c3 #245 A=com.example.app U=0 StackId=1 sz=2}
+        Hist #1: ActivityRecord{5d6e7f8 u0 com.example.app/.DetailActivity t245}
+        Hist #0: ActivityRecord{1a2b3c4 u0 com.example.app/.MainActivity t245}
+
+    Running activities (most recent first):
+      TaskRecord{8a1b2c3 #245 A=com.example.app U=0 StackId=1 sz=2}
+        Run #1: ActivityRecord{5d6e7f8 u0 com.example.app/.DetailActivity t245}
+        Run #0: ActivityRecord{1a2b3c4 u0 com.example.app/.MainActivity t245}
+
+    mResumedActivity: ActivityRecord{5d6e7f8 u0 com.example.app/.DetailActivity t245}
+
+  Stack #0: type=home mode=fullscreen
+    Task id #2
+      * TaskRecord{9f8e7d6 #2 I=com.android.launcher3/.Launcher U=0 StackId=0 sz=1}
+        Hist #0: ActivityRecord{aa11bb2 u0 com.android.launcher3/.Launcher t2}
+`
+
+// Android 13: Task headers, "* Hist" prefix, topResumedActivity, trailing flags
+const dumpsysActivitiesTiramisu = `ACTIVITY MANAGER ACTIVITIES (dumpsys activity activities)
+Display #0 (activities from top to bottom):
+  * Task{c0ffee1 #512 type=standard A=10234:com.example.app U=0 visible=true visibleRequested=true mode=fullscreen translucent=false sz=3}
+    topResumedActivity=ActivityRecord{deadbe1 u0 com.example.app/com.example.app.ui.CheckoutActivity t512}
+    * Hist  #2: ActivityRecord{deadbe1 u0 com.example.app/com.example.app.ui.CheckoutActivity t512}
+    * Hist  #1: ActivityRecord{deadbe2 u0 com.example.app/.CartActivity t512 f}}
+    * Hist  #0: ActivityRecord{deadbe3 u0 com.example.app/.MainActivity t512}
+  * Task{c0ffee2 #1 type=home U=0 visible=false mode=fullscreen translucent=false sz=1}
+    * Task{c0ffee3 #30 type=home I=com.google.android.apps.nexuslauncher/.NexusLauncherActivity U=0 sz=1}
+      * Hist  #0: ActivityRecord{deadbe4 u0 com.google.android.apps.nexuslauncher/.NexusLauncherActivity t30}
+`
+
+func TestParseActivityStack(t *testing.T) {
+	t.Run("android 9", func(t *testing.T) {
+		entries := parseActivityStack(dumpsysActivitiesPie)
+		if len(entries) != 3 {
+			t.Fatalf("expected 3 entries (Run # lines ignored), got %d: %+v", len(entries), entries)
+		}
+		if entries[0].Activity != ".DetailActivity" || entries[0].TaskID != 245 || !entries[0].Resumed {
+			t.Errorf("top entry = %+v, want resumed .DetailActivity in task 245", entries[0])
+		}
+		if entries[1].Resumed || entries[2].Resumed {
+			t.Errorf("only the top entry should be resumed: %+v", entries)
+		}
+		if entries[2].PackageName != "com.android.launcher3" || entries[2].TaskID != 2 {
+			t.Errorf("launcher entry = %+v", entries[2])
+		}
+	})
+
+	t.Run("android 13", func(t *testing.T) {
+		entries := parseActivityStack(dumpsysActivitiesTiramisu)
+		if len(entries) != 4 {
+			t.Fatalf("expected 4 entries, got %d: %+v", len(entries), entries)
+		}
+		if entries[0].Activity != "com.example.app.ui.CheckoutActivity" || !entries[0].Resumed {
+			t.Errorf("top entry = %+v, want resumed CheckoutActivity", entries[0])
+		}
+		if entries[1].Activity != ".CartActivity" || entries[1].TaskID != 512 {
+			t.Errorf("second entry = %+v, want .CartActivity in task 512", entries[1])
+		}
+		if entries[3].TaskID != 30 || entries[3].Resumed {
+			t.Errorf("launcher entry = %+v", entries[3])
+		}
+	})
+
+	t.Run("last resumed is not resumed", func(t *testing.T) {
+		output := `Display #0 (activities from top to bottom):
+  * Task{c0ffee1 #512 type=standard A=10234:com.example.app U=0 visible=false sz=2}
+    * Hist  #1: ActivityRecord{deadbe1 u0 com.example.app/.CheckoutActivity t512}
+    * Hist  #0: ActivityRecord{deadbe3 u0 com.example.app/.MainActivity t512}
+  mLastResumedActivity: ActivityRecord{deadbe1 u0 com.example.app/.CheckoutActivity t512}
+`
+		entries := parseActivityStack(output)
+		if len(entries) != 2 {
+			t.Fatalf("expected 2 entries, got %d: %+v", len(entries), entries)
+		}
+		if entries[0].Resumed || entries[1].Resumed {
+			t.Errorf("mLastResumedActivity must not mark an entry resumed: %+v", entries)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if entries := parseActivityStack(""); len(entries) != 0 {
+			t.Errorf("expected no entries, got %+v", entries)
+		}
+	})
+}
+
+func TestParseForegroundActivity(t *testing.T) {
+	t.Run("android 9", func(t *testing.T) {
+		fg, ok := parseForegroundActivity(dumpsysActivitiesPie)
+		want := ForegroundActivity{PackageName: "com.example.app", Activity: ".DetailActivity"}
+		if !ok || fg != want {
+			t.Errorf("got %+v, %v; want %+v", fg, ok, want)
+		}
+	})
+
+	t.Run("focused secondary display", func(t *testing.T) {
+		output := dumpsysActivitiesTiramisu + `Display #2 (activities from top to bottom):
+  * Task{c0ffee9 #600 type=standard A=10300:com.example.maps U=0 visible=true sz=1}
+    topResumedActivity=ActivityRecord{feed001 u0 com.example.maps/.NavActivity t600}
+    * Hist  #0: ActivityRecord{feed001 u0 com.example.maps/.NavActivity t600}
+  mTopFocusedDisplayId=2
+  mCurrentFocus=Window{1234567 u0 com.example.maps/com.example.maps.NavActivity}
+`
+		fg, ok := parseForegroundActivity(output)
+		want := ForegroundActivity{PackageName: "com.example.maps", Activity: ".NavActivity", DisplayID: 2}
+		if !ok || fg != want {
+			t.Errorf("got %+v, %v; want %+v", fg, ok, want)
+		}
+	})
+
+	t.Run("focused window fallback", func(t *testing.T) {
+		fg, ok := parseForegroundActivity("  mCurrentFocus=Window{ab3a179 u0 app.footos/app.footos.MainActivity}\n")
+		if !ok || fg.PackageName != "app.footos" || fg.Activity != "app.footos.MainActivity" {
+			t.Errorf("got %+v, %v", fg, ok)
+		}
+	})
+
+	t.Run("screen off keeps only the last resumed activity", func(t *testing.T) {
+		output := `Display #0 (activities from top to bottom):
+  * Task{c0ffee1 #512 type=standard A=10234:com.example.app U=0 visible=false sz=1}
+    * Hist  #0: ActivityRecord{deadbe1 u0 com.example.app/.CheckoutActivity t512}
+  mLastResumedActivity: ActivityRecord{deadbe1 u0 com.example.app/.CheckoutActivity t512}
+  mTopFocusedDisplayId=0
+  mCurrentFocus=null
+`
+		if fg, ok := parseForegroundActivity(output); ok {
+			t.Errorf("expected no foreground activity, got %+v", fg)
+		}
+	})
+
+	t.Run("nothing resumed", func(t *testing.T) {
+		if _, ok := parseForegroundActivity("  mCurrentFocus=null\n"); ok {
+			t.Error("expected no foreground activity")
+		}
+	})
+}
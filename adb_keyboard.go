@@ -179,28 +179,76 @@ func (a *App) InputText(deviceId string, text string) error {
 		return a.InputTextViaADBKeyboard(deviceId, text)
 	}
 
-	// ASCII path: use native adb input text
-	escaped := escapeForAdbInput(text)
-	_, err := a.RunAdbCommand(deviceId, fmt.Sprintf("shell input text %s", escaped))
-	return err
+	// ASCII path: use native adb input text, one command per chunk or key
+	for _, cmd := range asciiInputCommands(text) {
+		if _, err := a.RunAdbCommand(deviceId, cmd); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// escapeForAdbInput escapes a string for safe use with "adb shell input text".
-// Only suitable for ASCII text.
-func escapeForAdbInput(text string) string {
-	// adb input text uses %s for spaces
-	result := strings.ReplaceAll(text, " ", "%s")
-
-	// Escape shell special characters
-	shellSpecials := []string{
-		"'", "\"", "`", "\\", "$",
-		"(", ")", "{", "}", "[", "]",
-		"&", "|", ";", "<", ">",
-		"#", "!", "~", "*", "?",
+// maxInputTextChunk limits the characters per `input text` call; long strings are
+// slow to inject and some devices drop the tail of very long ones
+const maxInputTextChunk = 100
+
+// asciiInputCommands splits ASCII text into adb shell commands. Newlines are sent as
+// KEYCODE_ENTER and tabs as KEYCODE_TAB since `input text` can't type them.
+// `input text` turns every "%s" into a space, so a literal "%s" is split across two
+// calls to keep the percent sign.
+func asciiInputCommands(text string) []string {
+	var cmds []string
+	var chunk strings.Builder
+	flush := func() {
+		if chunk.Len() > 0 {
+			cmds = append(cmds, "shell input text "+escapeForAdbInput(chunk.String()))
+			chunk.Reset()
+		}
 	}
-	for _, ch := range shellSpecials {
-		result = strings.ReplaceAll(result, ch, "\\"+ch)
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '\n':
+			flush()
+			cmds = append(cmds, "shell input keyevent 66")
+			continue
+		case c == '\t':
+			flush()
+			cmds = append(cmds, "shell input keyevent 61")
+			continue
+		case c == '\r' || c < 0x20 || c == 0x7f:
+			continue // other control characters have no text form
+		case c == 's' && i > 0 && text[i-1] == '%':
+			flush()
+		}
+		chunk.WriteByte(c)
+		if chunk.Len() >= maxInputTextChunk {
+			flush()
+		}
 	}
+	flush()
+	return cmds
+}
 
-	return result
+// escapeForAdbInput escapes a string for safe use with "adb shell input text".
+// Only suitable for ASCII text without control characters.
+func escapeForAdbInput(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == ' ':
+			// adb input text uses %s for spaces
+			b.WriteString("%s")
+		case strings.ContainsRune(adbInputShellSpecials, r):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
 }
+
+// adbInputShellSpecials are characters the device shell would interpret
+const adbInputShellSpecials = `'"` + "`" + `\$(){}[]&|;<>#!~*?`
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTextInputRouting(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		viaIME  bool
+		ascCmds []string
+	}{
+		{name: "plain", text: "hello", ascCmds: []string{"shell input text hello"}},
+		{name: "spaces", text: "hello big world", ascCmds: []string{"shell input text hello%sbig%sworld"}},
+		{name: "single quotes", text: "it's", ascCmds: []string{`shell input text it\'s`}},
+		{name: "double quotes", text: `say "hi"`, ascCmds: []string{`shell input text say%s\"hi\"`}},
+		{name: "ampersand", text: "a&b && c", ascCmds: []string{`shell input text a\&b%s\&\&%sc`}},
+		{name: "backslash", text: `C:\path\n`, ascCmds: []string{`shell input text C:\\path\\n`}},
+		{name: "backslash before quote", text: `\'`, ascCmds: []string{`shell input text \\\'`}},
+		{name: "shell metacharacters", text: "$(rm -rf /);`id`|x", ascCmds: []string{"shell input text \\$\\(rm%s-rf%s/\\)\\;\\`id\\`\\|x"}},
+		{name: "literal percent-s", text: "100%sure", ascCmds: []string{"shell input text 100%", "shell input text sure"}},
+		{name: "newline", text: "line1\nline2", ascCmds: []string{"shell input text line1", "shell input keyevent 66", "shell input text line2"}},
+		{name: "tab", text: "user\tpass", ascCmds: []string{"shell input text user", "shell input keyevent 61", "shell input text pass"}},
+		{name: "CRLF", text: "a\r\nb", ascCmds: []string{"shell input text a", "shell input keyevent 66", "shell input text b"}},
+		{name: "emoji", text: "nice 👍", viaIME: true},
+		{name: "CJK", text: "你好世界", viaIME: true},
+		{name: "accented", text: "café", viaIME: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := containsNonASCII(tt.text); got != tt.viaIME {
+				t.Fatalf("containsNonASCII(%q) = %v, want %v", tt.text, got, tt.viaIME)
+			}
+			if tt.viaIME {
+				return
+			}
+			if got := asciiInputCommands(tt.text); !reflect.DeepEqual(got, tt.ascCmds) {
+				t.Errorf("asciiInputCommands(%q) =\n  %q\nwant\n  %q", tt.text, got, tt.ascCmds)
+			}
+		})
+	}
+}
+
+func TestAsciiInputCommandsChunksLongText(t *testing.T) {
+	text := strings.Repeat("a", maxInputTextChunk*2+5)
+	cmds := asciiInputCommands(text)
+	if len(cmds) != 3 {
+		t.Fatalf("got %d commands, want 3", len(cmds))
+	}
+	var typed string
+	for _, c := range cmds {
+		typed += strings.TrimPrefix(c, "shell input text ")
+	}
+	if typed != text {
+		t.Error("chunks don't add up to the original text")
+	}
+}
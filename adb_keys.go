@@ -0,0 +1,132 @@
+package main
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNoRoot is returned when an operation needs root on the device and neither
+// adbd runs as root nor su is available
+var ErrNoRoot = errors.New("root access is not available on the device")
+
+// AdbKeyInfo describes the host's adb public key
+type AdbKeyInfo struct {
+	Path        string `json:"path"`
+	PublicKey   string `json:"publicKey"`   // Full adbkey.pub line
+	Fingerprint string `json:"fingerprint"` // MD5, as shown in the device's authorization prompt
+	Comment     string `json:"comment"`     // Usually user@host
+}
+
+// adbKeyDir returns the directory adb keeps its key pair in
+func adbKeyDir() (string, error) {
+	if dir := os.Getenv("ANDROID_USER_HOME"); dir != "" {
+		return dir, nil
+	}
+	if dir := os.Getenv("ANDROID_SDK_HOME"); dir != "" {
+		return filepath.Join(dir, ".android"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".android"), nil
+}
+
+// GetAdbPublicKey returns the host's adb public key and the fingerprint devices show
+// in the "Allow USB debugging?" prompt
+func (a *App) GetAdbPublicKey() (AdbKeyInfo, error) {
+	dir, err := adbKeyDir()
+	if err != nil {
+		return AdbKeyInfo{}, fmt.Errorf("failed to locate adb key directory: %w", err)
+	}
+
+	info := AdbKeyInfo{Path: filepath.Join(dir, "adbkey.pub")}
+	data, err := os.ReadFile(info.Path)
+	if err != nil {
+		// The .pub file is optional; adb can derive it from the private key
+		privPath := filepath.Join(dir, "adbkey")
+		if _, statErr := os.Stat(privPath); statErr != nil {
+			return info, fmt.Errorf("no adb key found in %s; start the adb server once to generate it", dir)
+		}
+		out, _, pubErr := a.runAdb(nil, "pubkey", privPath)
+		if pubErr != nil {
+			return info, fmt.Errorf("failed to read adb public key: %w", pubErr)
+		}
+		info.Path = privPath
+		data = out
+	}
+
+	info.PublicKey = strings.TrimSpace(string(data))
+	fingerprint, comment, err := parseAdbPublicKey(info.PublicKey)
+	if err != nil {
+		return info, err
+	}
+	info.Fingerprint = fingerprint
+	info.Comment = comment
+	return info, nil
+}
+
+// parseAdbPublicKey splits an adbkey.pub line ("<base64> user@host") and returns the
+// colon-separated MD5 fingerprint of the decoded key
+func parseAdbPublicKey(line string) (fingerprint, comment string, err error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", "", fmt.Errorf("empty adb public key")
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[0])
+	if err != nil {
+		return "", "", fmt.Errorf("invalid adb public key: %w", err)
+	}
+	if len(fields) > 1 {
+		comment = strings.Join(fields[1:], " ")
+	}
+
+	sum := md5.Sum(blob)
+	hexParts := make([]string, len(sum))
+	for i, b := range sum {
+		hexParts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hexParts, ":"), comment, nil
+}
+
+// PreauthorizeDevice appends this host's adb key to /data/misc/adb/adb_keys so the
+// device trusts it without a prompt, e.g. when moving a provisioned fleet to a new
+// host. It needs a connection that can already run shell commands as root (adbd
+// root on userdebug builds, or su); otherwise it returns ErrNoRoot.
+func (a *App) PreauthorizeDevice(deviceId string) error {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return err
+	}
+
+	key, err := a.GetAdbPublicKey()
+	if err != nil {
+		return err
+	}
+	blob := strings.Fields(key.PublicKey)[0]
+
+	const keysFile = "/data/misc/adb/adb_keys"
+	script := fmt.Sprintf("grep -qF %s %s 2>/dev/null || echo %s >> %s; chown system:shell %s; chmod 640 %s; restorecon %s 2>/dev/null; echo PREAUTH_OK",
+		shellQuote(blob), keysFile, shellQuote(key.PublicKey), keysFile, keysFile, keysFile, keysFile)
+
+	var shellCmd string
+	if out, _, err := a.runAdb(nil, "-s", deviceId, "shell", "id -u"); err == nil && strings.TrimSpace(string(out)) == "0" {
+		shellCmd = script
+	} else if out, _, err := a.runAdb(nil, "-s", deviceId, "shell", "su -c 'id -u'"); err == nil && strings.TrimSpace(string(out)) == "0" {
+		shellCmd = "su -c " + shellQuote(script)
+	} else {
+		return ErrNoRoot
+	}
+
+	output, err := a.runAdbCombined(nil, "-s", deviceId, "shell", shellCmd)
+	if err != nil || !strings.Contains(string(output), "PREAUTH_OK") {
+		return fmt.Errorf("failed to install adb key: %v (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+
+	a.Log("Pre-authorized %s for adb key %s", deviceId, key.Fingerprint)
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseAdbPublicKey(t *testing.T) {
+	// Key blob is base64("hello adb")
+	fingerprint, comment, err := parseAdbPublicKey("aGVsbG8gYWRi dev@build-host")
+	if err != nil {
+		t.Fatalf("parseAdbPublicKey() error = %v", err)
+	}
+	if comment != "dev@build-host" {
+		t.Errorf("comment = %q, want dev@build-host", comment)
+	}
+	if want := "0F:78:D5:71:90:85:C0:8A:6B:C5:89:34:76:2B:36:E6"; fingerprint != want {
+		t.Errorf("fingerprint = %q, want %q", fingerprint, want)
+	}
+
+	if _, _, err := parseAdbPublicKey("not base64!! host"); err == nil {
+		t.Error("expected error for invalid base64")
+	}
+	if _, _, err := parseAdbPublicKey(""); err == nil {
+		t.Error("expected error for empty key")
+	}
+}
+
+func TestGetAdbPublicKey(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ANDROID_USER_HOME", dir)
+	priv := filepath.Join(dir, "adbkey")
+
+	if _, err := newTestApp(nil).GetAdbPublicKey(); err == nil || !strings.Contains(err.Error(), "no adb key") {
+		t.Errorf("expected a missing key error, got %v", err)
+	}
+
+	// Only the private key: the public half comes from `adb pubkey`
+	if err := os.WriteFile(priv, []byte("private"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	app := newTestApp(map[string]string{"pubkey " + priv: "aGVsbG8gYWRi dev@build-host\n"})
+	info, err := app.GetAdbPublicKey()
+	if err != nil {
+		t.Fatalf("GetAdbPublicKey: %v", err)
+	}
+	if info.Path != priv || info.Comment != "dev@build-host" || info.Fingerprint == "" {
+		t.Errorf("unexpected key info: %+v", info)
+	}
+
+	// The pubkey failure itself is reported, not the earlier missing-file error
+	_, err = newTestApp(nil).GetAdbPublicKey()
+	if err == nil || !strings.Contains(err.Error(), "fakeRunner") {
+		t.Errorf("expected the adb pubkey error to be wrapped, got %v", err)
+	}
+
+	// adbkey.pub is preferred when present
+	if err := os.WriteFile(priv+".pub", []byte("aGVsbG8gYWRi me@laptop\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	info, err = newTestApp(nil).GetAdbPublicKey()
+	if err != nil || info.Path != priv+".pub" || info.Comment != "me@laptop" {
+		t.Errorf("unexpected key info: %+v, %v", info, err)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ========================================
+// Retry for transient adb failures
+// ========================================
+
+const (
+	defaultAdbRetries = 3
+	maxAdbRetries     = 10
+	adbRetryBackoff   = 300 * time.Millisecond
+)
+
+// Error fragments adb prints while a device is (re)connecting. Anything else, such as
+// "Unknown package" or a non-zero exit of the shell command, is a genuine failure.
+var transientAdbErrors = []string{
+	"device offline",
+	"error: closed",
+	"protocol fault",
+	"connection reset",
+	"broken pipe",
+	"failed to get feature set",
+	"device still authorizing",
+	"device still connecting",
+}
+
+// errTransientAdb can be wrapped by runWithRetry callbacks to retry a failure that adb
+// itself doesn't report as transient (e.g. a uiautomator dump that printed no XML)
+var errTransientAdb = errors.New("transient adb failure")
+
+// isTransientAdbError reports whether a failure looks like a reconnect hiccup
+func isTransientAdbError(err error, output []byte) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, errTransientAdb) {
+		return true
+	}
+	text := strings.ToLower(err.Error() + " " + string(output))
+	for _, s := range transientAdbErrors {
+		if strings.Contains(text, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// runWithRetry calls fn up to attempts times while it fails with a transient adb error,
+// doubling backoff between tries. Genuine failures and context cancellation return at once.
+func runWithRetry(ctx context.Context, attempts int, backoff time.Duration, fn func() ([]byte, error)) ([]byte, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var out []byte
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return out, err
+			case <-time.After(backoff << (i - 1)):
+			}
+		}
+		out, err = fn()
+		if !isTransientAdbError(err, out) {
+			return out, err
+		}
+		LogDebug("adb_retry").Int("attempt", i+1).Int("attempts", attempts).Err(err).Msg("Transient adb failure")
+	}
+	return out, err
+}
+
+// runAdbWithRetry is runAdb retried on transient failures. stderr is taken into account
+// when classifying, since that's where adb reports "device offline".
+func (a *App) runAdbWithRetry(ctx context.Context, args ...string) (stdout, stderr []byte, err error) {
+	_, err = runWithRetry(ctx, a.GetAdbRetries(), adbRetryBackoff, func() ([]byte, error) {
+		var runErr error
+		stdout, stderr, runErr = a.runAdb(ctx, args...)
+		return stderr, runErr
+	})
+	return stdout, stderr, err
+}
+
+// GetAdbRetries returns how many attempts flaky adb commands (serial lookup, UI dump,
+// screencap) get when they fail with a transient error
+func (a *App) GetAdbRetries() int {
+	if a.cacheService != nil {
+		if n := a.cacheService.GetAdbRetries(); n > 0 {
+			return n
+		}
+	}
+	return defaultAdbRetries
+}
+
+// SetAdbRetries sets the attempt count for flaky adb commands. 1 disables retrying;
+// passing 0 restores the default.
+func (a *App) SetAdbRetries(attempts int) (int, error) {
+	if attempts < 0 || attempts > maxAdbRetries {
+		return a.GetAdbRetries(), fmt.Errorf("attempts must be between 0 and %d, got %d", maxAdbRetries, attempts)
+	}
+	if a.cacheService == nil {
+		return a.GetAdbRetries(), fmt.Errorf("settings are not available")
+	}
+
+	a.cacheService.SetAdbRetries(attempts)
+	go a.saveSettings()
+
+	n := a.GetAdbRetries()
+	a.Log("adb retry attempts set to %d", n)
+	return n, nil
+}
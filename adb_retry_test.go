@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIsTransientAdbError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		output string
+		want   bool
+	}{
+		{"success", nil, "device offline", false},
+		{"offline in stderr", errors.New("exit status 1"), "error: device offline", true},
+		{"closed in error", errors.New("error: closed"), "", true},
+		{"protocol fault", errors.New("exit status 1"), "error: protocol fault (couldn't read status): Connection reset by peer", true},
+		{"unknown package", errors.New("exit status 1"), "Failure [DELETE_FAILED_INTERNAL_ERROR] Unknown package: com.example", false},
+		{"shell failure", errors.New("exit status 127"), "/system/bin/sh: foo: not found", false},
+		{"marked transient", fmt.Errorf("%w: uiautomator dump returned no XML", errTransientAdb), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientAdbError(tt.err, []byte(tt.output)); got != tt.want {
+				t.Errorf("isTransientAdbError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunWithRetry(t *testing.T) {
+	offline := errors.New("error: device offline")
+
+	// Transient failures are retried until the command succeeds
+	calls := 0
+	out, err := runWithRetry(context.Background(), 3, time.Millisecond, func() ([]byte, error) {
+		calls++
+		if calls < 3 {
+			return nil, offline
+		}
+		return []byte("R5CT1234ABC"), nil
+	})
+	if err != nil || string(out) != "R5CT1234ABC" || calls != 3 {
+		t.Errorf("got %q, %v after %d calls; want success on the third", out, err, calls)
+	}
+
+	// Attempts are capped
+	calls = 0
+	if _, err := runWithRetry(context.Background(), 2, time.Millisecond, func() ([]byte, error) {
+		calls++
+		return nil, offline
+	}); !errors.Is(err, offline) || calls != 2 {
+		t.Errorf("got %v after %d calls; want the offline error after 2", err, calls)
+	}
+
+	// Genuine failures are not retried
+	calls = 0
+	runWithRetry(context.Background(), 5, time.Millisecond, func() ([]byte, error) {
+		calls++
+		return []byte("Unknown package"), errors.New("exit status 1")
+	})
+	if calls != 1 {
+		t.Errorf("genuine failure ran %d times, want 1", calls)
+	}
+
+	// Cancellation stops waiting for the next attempt
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	runWithRetry(ctx, 5, time.Hour, func() ([]byte, error) {
+		calls++
+		return nil, offline
+	})
+	if calls != 1 {
+		t.Errorf("cancelled retry ran %d times, want 1", calls)
+	}
+}
+
+func TestDumpUIHierarchy_RetriesOnce(t *testing.T) {
+	const dumpCmd = "-s dev1 shell uiautomator dump /data/local/tmp/view.xml && cat /data/local/tmp/view.xml"
+	const xmlDump = `UI hierchary dumped to: /data/local/tmp/view.xml
+<?xml version='1.0' encoding='UTF-8' standalone='yes' ?><hierarchy rotation="0"><node index="0" text="OK" resource-id="" class="android.widget.Button" package="com.example" content-desc="" bounds="[0,0][100,50]" /></hierarchy>`
+
+	a := newTestApp(map[string]string{dumpCmd: xmlDump})
+	result, err := a.dumpUIHierarchy(context.Background(), "dev1")
+	if err != nil {
+		t.Fatalf("dumpUIHierarchy() error = %v", err)
+	}
+	if result.Root == nil || !a.FindElement(result.Root, "text", "OK") {
+		t.Errorf("parsed hierarchy is missing the OK button: %+v", result.Root)
+	}
+
+	// A dump without XML is retried GetAdbRetries times in total, not retries × retries
+	a = newOutputTestApp(t)
+	a.runner = &fakeRunner{responses: map[string]string{
+		dumpCmd:                           "ERROR: could not get idle state.",
+		"-s dev1 shell pkill uiautomator": "",
+	}}
+	if _, err := a.SetAdbRetries(2); err != nil {
+		t.Fatalf("SetAdbRetries: %v", err)
+	}
+	if _, err := a.dumpUIHierarchy(context.Background(), "dev1"); err == nil {
+		t.Fatal("dumpUIHierarchy() error = nil; want failure without XML")
+	}
+	dumps := 0
+	for _, call := range a.runner.(*fakeRunner).calls {
+		if call == dumpCmd {
+			dumps++
+		}
+	}
+	if dumps != 2 {
+		t.Errorf("uiautomator dump ran %d times, want 2", dumps)
+	}
+}
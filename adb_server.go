@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"Gaze/pkg/cache"
+)
+
+// ========================================
+// adb server address
+// ========================================
+
+const defaultAdbServerPort = 5037
+
+var adbServerHostRegex = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9.-]*[A-Za-z0-9])?$`)
+
+// GetAdbServerAddress returns the adb server adb commands connect to. An empty host and
+// port 0 mean the local default, or whatever the app's environment already selects.
+func (a *App) GetAdbServerAddress() AdbServerSettings {
+	if a.cacheService == nil {
+		return AdbServerSettings{}
+	}
+	s := a.cacheService.GetAdbServer()
+	return AdbServerSettings{Host: s.Host, Port: s.Port}
+}
+
+// SetAdbServerAddress points adb at another server, e.g. a device farm host or a second
+// adb on a non-default port. A remote server must listen on all interfaces (adb -a).
+// Passing "" and 0 restores the local default. The new address is checked with
+// `adb devices` and rolled back if no server answers there.
+func (a *App) SetAdbServerAddress(host string, port int) (AdbServerSettings, error) {
+	host = strings.TrimSpace(host)
+	if host != "" && net.ParseIP(host) == nil && !adbServerHostRegex.MatchString(host) {
+		return a.GetAdbServerAddress(), fmt.Errorf("invalid adb server host: %q", host)
+	}
+	if port < 0 || port > 65535 {
+		return a.GetAdbServerAddress(), fmt.Errorf("port must be between 0 and 65535, got %d", port)
+	}
+	if a.cacheService == nil {
+		return a.GetAdbServerAddress(), fmt.Errorf("settings are not available")
+	}
+
+	previous := a.cacheService.GetAdbServer()
+	a.cacheService.SetAdbServer(cache.AdbServer{Host: host, Port: port})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if output, err := a.runAdbCombined(ctx, "devices"); err != nil {
+		a.cacheService.SetAdbServer(previous)
+		return a.GetAdbServerAddress(), fmt.Errorf("no adb server reachable at %s: %s", adbServerLabel(host, port), strings.TrimSpace(string(output)))
+	}
+	go a.saveSettings()
+
+	a.Log("adb server set to %s", adbServerLabel(host, port))
+	return a.GetAdbServerAddress(), nil
+}
+
+// adbServerLabel formats a server address for messages
+func adbServerLabel(host string, port int) string {
+	if host == "" {
+		host = "localhost"
+	}
+	if port == 0 {
+		port = defaultAdbServerPort
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// withAdbServerEnv applies a configured server address to an adb environment. adb reads
+// the host from ANDROID_ADB_SERVER_ADDRESS and the port from ANDROID_ADB_SERVER_PORT.
+// Inherited values are kept unless the setting overrides them.
+func withAdbServerEnv(env []string, server AdbServerSettings) []string {
+	var overrides []string
+	if server.Host != "" {
+		overrides = append(overrides, "ANDROID_ADB_SERVER_ADDRESS="+server.Host)
+	}
+	if server.Port != 0 {
+		overrides = append(overrides, "ANDROID_ADB_SERVER_PORT="+strconv.Itoa(server.Port))
+	}
+	if len(overrides) == 0 {
+		return env
+	}
+
+	result := make([]string, 0, len(env)+len(overrides))
+	for _, e := range env {
+		if (server.Host != "" && strings.HasPrefix(e, "ANDROID_ADB_SERVER_ADDRESS=")) ||
+			(server.Port != 0 && strings.HasPrefix(e, "ANDROID_ADB_SERVER_PORT=")) {
+			continue
+		}
+		result = append(result, e)
+	}
+	return append(result, overrides...)
+}
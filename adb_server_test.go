@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithAdbServerEnv(t *testing.T) {
+	env := []string{"PATH=/usr/bin", "ANDROID_ADB_SERVER_PORT=5038"}
+
+	if got := withAdbServerEnv(env, AdbServerSettings{}); !reflect.DeepEqual(got, env) {
+		t.Errorf("unconfigured server should keep the environment, got %v", got)
+	}
+
+	got := withAdbServerEnv(env, AdbServerSettings{Host: "farm.local"})
+	want := []string{"PATH=/usr/bin", "ANDROID_ADB_SERVER_PORT=5038", "ANDROID_ADB_SERVER_ADDRESS=farm.local"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("host only = %v, want %v", got, want)
+	}
+
+	got = withAdbServerEnv(env, AdbServerSettings{Host: "10.0.0.5", Port: 5040})
+	want = []string{"PATH=/usr/bin", "ANDROID_ADB_SERVER_ADDRESS=10.0.0.5", "ANDROID_ADB_SERVER_PORT=5040"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("host and port = %v, want %v", got, want)
+	}
+}
+
+func TestSetAdbServerAddress(t *testing.T) {
+	app := newOutputTestApp(t)
+	app.runner = &fakeRunner{responses: map[string]string{"devices": "List of devices attached\n\n"}}
+
+	got, err := app.SetAdbServerAddress("farm.local", 5040)
+	if err != nil {
+		t.Fatalf("SetAdbServerAddress: %v", err)
+	}
+	if got.Host != "farm.local" || got.Port != 5040 {
+		t.Errorf("address = %+v", got)
+	}
+
+	for _, bad := range []struct {
+		host string
+		port int
+	}{{"farm local", 0}, {"-farm", 0}, {"", 70000}, {"", -1}} {
+		if _, err := app.SetAdbServerAddress(bad.host, bad.port); err == nil {
+			t.Errorf("SetAdbServerAddress(%q, %d) should fail", bad.host, bad.port)
+		}
+	}
+}
+
+func TestSetAdbServerAddressRollsBackUnreachable(t *testing.T) {
+	app := newOutputTestApp(t) // fake runner without responses: every adb call fails
+
+	if _, err := app.SetAdbServerAddress("10.0.0.9", 5037); err == nil {
+		t.Fatal("expected an error when no server answers")
+	}
+	if got := app.GetAdbServerAddress(); got != (AdbServerSettings{}) {
+		t.Errorf("address should be rolled back, got %+v", got)
+	}
+}
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Annotation is a shape drawn onto a screenshot for bug reports
+type Annotation struct {
+	Type     string `json:"type"` // "rect", "arrow" or "text"
+	X        int    `json:"x"`    // rect: top-left, arrow: tail, text: top-left of the label
+	Y        int    `json:"y"`
+	X2       int    `json:"x2,omitempty"` // rect: bottom-right, arrow: head
+	Y2       int    `json:"y2,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Color    string `json:"color,omitempty"`    // #RRGGBB or #RRGGBBAA, default red
+	Width    int    `json:"width,omitempty"`    // stroke width in pixels, default 4
+	FontSize int    `json:"fontSize,omitempty"` // text height in pixels, default 32
+}
+
+const (
+	defaultAnnotationColor    = "#FF3B30"
+	defaultAnnotationWidth    = 4
+	defaultAnnotationFontSize = 32
+)
+
+// AnnotateScreenshot draws rectangles, arrows and text labels onto an image and saves
+// the result as PNG. If savePath is empty, "<name>_annotated.png" is written next to
+// the source image. Returns the path written.
+func (a *App) AnnotateScreenshot(imagePath string, annotations []Annotation, savePath string) (string, error) {
+	f, err := os.Open(imagePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open image: %w", err)
+	}
+	src, _, err := image.Decode(f)
+	f.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	out, err := renderAnnotations(src, annotations)
+	if err != nil {
+		return "", err
+	}
+
+	if savePath == "" {
+		base := strings.TrimSuffix(imagePath, filepath.Ext(imagePath))
+		savePath = base + "_annotated.png"
+	}
+	if err := os.MkdirAll(filepath.Dir(savePath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	w, err := os.Create(savePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	if err := png.Encode(w, out); err != nil {
+		w.Close()
+		os.Remove(savePath)
+		return "", fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("failed to write PNG: %w", err)
+	}
+	return savePath, nil
+}
+
+// ReadImageFileAsDataURL returns a local PNG or JPEG as a data URL so the annotation
+// editor can display a screenshot
+func (a *App) ReadImageFileAsDataURL(imagePath string) (string, error) {
+	stat, err := os.Stat(imagePath)
+	if err != nil {
+		return "", err
+	}
+	if stat.Size() > 50*1024*1024 {
+		return "", fmt.Errorf("image file too large for data URL (max 50MB)")
+	}
+	data, err := os.ReadFile(imagePath)
+	if err != nil {
+		return "", err
+	}
+
+	mimeType := "image/png"
+	switch strings.ToLower(filepath.Ext(imagePath)) {
+	case ".jpg", ".jpeg":
+		mimeType = "image/jpeg"
+	}
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// renderAnnotations validates every annotation against the image bounds before drawing
+// anything, so a bad annotation never produces a half-annotated image
+func renderAnnotations(src image.Image, annotations []Annotation) (*image.RGBA, error) {
+	bounds := src.Bounds()
+	colors := make([]color.Color, len(annotations))
+	for i, ann := range annotations {
+		c, err := validateAnnotation(ann, bounds)
+		if err != nil {
+			return nil, fmt.Errorf("annotation %d: %w", i+1, err)
+		}
+		colors[i] = c
+	}
+
+	dst := image.NewRGBA(bounds)
+	draw.Draw(dst, bounds, src, bounds.Min, draw.Src)
+
+	for i, ann := range annotations {
+		width := ann.Width
+		if width <= 0 {
+			width = defaultAnnotationWidth
+		}
+		switch ann.Type {
+		case "rect":
+			drawRectOutline(dst, image.Rect(ann.X, ann.Y, ann.X2, ann.Y2), width, colors[i])
+		case "arrow":
+			drawArrow(dst, image.Pt(ann.X, ann.Y), image.Pt(ann.X2, ann.Y2), width, colors[i])
+		case "text":
+			size := ann.FontSize
+			if size <= 0 {
+				size = defaultAnnotationFontSize
+			}
+			drawLabel(dst, image.Pt(ann.X, ann.Y), ann.Text, size, colors[i])
+		}
+	}
+	return dst, nil
+}
+
+func validateAnnotation(ann Annotation, bounds image.Rectangle) (color.Color, error) {
+	inBounds := func(x, y int) error {
+		// Max is inclusive here so a box can hug the right/bottom edge
+		if x < bounds.Min.X || y < bounds.Min.Y || x > bounds.Max.X || y > bounds.Max.Y {
+			return fmt.Errorf("point (%d,%d) is outside the %dx%d image", x, y, bounds.Dx(), bounds.Dy())
+		}
+		return nil
+	}
+
+	switch ann.Type {
+	case "rect", "arrow":
+		if err := inBounds(ann.X, ann.Y); err != nil {
+			return nil, err
+		}
+		if err := inBounds(ann.X2, ann.Y2); err != nil {
+			return nil, err
+		}
+		if ann.X == ann.X2 && ann.Y == ann.Y2 {
+			return nil, fmt.Errorf("%s has zero size", ann.Type)
+		}
+	case "text":
+		if err := inBounds(ann.X, ann.Y); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(ann.Text) == "" {
+			return nil, fmt.Errorf("text is required")
+		}
+	default:
+		return nil, fmt.Errorf("unknown annotation type %q (expected rect, arrow or text)", ann.Type)
+	}
+
+	if ann.Width < 0 || ann.Width > 100 {
+		return nil, fmt.Errorf("width must be at most 100")
+	}
+	if ann.FontSize < 0 || ann.FontSize > 512 {
+		return nil, fmt.Errorf("fontSize must be at most 512")
+	}
+
+	hex := ann.Color
+	if hex == "" {
+		hex = defaultAnnotationColor
+	}
+	return parseHexColor(hex)
+}
+
+// parseHexColor parses #RGB, #RRGGBB and #RRGGBBAA
+func parseHexColor(s string) (color.NRGBA, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return color.NRGBA{}, fmt.Errorf("invalid color %q", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid color %q", s)
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+func fillRect(dst draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(dst, r.Intersect(dst.Bounds()), image.NewUniform(c), image.Point{}, draw.Over)
+}
+
+func drawRectOutline(dst draw.Image, r image.Rectangle, width int, c color.Color) {
+	r = r.Canon()
+	if width*2 >= r.Dx() || width*2 >= r.Dy() {
+		fillRect(dst, r, c)
+		return
+	}
+	fillRect(dst, image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+width), c)             // top
+	fillRect(dst, image.Rect(r.Min.X, r.Max.Y-width, r.Max.X, r.Max.Y), c)             // bottom
+	fillRect(dst, image.Rect(r.Min.X, r.Min.Y+width, r.Min.X+width, r.Max.Y-width), c) // left
+	fillRect(dst, image.Rect(r.Max.X-width, r.Min.Y+width, r.Max.X, r.Max.Y-width), c) // right
+}
+
+// drawStrokes renders line segments with a square brush. The segments are stamped
+// into a mask first so translucent colors stay even where stamps overlap.
+func drawStrokes(dst *image.RGBA, segments [][2]image.Point, width int, c color.Color) {
+	half := width / 2
+	area := image.Rectangle{}
+	for _, seg := range segments {
+		area = area.Union(image.Rectangle{Min: seg[0], Max: seg[1]}.Canon().Inset(-width))
+	}
+	area = area.Intersect(dst.Bounds())
+	if area.Empty() {
+		return
+	}
+
+	mask := image.NewAlpha(area)
+	opaque := image.NewUniform(color.Alpha{A: 255})
+	for _, seg := range segments {
+		from, to := seg[0], seg[1]
+		dx, dy := to.X-from.X, to.Y-from.Y
+		steps := int(math.Max(math.Abs(float64(dx)), math.Abs(float64(dy))))
+		for i := 0; i <= steps; i++ {
+			t := 0.0
+			if steps > 0 {
+				t = float64(i) / float64(steps)
+			}
+			p := image.Pt(from.X+int(math.Round(t*float64(dx))), from.Y+int(math.Round(t*float64(dy))))
+			r := image.Rect(p.X-half, p.Y-half, p.X-half+width, p.Y-half+width).Intersect(area)
+			draw.Draw(mask, r, opaque, image.Point{}, draw.Src)
+		}
+	}
+	draw.DrawMask(dst, area, image.NewUniform(c), image.Point{}, mask, area.Min, draw.Over)
+}
+
+func drawArrow(dst *image.RGBA, tail, head image.Point, width int, c color.Color) {
+	segments := [][2]image.Point{{tail, head}}
+
+	headLen := math.Max(16, float64(width)*4)
+	angle := math.Atan2(float64(head.Y-tail.Y), float64(head.X-tail.X))
+	for _, spread := range []float64{math.Pi / 7, -math.Pi / 7} {
+		wing := image.Pt(
+			head.X-int(math.Round(headLen*math.Cos(angle+spread))),
+			head.Y-int(math.Round(headLen*math.Sin(angle+spread))),
+		)
+		segments = append(segments, [2]image.Point{head, wing})
+	}
+	drawStrokes(dst, segments, width, c)
+}
+
+// drawLabel renders text on a dark backdrop for contrast. Each character is drawn with
+// the first label font that has a glyph for it (see labelFonts).
+func drawLabel(dst *image.RGBA, at image.Point, text string, size int, c color.Color) {
+	fonts := labelFonts()
+	if len(fonts) == 0 {
+		return
+	}
+	faces := make(map[*opentype.Font]font.Face)
+	defer func() {
+		for _, face := range faces {
+			face.Close()
+		}
+	}()
+	faceFor := func(r rune) font.Face {
+		f := labelFontFor(fonts, r)
+		face, ok := faces[f]
+		if !ok {
+			var err error
+			face, err = opentype.NewFace(f, &opentype.FaceOptions{Size: float64(size), DPI: 72, Hinting: font.HintingFull})
+			if err != nil {
+				return nil
+			}
+			faces[f] = face
+		}
+		return face
+	}
+
+	// Measure first so the backdrop fits the text
+	var width fixed.Int26_6
+	var ascent, descent fixed.Int26_6
+	for _, r := range text {
+		face := faceFor(r)
+		if face == nil {
+			continue
+		}
+		if adv, ok := face.GlyphAdvance(r); ok {
+			width += adv
+		}
+		m := face.Metrics()
+		ascent = max(ascent, m.Ascent)
+		descent = max(descent, m.Descent)
+	}
+
+	pad := max(size/8, 1) * 2
+	backdrop := image.Rect(at.X, at.Y, at.X+width.Ceil()+pad*2, at.Y+(ascent+descent).Ceil()+pad*2)
+	fillRect(dst, backdrop, color.NRGBA{A: 160})
+
+	dot := fixed.P(at.X+pad, at.Y+pad+ascent.Ceil())
+	for _, r := range text {
+		face := faceFor(r)
+		if face == nil {
+			continue
+		}
+		d := font.Drawer{Dst: dst, Src: image.NewUniform(c), Face: face, Dot: dot}
+		d.DrawString(string(r))
+		dot = d.Dot
+	}
+}
+
+// labelFontPaths lists system fonts with CJK coverage, most preferred first
+var labelFontPaths = map[string][]string{
+	"darwin": {
+		"/System/Library/Fonts/PingFang.ttc",
+		"/System/Library/Fonts/Hiragino Sans GB.ttc",
+		"/System/Library/Fonts/STHeiti Medium.ttc",
+		"/Library/Fonts/Arial Unicode.ttf",
+	},
+	// Relative to %WINDIR%\Fonts
+	"windows": {"msyh.ttc", "msyh.ttf", "simhei.ttf", "YuGothM.ttc", "malgun.ttf"},
+	"linux": {
+		"/usr/share/fonts/opentype/noto/NotoSansCJK-Regular.ttc",
+		"/usr/share/fonts/noto-cjk/NotoSansCJK-Regular.ttc",
+		"/usr/share/fonts/google-noto-cjk/NotoSansCJK-Regular.ttc",
+		"/usr/share/fonts/truetype/wqy/wqy-microhei.ttc",
+		"/usr/share/fonts/wqy-microhei/wqy-microhei.ttc",
+		"/usr/share/fonts/truetype/droid/DroidSansFallbackFull.ttf",
+	},
+}
+
+var (
+	labelFontsOnce sync.Once
+	labelFontList  []*opentype.Font
+)
+
+// labelFonts returns the embedded Go font, which covers Latin, Greek and Cyrillic,
+// followed by the first CJK font found on the system. Bundling a CJK face would add
+// well over 10MB to the binary, while every desktop OS ships one.
+func labelFonts() []*opentype.Font {
+	labelFontsOnce.Do(func() {
+		if f, err := opentype.Parse(goregular.TTF); err == nil {
+			labelFontList = append(labelFontList, f)
+		}
+		for _, path := range labelFontPaths[runtime.GOOS] {
+			if runtime.GOOS == "windows" {
+				path = filepath.Join(os.Getenv("WINDIR"), "Fonts", path)
+			}
+			if f, err := loadFontFile(path); err == nil {
+				labelFontList = append(labelFontList, f)
+				break
+			}
+		}
+	})
+	return labelFontList
+}
+
+// loadFontFile parses a TTF/OTF file, or the first face of a TTC collection
+func loadFontFile(path string) (*opentype.Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".ttc") {
+		collection, err := opentype.ParseCollection(data)
+		if err != nil {
+			return nil, err
+		}
+		return collection.Font(0)
+	}
+	return opentype.Parse(data)
+}
+
+// labelFontFor picks the first font with a glyph for r, or the first font if none has one
+func labelFontFor(fonts []*opentype.Font, r rune) *opentype.Font {
+	var buf sfnt.Buffer
+	for _, f := range fonts {
+		if idx, err := f.GlyphIndex(&buf, r); err == nil && idx != 0 {
+			return f
+		}
+	}
+	return fonts[0]
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestPNG(t *testing.T, path string, w, h int) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAnnotateScreenshot(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "shot.png")
+	writeTestPNG(t, src, 200, 300)
+
+	app := &App{mcpMode: true}
+	out, err := app.AnnotateScreenshot(src, []Annotation{
+		{Type: "rect", X: 10, Y: 10, X2: 110, Y2: 60, Color: "#00FF00", Width: 3},
+		{Type: "arrow", X: 150, Y: 250, X2: 60, Y2: 100},
+		{Type: "text", X: 10, Y: 200, Text: "Bug #12"},
+	}, "")
+	if err != nil {
+		t.Fatalf("annotate: %v", err)
+	}
+	if out != filepath.Join(dir, "shot_annotated.png") {
+		t.Errorf("default save path = %s", out)
+	}
+
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isColor := func(x, y int, want color.RGBA) bool {
+		r, g, b, _ := img.At(x, y).RGBA()
+		return uint8(r>>8) == want.R && uint8(g>>8) == want.G && uint8(b>>8) == want.B
+	}
+	if !isColor(11, 11, color.RGBA{0, 255, 0, 255}) {
+		t.Error("rectangle border should be green")
+	}
+	if !isColor(50, 35, color.RGBA{255, 255, 255, 255}) {
+		t.Error("rectangle interior should be untouched")
+	}
+	if !isColor(105, 175, color.RGBA{0xFF, 0x3B, 0x30, 255}) {
+		t.Error("arrow shaft should use the default color")
+	}
+	// The label backdrop darkens the area behind the text
+	if r, _, _, _ := img.At(12, 202).RGBA(); r>>8 > 200 {
+		t.Error("text label should have a dark backdrop")
+	}
+}
+
+func TestAnnotateScreenshotValidatesBounds(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "shot.png")
+	writeTestPNG(t, src, 100, 100)
+	app := &App{mcpMode: true}
+
+	tests := []struct {
+		ann     Annotation
+		wantErr string
+	}{
+		{Annotation{Type: "rect", X: 10, Y: 10, X2: 150, Y2: 50}, "outside the 100x100 image"},
+		{Annotation{Type: "arrow", X: -1, Y: 0, X2: 10, Y2: 10}, "outside"},
+		{Annotation{Type: "text", X: 5, Y: 5}, "text is required"},
+		{Annotation{Type: "circle", X: 5, Y: 5}, "unknown annotation type"},
+		{Annotation{Type: "rect", X: 1, Y: 1, X2: 9, Y2: 9, Color: "red"}, "invalid color"},
+	}
+	for _, tt := range tests {
+		out := filepath.Join(dir, "out.png")
+		_, err := app.AnnotateScreenshot(src, []Annotation{tt.ann}, out)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%+v: err = %v, want %q", tt.ann, err, tt.wantErr)
+		}
+		if _, statErr := os.Stat(out); statErr == nil {
+			t.Errorf("%+v: no file should be written on validation errors", tt.ann)
+		}
+	}
+}
+
+func TestDrawLabelRendersNonASCII(t *testing.T) {
+	fonts := labelFonts()
+	if len(fonts) == 0 {
+		t.Fatal("the embedded Go font should always load")
+	}
+	if labelFontFor(fonts, 'A') != fonts[0] {
+		t.Error("Latin text should use the embedded font")
+	}
+
+	text := "Ошибка"
+	if len(fonts) > 1 {
+		// A system CJK font was found, so CJK must come from it
+		if labelFontFor(fonts, '错') == fonts[0] {
+			t.Error("CJK text should fall back to the system font")
+		}
+		text = "错误 Bug"
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 400, 100))
+	drawLabel(img, image.Pt(0, 0), text, 32, color.NRGBA{G: 255, A: 255})
+	green := 0
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 400; x++ {
+			if _, g, _, _ := img.At(x, y).RGBA(); g>>8 > 200 {
+				green++
+			}
+		}
+	}
+	if green < 50 {
+		t.Errorf("label %q drew only %d glyph pixels", text, green)
+	}
+}
+
+func TestReadImageFileAsDataURL(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "shot.png")
+	writeTestPNG(t, src, 4, 4)
+	url, err := (&App{}).ReadImageFileAsDataURL(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(url, "data:image/png;base64,") {
+		t.Errorf("data URL = %.40s", url)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log"
@@ -14,6 +15,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"Gaze/pkg/cache"
@@ -36,8 +38,10 @@ type App struct {
 	adbKeyboardPath  string // Path to extracted ADBKeyboard APK for Unicode text input
 	protocPath       string // Path to extracted protoc binary
 	protocIncludeDir string // Path to extracted protoc well-known type includes
-	logcatCmd        *exec.Cmd
-	logcatCancel     context.CancelFunc
+
+	// Binaries that could not be extracted (name -> error), surfaced to the UI
+	binaryExtractErrors   map[string]string
+	binaryExtractErrorsMu sync.Mutex
 
 	// Generic mutex for shared state
 	mu sync.Mutex
@@ -51,12 +55,26 @@ type App struct {
 	// Scrcpy process management
 	scrcpyCmds      map[string]*exec.Cmd
 	scrcpyRecordCmd map[string]*exec.Cmd
-	scrcpyMu        sync.Mutex
+	// Closed by StopRecording so a segmented recording isn't rotated to a new file
+	scrcpyRecordStop map[string]chan struct{}
+	scrcpyMu         sync.Mutex
+	// Config of each mirror the user wants running, used to relaunch it after a disconnect
+	scrcpyConfigs map[string]ScrcpyConfig
+	// Keeps the host awake while any mirror or recording runs
+	sleepInhibit sleepInhibitor
+
+	// Logcat streams by device ID
+	logcatStreams map[string]*logcatStream
+	logcatMu      sync.Mutex
 
 	// File open process management
 	openFileCmds map[string]*exec.Cmd
 	openFileMu   sync.Mutex
 
+	// File push process management, by local path
+	pushCmds map[string]*exec.Cmd
+	pushMu   sync.Mutex
+
 	// Wireless Server
 	httpServer *http.Server
 	localAddr  string
@@ -73,8 +91,8 @@ type App struct {
 	idToSerialMu sync.RWMutex
 
 	// Wireless stability
-	reconnectCooldown map[string]time.Time
-	reconnectMu       sync.Mutex
+	reconnectStates map[string]*ReconnectStats
+	reconnectMu     sync.Mutex
 
 	// Device monitor
 	deviceMonitorCancel context.CancelFunc
@@ -98,18 +116,25 @@ type App struct {
 
 	// Workflow file watcher (for MCP → GUI sync)
 	workflowWatcher *WorkflowWatcher
+
+	// Command runner for one-shot adb calls; nil uses exec (tests inject a fake)
+	runner CommandRunner
 }
 
 // NewApp creates a new App instance
 func NewApp(version string) *App {
 	app := &App{
-		scrcpyCmds:        make(map[string]*exec.Cmd),
-		scrcpyRecordCmd:   make(map[string]*exec.Cmd),
-		openFileCmds:      make(map[string]*exec.Cmd),
-		idToSerial:        make(map[string]string),
-		reconnectCooldown: make(map[string]time.Time),
-		sessionMonitors:   make(map[string]*DeviceMonitor),
-		version:           version,
+		scrcpyCmds:       make(map[string]*exec.Cmd),
+		scrcpyRecordCmd:  make(map[string]*exec.Cmd),
+		scrcpyRecordStop: make(map[string]chan struct{}),
+		scrcpyConfigs:    make(map[string]ScrcpyConfig),
+		logcatStreams:    make(map[string]*logcatStream),
+		openFileCmds:     make(map[string]*exec.Cmd),
+		pushCmds:         make(map[string]*exec.Cmd),
+		idToSerial:       make(map[string]string),
+		reconnectStates:  make(map[string]*ReconnectStats),
+		sessionMonitors:  make(map[string]*DeviceMonitor),
+		version:          version,
 	}
 	app.initCacheService()
 	return app
@@ -267,6 +292,7 @@ func (a *App) shutdownCore() {
 	a.shutdownEventSystem()
 
 	a.scrcpyMu.Lock()
+	clear(a.scrcpyConfigs) // no auto-restart of mirrors killed below
 	for id, cmd := range a.scrcpyCmds {
 		if cmd.Process != nil {
 			_ = cmd.Process.Kill()
@@ -281,14 +307,17 @@ func (a *App) shutdownCore() {
 	}
 	a.scrcpyMu.Unlock()
 
-	a.StopLogcat()
+	a.StopAllLogcat()
 	a.StopDeviceMonitor()
 	a.stopAllTouchRecordings()
 	a.stopAllActiveTasks()
 	a.StopAllDeviceStateMonitors()
 	a.stopAllSessionMonitors()
 	a.StopAllNetworkMonitors()
+	a.StopAllBatteryMonitors()
+	a.StopAllConnectionMonitors()
 	a.stopAllOpenFileCommands()
+	a.stopAllPushCommands()
 
 	LogAppState(StateStopped, nil)
 	CloseLogger()
@@ -376,14 +405,7 @@ func (a *App) updateLastActive(deviceId string) {
 		return
 	}
 
-	serial := deviceId
-	a.idToSerialMu.RLock()
-	if s, ok := a.idToSerial[deviceId]; ok {
-		serial = s
-	}
-	a.idToSerialMu.RUnlock()
-
-	a.cacheService.SetLastActive(serial, time.Now().Unix())
+	a.cacheService.SetLastActive(a.serialFor(deviceId), time.Now().Unix())
 	go a.saveSettings()
 }
 
@@ -434,9 +456,9 @@ func (a *App) setupBinaries() {
 
 		info, err := os.Stat(path)
 		if err != nil || info.Size() != int64(len(data)) {
-			err = os.WriteFile(path, data, 0755)
-			if err != nil {
-				LogDebug("app").Str("name", name).Err(err).Msg("Error extracting embedded binary")
+			if err := writeBinaryWithRetry(path, data); err != nil {
+				LogWarn("app").Str("name", name).Err(err).Msg("Error extracting embedded binary")
+				a.recordBinaryExtractError(name, err)
 			}
 		}
 
@@ -508,6 +530,75 @@ func (a *App) setupBinaries() {
 	a.Log("Final ADB path: %s", a.adbPath)
 }
 
+// writeBinaryWithRetry writes an extracted binary, retrying with backoff while the
+// target is locked. On Windows a running adb.exe from a previous instance holds the
+// file open; as a last resort the old file is renamed aside (Windows allows renaming
+// a running executable) and the new one written in its place.
+func writeBinaryWithRetry(path string, data []byte) error {
+	const attempts = 5
+	delay := 100 * time.Millisecond
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = os.WriteFile(path, data, 0755); err == nil {
+			return nil
+		}
+		if !isFileInUseError(err) {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+
+	if runtime.GOOS == "windows" {
+		stale := path + ".old"
+		_ = os.Remove(stale)
+		if renameErr := os.Rename(path, stale); renameErr == nil {
+			if err = os.WriteFile(path, data, 0755); err == nil {
+				return nil
+			}
+		}
+	}
+	return err
+}
+
+// isFileInUseError reports whether err is a Windows sharing/lock violation
+func isFileInUseError(err error) bool {
+	if runtime.GOOS != "windows" {
+		return false
+	}
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		// ERROR_ACCESS_DENIED, ERROR_SHARING_VIOLATION, ERROR_LOCK_VIOLATION
+		return errno == 5 || errno == 32 || errno == 33
+	}
+	return false
+}
+
+// recordBinaryExtractError remembers a failed extraction. Extraction runs before the
+// frontend is up, so the UI polls GetBinaryExtractErrors instead of listening for an event.
+func (a *App) recordBinaryExtractError(name string, err error) {
+	a.binaryExtractErrorsMu.Lock()
+	if a.binaryExtractErrors == nil {
+		a.binaryExtractErrors = make(map[string]string)
+	}
+	a.binaryExtractErrors[name] = err.Error()
+	a.binaryExtractErrorsMu.Unlock()
+}
+
+// GetBinaryExtractErrors returns bundled binaries that failed to extract at startup.
+// An entry means the app may be running a stale copy of that binary.
+func (a *App) GetBinaryExtractErrors() map[string]string {
+	a.binaryExtractErrorsMu.Lock()
+	defer a.binaryExtractErrorsMu.Unlock()
+
+	result := make(map[string]string, len(a.binaryExtractErrors))
+	for k, v := range a.binaryExtractErrors {
+		result[k] = v
+	}
+	return result
+}
+
 // extractEmbedDir extracts an embedded filesystem directory to disk.
 // srcPrefix is the embedded path prefix (e.g. "bin/protoc-include"),
 // dstDir is the target directory on disk.
@@ -547,7 +638,18 @@ func (a *App) newAdbCommand(ctx context.Context, args ...string) *exec.Cmd {
 	} else {
 		cmd = exec.Command(a.adbPath, args...)
 	}
+	cmd.Env = a.adbCommandEnv()
+	return cmd
+}
 
+// adbCommandEnv returns the process environment for adb with proxy variables removed
+// and the configured adb server address applied
+func (a *App) adbCommandEnv() []string {
+	return withAdbServerEnv(proxyFreeEnv(), a.GetAdbServerAddress())
+}
+
+// proxyFreeEnv returns the process environment with proxy variables removed
+func proxyFreeEnv() []string {
 	env := os.Environ()
 	newEnv := make([]string, 0, len(env))
 	proxyVars := []string{"HTTP_PROXY", "HTTPS_PROXY", "ALL_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "all_proxy", "no_proxy"}
@@ -564,8 +666,7 @@ func (a *App) newAdbCommand(ctx context.Context, args ...string) *exec.Cmd {
 			newEnv = append(newEnv, e)
 		}
 	}
-	cmd.Env = newEnv
-	return cmd
+	return newEnv
 }
 
 // newScrcpyCommand creates an exec.Cmd for scrcpy with a clean environment
@@ -576,24 +677,8 @@ func (a *App) newScrcpyCommand(args ...string) *exec.Cmd {
 func (a *App) newScrcpyCommandContext(ctx context.Context, args ...string) *exec.Cmd {
 	cmd := exec.CommandContext(ctx, a.scrcpyPath, args...)
 
-	env := os.Environ()
-	newEnv := make([]string, 0, len(env))
-	proxyVars := []string{"HTTP_PROXY", "HTTPS_PROXY", "ALL_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "all_proxy", "no_proxy"}
-
-	for _, e := range env {
-		isProxy := false
-		for _, v := range proxyVars {
-			if strings.HasPrefix(e, v+"=") {
-				isProxy = true
-				break
-			}
-		}
-		if !isProxy {
-			newEnv = append(newEnv, e)
-		}
-	}
-
-	newEnv = append(newEnv,
+	// scrcpy runs adb itself, so it needs the same server address
+	newEnv := append(a.adbCommandEnv(),
 		"SCRCPY_SERVER_PATH="+a.serverPath,
 		"ADB="+a.adbPath,
 	)
@@ -621,9 +706,12 @@ func (a *App) initEventSystem() {
 		return
 	}
 	a.eventStore = store
+	a.applyRetentionPolicy()
 
 	// Create event pipeline
 	a.eventPipeline = NewEventPipeline(context.Background(), a.ctx, store, a.mcpMode)
+	a.eventPipeline.SetTraceWindow(int64(a.GetTraceWindowMs()))
+	a.applySourceSampling()
 	a.eventPipeline.Start()
 
 	// Create assertion engine
@@ -836,7 +924,7 @@ func (a *App) EndActiveSession(sessionID, status string) {
 	if session != nil {
 		if session.Config.Logcat.Enabled {
 			log.Printf("[EndActiveSession] Stopping logcat")
-			a.StopLogcat()
+			a.StopLogcat(session.DeviceID)
 			a.stopAllTouchRecordings()
 			a.StopAllDeviceStateMonitors()
 			a.StopAllPerfMonitors()
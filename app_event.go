@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"log"
 	"runtime"
+	"strconv"
 	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // ========================================
@@ -30,6 +33,15 @@ func (a *App) QuerySessionEvents(query EventQuery) (*EventQueryResult, error) {
 	return result, err
 }
 
+// SearchAllSessions searches events across every stored session, newest first.
+// Each result carries its session ID and name so the UI can jump to it.
+func (a *App) SearchAllSessions(query string, limit int) ([]UnifiedEvent, error) {
+	if a.eventStore == nil {
+		return []UnifiedEvent{}, nil
+	}
+	return a.eventStore.SearchAllSessions(query, limit)
+}
+
 // GetStoredEvent gets a single event by ID
 func (a *App) GetStoredEvent(eventID string) (*UnifiedEvent, error) {
 	if a.eventStore == nil {
@@ -70,6 +82,15 @@ func (a *App) ListStoredSessions(deviceID string, limit int) ([]DeviceSession, e
 	return a.eventStore.ListSessions(deviceID, limit)
 }
 
+// ListStoredSessionsFiltered lists sessions by device, start time range (Unix seconds),
+// status and name, with the total count for pagination
+func (a *App) ListStoredSessionsFiltered(deviceID string, startUnix, endUnix int64, status, searchName string, limit, offset int) (*SessionListResult, error) {
+	if a.eventStore == nil {
+		return &SessionListResult{Sessions: []DeviceSession{}}, nil
+	}
+	return a.eventStore.ListSessionsFiltered(deviceID, startUnix, endUnix, status, searchName, limit, offset)
+}
+
 // DeleteStoredSession deletes a session and its events
 func (a *App) DeleteStoredSession(sessionID string) error {
 	if a.eventStore == nil {
@@ -187,6 +208,78 @@ func (a *App) CleanupOldSessionData(maxAgeDays int) (int, error) {
 	return a.eventStore.CleanupOldSessions(time.Duration(maxAgeDays) * 24 * time.Hour)
 }
 
+// Setting keys the retention policy is stored under; a missing key means no limit
+const (
+	retentionMaxAgeDaysKey     = "retention.maxAgeDays"
+	retentionMaxTotalSizeMBKey = "retention.maxTotalSizeMB"
+)
+
+// GetRetentionPolicy returns the saved event store retention policy
+func (a *App) GetRetentionPolicy() RetentionPolicy {
+	return RetentionPolicy{
+		MaxAgeDays:     a.retentionLimit(retentionMaxAgeDaysKey),
+		MaxTotalSizeMB: a.retentionLimit(retentionMaxTotalSizeMBKey),
+	}
+}
+
+// retentionLimit reads one retention limit setting; unset or invalid values disable it
+func (a *App) retentionLimit(key string) int {
+	value, err := a.GetSetting(key)
+	if err != nil || value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		LogWarn("event_store").Str("key", key).Str("value", value).Msg("Ignoring invalid retention setting")
+		return 0
+	}
+	return n
+}
+
+// SetRetentionPolicy saves how long and how much session history to keep, then
+// enforces it in the background. Passing 0 for a limit disables it.
+func (a *App) SetRetentionPolicy(maxAgeDays int, maxTotalSizeMB int) error {
+	if maxAgeDays < 0 || maxTotalSizeMB < 0 {
+		return fmt.Errorf("retention limits must not be negative, got %d days / %d MB", maxAgeDays, maxTotalSizeMB)
+	}
+	if err := a.SetSetting(retentionMaxAgeDaysKey, retentionSettingValue(maxAgeDays)); err != nil {
+		return err
+	}
+	if err := a.SetSetting(retentionMaxTotalSizeMBKey, retentionSettingValue(maxTotalSizeMB)); err != nil {
+		return err
+	}
+
+	if a.eventStore != nil {
+		a.eventStore.SetRetentionPolicy(a.GetRetentionPolicy())
+		a.eventStore.enforceRetentionAsync()
+	}
+	return nil
+}
+
+// retentionSettingValue formats a limit for SetSetting; 0 removes the key
+func retentionSettingValue(limit int) string {
+	if limit == 0 {
+		return ""
+	}
+	return strconv.Itoa(limit)
+}
+
+// applyRetentionPolicy loads the saved policy into the store, reports cleanups to the
+// UI and runs one pass right away rather than waiting for the hourly worker
+func (a *App) applyRetentionPolicy() {
+	a.eventStore.SetRetentionPolicy(a.GetRetentionPolicy())
+	a.eventStore.SetCleanupHandler(func(r RetentionResult) {
+		if !a.mcpMode {
+			wailsRuntime.EventsEmit(a.ctx, "store-cleanup", map[string]interface{}{
+				"sessionsRemoved": r.SessionsRemoved,
+				"bytesFreed":      r.BytesFreed,
+				"vacuumed":        r.Vacuumed,
+			})
+		}
+	})
+	a.eventStore.enforceRetentionAsync()
+}
+
 // GetEventSystemStats returns statistics about the event system
 func (a *App) GetEventSystemStats() map[string]interface{} {
 	stats := make(map[string]interface{})
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ========================================
+// Detailed app permissions
+// ========================================
+
+// AppPermission is one permission of an app as reported by dumpsys package
+type AppPermission struct {
+	Name            string `json:"name"`
+	Group           string `json:"group"`                     // Human-readable group, e.g. "Location"; "Other" when unknown
+	ProtectionLevel string `json:"protectionLevel,omitempty"` // "dangerous" for runtime permissions, empty when unknown
+	Requested       bool   `json:"requested"`                 // Listed in the app's manifest
+	Granted         bool   `json:"granted"`
+}
+
+// permissionGroups maps well-known permissions to the group Android settings shows them under
+var permissionGroups = map[string]string{
+	"android.permission.ACCESS_FINE_LOCATION":            "Location",
+	"android.permission.ACCESS_COARSE_LOCATION":          "Location",
+	"android.permission.ACCESS_BACKGROUND_LOCATION":      "Location",
+	"android.permission.ACCESS_MEDIA_LOCATION":           "Location",
+	"android.permission.CAMERA":                          "Camera",
+	"android.permission.RECORD_AUDIO":                    "Microphone",
+	"android.permission.READ_CONTACTS":                   "Contacts",
+	"android.permission.WRITE_CONTACTS":                  "Contacts",
+	"android.permission.GET_ACCOUNTS":                    "Contacts",
+	"android.permission.READ_CALENDAR":                   "Calendar",
+	"android.permission.WRITE_CALENDAR":                  "Calendar",
+	"android.permission.READ_PHONE_STATE":                "Phone",
+	"android.permission.READ_PHONE_NUMBERS":              "Phone",
+	"android.permission.CALL_PHONE":                      "Phone",
+	"android.permission.ANSWER_PHONE_CALLS":              "Phone",
+	"android.permission.ADD_VOICEMAIL":                   "Phone",
+	"android.permission.USE_SIP":                         "Phone",
+	"android.permission.ACCEPT_HANDOVER":                 "Phone",
+	"android.permission.READ_CALL_LOG":                   "Call logs",
+	"android.permission.WRITE_CALL_LOG":                  "Call logs",
+	"android.permission.PROCESS_OUTGOING_CALLS":          "Call logs",
+	"android.permission.SEND_SMS":                        "SMS",
+	"android.permission.RECEIVE_SMS":                     "SMS",
+	"android.permission.READ_SMS":                        "SMS",
+	"android.permission.RECEIVE_MMS":                     "SMS",
+	"android.permission.RECEIVE_WAP_PUSH":                "SMS",
+	"android.permission.READ_EXTERNAL_STORAGE":           "Storage",
+	"android.permission.WRITE_EXTERNAL_STORAGE":          "Storage",
+	"android.permission.MANAGE_EXTERNAL_STORAGE":         "Storage",
+	"android.permission.READ_MEDIA_IMAGES":               "Photos and videos",
+	"android.permission.READ_MEDIA_VIDEO":                "Photos and videos",
+	"android.permission.READ_MEDIA_VISUAL_USER_SELECTED": "Photos and videos",
+	"android.permission.READ_MEDIA_AUDIO":                "Music and audio",
+	"android.permission.BODY_SENSORS":                    "Body sensors",
+	"android.permission.BODY_SENSORS_BACKGROUND":         "Body sensors",
+	"android.permission.ACTIVITY_RECOGNITION":            "Physical activity",
+	"android.permission.BLUETOOTH_SCAN":                  "Nearby devices",
+	"android.permission.BLUETOOTH_CONNECT":               "Nearby devices",
+	"android.permission.BLUETOOTH_ADVERTISE":             "Nearby devices",
+	"android.permission.NEARBY_WIFI_DEVICES":             "Nearby devices",
+	"android.permission.UWB_RANGING":                     "Nearby devices",
+	"android.permission.POST_NOTIFICATIONS":              "Notifications",
+	"android.permission.INTERNET":                        "Network",
+	"android.permission.ACCESS_NETWORK_STATE":            "Network",
+	"android.permission.ACCESS_WIFI_STATE":               "Network",
+	"android.permission.CHANGE_WIFI_STATE":               "Network",
+	"android.permission.CHANGE_NETWORK_STATE":            "Network",
+	"android.permission.SYSTEM_ALERT_WINDOW":             "Display over other apps",
+	"android.permission.REQUEST_INSTALL_PACKAGES":        "Install unknown apps",
+	"android.permission.PACKAGE_USAGE_STATS":             "Usage access",
+	"android.permission.SCHEDULE_EXACT_ALARM":            "Alarms and reminders",
+	"android.permission.USE_EXACT_ALARM":                 "Alarms and reminders",
+}
+
+// permissionGroup returns the display group of a permission
+func permissionGroup(name string) string {
+	if group, ok := permissionGroups[name]; ok {
+		return group
+	}
+	return "Other"
+}
+
+// GetAppPermissionsDetailed lists the permissions an app requests or holds, with whether
+// each is granted. Runtime permissions are reported for the first user in dumpsys, which
+// is normally user 0.
+func (a *App) GetAppPermissionsDetailed(deviceId, packageName string) ([]AppPermission, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return nil, err
+	}
+	if packageName == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	output, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "dumpsys", "package", packageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get package info: %w", err)
+	}
+	if !strings.Contains(string(output), "Package ["+packageName+"]") {
+		return nil, fmt.Errorf("package %s not found", packageName)
+	}
+	return parseDetailedPermissions(string(output)), nil
+}
+
+// parseDetailedPermissions reads the permission sections of `dumpsys package <pkg>`:
+//
+//	requested permissions:
+//	  android.permission.CAMERA
+//	install permissions:
+//	  android.permission.INTERNET: granted=true
+//	User 0: ceDataInode=...
+//	  runtime permissions:
+//	    android.permission.CAMERA: granted=false, flags=[ USER_SET ]
+//
+// Permissions are returned in manifest order, followed by held permissions the app
+// didn't request itself (e.g. through a shared user ID).
+func parseDetailedPermissions(output string) []AppPermission {
+	var perms []AppPermission
+	index := make(map[string]int)
+	entry := func(name string) *AppPermission {
+		if i, ok := index[name]; ok {
+			return &perms[i]
+		}
+		index[name] = len(perms)
+		perms = append(perms, AppPermission{Name: name, Group: permissionGroup(name)})
+		return &perms[len(perms)-1]
+	}
+
+	section := ""
+	sectionIndent := 0
+	runtimeSeen := make(map[string]bool) // Only the first user's runtime state counts
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if strings.HasSuffix(trimmed, "permissions:") {
+			section = strings.TrimSuffix(trimmed, ":")
+			sectionIndent = indent
+			continue
+		}
+		if section == "" {
+			continue
+		}
+		if indent <= sectionIndent {
+			section = ""
+			continue
+		}
+
+		// Restricted permissions are listed as "android.permission.READ_SMS, restricted"
+		name, attrs, _ := strings.Cut(trimmed, ":")
+		name, _, _ = strings.Cut(name, ",")
+		name = strings.TrimSpace(name)
+		if strings.ContainsAny(name, " =") {
+			continue
+		}
+		granted := strings.Contains(attrs, "granted=true")
+
+		switch section {
+		case "requested permissions":
+			entry(name).Requested = true
+		case "install permissions":
+			entry(name).Granted = granted
+		case "runtime permissions":
+			if runtimeSeen[name] {
+				continue
+			}
+			runtimeSeen[name] = true
+			p := entry(name)
+			p.Granted = granted
+			p.ProtectionLevel = "dangerous"
+		}
+	}
+
+	// Requested permissions first, keeping manifest order
+	ordered := make([]AppPermission, 0, len(perms))
+	for _, p := range perms {
+		if p.Requested {
+			ordered = append(ordered, p)
+		}
+	}
+	for _, p := range perms {
+		if !p.Requested {
+			ordered = append(ordered, p)
+		}
+	}
+	return ordered
+}
+
+// ========================================
+// Granting and revoking runtime permissions
+// ========================================
+
+var permissionNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z0-9_]+)*$`)
+
+// normalizePermissionName accepts "CAMERA", "camera" or "android.permission.CAMERA"
+// and returns the fully qualified name. Names with a dot are taken as given.
+func normalizePermissionName(permission string) (string, error) {
+	permission = strings.TrimSpace(permission)
+	if !permissionNameRegex.MatchString(permission) {
+		return "", fmt.Errorf("invalid permission name: %q", permission)
+	}
+	if !strings.Contains(permission, ".") {
+		permission = "android.permission." + strings.ToUpper(permission)
+	}
+	return permission, nil
+}
+
+// GrantPermission grants a runtime permission to an app with `pm grant`
+func (a *App) GrantPermission(deviceId, packageName, permission string) (string, error) {
+	return a.changePermission(deviceId, packageName, permission, "grant")
+}
+
+// RevokePermission revokes a runtime permission from an app with `pm revoke`
+func (a *App) RevokePermission(deviceId, packageName, permission string) (string, error) {
+	return a.changePermission(deviceId, packageName, permission, "revoke")
+}
+
+// ResetPermissions revokes every granted runtime permission of one app and clears the
+// user-set/user-fixed flags so the app asks again. `pm reset-permissions` isn't used
+// because it resets the runtime permissions of every app on the device.
+func (a *App) ResetPermissions(deviceId, packageName string) (string, error) {
+	a.updateLastActive(deviceId)
+	perms, err := a.GetAppPermissionsDetailed(deviceId, packageName)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	revoked := 0
+	for _, p := range perms {
+		if p.ProtectionLevel != "dangerous" {
+			continue
+		}
+		if p.Granted {
+			output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "pm", "revoke", packageName, p.Name)
+			outStr := strings.TrimSpace(string(output))
+			if err != nil || pmCommandFailed(outStr) {
+				return fmt.Sprintf("Revoked %d permission(s)", revoked), permissionChangeError("revoke", p.Name, outStr, err)
+			}
+			revoked++
+		}
+		// clear-permission-flags is missing on older Android; the revoke above still applies
+		output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "pm", "clear-permission-flags", packageName, p.Name, "user-set", "user-fixed")
+		if outStr := strings.TrimSpace(string(output)); err != nil || pmCommandFailed(outStr) {
+			LogDebug("permissions").Str("permission", p.Name).Str("output", outStr).Err(err).Msg("Could not clear permission flags")
+		}
+	}
+
+	a.Log("Reset permissions of %s on %s (%d revoked)", packageName, deviceId, revoked)
+	return fmt.Sprintf("Revoked %d permission(s)", revoked), nil
+}
+
+// changePermission runs `pm grant` or `pm revoke` for one permission
+func (a *App) changePermission(deviceId, packageName, permission, verb string) (string, error) {
+	a.updateLastActive(deviceId)
+	name, err := normalizePermissionName(permission)
+	if err != nil {
+		return "", err
+	}
+	if err := a.requireInstalledPackage(deviceId, packageName); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "pm", verb, packageName, name)
+	outStr := strings.TrimSpace(string(output))
+	if err != nil || pmCommandFailed(outStr) {
+		return outStr, permissionChangeError(verb, name, outStr, err)
+	}
+	a.Log("%s %s for %s on %s", verb, name, packageName, deviceId)
+	return outStr, nil
+}
+
+// requireInstalledPackage validates the device and package and checks the package exists
+func (a *App) requireInstalledPackage(deviceId, packageName string) error {
+	installed, err := a.IsAppInstalled(deviceId, packageName)
+	if err != nil {
+		return err
+	}
+	if !installed {
+		return fmt.Errorf("package %s is not installed", packageName)
+	}
+	return nil
+}
+
+// pmCommandFailed detects failures that pm reports on stdout; older Android versions
+// exit with status 0 even when an exception is printed
+func pmCommandFailed(output string) bool {
+	return strings.Contains(output, "Exception") || strings.HasPrefix(output, "Error") || strings.HasPrefix(output, "Failure")
+}
+
+// permissionChangeError explains a failed grant/revoke. The usual cause is a permission
+// that isn't a runtime permission: install-time permissions can't be changed at all.
+func permissionChangeError(verb, permission, output string, err error) error {
+	switch {
+	case strings.Contains(output, "not a changeable permission type"):
+		return fmt.Errorf("%s is not a runtime permission, only runtime permissions can be granted or revoked", permission)
+	case strings.Contains(output, "has not requested permission"):
+		return fmt.Errorf("the app does not request %s in its manifest", permission)
+	case strings.Contains(output, "Unknown permission"):
+		return fmt.Errorf("unknown permission: %s", permission)
+	}
+	if output == "" && err != nil {
+		output = err.Error()
+	}
+	return fmt.Errorf("failed to %s %s: %s", verb, permission, output)
+}
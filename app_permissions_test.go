@@ -0,0 +1,149 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const dumpsysPackagePermissions = `Packages:
+  Package [com.example.app] (8a1b2c3):
+    userId=10234
+    declared permissions:
+      com.example.app.permission.C2D_MESSAGE: prot=signature, INSTALLED
+    requested permissions:
+      android.permission.INTERNET
+      android.permission.CAMERA
+      android.permission.ACCESS_FINE_LOCATION
+      android.permission.READ_SMS, restricted
+      com.example.app.permission.C2D_MESSAGE
+    install permissions:
+      android.permission.INTERNET: granted=true
+      com.example.app.permission.C2D_MESSAGE: granted=true
+      android.permission.WAKE_LOCK: granted=true
+    User 0: ceDataInode=123456 installed=true hidden=false suspended=false stopped=false
+      gids=[3003]
+      runtime permissions:
+        android.permission.ACCESS_FINE_LOCATION: granted=true, flags=[ USER_SET ]
+        android.permission.CAMERA: granted=false, flags=[ USER_SET ]
+        android.permission.READ_SMS: granted=false, flags=[ RESTRICTION_INSTALLER_EXEMPT ]
+    User 10: ceDataInode=0 installed=true hidden=false suspended=false stopped=true
+      runtime permissions:
+        android.permission.CAMERA: granted=true, flags=[ USER_SET ]
+`
+
+func TestParseDetailedPermissions(t *testing.T) {
+	perms := parseDetailedPermissions(dumpsysPackagePermissions)
+
+	want := []AppPermission{
+		{Name: "android.permission.INTERNET", Group: "Network", Requested: true, Granted: true},
+		{Name: "android.permission.CAMERA", Group: "Camera", ProtectionLevel: "dangerous", Requested: true},
+		{Name: "android.permission.ACCESS_FINE_LOCATION", Group: "Location", ProtectionLevel: "dangerous", Requested: true, Granted: true},
+		{Name: "android.permission.READ_SMS", Group: "SMS", ProtectionLevel: "dangerous", Requested: true},
+		{Name: "com.example.app.permission.C2D_MESSAGE", Group: "Other", Requested: true, Granted: true},
+		{Name: "android.permission.WAKE_LOCK", Group: "Other", Granted: true},
+	}
+	if len(perms) != len(want) {
+		t.Fatalf("got %d permissions, want %d: %+v", len(perms), len(want), perms)
+	}
+	for i := range want {
+		if perms[i] != want[i] {
+			t.Errorf("permission %d = %+v, want %+v", i, perms[i], want[i])
+		}
+	}
+}
+
+func TestGetAppPermissionsDetailedUnknownPackage(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell dumpsys package com.missing": "Dexopt state:\n  Unable to find package: com.missing\n",
+	})
+	if _, err := app.GetAppPermissionsDetailed("R5CT1234ABC", "com.missing"); err == nil {
+		t.Error("expected an error for a package dumpsys doesn't know")
+	}
+}
+
+func TestNormalizePermissionName(t *testing.T) {
+	tests := map[string]string{
+		"CAMERA":                           "android.permission.CAMERA",
+		"camera":                           "android.permission.CAMERA",
+		" android.permission.RECORD_AUDIO": "android.permission.RECORD_AUDIO",
+		"com.example.app.permission.C2D":   "com.example.app.permission.C2D",
+	}
+	for in, want := range tests {
+		got, err := normalizePermissionName(in)
+		if err != nil || got != want {
+			t.Errorf("normalizePermissionName(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "CAMERA; reboot", "android..CAMERA"} {
+		if _, err := normalizePermissionName(bad); err == nil {
+			t.Errorf("normalizePermissionName(%q) should fail", bad)
+		}
+	}
+}
+
+func TestGrantPermission(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell pm list packages com.example.app":                     "package:com.example.app\n",
+		"-s R5CT1234ABC shell pm grant com.example.app android.permission.CAMERA":   "",
+		"-s R5CT1234ABC shell pm grant com.example.app android.permission.INTERNET": "Exception occurred while executing 'grant':\njava.lang.SecurityException: Permission android.permission.INTERNET requested by com.example.app is not a changeable permission type\n",
+		"-s R5CT1234ABC shell pm revoke com.example.app android.permission.CAMERA":  "",
+		"-s R5CT1234ABC shell pm list packages com.missing":                         "",
+	})
+
+	if _, err := app.GrantPermission("R5CT1234ABC", "com.example.app", "camera"); err != nil {
+		t.Errorf("grant CAMERA: %v", err)
+	}
+	if _, err := app.RevokePermission("R5CT1234ABC", "com.example.app", "android.permission.CAMERA"); err != nil {
+		t.Errorf("revoke CAMERA: %v", err)
+	}
+
+	_, err := app.GrantPermission("R5CT1234ABC", "com.example.app", "INTERNET")
+	if err == nil || !strings.Contains(err.Error(), "not a runtime permission") {
+		t.Errorf("grant INTERNET error = %v, want a not-a-runtime-permission error", err)
+	}
+
+	if _, err := app.GrantPermission("R5CT1234ABC", "com.missing", "CAMERA"); err == nil {
+		t.Error("expected an error for a package that isn't installed")
+	}
+}
+
+func TestResetPermissions(t *testing.T) {
+	const prefix = "-s R5CT1234ABC shell pm "
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell dumpsys package com.example.app":                                                        dumpsysPackagePermissions,
+		prefix + "revoke com.example.app android.permission.ACCESS_FINE_LOCATION":                                     "",
+		prefix + "clear-permission-flags com.example.app android.permission.ACCESS_FINE_LOCATION user-set user-fixed": "",
+		prefix + "clear-permission-flags com.example.app android.permission.CAMERA user-set user-fixed":               "",
+		// READ_SMS flags fail to clear (older Android); that must not fail the reset
+	})
+
+	out, err := app.ResetPermissions("R5CT1234ABC", "com.example.app")
+	if err != nil {
+		t.Fatalf("ResetPermissions: %v", err)
+	}
+	if !strings.Contains(out, "Revoked 1") {
+		t.Errorf("output = %q, want one revoked permission", out)
+	}
+
+	var revokes []string
+	for _, call := range app.runner.(*fakeRunner).calls {
+		if strings.Contains(call, "reset-permissions") {
+			t.Errorf("device-wide reset ran: %q", call)
+		}
+		if strings.HasPrefix(call, prefix+"revoke ") {
+			revokes = append(revokes, call)
+		}
+	}
+	// Only granted runtime permissions are revoked; install permissions are left alone
+	if len(revokes) != 1 || !strings.HasSuffix(revokes[0], "ACCESS_FINE_LOCATION") {
+		t.Errorf("revokes = %v, want only ACCESS_FINE_LOCATION", revokes)
+	}
+
+	failing := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell dumpsys package com.example.app":                    dumpsysPackagePermissions,
+		prefix + "revoke com.example.app android.permission.ACCESS_FINE_LOCATION": "Exception occurred while executing 'revoke':\njava.lang.SecurityException: denied\n",
+	})
+	if _, err := failing.ResetPermissions("R5CT1234ABC", "com.example.app"); err == nil {
+		t.Error("expected a failed revoke to be reported")
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ========================================
+// App standby buckets and battery optimization
+// ========================================
+
+// standbyBucketNames maps UsageStatsManager.STANDBY_BUCKET_* values to the names
+// `am set-standby-bucket` accepts
+var standbyBucketNames = map[int]string{
+	5:  "exempted",
+	10: "active",
+	20: "working_set",
+	30: "frequent",
+	40: "rare",
+	45: "restricted",
+	50: "never",
+}
+
+// AppPowerState is how the system throttles an app in the background
+type AppPowerState struct {
+	PackageName string `json:"packageName"`
+	Bucket      string `json:"bucket"`    // Standby bucket name, "" if unknown
+	Optimized   bool   `json:"optimized"` // False when the app is on the Doze whitelist
+	// Apps the system whitelists itself can't be un-whitelisted from the shell
+	SystemWhitelisted bool `json:"systemWhitelisted"`
+}
+
+// SetAppStandbyBucket moves an app into a standby bucket: active, working_set,
+// frequent, rare or restricted (Android 11+). Requires Android 9.
+func (a *App) SetAppStandbyBucket(deviceId, packageName, bucket string) error {
+	bucket = strings.ToLower(strings.TrimSpace(bucket))
+	switch bucket {
+	case "active", "working_set", "frequent", "rare", "restricted":
+	default:
+		return fmt.Errorf("invalid standby bucket %q: use active, working_set, frequent, rare or restricted", bucket)
+	}
+	if err := a.requireInstalledPackage(deviceId, packageName); err != nil {
+		return err
+	}
+	a.updateLastActive(deviceId)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "am", "set-standby-bucket", packageName, bucket)
+	outStr := strings.TrimSpace(string(output))
+	if err != nil || pmCommandFailed(outStr) || strings.Contains(outStr, "Unknown command") {
+		if strings.Contains(outStr, "Unknown command") {
+			return fmt.Errorf("standby buckets require Android 9 or newer")
+		}
+		return fmt.Errorf("failed to set standby bucket: %v %s", err, outStr)
+	}
+	a.Log("Standby bucket of %s on %s set to %s", packageName, deviceId, bucket)
+	return nil
+}
+
+// ToggleBatteryOptimization adds an app to (optimize=false) or removes it from
+// (optimize=true) the Doze whitelist
+func (a *App) ToggleBatteryOptimization(deviceId, packageName string, optimize bool) error {
+	if err := a.requireInstalledPackage(deviceId, packageName); err != nil {
+		return err
+	}
+	a.updateLastActive(deviceId)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	op := "+"
+	if optimize {
+		op = "-"
+	}
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "dumpsys", "deviceidle", "whitelist", op+packageName)
+	outStr := strings.TrimSpace(string(output))
+	if err != nil || strings.Contains(outStr, "Exception") || strings.HasPrefix(outStr, "Package must be") {
+		return fmt.Errorf("failed to change battery optimization: %v %s", err, outStr)
+	}
+
+	if optimize {
+		state, err := a.GetAppPowerState(deviceId, packageName)
+		if err == nil && state.SystemWhitelisted {
+			return fmt.Errorf("%s is whitelisted by the system and can't be optimized", packageName)
+		}
+	}
+	a.Log("Battery optimization for %s on %s: %v", packageName, deviceId, optimize)
+	return nil
+}
+
+// GetAppPowerState returns the standby bucket and Doze whitelist state of an app
+func (a *App) GetAppPowerState(deviceId, packageName string) (AppPowerState, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return AppPowerState{}, err
+	}
+	if !packageNameRegex.MatchString(packageName) {
+		return AppPowerState{}, fmt.Errorf("invalid package name: %q", packageName)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	state := AppPowerState{PackageName: packageName, Optimized: true}
+
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "dumpsys", "deviceidle", "whitelist")
+	if err != nil {
+		return state, fmt.Errorf("failed to read the Doze whitelist: %w", err)
+	}
+	user, system := parseDozeWhitelist(string(out), packageName)
+	state.Optimized = !user && !system
+	state.SystemWhitelisted = system
+
+	// Missing before Android 9; the bucket is then left empty
+	if out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "am", "get-standby-bucket", packageName); err == nil {
+		state.Bucket = parseStandbyBucket(string(out))
+	}
+	return state, nil
+}
+
+// parseDozeWhitelist reports whether packageName is on the user and/or system part of
+// `dumpsys deviceidle whitelist`, whose lines look like "user,com.example.app,10123"
+// or "system-excidle,com.android.shell,2000"
+func parseDozeWhitelist(output, packageName string) (user, system bool) {
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.Split(strings.TrimSpace(line), ",")
+		if len(parts) < 2 || parts[1] != packageName {
+			continue
+		}
+		if parts[0] == "user" {
+			user = true
+		} else if strings.HasPrefix(parts[0], "system") {
+			system = true
+		}
+	}
+	return user, system
+}
+
+// parseStandbyBucket turns `am get-standby-bucket` output (a number, or a name on
+// some builds) into a bucket name
+func parseStandbyBucket(output string) string {
+	value := strings.TrimSpace(output)
+	if n, err := strconv.Atoi(value); err == nil {
+		if name, ok := standbyBucketNames[n]; ok {
+			return name
+		}
+		return value
+	}
+	for _, name := range standbyBucketNames {
+		if strings.EqualFold(value, name) {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,48 @@
+package main
+
+import "testing"
+
+const dozeWhitelistOutput = `system-excidle,com.android.shell,2000
+system,com.google.android.gms,10145
+user,com.example.app,10123
+`
+
+func TestGetAppPowerState(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell dumpsys deviceidle whitelist":                 dozeWhitelistOutput,
+		"-s R5CT1234ABC shell am get-standby-bucket com.example.app":        "40\n",
+		"-s R5CT1234ABC shell am get-standby-bucket com.google.android.gms": "5\n",
+		"-s R5CT1234ABC shell am get-standby-bucket com.example.other":      "working_set\n",
+	})
+
+	cases := []struct {
+		pkg  string
+		want AppPowerState
+	}{
+		{"com.example.app", AppPowerState{PackageName: "com.example.app", Bucket: "rare"}},
+		{"com.google.android.gms", AppPowerState{PackageName: "com.google.android.gms", Bucket: "exempted", SystemWhitelisted: true}},
+		{"com.example.other", AppPowerState{PackageName: "com.example.other", Bucket: "working_set", Optimized: true}},
+	}
+	for _, c := range cases {
+		got, err := app.GetAppPowerState("R5CT1234ABC", c.pkg)
+		if err != nil {
+			t.Fatalf("%s: %v", c.pkg, err)
+		}
+		if got != c.want {
+			t.Errorf("%s: got %+v, want %+v", c.pkg, got, c.want)
+		}
+	}
+}
+
+func TestSetAppStandbyBucket(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell pm list packages com.example.app":                 "package:com.example.app\n",
+		"-s R5CT1234ABC shell am set-standby-bucket com.example.app restricted": "",
+	})
+	if err := app.SetAppStandbyBucket("R5CT1234ABC", "com.example.app", "Restricted"); err != nil {
+		t.Errorf("SetAppStandbyBucket: %v", err)
+	}
+	if err := app.SetAppStandbyBucket("R5CT1234ABC", "com.example.app", "sleepy"); err == nil {
+		t.Error("expected an error for an unknown bucket")
+	}
+}
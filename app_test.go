@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWriteBinaryWithRetry(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "adb")
+
+	if err := writeBinaryWithRetry(path, []byte("binary")); err != nil {
+		t.Fatalf("writeBinaryWithRetry: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "binary" {
+		t.Fatalf("unexpected file contents %q, %v", data, err)
+	}
+
+	// Errors other than a lock violation fail fast instead of retrying
+	start := time.Now()
+	if err := writeBinaryWithRetry(filepath.Join(dir, "missing", "adb"), []byte("x")); err == nil {
+		t.Error("expected error for a missing directory")
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("non-lock error should not be retried, took %v", elapsed)
+	}
+}
+
+func TestIsFileInUseError(t *testing.T) {
+	sharing := &os.PathError{Op: "open", Path: "adb.exe", Err: syscall.Errno(32)}
+	if got := isFileInUseError(sharing); got != (runtime.GOOS == "windows") {
+		t.Errorf("isFileInUseError(sharing violation) = %v on %s", got, runtime.GOOS)
+	}
+	if isFileInUseError(errors.New("disk full")) {
+		t.Error("plain errors are not lock violations")
+	}
+}
+
+func TestBinaryExtractErrors(t *testing.T) {
+	app := newTestApp(nil)
+	if errs := app.GetBinaryExtractErrors(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	app.recordBinaryExtractError("adb", errors.New("access denied"))
+	app.recordBinaryExtractError("aapt", errors.New("sharing violation"))
+	app.recordBinaryExtractError("adb", errors.New("still locked"))
+
+	errs := app.GetBinaryExtractErrors()
+	if len(errs) != 2 || errs["adb"] != "still locked" || errs["aapt"] != "sharing violation" {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	// The result is a copy
+	errs["scrcpy"] = "x"
+	if _, ok := app.GetBinaryExtractErrors()["scrcpy"]; ok {
+		t.Error("GetBinaryExtractErrors must not expose the internal map")
+	}
+}
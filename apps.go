@@ -4,10 +4,12 @@ import (
 	"archive/zip"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -19,20 +21,93 @@ import (
 	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
-// ListPackages returns a list of installed packages with their type and state
-func (a *App) ListPackages(deviceId string, packageType string) ([]AppPackage, error) {
+// ListPackages returns a list of installed packages with their type and state.
+// userId selects the user or work profile (0 = owner, see ListUsers).
+func (a *App) ListPackages(deviceId string, packageType string, userId int) ([]AppPackage, error) {
 	if err := ValidateDeviceID(deviceId); err != nil {
 		return nil, err
 	}
+	if err := validateUserID(userId); err != nil {
+		return nil, err
+	}
+
+	packages, err := a.listPackageEntries(deviceId, packageType, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch labels and icons from cache in parallel
+	a.enrichPackages(packages)
+
+	return packages, nil
+}
+
+// StartListPackages lists packages in the background, emitting "package-found"
+// events in batches as they are enriched and a final "package-list-complete".
+// Use it instead of ListPackages when the device has many apps installed.
+func (a *App) StartListPackages(deviceId string, packageType string, userId int) error {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return err
+	}
+	if err := validateUserID(userId); err != nil {
+		return err
+	}
+
+	go func() {
+		const batchSize = 50
+
+		packages, err := a.listPackageEntries(deviceId, packageType, userId)
+		if err != nil {
+			if !a.mcpMode {
+				wailsRuntime.EventsEmit(a.ctx, "package-list-complete", map[string]interface{}{
+					"deviceId": deviceId,
+					"total":    0,
+					"error":    err.Error(),
+				})
+			}
+			return
+		}
+
+		for start := 0; start < len(packages); start += batchSize {
+			end := start + batchSize
+			if end > len(packages) {
+				end = len(packages)
+			}
+			batch := packages[start:end]
+			a.enrichPackages(batch)
+
+			if !a.mcpMode {
+				wailsRuntime.EventsEmit(a.ctx, "package-found", map[string]interface{}{
+					"deviceId": deviceId,
+					"packages": batch,
+				})
+			}
+		}
+
+		if !a.mcpMode {
+			wailsRuntime.EventsEmit(a.ctx, "package-list-complete", map[string]interface{}{
+				"deviceId": deviceId,
+				"total":    len(packages),
+			})
+		}
+	}()
+
+	return nil
+}
 
+// listPackageEntries runs pm list packages and returns bare entries (name, type, state)
+func (a *App) listPackageEntries(deviceId string, packageType string, userId int) ([]AppPackage, error) {
 	if packageType == "" {
 		packageType = "user"
 	}
 
+	listArgs := func(flag string) []string {
+		return append([]string{"-s", deviceId, "shell", "pm", "list", "packages", flag}, userArgs(userId)...)
+	}
+
 	// Get list of disabled packages
 	disabledPackages := make(map[string]bool)
-	cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "pm", "list", "packages", "-d")
-	output, err := cmd.Output()
+	output, _, err := a.runAdb(nil, listArgs("-d")...)
 	if err == nil {
 		lines := strings.Split(string(output), "\n")
 		for _, line := range lines {
@@ -46,8 +121,7 @@ func (a *App) ListPackages(deviceId string, packageType string) ([]AppPackage, e
 	var packages []AppPackage
 
 	fetch := func(flag, typeName string) error {
-		cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "pm", "list", "packages", flag)
-		output, err := cmd.Output()
+		output, _, err := a.runAdb(nil, listArgs(flag)...)
 		if err != nil {
 			return err
 		}
@@ -87,9 +161,14 @@ func (a *App) ListPackages(deviceId string, packageType string) ([]AppPackage, e
 		}
 	}
 
-	// Fetch labels and icons from cache in parallel
+	return packages, nil
+}
+
+// enrichPackages fills in labels, icons and versions from the aapt cache,
+// falling back to a heuristic label derived from the package name
+func (a *App) enrichPackages(packages []AppPackage) {
 	var wg sync.WaitGroup
-	sem := make(chan struct{}, 10)
+	sem := make(chan struct{}, a.GetConcurrency().Thumbnails)
 
 	for i := range packages {
 		wg.Add(1)
@@ -99,73 +178,73 @@ func (a *App) ListPackages(deviceId string, packageType string) ([]AppPackage, e
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
-			pkg := &packages[idx]
+			a.enrichPackage(&packages[idx])
+		}(i)
+	}
+	wg.Wait()
+}
 
-			if a.cacheService != nil {
-				if cached, ok := a.cacheService.GetCachedPackage(pkg.Name); ok {
-					if cached.Label != "" {
-						pkg.Label = cached.Label
-					}
-					if cached.Icon != "" {
-						pkg.Icon = cached.Icon
-					}
-					if cached.VersionName != "" {
-						pkg.VersionName = cached.VersionName
-					}
-					if cached.VersionCode != "" {
-						pkg.VersionCode = cached.VersionCode
-					}
-					if cached.MinSdkVersion != "" {
-						pkg.MinSdkVersion = cached.MinSdkVersion
-					}
-					if cached.TargetSdkVersion != "" {
-						pkg.TargetSdkVersion = cached.TargetSdkVersion
-					}
-					if len(cached.Permissions) > 0 {
-						pkg.Permissions = cached.Permissions
-					}
-				}
+func (a *App) enrichPackage(pkg *AppPackage) {
+	if a.cacheService != nil {
+		if cached, ok := a.cacheService.GetCachedPackage(pkg.Name); ok {
+			if cached.Label != "" {
+				pkg.Label = cached.Label
+			}
+			if cached.Icon != "" {
+				pkg.Icon = cached.Icon
+			}
+			if cached.VersionName != "" {
+				pkg.VersionName = cached.VersionName
+			}
+			if cached.VersionCode != "" {
+				pkg.VersionCode = cached.VersionCode
+			}
+			if cached.MinSdkVersion != "" {
+				pkg.MinSdkVersion = cached.MinSdkVersion
+			}
+			if cached.TargetSdkVersion != "" {
+				pkg.TargetSdkVersion = cached.TargetSdkVersion
 			}
+			if len(cached.Permissions) > 0 {
+				pkg.Permissions = cached.Permissions
+			}
+		}
+	}
 
-			if pkg.Label == "" {
-				brandMap := map[string]string{
-					"com.google.android.youtube": "YouTube",
-					"com.google.android.gms":     "Google Play Services",
-					"com.android.vending":        "Google Play Store",
-					"com.whatsapp":               "WhatsApp",
-					"com.facebook.katana":        "Facebook",
-					"com.facebook.orca":          "Messenger",
-					"com.instagram.android":      "Instagram",
-				}
+	if pkg.Label == "" {
+		brandMap := map[string]string{
+			"com.google.android.youtube": "YouTube",
+			"com.google.android.gms":     "Google Play Services",
+			"com.android.vending":        "Google Play Store",
+			"com.whatsapp":               "WhatsApp",
+			"com.facebook.katana":        "Facebook",
+			"com.facebook.orca":          "Messenger",
+			"com.instagram.android":      "Instagram",
+		}
 
-				if brand, ok := brandMap[pkg.Name]; ok {
-					pkg.Label = brand
-				} else {
-					parts := strings.Split(pkg.Name, ".")
-					var meaningful []string
-					skip := map[string]bool{
-						"com": true, "net": true, "org": true, "android": true,
-						"google": true, "ss": true, "ugc": true, "app": true,
-					}
-					for _, p := range parts {
-						if !skip[strings.ToLower(p)] && len(p) > 2 {
-							meaningful = append(meaningful, p)
-						}
-					}
-					if len(meaningful) == 0 {
-						meaningful = parts[len(parts)-1:]
-					}
-					for i, p := range meaningful {
-						meaningful[i] = strings.ToUpper(p[:1]) + p[1:]
-					}
-					pkg.Label = strings.Join(meaningful, " ")
+		if brand, ok := brandMap[pkg.Name]; ok {
+			pkg.Label = brand
+		} else {
+			parts := strings.Split(pkg.Name, ".")
+			var meaningful []string
+			skip := map[string]bool{
+				"com": true, "net": true, "org": true, "android": true,
+				"google": true, "ss": true, "ugc": true, "app": true,
+			}
+			for _, p := range parts {
+				if !skip[strings.ToLower(p)] && len(p) > 2 {
+					meaningful = append(meaningful, p)
 				}
 			}
-		}(i)
+			if len(meaningful) == 0 {
+				meaningful = parts[len(parts)-1:]
+			}
+			for i, p := range meaningful {
+				meaningful[i] = strings.ToUpper(p[:1]) + p[1:]
+			}
+			pkg.Label = strings.Join(meaningful, " ")
+		}
 	}
-	wg.Wait()
-
-	return packages, nil
 }
 
 // GetAppInfo returns detailed information for a specific package
@@ -219,12 +298,89 @@ func (a *App) GetAppInfo(deviceId, packageName string, force bool) (AppPackage,
 	return pkg, nil
 }
 
+// appMetadataInFlight tracks device/package pairs currently being prefetched,
+// so repeated scroll-triggered requests don't pull the same APK twice.
+var (
+	appMetadataInFlight   = make(map[string]bool)
+	appMetadataInFlightMu sync.Mutex
+)
+
+// PrefetchAppMetadata loads aapt metadata (label, icon, version) for the given
+// packages in the background. Packages already in the cache are skipped. An
+// "app-metadata-ready" event is emitted for each package as it completes.
+func (a *App) PrefetchAppMetadata(deviceId string, packageNames []string) error {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return err
+	}
+
+	var pending []string
+	appMetadataInFlightMu.Lock()
+	for _, name := range packageNames {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if a.cacheService != nil {
+			if cached, ok := a.cacheService.GetCachedPackage(name); ok && cached.Label != "" {
+				continue
+			}
+		}
+		key := deviceId + "/" + name
+		if appMetadataInFlight[key] {
+			continue
+		}
+		appMetadataInFlight[key] = true
+		pending = append(pending, name)
+	}
+	appMetadataInFlightMu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	go func() {
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, a.GetConcurrency().Aapt)
+
+		for _, name := range pending {
+			wg.Add(1)
+			go func(packageName string) {
+				defer wg.Done()
+
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				pkg, err := a.getAppInfoWithAapt(deviceId, packageName)
+
+				appMetadataInFlightMu.Lock()
+				delete(appMetadataInFlight, deviceId+"/"+packageName)
+				appMetadataInFlightMu.Unlock()
+
+				payload := map[string]interface{}{
+					"deviceId":    deviceId,
+					"packageName": packageName,
+				}
+				if err != nil {
+					payload["error"] = err.Error()
+				} else {
+					payload["package"] = pkg
+				}
+				if !a.mcpMode {
+					wailsRuntime.EventsEmit(a.ctx, "app-metadata-ready", payload)
+				}
+			}(name)
+		}
+		wg.Wait()
+	}()
+
+	return nil
+}
+
 func (a *App) getAdbDetailedInfo(deviceId, packageName string) (AppPackage, error) {
 	var pkg AppPackage
 	pkg.Name = packageName
 
-	cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "dumpsys", "package", packageName)
-	output, err := cmd.Output()
+	output, _, err := a.runAdb(nil, "-s", deviceId, "shell", "dumpsys", "package", packageName)
 	if err != nil {
 		return pkg, err
 	}
@@ -339,22 +495,12 @@ func (a *App) getAppInfoWithAapt(deviceId, packageName string) (AppPackage, erro
 		return pkg, fmt.Errorf("aapt not available (file missing or empty)")
 	}
 
-	cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "pm", "path", packageName)
-	output, err := cmd.Output()
+	paths, err := a.packageAPKPaths(deviceId, packageName)
 	if err != nil {
-		return pkg, fmt.Errorf("failed to get APK path: %w", err)
-	}
-
-	remotePath := strings.TrimSpace(string(output))
-	if remotePath == "" {
-		return pkg, fmt.Errorf("empty output from pm path for %s", packageName)
-	}
-
-	lines := strings.Split(remotePath, "\n")
-	if len(lines) == 0 || !strings.HasPrefix(lines[0], "package:") {
-		return pkg, fmt.Errorf("unexpected output from pm path: %s", remotePath)
+		return pkg, err
 	}
-	remotePath = strings.TrimPrefix(lines[0], "package:")
+	// Badging of a split install lives in the base APK
+	remotePath := baseAPKPath(paths)
 
 	tmpDir := filepath.Join(os.TempDir(), "adb-gui-apk")
 	_ = os.MkdirAll(tmpDir, 0755)
@@ -746,12 +892,32 @@ func (a *App) extractFileFromAPK(apkPath, filePath string) ([]byte, error) {
 
 // App control functions
 
-// UninstallApp uninstalls an app
-func (a *App) UninstallApp(deviceId, packageName string) (string, error) {
+// UninstallApp uninstalls an app. If expectedSerial is set, the call fails with
+// ErrDeviceMismatch unless deviceId still resolves to that serial. A non-zero userId
+// removes the package for that user or work profile only.
+func (a *App) UninstallApp(deviceId, packageName, expectedSerial string, userId int) (string, error) {
 	a.updateLastActive(deviceId)
 	if err := ValidateDeviceID(deviceId); err != nil {
 		return "", err
 	}
+	if err := validateUserID(userId); err != nil {
+		return "", err
+	}
+	if err := a.verifyExpectedSerial(deviceId, expectedSerial); err != nil {
+		return "", err
+	}
+
+	if userId > 0 {
+		a.Log("Uninstalling %s from %s for user %d", packageName, deviceId, userId)
+		args := append([]string{"-s", deviceId, "shell", "pm", "uninstall"}, userArgs(userId)...)
+		cmd := a.newAdbCommand(nil, append(args, packageName)...)
+		output, err := cmd.CombinedOutput()
+		outStr := string(output)
+		if err != nil || strings.Contains(outStr, "Failure") {
+			return outStr, fmt.Errorf("failed to uninstall: %s", outStr)
+		}
+		return outStr, nil
+	}
 
 	a.Log("Uninstalling %s from %s", packageName, deviceId)
 
@@ -774,11 +940,14 @@ func (a *App) UninstallApp(deviceId, packageName string) (string, error) {
 	return outStr2, nil
 }
 
-// ClearAppData clears the application data
-func (a *App) ClearAppData(deviceId, packageName string) (string, error) {
+// ClearAppData clears the application data. See UninstallApp for expectedSerial.
+func (a *App) ClearAppData(deviceId, packageName, expectedSerial string) (string, error) {
 	if deviceId == "" {
 		return "", fmt.Errorf("no device specified")
 	}
+	if err := a.verifyExpectedSerial(deviceId, expectedSerial); err != nil {
+		return "", err
+	}
 	cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "pm", "clear", packageName)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -814,12 +983,16 @@ func (a *App) StartApp(deviceId, packageName string) (string, error) {
 	return string(output), nil
 }
 
-// EnableApp enables the application
-func (a *App) EnableApp(deviceId, packageName string) (string, error) {
+// EnableApp enables the application for the given user (0 = owner)
+func (a *App) EnableApp(deviceId, packageName string, userId int) (string, error) {
 	if deviceId == "" {
 		return "", fmt.Errorf("no device specified")
 	}
-	cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "pm", "enable", packageName)
+	if err := validateUserID(userId); err != nil {
+		return "", err
+	}
+	args := append([]string{"-s", deviceId, "shell", "pm", "enable"}, userArgs(userId)...)
+	cmd := a.newAdbCommand(nil, append(args, packageName)...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return string(output), fmt.Errorf("failed to enable app: %w", err)
@@ -827,12 +1000,16 @@ func (a *App) EnableApp(deviceId, packageName string) (string, error) {
 	return string(output), nil
 }
 
-// DisableApp disables the application
-func (a *App) DisableApp(deviceId, packageName string) (string, error) {
+// DisableApp disables the application for the given user (0 = owner)
+func (a *App) DisableApp(deviceId, packageName string, userId int) (string, error) {
 	if deviceId == "" {
 		return "", fmt.Errorf("no device specified")
 	}
-	cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "pm", "disable-user", packageName)
+	if err := validateUserID(userId); err != nil {
+		return "", err
+	}
+	args := append([]string{"-s", deviceId, "shell", "pm", "disable-user"}, userArgs(userId)...)
+	cmd := a.newAdbCommand(nil, append(args, packageName)...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return string(output), fmt.Errorf("failed to disable app: %w", err)
@@ -840,6 +1017,84 @@ func (a *App) DisableApp(deviceId, packageName string) (string, error) {
 	return string(output), nil
 }
 
+// minSuspendSdk is the first Android release (9 / P) that ships `pm suspend`
+const minSuspendSdk = 28
+
+// SuspendApp suspends (freezes) the application. Unlike DisableApp, the app stays
+// installed and enabled; it is hidden from the launcher and cannot run until
+// unsuspended. Requires Android 9+.
+func (a *App) SuspendApp(deviceId, packageName string) (string, error) {
+	return a.setAppSuspended(deviceId, packageName, true)
+}
+
+// UnsuspendApp lifts a previous SuspendApp
+func (a *App) UnsuspendApp(deviceId, packageName string) (string, error) {
+	return a.setAppSuspended(deviceId, packageName, false)
+}
+
+func (a *App) setAppSuspended(deviceId, packageName string, suspend bool) (string, error) {
+	if deviceId == "" {
+		return "", fmt.Errorf("no device specified")
+	}
+	if packageName == "" {
+		return "", fmt.Errorf("no package specified")
+	}
+	if err := a.requireSuspendSupport(deviceId); err != nil {
+		return "", err
+	}
+
+	verb := "unsuspend"
+	if suspend {
+		verb = "suspend"
+	}
+	cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "pm", verb, packageName)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("failed to %s app: %w", verb, err)
+	}
+	if strings.Contains(string(output), "Error") || strings.Contains(string(output), "Exception") {
+		return string(output), fmt.Errorf("failed to %s app: %s", verb, strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
+
+// IsAppSuspended reports whether the package is currently suspended for user 0
+func (a *App) IsAppSuspended(deviceId, packageName string) (bool, error) {
+	if deviceId == "" {
+		return false, fmt.Errorf("no device specified")
+	}
+	if err := a.requireSuspendSupport(deviceId); err != nil {
+		return false, err
+	}
+
+	cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "dumpsys", "package", packageName)
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to query package state: %w", err)
+	}
+	return parseSuspendedFromDumpsys(string(output)), nil
+}
+
+func (a *App) requireSuspendSupport(deviceId string) error {
+	sdk, err := a.getDeviceSdkInt(deviceId)
+	if err != nil {
+		return err
+	}
+	if sdk < minSuspendSdk {
+		return fmt.Errorf("app suspend requires Android 9 (API %d) or newer, device is API %d", minSuspendSdk, sdk)
+	}
+	return nil
+}
+
+// suspendedRegex matches the per-user "suspended=" flag in dumpsys package output
+var suspendedRegex = regexp.MustCompile(`\bsuspended=(true|false)`)
+
+// parseSuspendedFromDumpsys extracts the suspended flag of the first (primary) user
+func parseSuspendedFromDumpsys(output string) bool {
+	m := suspendedRegex.FindStringSubmatch(output)
+	return m != nil && m[1] == "true"
+}
+
 // StartActivity launches a specific activity
 func (a *App) StartActivity(deviceId, activityName string) (string, error) {
 	if deviceId == "" {
@@ -860,6 +1115,63 @@ func (a *App) StartActivity(deviceId, activityName string) (string, error) {
 	return outStr, nil
 }
 
+// LaunchWithDeepLink opens a URL with an ACTION_VIEW intent, the way a tapped app
+// link would, and returns the component that handled it
+func (a *App) LaunchWithDeepLink(deviceId, url string) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return "", fmt.Errorf("no URL specified")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// -W waits for the launch so the output names the activity that handled the link
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "am start -W -a android.intent.action.VIEW -d "+shellQuote(url))
+	outStr := string(output)
+
+	component, parseErr := parseDeepLinkResult(outStr)
+	if parseErr != nil {
+		return outStr, parseErr
+	}
+	if err != nil {
+		return outStr, fmt.Errorf("failed to open link: %w", err)
+	}
+	return component, nil
+}
+
+// ErrNoLinkHandler is returned when no installed activity resolves a link
+var ErrNoLinkHandler = errors.New("no app handles this link")
+
+// parseDeepLinkResult extracts the handling activity from `am start -W` output
+func parseDeepLinkResult(output string) (string, error) {
+	if strings.Contains(output, "unable to resolve Intent") || strings.Contains(output, "No Activity found") ||
+		strings.Contains(output, "No Activities found") {
+		return "", ErrNoLinkHandler
+	}
+	if idx := strings.Index(output, "Error:"); idx != -1 {
+		return "", fmt.Errorf("failed to open link: %s", strings.TrimSpace(strings.SplitN(output[idx:], "\n", 2)[0]))
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "Activity:") {
+			continue
+		}
+		component := strings.TrimSpace(strings.TrimPrefix(line, "Activity:"))
+		if strings.Contains(component, "ResolverActivity") || strings.Contains(component, "ChooserActivity") {
+			return component, fmt.Errorf("multiple apps handle this link; the chooser was shown (%s)", component)
+		}
+		return component, nil
+	}
+
+	// Delivered to an already-running top activity; -W doesn't always report it
+	return "", nil
+}
+
 // OpenSettings opens a specific system settings page
 func (a *App) OpenSettings(deviceId string, action string, data string) (string, error) {
 	if deviceId == "" {
@@ -910,15 +1222,19 @@ func (a *App) IsAppRunning(deviceId, packageName string) (bool, error) {
 	return false, nil
 }
 
-// InstallAPK installs an APK to the specified device
-func (a *App) InstallAPK(deviceId string, path string) (string, error) {
+// InstallAPK installs an APK to the specified device for the given user (0 = owner)
+func (a *App) InstallAPK(deviceId string, path string, userId int) (string, error) {
 	if err := ValidateDeviceID(deviceId); err != nil {
 		return "", err
 	}
+	if err := validateUserID(userId); err != nil {
+		return "", err
+	}
 
 	a.Log("Installing APK %s to device %s", path, deviceId)
 
-	cmd := a.newAdbCommand(nil, "-s", deviceId, "install", "-r", path)
+	args := append([]string{"-s", deviceId, "install", "-r"}, userArgs(userId)...)
+	cmd := a.newAdbCommand(nil, append(args, path)...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return string(output), fmt.Errorf("failed to install APK: %w\nOutput: %s", err, string(output))
@@ -1237,54 +1553,129 @@ func (a *App) findBundletool() string {
 	return ""
 }
 
-// InstallPackage installs APK, XAPK, or AAB based on file extension
-func (a *App) InstallPackage(deviceId string, path string) (string, error) {
+// InstallPackage installs APK, XAPK, or AAB based on file extension. Only plain
+// APKs can target a secondary user or work profile.
+func (a *App) InstallPackage(deviceId string, path string, userId int) (string, error) {
 	lowerPath := strings.ToLower(path)
 
+	if userId > 0 && !strings.HasSuffix(lowerPath, ".apk") {
+		return "", fmt.Errorf("installing %s for user %d is not supported; install an APK instead", filepath.Ext(path), userId)
+	}
+
 	switch {
 	case strings.HasSuffix(lowerPath, ".apk"):
-		return a.InstallAPK(deviceId, path)
-	case strings.HasSuffix(lowerPath, ".xapk"):
+		return a.InstallAPK(deviceId, path, userId)
+	case strings.HasSuffix(lowerPath, ".xapk"), strings.HasSuffix(lowerPath, ".apks"):
+		// Both are zips of APKs; .apks is what ExportAPK writes for split installs
 		return a.InstallXAPK(deviceId, path)
 	case strings.HasSuffix(lowerPath, ".aab"):
 		return a.InstallAAB(deviceId, path)
 	default:
-		return "", fmt.Errorf("unsupported file format: %s (supported: .apk, .xapk, .aab)", filepath.Ext(path))
+		return "", fmt.Errorf("unsupported file format: %s (supported: .apk, .apks, .xapk, .aab)", filepath.Ext(path))
 	}
 }
 
-// ExportAPK extracts an installed APK from the device to the local machine
-func (a *App) ExportAPK(deviceId string, packageName string) (string, error) {
+var packageNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*(\.[a-zA-Z0-9_]+)+$`)
+
+// IsAppInstalled reports whether packageName is installed on the device.
+// pm list packages filters by substring, so the output is compared line by line.
+func (a *App) IsAppInstalled(deviceId, packageName string) (bool, error) {
 	if err := ValidateDeviceID(deviceId); err != nil {
-		return "", err
+		return false, err
+	}
+	if !packageNameRegex.MatchString(packageName) {
+		return false, fmt.Errorf("invalid package name: %q", packageName)
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stdout, stderr, err := a.runAdb(ctx, "-s", deviceId, "shell", "pm", "list", "packages", packageName)
+	if err != nil {
+		return false, fmt.Errorf("failed to list packages: %w (%s)", err, strings.TrimSpace(string(stderr)))
+	}
+	return packageListed(string(stdout), packageName), nil
+}
+
+// packageListed checks `pm list packages` output for an exact "package:<name>" line
+func packageListed(output, packageName string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "package:"+packageName {
+			return true
+		}
+	}
+	return false
+}
+
+// packageAPKPaths returns every APK of an installed package. Apps installed from an
+// app bundle have a base.apk plus one split_*.apk per configuration.
+func (a *App) packageAPKPaths(deviceId, packageName string) ([]string, error) {
 	cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "pm", "path", packageName)
 	output, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("failed to get APK path: %w", err)
+		return nil, fmt.Errorf("failed to get APK path: %w", err)
 	}
+	paths := parsePmPaths(string(output))
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("unexpected output from pm path for %s: %s", packageName, strings.TrimSpace(string(output)))
+	}
+	return paths, nil
+}
+
+// parsePmPaths extracts the paths from `pm path` output ("package:/data/app/.../base.apk")
+func parsePmPaths(output string) []string {
+	var paths []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if p, ok := strings.CutPrefix(line, "package:"); ok && p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
 
-	remotePath := strings.TrimSpace(string(output))
-	lines := strings.Split(remotePath, "\n")
-	if len(lines) == 0 || !strings.HasPrefix(lines[0], "package:") {
-		return "", fmt.Errorf("unexpected output from pm path: %s", remotePath)
+// baseAPKPath picks the base APK of a split install: base.apk, or else the first path
+// that isn't a split_ config APK
+func baseAPKPath(paths []string) string {
+	for _, p := range paths {
+		if path.Base(p) == "base.apk" {
+			return p
+		}
+	}
+	for _, p := range paths {
+		if !strings.HasPrefix(path.Base(p), "split_") {
+			return p
+		}
 	}
-	remotePath = strings.TrimPrefix(lines[0], "package:")
+	return paths[0]
+}
+
+// ExportAPK extracts an installed APK from the device to the local machine. Split
+// installs are exported as a .apks archive holding every APK, which InstallPackage
+// installs again with install-multiple.
+func (a *App) ExportAPK(deviceId string, packageName string) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+
+	paths, err := a.packageAPKPaths(deviceId, packageName)
+	if err != nil {
+		return "", err
+	}
+	split := len(paths) > 1
 
 	fileName := packageName + ".apk"
-	defaultDir, _ := os.UserHomeDir()
-	downloadsDir := filepath.Join(defaultDir, "Downloads")
-	if _, err := os.Stat(downloadsDir); err == nil {
-		defaultDir = downloadsDir
+	filter := wailsRuntime.FileFilter{DisplayName: "Android Package (*.apk)", Pattern: "*.apk"}
+	if split {
+		fileName = packageName + ".apks"
+		filter = wailsRuntime.FileFilter{DisplayName: "Split APKs (*.apks)", Pattern: "*.apks"}
 	}
+	defaultDir := a.outputDir(OutputExports)
 
 	savePath, err := wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
-		DefaultFilename: fileName,
-		Title:           "Export APK",
-		Filters: []wailsRuntime.FileFilter{
-			{DisplayName: "Android Package (*.apk)", Pattern: "*.apk"},
-		},
+		DefaultFilename:  fileName,
+		Title:            "Export APK",
+		Filters:          []wailsRuntime.FileFilter{filter},
 		DefaultDirectory: defaultDir,
 	})
 
@@ -1295,7 +1686,15 @@ func (a *App) ExportAPK(deviceId string, packageName string) (string, error) {
 		return "", nil
 	}
 
-	pullCmd := a.newAdbCommand(nil, "-s", deviceId, "pull", remotePath, savePath)
+	if split {
+		if err := a.exportSplitAPKs(deviceId, paths, savePath); err != nil {
+			return "", err
+		}
+		a.Log("Exported %d APKs of %s to %s", len(paths), packageName, savePath)
+		return savePath, nil
+	}
+
+	pullCmd := a.newAdbCommand(nil, "-s", deviceId, "pull", paths[0], savePath)
 	pullOutput, err := pullCmd.CombinedOutput()
 	if err != nil {
 		return string(pullOutput), fmt.Errorf("failed to pull APK: %w (output: %s)", err, string(pullOutput))
@@ -1303,3 +1702,62 @@ func (a *App) ExportAPK(deviceId string, packageName string) (string, error) {
 
 	return savePath, nil
 }
+
+// exportSplitAPKs pulls every APK of a split install and stores them in a zip at savePath
+func (a *App) exportSplitAPKs(deviceId string, paths []string, savePath string) error {
+	tmpDir, err := os.MkdirTemp("", "apks_export_")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	out, err := os.Create(savePath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", savePath, err)
+	}
+	zw := zip.NewWriter(out)
+
+	writeErr := func() error {
+		for _, remote := range paths {
+			name := path.Base(remote)
+			local := filepath.Join(tmpDir, name)
+			pullCmd := a.newAdbCommand(nil, "-s", deviceId, "pull", remote, local)
+			if pullOutput, err := pullCmd.CombinedOutput(); err != nil {
+				return fmt.Errorf("failed to pull %s: %w (output: %s)", name, err, string(pullOutput))
+			}
+			if err := addFileToZip(zw, local, name); err != nil {
+				return err
+			}
+			os.Remove(local)
+		}
+		return nil
+	}()
+
+	if err := zw.Close(); writeErr == nil && err != nil {
+		writeErr = fmt.Errorf("failed to finish %s: %w", savePath, err)
+	}
+	if err := out.Close(); writeErr == nil && err != nil {
+		writeErr = err
+	}
+	if writeErr != nil {
+		os.Remove(savePath)
+	}
+	return writeErr
+}
+
+// addFileToZip stores a file uncompressed; APKs are already compressed
+func addFileToZip(zw *zip.Writer, localPath, name string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	if _, err := io.Copy(fw, f); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"Gaze/pkg/cache"
+)
+
+const dumpsysPackageSuspended = `Packages:
+  Package [com.example.app] (8a1b2c3):
+    userId=10234
+    pkg=Package{5d6e7f8 com.example.app}
+    versionCode=42 minSdk=24 targetSdk=34
+    versionName=1.4.2
+    User 0: ceDataInode=123456 installed=true hidden=false suspended=true distractionFlags=0 stopped=false notLaunched=false enabled=0 instant=false virtual=false
+      suspendingPackage=com.android.shell dialogInfo=null
+    User 10: ceDataInode=0 installed=true hidden=false suspended=false distractionFlags=0 stopped=true notLaunched=true enabled=0 instant=false virtual=false
+`
+
+const dumpsysPackageActive = `Packages:
+  Package [com.example.app] (8a1b2c3):
+    userId=10234
+    User 0: ceDataInode=123456 installed=true hidden=false suspended=false distractionFlags=0 stopped=false notLaunched=false enabled=0 instant=false virtual=false
+`
+
+func TestParseSuspendedFromDumpsys(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{"suspended for user 0", dumpsysPackageSuspended, true},
+		{"not suspended", dumpsysPackageActive, false},
+		{"empty output", "", false},
+		{"flag missing (pre-P)", "User 0: ceDataInode=1 installed=true hidden=false stopped=false", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSuspendedFromDumpsys(tt.output); got != tt.want {
+				t.Errorf("parseSuspendedFromDumpsys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDeepLinkResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		component string
+		wantErr   bool
+	}{
+		{
+			name: "handled",
+			output: `Starting: Intent { act=android.intent.action.VIEW dat=https://example.com/... }
+Status: ok
+LaunchState: COLD
+Activity: com.example.app/.DeepLinkActivity
+TotalTime: 512
+WaitTime: 530
+Complete
+`,
+			component: "com.example.app/.DeepLinkActivity",
+		},
+		{
+			name: "no handler",
+			output: `Starting: Intent { act=android.intent.action.VIEW dat=myapp://nothing }
+Error: Activity not started, unable to resolve Intent { act=android.intent.action.VIEW dat=myapp://nothing flg=0x10000000 }
+`,
+			wantErr: true,
+		},
+		{
+			name: "chooser",
+			output: `Status: ok
+Activity: android/com.android.internal.app.ResolverActivity
+`,
+			component: "android/com.android.internal.app.ResolverActivity",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDeepLinkResult(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDeepLinkResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.component {
+				t.Errorf("parseDeepLinkResult() = %q, want %q", got, tt.component)
+			}
+		})
+	}
+}
+
+func TestIsAppInstalledExactMatch(t *testing.T) {
+	// pm filters by substring, so the target only appears as a prefix of other packages here
+	a := newTestApp(map[string]string{
+		"-s dev1 shell pm list packages com.example.app":  "package:com.example.app.debug\npackage:com.example.apptools\n",
+		"-s dev1 shell pm list packages com.example.tool": "package:com.example.tool\r\npackage:com.example.toolbox\r\n",
+	})
+
+	installed, err := a.IsAppInstalled("dev1", "com.example.app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if installed {
+		t.Error("com.example.app reported installed from substring matches")
+	}
+
+	installed, err = a.IsAppInstalled("dev1", "com.example.tool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !installed {
+		t.Error("com.example.tool not found despite exact line")
+	}
+
+	if _, err := a.IsAppInstalled("dev1", "com.example; reboot"); err == nil {
+		t.Error("expected invalid package name error")
+	}
+}
+
+func TestParsePmPathsSplitInstall(t *testing.T) {
+	output := "package:/data/app/~~ab12==/com.example.app-cd34==/base.apk\r\n" +
+		"package:/data/app/~~ab12==/com.example.app-cd34==/split_config.arm64_v8a.apk\r\n" +
+		"package:/data/app/~~ab12==/com.example.app-cd34==/split_config.xxhdpi.apk\r\n"
+
+	paths := parsePmPaths(output)
+	if len(paths) != 3 {
+		t.Fatalf("parsePmPaths() = %v, want 3 paths", paths)
+	}
+	if got := baseAPKPath(paths); got != "/data/app/~~ab12==/com.example.app-cd34==/base.apk" {
+		t.Errorf("baseAPKPath() = %q", got)
+	}
+
+	// Base listed after its splits
+	reordered := []string{paths[1], paths[2], paths[0]}
+	if got := baseAPKPath(reordered); got != paths[0] {
+		t.Errorf("baseAPKPath(reordered) = %q, want %q", got, paths[0])
+	}
+
+	if got := parsePmPaths("\n"); len(got) != 0 {
+		t.Errorf("parsePmPaths(empty) = %v, want none", got)
+	}
+	single := parsePmPaths("package:/system/app/Settings/Settings.apk\n")
+	if len(single) != 1 || baseAPKPath(single) != "/system/app/Settings/Settings.apk" {
+		t.Errorf("single APK: got %v", single)
+	}
+}
+
+func TestPrefetchAppMetadata_SkipsCachedAndInFlight(t *testing.T) {
+	app := newOutputTestApp(t)
+	app.mcpMode = true
+
+	if err := app.PrefetchAppMetadata("bad id;", []string{"com.example.app"}); err == nil {
+		t.Error("expected error for an invalid device ID")
+	}
+
+	app.cacheService.SetCachedPackage("com.example.cached", cache.AppPackage{Name: "com.example.cached", Label: "Cached"})
+	key := "dev1/com.example.busy"
+	appMetadataInFlightMu.Lock()
+	appMetadataInFlight[key] = true
+	appMetadataInFlightMu.Unlock()
+	defer func() {
+		appMetadataInFlightMu.Lock()
+		delete(appMetadataInFlight, key)
+		appMetadataInFlightMu.Unlock()
+	}()
+
+	if err := app.PrefetchAppMetadata("dev1", []string{"com.example.cached", "com.example.busy", "  "}); err != nil {
+		t.Fatalf("PrefetchAppMetadata: %v", err)
+	}
+	appMetadataInFlightMu.Lock()
+	queued := len(appMetadataInFlight)
+	appMetadataInFlightMu.Unlock()
+	if queued != 1 {
+		t.Errorf("cached and in-flight packages must not be queued again, in flight: %d", queued)
+	}
+
+	// An uncached package is fetched in the background and leaves the in-flight set when done,
+	// even when the fetch fails (no aapt here)
+	if err := app.PrefetchAppMetadata("dev1", []string{"com.example.new"}); err != nil {
+		t.Fatalf("PrefetchAppMetadata: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		appMetadataInFlightMu.Lock()
+		busy := appMetadataInFlight["dev1/com.example.new"]
+		appMetadataInFlightMu.Unlock()
+		if !busy {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("prefetch did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestListPackageEntries(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s dev1 shell pm list packages -d":           "package:com.example.off\n",
+		"-s dev1 shell pm list packages -3":           "package:com.example.app\npackage:com.example.off\n",
+		"-s dev1 shell pm list packages -s":           "package:android\n",
+		"-s dev1 shell pm list packages -3 --user 10": "package:com.work.app\n",
+	})
+
+	user, err := app.listPackageEntries("dev1", "", 0)
+	if err != nil {
+		t.Fatalf("listPackageEntries: %v", err)
+	}
+	if len(user) != 2 || user[0].Name != "com.example.app" || user[0].Type != "user" || user[0].State != "enabled" {
+		t.Fatalf("unexpected user packages: %+v", user)
+	}
+	if user[1].State != "disabled" {
+		t.Errorf("com.example.off should be disabled, got %q", user[1].State)
+	}
+
+	all, err := app.listPackageEntries("dev1", "all", 0)
+	if err != nil {
+		t.Fatalf("listPackageEntries(all): %v", err)
+	}
+	if len(all) != 3 || all[0].Name != "android" || all[0].Type != "system" {
+		t.Errorf("unexpected all packages: %+v", all)
+	}
+
+	work, err := app.listPackageEntries("dev1", "user", 10)
+	if err != nil || len(work) != 1 || work[0].Name != "com.work.app" {
+		t.Errorf("unexpected work profile packages: %+v, %v", work, err)
+	}
+
+	if _, err := app.listPackageEntries("dev1", "system", 10); err == nil {
+		t.Error("expected error when pm list fails")
+	}
+}
+
+func TestStartListPackages_Validation(t *testing.T) {
+	app := newTestApp(nil)
+	if err := app.StartListPackages("bad id;", "user", 0); err == nil {
+		t.Error("expected error for an invalid device ID")
+	}
+	if err := app.StartListPackages("dev1", "user", -1); err == nil {
+		t.Error("expected error for a negative user ID")
+	}
+}
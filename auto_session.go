@@ -0,0 +1,139 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// ========================================
+// Auto sessions - open a session while a device is connected
+// ========================================
+
+// autoSessionEntry tracks a session (and device monitor) opened by auto-session mode
+type autoSessionEntry struct {
+	sessionID      string
+	startedMonitor bool
+}
+
+var (
+	autoSessions   = make(map[string]*autoSessionEntry) // deviceId -> entry
+	autoSessionsMu sync.Mutex
+)
+
+// EnableAutoSessions turns auto-session mode on or off. When enabled, every device
+// reported by the device tracker gets a session for as long as it stays connected,
+// so logcat/network/touch events are captured without starting one manually.
+func (a *App) EnableAutoSessions(enabled bool) {
+	if a.cacheService != nil {
+		a.cacheService.SetAutoSessions(enabled)
+		go a.saveSettings()
+	}
+
+	if !enabled {
+		a.endAllAutoSessions()
+		return
+	}
+
+	// Pick up devices that are already connected
+	go func() {
+		devices, err := a.GetDevices(false)
+		if err != nil {
+			a.Log("Auto sessions: failed to list devices: %v", err)
+			return
+		}
+		states := make(map[string]string, len(devices))
+		for _, d := range devices {
+			states[d.ID] = d.State
+		}
+		a.syncAutoSessions(states)
+	}()
+}
+
+// IsAutoSessionsEnabled reports whether auto-session mode is on
+func (a *App) IsAutoSessionsEnabled() bool {
+	return a.cacheService != nil && a.cacheService.GetAutoSessions()
+}
+
+// syncAutoSessions reconciles auto sessions with the current device states
+// (adb id -> state, as reported by track-devices)
+func (a *App) syncAutoSessions(states map[string]string) {
+	if !a.IsAutoSessionsEnabled() || a.eventPipeline == nil {
+		return
+	}
+
+	autoSessionsMu.Lock()
+	defer autoSessionsMu.Unlock()
+
+	// Close sessions for devices that went away
+	for deviceID, entry := range autoSessions {
+		if states[deviceID] == "device" {
+			continue
+		}
+		a.endAutoSession(deviceID, entry)
+		delete(autoSessions, deviceID)
+	}
+
+	// Open sessions for newly connected devices
+	for deviceID, state := range states {
+		if state != "device" {
+			continue
+		}
+		if _, ok := autoSessions[deviceID]; ok {
+			continue
+		}
+		// Respect a session the user already started
+		if a.eventPipeline.GetActiveSessionID(deviceID) != "" {
+			continue
+		}
+
+		entry := &autoSessionEntry{}
+		entry.sessionID = a.eventPipeline.StartSession(deviceID, "auto", "Connected "+time.Now().Format("15:04:05"), nil)
+
+		deviceStateMonitorsMu.Lock()
+		_, running := deviceStateMonitors[deviceID]
+		deviceStateMonitorsMu.Unlock()
+		if !running {
+			a.StartDeviceStateMonitor(deviceID)
+			entry.startedMonitor = true
+		}
+
+		autoSessions[deviceID] = entry
+		a.Log("Auto session %s started for %s", entry.sessionID, deviceID)
+	}
+}
+
+// endAutoSession stops what auto-session mode started for a device. Caller holds autoSessionsMu.
+func (a *App) endAutoSession(deviceID string, entry *autoSessionEntry) {
+	if entry.startedMonitor {
+		a.StopDeviceStateMonitor(deviceID)
+	}
+	// Only close the session if it is still the device's active one
+	if a.eventPipeline != nil && a.eventPipeline.GetActiveSessionID(deviceID) == entry.sessionID {
+		a.eventPipeline.EndSession(entry.sessionID, "completed")
+	}
+	a.Log("Auto session %s ended for %s", entry.sessionID, deviceID)
+}
+
+func (a *App) endAllAutoSessions() {
+	autoSessionsMu.Lock()
+	defer autoSessionsMu.Unlock()
+
+	for deviceID, entry := range autoSessions {
+		a.endAutoSession(deviceID, entry)
+	}
+	autoSessions = make(map[string]*autoSessionEntry)
+}
+
+// parseTrackDevices parses an `adb track-devices` payload ("serial\tstate" per line)
+func parseTrackDevices(data string) map[string]string {
+	states := make(map[string]string)
+	for _, line := range strings.Split(data, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			continue
+		}
+		states[parts[0]] = parts[1]
+	}
+	return states
+}
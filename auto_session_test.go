@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+
+	"Gaze/pkg/cache"
+)
+
+func TestParseTrackDevices(t *testing.T) {
+	states := parseTrackDevices("R5CT1234ABC\tdevice\nemulator-5554\toffline\n\nbroken\n")
+	if len(states) != 2 || states["R5CT1234ABC"] != "device" || states["emulator-5554"] != "offline" {
+		t.Errorf("unexpected states: %v", states)
+	}
+}
+
+func TestAutoSessions_FollowDeviceConnections(t *testing.T) {
+	app, _, cleanup := setupTestAppForSession(t)
+	defer cleanup()
+	svc, err := cache.New(cache.Config{ConfigDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	app.cacheService = svc
+
+	// Pretend state monitors are already running so no adb process is spawned
+	deviceStateMonitorsMu.Lock()
+	deviceStateMonitors["auto-dev-1"] = &DeviceMonitor{}
+	deviceStateMonitors["auto-dev-2"] = &DeviceMonitor{}
+	deviceStateMonitorsMu.Unlock()
+	defer func() {
+		deviceStateMonitorsMu.Lock()
+		delete(deviceStateMonitors, "auto-dev-1")
+		delete(deviceStateMonitors, "auto-dev-2")
+		deviceStateMonitorsMu.Unlock()
+		app.endAllAutoSessions()
+	}()
+
+	// Disabled: nothing happens
+	app.syncAutoSessions(map[string]string{"auto-dev-1": "device"})
+	if app.eventPipeline.GetActiveSessionID("auto-dev-1") != "" {
+		t.Fatal("no session expected while auto sessions are off")
+	}
+
+	svc.SetAutoSessions(true)
+	if !app.IsAutoSessionsEnabled() {
+		t.Fatal("expected auto sessions to be enabled")
+	}
+	manual := app.eventPipeline.StartSession("auto-dev-2", "manual", "Manual", nil)
+
+	app.syncAutoSessions(map[string]string{"auto-dev-1": "device", "auto-dev-2": "device", "auto-dev-3": "unauthorized"})
+	auto := app.eventPipeline.GetActiveSessionID("auto-dev-1")
+	if auto == "" {
+		t.Fatal("expected an auto session for the connected device")
+	}
+	if s := app.eventPipeline.GetSession(auto); s == nil || s.Type != "auto" {
+		t.Errorf("unexpected session: %+v", s)
+	}
+	if app.eventPipeline.GetActiveSessionID("auto-dev-2") != manual {
+		t.Error("a session the user started must be kept")
+	}
+	if app.eventPipeline.GetActiveSessionID("auto-dev-3") != "" {
+		t.Error("unauthorized devices must not get a session")
+	}
+
+	// Device disconnects: its auto session ends, the manual one is untouched
+	app.syncAutoSessions(map[string]string{"auto-dev-2": "device"})
+	if app.eventPipeline.GetActiveSessionID("auto-dev-1") != "" {
+		t.Error("expected the auto session to end on disconnect")
+	}
+	if app.eventPipeline.GetActiveSessionID("auto-dev-2") != manual {
+		t.Error("manual session should survive a sync")
+	}
+
+	// Turning the mode off closes remaining auto sessions
+	app.syncAutoSessions(map[string]string{"auto-dev-1": "device", "auto-dev-2": "device"})
+	app.EnableAutoSessions(false)
+	if app.IsAutoSessionsEnabled() {
+		t.Error("expected auto sessions to be disabled")
+	}
+	if app.eventPipeline.GetActiveSessionID("auto-dev-1") != "" {
+		t.Error("expected auto sessions to end when the mode is turned off")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -158,6 +159,33 @@ func (a *App) GetTouchInputDevice(deviceId string) (string, error) {
 	return "", fmt.Errorf("no touch input device found")
 }
 
+// getTouchAxisRange reads the raw coordinate range of a touch input device, used to
+// scale getevent positions to screen pixels. Unknown axes are returned as 0.
+func (a *App) getTouchAxisRange(deviceId, inputDevice string) (minX, maxX, minY, maxY int) {
+	propsOutput, err := a.RunAdbCommand(deviceId, fmt.Sprintf("shell getevent -p %s", inputDevice))
+	if err != nil {
+		return
+	}
+	// Regex to match "min 0, max 1079"
+	re := regexp.MustCompile(`min\s+(-?\d+),\s+max\s+(-?\d+)`)
+
+	for _, line := range strings.Split(propsOutput, "\n") {
+		if strings.Contains(line, "ABS_MT_POSITION_X") || strings.Contains(line, "0035") {
+			if matches := re.FindStringSubmatch(line); len(matches) >= 3 {
+				minX, _ = strconv.Atoi(matches[1])
+				maxX, _ = strconv.Atoi(matches[2])
+			}
+		}
+		if strings.Contains(line, "ABS_MT_POSITION_Y") || strings.Contains(line, "0036") {
+			if matches := re.FindStringSubmatch(line); len(matches) >= 3 {
+				minY, _ = strconv.Atoi(matches[1])
+				maxY, _ = strconv.Atoi(matches[2])
+			}
+		}
+	}
+	return
+}
+
 // GetDeviceResolution gets the screen resolution of the device
 func (a *App) GetDeviceResolution(deviceId string) (string, error) {
 	output, err := a.RunAdbCommand(deviceId, "shell wm size")
@@ -190,6 +218,17 @@ func (a *App) StartTouchRecording(deviceId string, recordingMode string) error {
 		return fmt.Errorf("already recording on this device")
 	}
 
+	// A UI dump running alongside getevent makes both flaky
+	if err := a.AcquireDevice(deviceId, DeviceOpTouchRecording); err != nil {
+		return err
+	}
+	started := false
+	defer func() {
+		if !started {
+			a.ReleaseDevice(deviceId, DeviceOpTouchRecording)
+		}
+	}()
+
 	// Get touch input device
 	inputDevice, err := a.GetTouchInputDevice(deviceId)
 	if err != nil {
@@ -238,36 +277,13 @@ func (a *App) StartTouchRecording(deviceId string, recordingMode string) error {
 	}()
 
 	// Get device min/max coordinates
-	maxX, maxY := 0, 0
-	minX, minY := 0, 0
-
-	propsCmd := fmt.Sprintf("shell getevent -p %s", inputDevice)
-	propsOutput, err := a.RunAdbCommand(deviceId, propsCmd)
-	if err == nil {
-		lines := strings.Split(propsOutput, "\n")
-		// Regex to match "min 0, max 1079"
-		re := regexp.MustCompile(`min\s+(-?\d+),\s+max\s+(-?\d+)`)
-
-		for _, line := range lines {
-			if strings.Contains(line, "ABS_MT_POSITION_X") || strings.Contains(line, "0035") {
-				if matches := re.FindStringSubmatch(line); len(matches) >= 3 {
-					minX, _ = strconv.Atoi(matches[1])
-					maxX, _ = strconv.Atoi(matches[2])
-				}
-			}
-			if strings.Contains(line, "ABS_MT_POSITION_Y") || strings.Contains(line, "0036") {
-				if matches := re.FindStringSubmatch(line); len(matches) >= 3 {
-					minY, _ = strconv.Atoi(matches[1])
-					maxY, _ = strconv.Atoi(matches[2])
-				}
-			}
-		}
-	}
+	minX, maxX, minY, maxY := a.getTouchAxisRange(deviceId, inputDevice)
 	LogDebug("automation").Int("minX", minX).Int("maxX", maxX).Int("minY", minY).Int("maxY", maxY).Msg("Touch device coords detected")
 
 	// Store recording state
 	touchRecordCmd[deviceId] = cmd
 	touchRecordCancel[deviceId] = cancel
+	started = true
 
 	// Default to fast mode if not specified
 	if recordingMode == "" {
@@ -539,6 +555,7 @@ func (a *App) StopTouchRecording(deviceId string) (*TouchScript, error) {
 
 	// Cancel the recording - this stops the getevent process
 	cancel()
+	defer a.ReleaseDevice(deviceId, DeviceOpTouchRecording)
 
 	// Wait for process to finish - don't hold the lock here!
 	// This allows the reading goroutine to finish processing remaining events
@@ -615,6 +632,7 @@ func (a *App) stopAllTouchRecordings() {
 
 	for deviceId, cancel := range touchRecordCancel {
 		cancel()
+		releaseDeviceOp(deviceId, DeviceOpTouchRecording)
 		LogInfo("shutdown").Str("device", deviceId).Msg("Cancelled touch recording")
 	}
 	for deviceId, cmd := range touchRecordCmd {
@@ -641,8 +659,11 @@ func (a *App) stopAllActiveTasks() {
 	}
 	activeTaskCancel = make(map[string]context.CancelFunc)
 
-	// Also clear pause signals
+	// Also clear pause signals, unblocking anything still waiting on them
 	taskPauseMu.Lock()
+	for _, ch := range taskPauseSignal {
+		close(ch)
+	}
 	taskPauseSignal = make(map[string]chan struct{})
 	taskIsPaused = make(map[string]bool)
 	taskPauseMu.Unlock()
@@ -1348,6 +1369,10 @@ func (a *App) PlayTouchScript(deviceId string, script TouchScript) error {
 		"event_count": len(script.Events),
 	})
 
+	if err := validateScriptCheckpoints(script); err != nil {
+		return err
+	}
+
 	activeTaskMu.Lock()
 	if _, exists := activeTaskCancel[deviceId]; exists {
 		activeTaskMu.Unlock()
@@ -1401,6 +1426,10 @@ func (a *App) playTouchScriptSync(ctx context.Context, deviceId string, script T
 	startTime := time.Now()
 	total := len(script.Events)
 
+	if err := validateScriptCheckpoints(script); err != nil {
+		return err
+	}
+
 	// 1. Get target device resolution
 	targetResStr, err := a.GetDeviceResolution(deviceId)
 	var scaleX, scaleY float64 = 1.0, 1.0
@@ -1497,6 +1526,12 @@ func (a *App) playTouchScriptSync(ctx context.Context, deviceId string, script T
 			LogDebug("automation").Err(err).Msg("Action command failed")
 		}
 
+		if event.Checkpoint != nil {
+			if err := a.verifyPlaybackCheckpoint(ctx, deviceId, i, event.Checkpoint); err != nil {
+				return err
+			}
+		}
+
 		if progressCb != nil {
 			progressCb(i+1, total)
 		}
@@ -1613,6 +1648,72 @@ func (a *App) StopTask(deviceId string) {
 	log.Printf("[Task] StopTask completed for device: %s", deviceId)
 }
 
+// PauseAllTasks pauses every running playback/workflow across all devices
+func (a *App) PauseAllTasks() {
+	deviceIds := activeTaskDevices()
+	for _, deviceId := range deviceIds {
+		a.PauseTask(deviceId)
+	}
+	a.emitTasksBulkAction("pause", deviceIds)
+}
+
+// ResumeAllTasks resumes every paused task across all devices
+func (a *App) ResumeAllTasks() {
+	deviceIds := pausedTaskDevices()
+	for _, deviceId := range deviceIds {
+		a.ResumeTask(deviceId)
+	}
+	a.emitTasksBulkAction("resume", deviceIds)
+}
+
+// StopAllTasks stops every running task across all devices (emergency stop).
+// Paused devices without a running task are resumed too, so nothing stays blocked.
+func (a *App) StopAllTasks() {
+	deviceIds := activeTaskDevices()
+	for _, deviceId := range deviceIds {
+		a.StopTask(deviceId)
+	}
+	for _, deviceId := range pausedTaskDevices() {
+		cleanupTaskPause(deviceId)
+	}
+	a.emitTasksBulkAction("stop", deviceIds)
+}
+
+// activeTaskDevices snapshots the devices with a running task
+func activeTaskDevices() []string {
+	activeTaskMu.Lock()
+	defer activeTaskMu.Unlock()
+	deviceIds := make([]string, 0, len(activeTaskCancel))
+	for deviceId := range activeTaskCancel {
+		deviceIds = append(deviceIds, deviceId)
+	}
+	sort.Strings(deviceIds)
+	return deviceIds
+}
+
+// pausedTaskDevices snapshots the devices with a pending pause signal
+func pausedTaskDevices() []string {
+	taskPauseMu.Lock()
+	defer taskPauseMu.Unlock()
+	deviceIds := make([]string, 0, len(taskPauseSignal))
+	for deviceId := range taskPauseSignal {
+		deviceIds = append(deviceIds, deviceId)
+	}
+	sort.Strings(deviceIds)
+	return deviceIds
+}
+
+func (a *App) emitTasksBulkAction(action string, deviceIds []string) {
+	LogInfo("automation").Str("action", action).Int("devices", len(deviceIds)).Msg("Bulk task action")
+	if !a.mcpMode {
+		wailsRuntime.EventsEmit(a.ctx, "tasks-bulk-action", map[string]interface{}{
+			"action":    action,
+			"deviceIds": deviceIds,
+			"count":     len(deviceIds),
+		})
+	}
+}
+
 // checkPause blocks if the device is paused
 // Returns true if the pause was interrupted by context cancellation
 func (a *App) checkPause(deviceId string) bool {
@@ -1704,6 +1805,9 @@ func (a *App) getScriptsPath() string {
 
 // SaveTouchScript saves a touch script to file
 func (a *App) SaveTouchScript(script TouchScript) error {
+	if err := validateScriptCheckpoints(script); err != nil {
+		return err
+	}
 	scriptsPath := a.getScriptsPath()
 
 	// Sanitize filename
@@ -1965,6 +2069,15 @@ func (a *App) RunScriptTask(deviceId string, task ScriptTask) error {
 			scriptMap[s.Name] = s
 		}
 
+		// Overall progress counts one unit per step iteration
+		totalUnits := taskProgressUnits(task)
+		doneUnits := 0
+		completeUnit := func() {
+			doneUnits++
+			a.emitTaskProgress(deviceId, task.Name, doneUnits, totalUnits)
+		}
+		a.emitTaskProgress(deviceId, task.Name, 0, totalUnits)
+
 		for i, step := range task.Steps {
 			// Check cancel
 			select {
@@ -2024,6 +2137,7 @@ func (a *App) RunScriptTask(deviceId string, task ScriptTask) error {
 					script, ok := scriptMap[step.Value]
 					if !ok {
 						LogDebug("automation").Str("script", step.Value).Msg("Script not found")
+						completeUnit()
 						continue
 					}
 
@@ -2108,6 +2222,8 @@ func (a *App) RunScriptTask(deviceId string, task ScriptTask) error {
 						LogDebug("automation").Str("checkType", checkType).Str("checkValue", step.CheckValue).Msg("Element found")
 					}
 				}
+
+				completeUnit()
 			}
 
 			// Apply PostDelay after the step (all loops) is completed
@@ -2139,10 +2255,51 @@ func (a *App) RunScriptTask(deviceId string, task ScriptTask) error {
 	return nil
 }
 
+// taskProgressUnits returns the number of executable units in a task (steps × loops).
+// Task steps run in a fixed order with fixed loop counts, so the total is exact; tasks
+// have no conditional branches or while-loops to estimate.
+func taskProgressUnits(task ScriptTask) int {
+	total := 0
+	for _, step := range task.Steps {
+		if step.Loop > 1 {
+			total += step.Loop
+		} else {
+			total++
+		}
+	}
+	return total
+}
+
+// emitTaskProgress reports overall task completion as a 0-100 percentage
+func (a *App) emitTaskProgress(deviceId, taskName string, done, total int) {
+	if a.mcpMode {
+		return
+	}
+	wailsRuntime.EventsEmit(a.ctx, "task-progress", map[string]interface{}{
+		"deviceId":  deviceId,
+		"taskName":  taskName,
+		"completed": done,
+		"total":     total,
+		"percent":   taskProgressPercent(done, total),
+	})
+}
+
+// taskProgressPercent converts completed units into a 0-100 percentage
+func taskProgressPercent(done, total int) int {
+	if total <= 0 {
+		return 100
+	}
+	if done >= total {
+		return 100
+	}
+	return done * 100 / total
+}
+
 // UI Hierarchy structures for parsing uiautomator dump
 type UINode struct {
 	XMLName       xml.Name `xml:"node" json:"-"`
 	Text          string   `xml:"text,attr" json:"text"`
+	Hint          string   `xml:"hint,attr" json:"hint,omitempty"` // Android 8+; empty fields may report their hint as text
 	ResourceID    string   `xml:"resource-id,attr" json:"resourceId"`
 	Class         string   `xml:"class,attr" json:"class"`
 	Package       string   `xml:"package,attr" json:"package"`
@@ -2178,45 +2335,50 @@ func (a *App) GetUIHierarchy(deviceId string) (*UIHierarchyResult, error) {
 	return a.GetUIHierarchyWithContext(ctx, deviceId)
 }
 
-// GetUIHierarchyWithContext dumps the UI hierarchy with context for timeout control
+// GetUIHierarchyWithContext dumps the UI hierarchy with context for timeout control.
+// It fails with ErrDeviceBusy while touch recording is active on the device.
 func (a *App) GetUIHierarchyWithContext(ctx context.Context, deviceId string) (*UIHierarchyResult, error) {
-	// Try dumping several times as it can be flaky
-	var xmlContent string
-	var err error
-	maxRetries := 3
-	dumpFile := "/data/local/tmp/view.xml"
+	if err := a.AcquireDevice(deviceId, DeviceOpUIDump); err != nil {
+		return nil, err
+	}
+	defer a.ReleaseDevice(deviceId, DeviceOpUIDump)
 
-	for i := 0; i < maxRetries; i++ {
-		// Check context before each attempt
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
+	return a.dumpUIHierarchy(ctx, deviceId)
+}
+
+// dumpUIHierarchy runs uiautomator without checking the busy registry.
+// Touch recording uses it directly for its own throttled element captures.
+func (a *App) dumpUIHierarchy(ctx context.Context, deviceId string) (*UIHierarchyResult, error) {
+	// Dump and read in single command to reduce adb overhead
+	// Using && ensures cat only runs if dump succeeds
+	dumpFile := "/data/local/tmp/view.xml"
+	combinedCmd := fmt.Sprintf("shell uiautomator dump %s && cat %s", dumpFile, dumpFile)
 
-		if i > 0 {
+	// uiautomator dumps are flaky, so a dump without XML is retried like a transient adb error
+	attempt := 0
+	out, err := runWithRetry(ctx, a.GetAdbRetries(), adbRetryBackoff, func() ([]byte, error) {
+		if attempt > 0 {
 			// Cleanup on retry: kill any existing uiautomator processes
 			a.RunAdbCommandWithContext(ctx, deviceId, "shell pkill uiautomator")
 			time.Sleep(500 * time.Millisecond)
 		}
-
-		// Dump and read in single command to reduce adb overhead
-		// Using && ensures cat only runs if dump succeeds
-		combinedCmd := fmt.Sprintf("shell uiautomator dump %s && cat %s", dumpFile, dumpFile)
-		xmlContent, err = a.RunAdbCommandWithContext(ctx, deviceId, combinedCmd)
-		if err == nil && strings.Contains(xmlContent, "<?xml") {
-			break
-		}
-		// Check if context was cancelled
-		if ctx.Err() != nil {
-			return nil, ctx.Err()
+		attempt++
+		res, runErr := a.RunAdbCommandWithContext(ctx, deviceId, combinedCmd)
+		if ctx.Err() == nil && !strings.Contains(res, "<?xml") {
+			if runErr == nil {
+				runErr = fmt.Errorf("output: %s", strings.TrimSpace(res))
+			}
+			return []byte(res), fmt.Errorf("%w: uiautomator dump returned no XML: %v", errTransientAdb, runErr)
 		}
-		LogDebug("automation").Int("retry", i+1).Int("maxRetries", maxRetries).Err(err).Msg("UI dump retry")
+		return []byte(res), runErr
+	})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
-
-	if err != nil || xmlContent == "" {
-		return nil, fmt.Errorf("failed to dump UI after %d attempts: %v", maxRetries, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump UI after %d attempts: %w", attempt, err)
 	}
+	xmlContent := string(out)
 
 	// Basic cleanup if output has extra stuff (sometimes ADB adds headers or footers)
 	startIdx := strings.Index(xmlContent, "<?xml")
@@ -2806,8 +2968,10 @@ func (a *App) captureElementInfoAtPoint(deviceId string, x, y int) *ElementInfo
 	}
 	uiHierarchyCacheMu.Unlock()
 
-	// Perform new UI dump
-	result, err := a.GetUIHierarchy(deviceId)
+	// Perform new UI dump (bypasses the busy registry, which recording itself holds)
+	dumpCtx, dumpCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	result, err := a.dumpUIHierarchy(dumpCtx, deviceId)
+	dumpCancel()
 	if err != nil {
 		return nil
 	}
@@ -2874,6 +3038,30 @@ func (a *App) InputNodeText(deviceId string, bounds string, text string) error {
 	return a.InputText(deviceId, text)
 }
 
+// TypeText sends text to whatever view currently has input focus, without tapping
+// anything first. Use it when the field is already focused (e.g. an auto-focused
+// search box). Unicode text goes through ADBKeyboard like InputNodeText.
+func (a *App) TypeText(deviceId string, text string) error {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return err
+	}
+	if text == "" {
+		return nil
+	}
+	a.updateLastActive(deviceId)
+	return a.InputText(deviceId, text)
+}
+
+// PressEnter sends KEYCODE_ENTER to the focused view (submits search boxes and forms)
+func (a *App) PressEnter(deviceId string) error {
+	return a.SendKeyEvent(deviceId, "ENTER")
+}
+
+// PressTab sends KEYCODE_TAB to move focus to the next field
+func (a *App) PressTab(deviceId string) error {
+	return a.SendKeyEvent(deviceId, "TAB")
+}
+
 // emitTouchEvent sends a touch event to the event pipeline
 func (a *App) emitTouchEvent(deviceId string, x, y int, gestureType string) {
 	if a.eventPipeline == nil {
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	defaultCheckpointTimeoutMs = 3000
+	checkpointPollInterval     = 500 * time.Millisecond
+)
+
+// checkpointFindType maps selector types used by recorded scripts onto FindElement's
+// check types. Recorded "xpath" and "coordinates" selectors have no FindElement
+// equivalent and are rejected rather than left to time out during playback.
+func checkpointFindType(selectorType string) (string, error) {
+	switch selectorType {
+	case "text", "id", "class", "contains", "description", "bounds":
+		return selectorType, nil
+	case "resourceId":
+		return "id", nil
+	case "desc", "contentDesc":
+		return "description", nil
+	case "className":
+		return "class", nil
+	default:
+		return "", fmt.Errorf("checkpoint selector type %q is not supported (use text, id, description, class, contains or bounds)", selectorType)
+	}
+}
+
+// validateScriptCheckpoints checks every checkpoint in a script before playback starts
+func validateScriptCheckpoints(script TouchScript) error {
+	for i, event := range script.Events {
+		if event.Checkpoint == nil {
+			continue
+		}
+		if _, err := checkpointFindType(event.Checkpoint.Selector.Type); err != nil {
+			return fmt.Errorf("event %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// verifyPlaybackCheckpoint waits for a checkpoint element after a playback event.
+// A missing element emits "playback-checkpoint-failed" and then, depending on OnFailure,
+// stops playback (returns an error), pauses it until the user resumes, or continues.
+func (a *App) verifyPlaybackCheckpoint(ctx context.Context, deviceId string, eventIndex int, cp *PlaybackCheckpoint) error {
+	checkType, err := checkpointFindType(cp.Selector.Type)
+	if err != nil {
+		return fmt.Errorf("event %d: %w", eventIndex+1, err)
+	}
+	timeout := cp.TimeoutMs
+	if timeout <= 0 {
+		timeout = defaultCheckpointTimeoutMs
+	}
+
+	deadline := time.Now().Add(time.Duration(timeout) * time.Millisecond)
+	for {
+		result, err := a.GetUIHierarchyWithContext(ctx, deviceId)
+		if err == nil && a.FindElement(result.Root, checkType, cp.Selector.Value) {
+			LogDebug("automation").Int("event", eventIndex).Str("checkType", checkType).Str("checkValue", cp.Selector.Value).Msg("Checkpoint passed")
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(checkpointPollInterval):
+		}
+	}
+
+	onFailure := cp.OnFailure
+	if onFailure == "" {
+		onFailure = "stop"
+	}
+	LogWarn("automation").Int("event", eventIndex).Str("checkType", checkType).Str("checkValue", cp.Selector.Value).Str("onFailure", onFailure).Msg("Checkpoint failed")
+
+	if !a.mcpMode {
+		wailsRuntime.EventsEmit(a.ctx, "playback-checkpoint-failed", map[string]interface{}{
+			"deviceId":   deviceId,
+			"eventIndex": eventIndex,
+			"selector":   cp.Selector,
+			"onFailure":  onFailure,
+		})
+	}
+
+	switch onFailure {
+	case "continue":
+		return nil
+	case "pause":
+		a.PauseTask(deviceId)
+		if a.checkPauseWithContext(ctx, deviceId) {
+			return ctx.Err()
+		}
+		return nil
+	default:
+		return fmt.Errorf("checkpoint failed after event %d: %s=%s not found", eventIndex+1, cp.Selector.Type, cp.Selector.Value)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCheckpointFindType(t *testing.T) {
+	tests := []struct {
+		selectorType string
+		want         string
+		wantErr      bool
+	}{
+		{"text", "text", false},
+		{"id", "id", false},
+		{"resourceId", "id", false},
+		{"desc", "description", false},
+		{"contentDesc", "description", false},
+		{"description", "description", false},
+		{"className", "class", false},
+		{"contains", "contains", false},
+		{"bounds", "bounds", false},
+		{"xpath", "", true},
+		{"coordinates", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := checkpointFindType(tt.selectorType)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("checkpointFindType(%q) = %q, %v; want %q, error %v", tt.selectorType, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateScriptCheckpoints(t *testing.T) {
+	script := TouchScript{Events: []TouchEvent{
+		{Type: "tap"},
+		{Type: "tap", Checkpoint: &PlaybackCheckpoint{Selector: ElementSelector{Type: "text", Value: "OK"}}},
+	}}
+	if err := validateScriptCheckpoints(script); err != nil {
+		t.Fatalf("validateScriptCheckpoints() error = %v", err)
+	}
+
+	script.Events = append(script.Events, TouchEvent{
+		Type:       "tap",
+		Checkpoint: &PlaybackCheckpoint{Selector: ElementSelector{Type: "xpath", Value: "//node"}},
+	})
+	err := validateScriptCheckpoints(script)
+	if err == nil || !strings.Contains(err.Error(), "event 3") || !strings.Contains(err.Error(), `"xpath"`) {
+		t.Errorf("validateScriptCheckpoints() error = %v; want event 3 xpath rejection", err)
+	}
+}
+
+func TestVerifyPlaybackCheckpoint_UnsupportedTypeFailsFast(t *testing.T) {
+	a := newTestApp(map[string]string{})
+	cp := &PlaybackCheckpoint{Selector: ElementSelector{Type: "coordinates", Value: "100,200"}, OnFailure: "continue"}
+
+	if err := a.verifyPlaybackCheckpoint(context.Background(), "dev1", 0, cp); err == nil {
+		t.Fatal("verifyPlaybackCheckpoint() error = nil; want unsupported selector error")
+	}
+	if calls := a.runner.(*fakeRunner).calls; len(calls) != 0 {
+		t.Errorf("adb calls = %v; want none before rejecting the selector", calls)
+	}
+}
+
+func TestPlayTouchScript_RejectsUnsupportedCheckpoint(t *testing.T) {
+	a := newTestApp(map[string]string{})
+	script := TouchScript{Name: "s", Events: []TouchEvent{
+		{Type: "tap", X: 1, Y: 1, Checkpoint: &PlaybackCheckpoint{Selector: ElementSelector{Type: "xpath", Value: "//a"}}},
+	}}
+
+	if err := a.PlayTouchScript("dev1", script); err == nil {
+		t.Fatal("PlayTouchScript() error = nil; want unsupported selector error")
+	}
+	if err := a.SaveTouchScript(script); err == nil {
+		t.Fatal("SaveTouchScript() error = nil; want unsupported selector error")
+	}
+}
@@ -373,6 +373,56 @@ func TestCheckPauseWithContextResumeAndCancel(t *testing.T) {
 	}
 }
 
+func TestStopAllTasksUnblocksEveryDevice(t *testing.T) {
+	deviceIds := []string{"test-stop-all-a", "test-stop-all-b"}
+	app := &App{mcpMode: true}
+
+	ctxs := make([]context.Context, len(deviceIds))
+	for i, deviceId := range deviceIds {
+		cleanupTaskPause(deviceId)
+		ctx, cancel := context.WithCancel(context.Background())
+		ctxs[i] = ctx
+		activeTaskMu.Lock()
+		activeTaskCancel[deviceId] = cancel
+		activeTaskMu.Unlock()
+	}
+
+	app.PauseAllTasks()
+	for _, deviceId := range deviceIds {
+		if !app.IsTaskPaused(deviceId) {
+			t.Fatalf("expected %s to be paused", deviceId)
+		}
+	}
+
+	results := make(chan bool, len(deviceIds))
+	for i, deviceId := range deviceIds {
+		go func(ctx context.Context, deviceId string) {
+			results <- app.checkPauseWithContext(ctx, deviceId)
+		}(ctxs[i], deviceId)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	app.StopAllTasks()
+
+	for range deviceIds {
+		select {
+		case cancelled := <-results:
+			if !cancelled {
+				t.Error("Expected checkPauseWithContext to report cancellation")
+			}
+		case <-time.After(1 * time.Second):
+			t.Fatal("a paused task was left blocked after StopAllTasks")
+		}
+	}
+
+	if remaining := activeTaskDevices(); len(remaining) != 0 {
+		t.Errorf("expected no active tasks, got %v", remaining)
+	}
+	if paused := pausedTaskDevices(); len(paused) != 0 {
+		t.Errorf("expected no paused devices, got %v", paused)
+	}
+}
+
 // ========================================
 // parseResolution Tests (Phase 4.1)
 // ========================================
@@ -828,3 +878,45 @@ func TestSaveScriptFilenameGeneration(t *testing.T) {
 		})
 	}
 }
+
+// ========================================
+// Task Progress Tests
+// ========================================
+
+func TestTaskProgressUnits(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []TaskStep
+		want  int
+	}{
+		{"empty task", nil, 0},
+		{"single steps", []TaskStep{{Type: "wait"}, {Type: "adb"}}, 2},
+		{"zero and negative loops count once", []TaskStep{{Loop: 0}, {Loop: -3}, {Loop: 1}}, 3},
+		{"loops multiply their step", []TaskStep{{Loop: 5}, {Type: "wait"}, {Loop: 3}}, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taskProgressUnits(ScriptTask{Steps: tt.steps}); got != tt.want {
+				t.Errorf("taskProgressUnits() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskProgressPercent(t *testing.T) {
+	tests := []struct {
+		done, total, want int
+	}{
+		{0, 0, 100},
+		{0, 4, 0},
+		{1, 3, 33},
+		{2, 3, 66},
+		{3, 3, 100},
+		{5, 3, 100},
+	}
+	for _, tt := range tests {
+		if got := taskProgressPercent(tt.done, tt.total); got != tt.want {
+			t.Errorf("taskProgressPercent(%d, %d) = %d, want %d", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
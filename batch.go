@@ -1,9 +1,15 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
@@ -22,12 +28,16 @@ func (a *App) ExecuteBatchOperation(op BatchOperation) BatchOperationResult {
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	resultsChan := make(chan BatchResult, len(op.DeviceIDs))
+	sem := make(chan struct{}, a.GetConcurrency().Shell)
 
 	for _, deviceID := range op.DeviceIDs {
 		wg.Add(1)
 		go func(devID string) {
 			defer wg.Done()
 
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
 			var br BatchResult
 			br.DeviceID = devID
 
@@ -81,6 +91,109 @@ func (a *App) ExecuteBatchOperation(op BatchOperation) BatchOperationResult {
 	return result
 }
 
+var screenshotNameUnsafe = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// BatchScreenshot captures a screenshot of every given device (all online devices if the
+// list is empty) into outputDir, named after each device's model and serial. An empty
+// outputDir uses the screenshots output directory. One device failing doesn't stop the rest.
+func (a *App) BatchScreenshot(devices []string, outputDir string) (BatchScreenshotResult, error) {
+	result := BatchScreenshotResult{Paths: []string{}, Skipped: []string{}, Errors: map[string]string{}}
+
+	if outputDir == "" {
+		outputDir = a.outputDir(OutputScreenshots)
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return result, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	online, err := a.GetDevices(false)
+	if err != nil {
+		return result, err
+	}
+	models := make(map[string]string)
+	for _, d := range online {
+		for _, id := range append([]string{d.ID}, d.IDs...) {
+			models[id] = d.Model
+		}
+		if len(devices) == 0 && d.State == "device" {
+			devices = append(devices, d.ID)
+		}
+	}
+	if len(devices) == 0 {
+		return result, fmt.Errorf("no online devices")
+	}
+
+	timestamp := time.Now().Format("20060102_150405")
+	emit := func(deviceID, status, path, errMsg string) {
+		if !a.mcpMode {
+			wailsRuntime.EventsEmit(a.ctx, "batch-screenshot-progress", map[string]string{
+				"deviceId": deviceID,
+				"status":   status, // capturing, success, skipped, error
+				"path":     path,
+				"error":    errMsg,
+			})
+		}
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, a.GetConcurrency().Shell)
+
+	for _, deviceID := range devices {
+		wg.Add(1)
+		go func(devID string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			fail := func(err error) {
+				mu.Lock()
+				result.Errors[devID] = err.Error()
+				mu.Unlock()
+				emit(devID, "error", "", err.Error())
+			}
+			if err := ValidateDeviceID(devID); err != nil {
+				fail(err)
+				return
+			}
+
+			model := models[devID]
+			if model == "" {
+				model = "Device"
+			}
+			name := fmt.Sprintf("Screenshot_%s_%s_%s.png",
+				screenshotNameUnsafe.ReplaceAllString(model, "_"),
+				screenshotNameUnsafe.ReplaceAllString(devID, "_"),
+				timestamp)
+			savePath := filepath.Join(outputDir, name)
+
+			emit(devID, "capturing", "", "")
+			err := a.captureScreenshot(devID, savePath, false, func(string, ...interface{}) {})
+			switch {
+			case err == nil:
+				mu.Lock()
+				result.Paths = append(result.Paths, savePath)
+				mu.Unlock()
+				emit(devID, "success", savePath, "")
+			case errors.Is(err, ErrScreenOff):
+				mu.Lock()
+				result.Skipped = append(result.Skipped, devID)
+				mu.Unlock()
+				emit(devID, "skipped", "", err.Error())
+			default:
+				fail(err)
+			}
+		}(deviceID)
+	}
+	wg.Wait()
+
+	sort.Strings(result.Paths)
+	sort.Strings(result.Skipped)
+	a.Log("Batch screenshot: %d saved, %d skipped, %d failed", len(result.Paths), len(result.Skipped), len(result.Errors))
+	return result, nil
+}
+
 func (a *App) batchInstall(deviceID, apkPath string) BatchResult {
 	br := BatchResult{DeviceID: deviceID}
 
@@ -195,6 +308,10 @@ func (a *App) batchShellCommand(deviceID, command string) BatchResult {
 		br.Error = "no command specified"
 		return br
 	}
+	if err := a.checkSafeMode(deviceID, command); err != nil {
+		br.Error = err.Error()
+		return br
+	}
 
 	cmd := a.newAdbCommand(nil, "-s", deviceID, "shell", command)
 	output, err := cmd.CombinedOutput()
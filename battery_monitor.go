@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Battery Monitor State
+var (
+	batteryMonitorCancels = make(map[string]context.CancelFunc)
+	batteryMonitorMu      sync.Mutex
+)
+
+const batteryPollInterval = 5 * time.Second
+
+// BatteryStats is one battery/thermal sample emitted as a "battery-stats" event
+type BatteryStats struct {
+	DeviceId      string  `json:"deviceId"`
+	Level         int     `json:"level"`         // Percent, -1 when unknown
+	Status        string  `json:"status"`        // charging, discharging, full, not_charging, unknown
+	Charging      bool    `json:"charging"`      // Charging or full while plugged in
+	Plugged       string  `json:"plugged"`       // ac, usb, wireless, dock, none
+	Temperature   float64 `json:"temperature"`   // Celsius
+	Voltage       int     `json:"voltage"`       // Millivolts
+	Health        string  `json:"health"`        // good, overheat, dead, over_voltage, failure, cold, unknown
+	ThermalStatus int     `json:"thermalStatus"` // 0 (none) to 6 (shutdown), -1 when unknown
+	Thermal       string  `json:"thermal"`       // Name of ThermalStatus
+	Source        string  `json:"source"`        // "dumpsys" or "sysfs"
+	Time          int64   `json:"time"`
+}
+
+// StartBatteryMonitor polls battery and thermal state of a device every few seconds
+func (a *App) StartBatteryMonitor(deviceId string) {
+	a.StopBatteryMonitor(deviceId)
+
+	batteryMonitorMu.Lock()
+	ctx, cancel := context.WithCancel(a.ctx)
+	batteryMonitorCancels[deviceId] = cancel
+	batteryMonitorMu.Unlock()
+	a.registerMonitor("battery", deviceId, func() { a.StopBatteryMonitor(deviceId) })
+
+	go func() {
+		ticker := time.NewTicker(batteryPollInterval)
+		defer ticker.Stop()
+
+		for {
+			stats, err := a.getBatteryStats(ctx, deviceId)
+			if err == nil && !a.mcpMode {
+				wailsRuntime.EventsEmit(a.ctx, "battery-stats", stats)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// StopBatteryMonitor stops the battery monitor of a device
+func (a *App) StopBatteryMonitor(deviceId string) {
+	batteryMonitorMu.Lock()
+	defer batteryMonitorMu.Unlock()
+	if cancel, ok := batteryMonitorCancels[deviceId]; ok {
+		cancel()
+		delete(batteryMonitorCancels, deviceId)
+	}
+	unregisterMonitor("battery", deviceId)
+}
+
+// StopAllBatteryMonitors stops all battery monitoring
+func (a *App) StopAllBatteryMonitors() {
+	batteryMonitorMu.Lock()
+	defer batteryMonitorMu.Unlock()
+	for id, cancel := range batteryMonitorCancels {
+		cancel()
+		delete(batteryMonitorCancels, id)
+	}
+	unregisterMonitorKind("battery")
+}
+
+// sysfsBatteryScript prints the battery files the dumpsys fallback needs as key=value
+const sysfsBatteryScript = `for f in capacity status temp voltage_now health; do echo "$f=$(cat /sys/class/power_supply/battery/$f 2>/dev/null)"; done`
+
+// getBatteryStats takes one sample. Fields dumpsys doesn't report are filled from
+// /sys/class/power_supply/battery, which most kernels expose to the shell user.
+func (a *App) getBatteryStats(ctx context.Context, deviceId string) (BatteryStats, error) {
+	sampleCtx, cancel := context.WithTimeout(ctx, 4*time.Second)
+	defer cancel()
+
+	out, _, err := a.runAdb(sampleCtx, "-s", deviceId, "shell", "dumpsys", "battery")
+	if err != nil {
+		return BatteryStats{}, fmt.Errorf("failed to read battery state: %w", err)
+	}
+	stats := batteryStatsFromState(parseBatteryDump(string(out)))
+	stats.DeviceId = deviceId
+	stats.Time = time.Now().UnixMilli()
+
+	if stats.Level < 0 || stats.Temperature == 0 || stats.Voltage == 0 || stats.Status == "unknown" {
+		if sysOut, _, err := a.runAdb(sampleCtx, "-s", deviceId, "shell", sysfsBatteryScript); err == nil {
+			mergeSysfsBattery(&stats, string(sysOut))
+		}
+	}
+
+	stats.ThermalStatus = -1
+	if thermalOut, _, err := a.runAdb(sampleCtx, "-s", deviceId, "shell", "dumpsys", "thermalservice"); err == nil {
+		stats.ThermalStatus = parseThermalStatus(string(thermalOut))
+	}
+	stats.Thermal = thermalStatusName(stats.ThermalStatus)
+	return stats, nil
+}
+
+// batteryStatsFromState converts a parsed `dumpsys battery` into a sample, with the
+// temperature in degrees Celsius
+func batteryStatsFromState(state *BatteryState) BatteryStats {
+	return BatteryStats{
+		Level:       state.Level,
+		Status:      state.Status,
+		Charging:    state.Charging,
+		Plugged:     state.Plugged,
+		Temperature: float64(state.Temperature) / 10,
+		Voltage:     state.Voltage,
+		Health:      state.Health,
+		Source:      "dumpsys",
+	}
+}
+
+// normalizeMillivolts accepts volts ("4.2"), millivolts ("4200") or microvolts ("4200000")
+func normalizeMillivolts(value string) int {
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || v <= 0 {
+		return 0
+	}
+	switch {
+	case v < 100:
+		return int(v * 1000)
+	case v > 100000:
+		return int(v / 1000)
+	}
+	return int(v)
+}
+
+// mergeSysfsBattery fills fields dumpsys left empty from sysfsBatteryScript output
+func mergeSysfsBattery(stats *BatteryStats, output string) {
+	values := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		if k, v, ok := strings.Cut(strings.TrimSpace(line), "="); ok && v != "" {
+			values[k] = v
+		}
+	}
+	used := false
+	if n, err := strconv.Atoi(values["capacity"]); err == nil && stats.Level < 0 {
+		stats.Level = n
+		used = true
+	}
+	if s := values["status"]; s != "" && stats.Status == "unknown" {
+		stats.Status = strings.ReplaceAll(strings.ToLower(s), " ", "_")
+		used = true
+	}
+	if n, err := strconv.Atoi(values["temp"]); err == nil && stats.Temperature == 0 {
+		stats.Temperature = float64(n) / 10
+		used = true
+	}
+	if mv := normalizeMillivolts(values["voltage_now"]); mv > 0 && stats.Voltage == 0 {
+		stats.Voltage = mv
+		used = true
+	}
+	if h := values["health"]; h != "" && stats.Health == "unknown" {
+		stats.Health = strings.ReplaceAll(strings.ToLower(h), " ", "_")
+		used = true
+	}
+	if used {
+		stats.Source = "sysfs"
+		stats.Charging = stats.Status == "charging" || (stats.Status == "full" && stats.Plugged != "none")
+	}
+}
+
+var thermalStatusRegex = regexp.MustCompile(`(?im)^\s*Thermal Status:\s*(\d+)`)
+
+// parseThermalStatus reads "Thermal Status: N" from `dumpsys thermalservice`, or -1
+func parseThermalStatus(output string) int {
+	m := thermalStatusRegex.FindStringSubmatch(output)
+	if m == nil {
+		return -1
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n
+}
+
+// thermalStatusName maps PowerManager.THERMAL_STATUS_* values to names
+func thermalStatusName(status int) string {
+	names := []string{"none", "light", "moderate", "severe", "critical", "emergency", "shutdown"}
+	if status < 0 || status >= len(names) {
+		return "unknown"
+	}
+	return names[status]
+}
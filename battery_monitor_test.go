@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+const dumpsysBatteryPixel = `Current Battery Service state:
+  AC powered: false
+  USB powered: true
+  Wireless powered: false
+  Max charging current: 500000
+  status: 2
+  health: 2
+  present: true
+  level: 76
+  scale: 100
+  voltage: 4123
+  temperature: 312
+  technology: Li-ion
+`
+
+func TestParseBatteryDump(t *testing.T) {
+	state := parseBatteryDump(dumpsysBatteryPixel)
+	wantState := BatteryState{Level: 76, Status: "charging", Charging: true, Temperature: 312, Voltage: 4123, Health: "good", Plugged: "usb"}
+	if *state != wantState {
+		t.Errorf("got %+v\nwant %+v", *state, wantState)
+	}
+
+	// The monitor sample reports the same reading in degrees Celsius
+	got := batteryStatsFromState(state)
+	want := BatteryStats{Level: 76, Status: "charging", Charging: true, Plugged: "usb", Temperature: 31.2, Voltage: 4123, Health: "good", Source: "dumpsys"}
+	if got != want {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseBatteryDumpOEMVariations(t *testing.T) {
+	// Scale other than 100, microvolt voltage, capitalised keys, no temperature line
+	got := parseBatteryDump("  Level: 150\n  Scale: 200\n  Voltage: 3950000\n  Status: 5\n  AC powered: true\n")
+	if got.Level != 75 || got.Voltage != 3950 || got.Status != "full" || !got.Charging || got.Plugged != "ac" {
+		t.Errorf("got %+v", got)
+	}
+	if got.Temperature != 0 || got.Health != "unknown" {
+		t.Errorf("missing fields should stay unset, got %+v", got)
+	}
+}
+
+func TestMergeSysfsBattery(t *testing.T) {
+	stats := batteryStatsFromState(parseBatteryDump("Current Battery Service state:\n  status: 3\n"))
+	mergeSysfsBattery(&stats, "capacity=54\nstatus=Discharging\ntemp=287\nvoltage_now=3870000\nhealth=Good\n")
+	if stats.Level != 54 || stats.Temperature != 28.7 || stats.Voltage != 3870 || stats.Health != "good" {
+		t.Errorf("got %+v", stats)
+	}
+	if stats.Status != "discharging" || stats.Source != "sysfs" {
+		t.Errorf("status/source = %q/%q", stats.Status, stats.Source)
+	}
+}
+
+func TestGetBatteryStatsThermal(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell dumpsys battery":        dumpsysBatteryPixel,
+		"-s R5CT1234ABC shell dumpsys thermalservice": "IsStatusOverride: false\nThermalEventListeners:\nThermal Status: 2\nCached temperatures:\n",
+	})
+	stats, err := app.getBatteryStats(context.Background(), "R5CT1234ABC")
+	if err != nil {
+		t.Fatalf("getBatteryStats: %v", err)
+	}
+	if stats.ThermalStatus != 2 || stats.Thermal != "moderate" {
+		t.Errorf("thermal = %d %q", stats.ThermalStatus, stats.Thermal)
+	}
+	if stats.DeviceId != "R5CT1234ABC" || stats.Level != 76 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
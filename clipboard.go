@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ========================================
+// Device clipboard
+// ========================================
+
+var (
+	// ErrClipboardUnsupported is returned when the device has no clipboard shell command
+	ErrClipboardUnsupported = errors.New("clipboard access from adb is not supported on this Android version")
+	// ErrClipboardReadRestricted is returned when Android hides the clipboard from the shell.
+	// Since Android 10 only the focused app or the default IME may read it.
+	ErrClipboardReadRestricted = errors.New("reading the clipboard requires a focused app; Android 10+ blocks it from adb")
+	// ErrClipboardNeedsField is returned when setting the clipboard needs a focused text
+	// field (devices without `cmd clipboard set-text`) and none is focused
+	ErrClipboardNeedsField = errors.New("setting the clipboard on this device needs a focused text field")
+)
+
+// clipboardCommandMissing reports output of a `cmd clipboard` call on a device without it
+func clipboardCommandMissing(output string) bool {
+	return strings.Contains(output, "Unknown command") ||
+		strings.Contains(output, "No shell command implementation") ||
+		strings.Contains(output, "Can't find service")
+}
+
+// parseClipboardText interprets `cmd clipboard get-text` output. An empty or "null"
+// result from Android 10+ means the read was blocked rather than an empty clipboard.
+func parseClipboardText(output string, sdk int) (string, error) {
+	if clipboardCommandMissing(output) {
+		return "", ErrClipboardUnsupported
+	}
+	text := strings.TrimSuffix(output, "\n")
+	if text == "" || text == "null" {
+		if sdk >= 29 {
+			return "", ErrClipboardReadRestricted
+		}
+		return "", nil
+	}
+	return text, nil
+}
+
+// GetDeviceClipboard returns the text on the device clipboard, independent of mirroring
+func (a *App) GetDeviceClipboard(deviceId string) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+	sdk, err := a.getDeviceSdkInt(deviceId)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "cmd", "clipboard", "get-text")
+	if err != nil && !clipboardCommandMissing(string(output)) {
+		return "", fmt.Errorf("failed to read clipboard: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return parseClipboardText(string(output), sdk)
+}
+
+// SetDeviceClipboard puts text on the device clipboard. It uses `cmd clipboard set-text`
+// where available. Otherwise, on Android 13+, it goes through the focused text field:
+// the field's text is read from the UI hierarchy, the new text is typed over it and cut,
+// and the original text is typed back, so a text field must be focused.
+func (a *App) SetDeviceClipboard(deviceId, text string) error {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return err
+	}
+	a.updateLastActive(deviceId)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "cmd", "clipboard", "set-text", shellQuote(text))
+	outStr := strings.TrimSpace(string(output))
+	if !clipboardCommandMissing(outStr) {
+		if err != nil {
+			return fmt.Errorf("failed to set clipboard: %w (%s)", err, outStr)
+		}
+		return nil
+	}
+
+	sdk, err := a.getDeviceSdkInt(deviceId)
+	if err != nil {
+		return err
+	}
+	if sdk < 33 {
+		return ErrClipboardUnsupported
+	}
+	return a.setClipboardViaFocusedField(deviceId, text)
+}
+
+// setClipboardViaFocusedField copies text to the clipboard by typing it into the focused
+// field and cutting it, then restores what the field held before
+func (a *App) setClipboardViaFocusedField(deviceId, text string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	hierarchy, err := a.GetUIHierarchyWithContext(ctx, deviceId)
+	if err != nil {
+		return fmt.Errorf("failed to read the focused field: %w", err)
+	}
+	field := findFocusedNode(hierarchy.Root)
+	if field == nil {
+		return ErrClipboardNeedsField
+	}
+	if field.Password {
+		// Its text can't be read back, so it couldn't be restored
+		return fmt.Errorf("%w (a password field is focused)", ErrClipboardNeedsField)
+	}
+	original := field.Text
+	if original == field.Hint {
+		original = ""
+	}
+
+	selectAll := "shell input keycombination KEYCODE_CTRL_LEFT KEYCODE_A"
+	if _, err := a.RunAdbCommand(deviceId, selectAll); err != nil {
+		return fmt.Errorf("failed to select the field text: %w", err)
+	}
+	if err := a.InputText(deviceId, text); err != nil {
+		return fmt.Errorf("failed to type clipboard text: %w", err)
+	}
+	if _, err := a.RunAdbCommand(deviceId, selectAll); err != nil {
+		return fmt.Errorf("failed to select clipboard text: %w", err)
+	}
+	if _, err := a.RunAdbCommand(deviceId, "shell input keyevent KEYCODE_CUT"); err != nil {
+		return fmt.Errorf("failed to cut clipboard text: %w", err)
+	}
+	if original != "" {
+		if err := a.InputText(deviceId, original); err != nil {
+			return fmt.Errorf("clipboard set, but restoring the field text failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// findFocusedNode returns the node holding input focus, or nil
+func findFocusedNode(node *UINode) *UINode {
+	if node == nil {
+		return nil
+	}
+	if node.Focused {
+		return node
+	}
+	for i := range node.Nodes {
+		if found := findFocusedNode(&node.Nodes[i]); found != nil {
+			return found
+		}
+	}
+	return nil
+}
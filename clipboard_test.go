@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseClipboardText(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		sdk     int
+		want    string
+		wantErr error
+	}{
+		{name: "text", output: "hello world\n", sdk: 34, want: "hello world"},
+		{name: "multi-line text", output: "line one\nline two\n", sdk: 34, want: "line one\nline two"},
+		{name: "blocked on Android 10+", output: "null\n", sdk: 30, wantErr: ErrClipboardReadRestricted},
+		{name: "empty before Android 10", output: "", sdk: 28, want: ""},
+		{name: "no clipboard command", output: "Unknown command: get-text\n", sdk: 30, wantErr: ErrClipboardUnsupported},
+		{name: "no shell command", output: "No shell command implementation.\n", sdk: 28, wantErr: ErrClipboardUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseClipboardText(tt.output, tt.sdk)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("text = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetDeviceClipboard_FallbackRestoresField(t *testing.T) {
+	const dumpCmd = "-s dev1 shell uiautomator dump /data/local/tmp/view.xml && cat /data/local/tmp/view.xml"
+	const selectAll = "-s dev1 shell input keycombination KEYCODE_CTRL_LEFT KEYCODE_A"
+	dump := func(text, hint string) string {
+		return `<?xml version='1.0' encoding='UTF-8' standalone='yes' ?><hierarchy rotation="0"><node index="0" text="" class="android.widget.FrameLayout" package="com.example" bounds="[0,0][100,100]"><node index="0" text="` + text + `" hint="` + hint + `" class="android.widget.EditText" package="com.example" focused="true" bounds="[0,0][100,50]" /></node></hierarchy>`
+	}
+	responses := func(fieldDump string) map[string]string {
+		return map[string]string{
+			"-s dev1 shell cmd clipboard set-text 'code'": "Unknown command: set-text",
+			"-s dev1 shell getprop ro.build.version.sdk":  "34\n",
+			dumpCmd:                         fieldDump,
+			selectAll:                       "",
+			"-s dev1 shell input text code": "",
+			"-s dev1 shell input keyevent KEYCODE_CUT": "",
+			"-s dev1 shell input text draft":           "",
+		}
+	}
+
+	a := newTestApp(responses(dump("draft", "Message")))
+	if err := a.SetDeviceClipboard("dev1", "code"); err != nil {
+		t.Fatalf("SetDeviceClipboard: %v", err)
+	}
+	calls := a.runner.(*fakeRunner).calls
+	want := []string{selectAll, "-s dev1 shell input text code", selectAll, "-s dev1 shell input keyevent KEYCODE_CUT", "-s dev1 shell input text draft"}
+	if got := calls[len(calls)-len(want):]; strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("input calls = %q, want %q", got, want)
+	}
+
+	// An empty field showing its hint gets nothing typed back
+	a = newTestApp(responses(dump("Message", "Message")))
+	if err := a.SetDeviceClipboard("dev1", "code"); err != nil {
+		t.Fatalf("SetDeviceClipboard (hint): %v", err)
+	}
+	if calls := a.runner.(*fakeRunner).calls; calls[len(calls)-1] != "-s dev1 shell input keyevent KEYCODE_CUT" {
+		t.Errorf("hint text should not be restored, last call = %q", calls[len(calls)-1])
+	}
+
+	// Without a focused field nothing is typed
+	a = newTestApp(responses(strings.Replace(dump("", ""), `focused="true"`, "", 1)))
+	if err := a.SetDeviceClipboard("dev1", "code"); !errors.Is(err, ErrClipboardNeedsField) {
+		t.Errorf("err = %v, want ErrClipboardNeedsField", err)
+	}
+	for _, call := range a.runner.(*fakeRunner).calls {
+		if strings.Contains(call, " input ") {
+			t.Errorf("unexpected input without a focused field: %q", call)
+		}
+	}
+}
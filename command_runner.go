@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+)
+
+// CommandRunner runs an external command to completion and returns its output.
+// The default implementation uses os/exec; tests replace App.runner with a fake
+// that replays canned output, so parsers can be exercised without a device.
+type CommandRunner interface {
+	Run(ctx context.Context, name string, args []string) (stdout, stderr []byte, err error)
+}
+
+// execRunner is the CommandRunner backed by os/exec
+type execRunner struct {
+	env []string // nil inherits the current environment
+}
+
+func (r execRunner) Run(ctx context.Context, name string, args []string) ([]byte, []byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	if r.env != nil {
+		cmd.Env = r.env
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
+// adbRunner returns the injected runner, or an exec runner with the same clean
+// environment newAdbCommand uses
+func (a *App) adbRunner() CommandRunner {
+	if a.runner != nil {
+		return a.runner
+	}
+	return execRunner{env: a.adbCommandEnv()}
+}
+
+// runAdb runs a one-shot adb command through the runner; ctx may be nil.
+// Long-running or streaming commands still use newAdbCommand directly.
+func (a *App) runAdb(ctx context.Context, args ...string) (stdout, stderr []byte, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return a.adbRunner().Run(ctx, a.adbPath, args)
+}
+
+// runAdbCombined is runAdb with stdout and stderr joined, like exec.Cmd.CombinedOutput
+func (a *App) runAdbCombined(ctx context.Context, args ...string) ([]byte, error) {
+	stdout, stderr, err := a.runAdb(ctx, args...)
+	return append(stdout, stderr...), err
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRunner replays canned output keyed by the space-joined adb arguments
+type fakeRunner struct {
+	mu        sync.Mutex
+	responses map[string]string
+	calls     []string
+}
+
+func (f *fakeRunner) Run(ctx context.Context, name string, args []string) ([]byte, []byte, error) {
+	key := strings.Join(args, " ")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, key)
+	out, ok := f.responses[key]
+	if !ok {
+		return nil, []byte("unexpected command"), fmt.Errorf("fakeRunner: no response for %q", key)
+	}
+	return []byte(out), nil, nil
+}
+
+func newTestApp(responses map[string]string) *App {
+	return &App{
+		adbPath:         "adb",
+		idToSerial:      make(map[string]string),
+		reconnectStates: make(map[string]*ReconnectStats),
+		runner:          &fakeRunner{responses: responses},
+	}
+}
+
+const adbDevicesOutput = `List of devices attached
+R5CT1234ABC            device usb:1-1 product:o1sxeea model:SM_G991B device:o1s transport_id:1
+adb-R5CT1234ABC-Xy7Qz1._adb-tls-connect._tcp device product:o1sxeea model:SM_G991B device:o1s transport_id:2
+0123456789ABCDEF       no permissions (missing udev rules? user is in the plugdev group); see [http://developer.android.com/tools/device.html] usb:1-2 transport_id:3
+emulator-5554          unauthorized transport_id:4
+
+`
+
+func TestGetDevicesGrouping(t *testing.T) {
+	const mdnsID = "adb-R5CT1234ABC-Xy7Qz1._adb-tls-connect._tcp"
+	a := newTestApp(map[string]string{
+		"devices -l": adbDevicesOutput,
+		"-s R5CT1234ABC shell getprop ro.serialno":                                       "R5CT1234ABC\n",
+		"-s " + mdnsID + " shell getprop ro.serialno":                                    "R5CT1234ABC\n",
+		"-s R5CT1234ABC shell getprop ro.product.manufacturer; getprop ro.product.model": "samsung\nSM-G991B\n",
+	})
+
+	devices, err := a.GetDevices(false)
+	if err != nil {
+		t.Fatalf("GetDevices() error = %v", err)
+	}
+	if len(devices) != 3 {
+		t.Fatalf("expected 3 devices, got %d: %+v", len(devices), devices)
+	}
+
+	byState := make(map[string]Device)
+	for _, d := range devices {
+		byState[d.State] = d
+	}
+
+	phone := byState["device"]
+	if phone.ID != "R5CT1234ABC" || phone.Serial != "R5CT1234ABC" {
+		t.Errorf("phone id/serial = %s/%s, want wired id as primary", phone.ID, phone.Serial)
+	}
+	if phone.Type != "both" || len(phone.IDs) != 2 || phone.WifiAddr != mdnsID {
+		t.Errorf("phone not grouped with its mDNS connection: %+v", phone)
+	}
+	if phone.Brand != "samsung" || phone.Model != "SM-G991B" {
+		t.Errorf("phone brand/model = %s/%s", phone.Brand, phone.Model)
+	}
+
+	if np := byState["no_permissions"]; np.ID != "0123456789ABCDEF" || np.Hint == "" {
+		t.Errorf("no-permissions device = %+v", np)
+	}
+	if ua := byState["unauthorized"]; ua.ID != "emulator-5554" || ua.Type != "wired" {
+		t.Errorf("unauthorized device = %+v", ua)
+	}
+}
+
+func TestGetDevice(t *testing.T) {
+	const mdnsID = "adb-R5CT1234ABC-Xy7Qz1._adb-tls-connect._tcp"
+	runner := &fakeRunner{responses: map[string]string{
+		"devices -l": adbDevicesOutput,
+		"-s R5CT1234ABC shell getprop ro.serialno":                                       "R5CT1234ABC\n",
+		"-s " + mdnsID + " shell getprop ro.serialno":                                    "R5CT1234ABC\n",
+		"-s R5CT1234ABC shell getprop ro.product.manufacturer; getprop ro.product.model": "samsung\nSM-G991B\n",
+	}}
+	a := newTestApp(nil)
+	a.runner = runner
+
+	// The wireless alias resolves to the grouped phone
+	d, err := a.GetDevice(mdnsID)
+	if err != nil {
+		t.Fatalf("GetDevice() error = %v", err)
+	}
+	if d.Serial != "R5CT1234ABC" || d.ID != "R5CT1234ABC" || d.Model != "SM-G991B" {
+		t.Errorf("GetDevice(alias) = %+v", d)
+	}
+
+	// Cached serials narrow the lookup but the candidates are still asked
+	runner.calls = nil
+	if d, err = a.GetDevice("R5CT1234ABC"); err != nil || d.Type != "both" {
+		t.Fatalf("GetDevice(serial) = %+v, %v", d, err)
+	}
+	asked := map[string]bool{}
+	for _, call := range runner.calls {
+		if strings.HasSuffix(call, "getprop ro.serialno") {
+			asked[strings.Fields(call)[1]] = true
+		}
+	}
+	if !asked["R5CT1234ABC"] || !asked[mdnsID] || asked["emulator-5554"] || asked["0123456789ABCDEF"] {
+		t.Errorf("serial lookups = %v, want only the phone's IDs", asked)
+	}
+
+	if _, err := a.GetDevice("unknown-serial"); !errors.Is(err, ErrDeviceNotFound) {
+		t.Errorf("expected ErrDeviceNotFound for unknown serial, got %v", err)
+	}
+}
+
+func TestGetDevice_ReusedID(t *testing.T) {
+	const addr = "192.168.1.20:5555"
+	a := newTestApp(map[string]string{
+		"devices -l": "List of devices attached\n" + addr + " device product:x model:Pixel_8 device:x transport_id:9\n",
+		"-s " + addr + " shell getprop ro.serialno":                                       "PIXEL8SERIAL\n",
+		"-s " + addr + " shell getprop ro.product.manufacturer; getprop ro.product.model": "Google\nPixel 8\n",
+	})
+	// The address used to belong to another phone
+	a.idToSerial[addr] = "R5CT1234ABC"
+	a.idToSerial["R5CT1234ABC"] = "R5CT1234ABC"
+
+	d, err := a.GetDevice(addr)
+	if err != nil || d.Serial != "PIXEL8SERIAL" {
+		t.Fatalf("GetDevice(addr) = %+v, %v; want the phone now at the address", d, err)
+	}
+
+	a.idToSerial[addr] = "R5CT1234ABC"
+	if d, err := a.GetDevice("PIXEL8SERIAL"); err != nil || d.ID != addr {
+		t.Errorf("GetDevice(serial) behind a stale ID = %+v, %v", d, err)
+	}
+	if _, err := a.GetDevice("R5CT1234ABC"); !errors.Is(err, ErrDeviceNotFound) {
+		t.Errorf("the old phone is gone, got %v", err)
+	}
+}
+
+func TestListFiles(t *testing.T) {
+	a := newTestApp(map[string]string{
+		`-s R5CT1234ABC shell ls -la '/sdcard/'`: `total 48
+drwxrwx--x  2 root sdcard_rw 4096 2024-03-01 10:12 Alarms
+drwxrwx--x  5 root sdcard_rw 4096 2024-03-02 18:40 DCIM
+-rw-rw----  1 root sdcard_rw 1832 2024-03-05 09:01 notes.txt
+lrwxrwxrwx  1 root root        21 2024-01-01 00:00 storage -> /storage/self/primary
+`,
+	})
+
+	files, err := a.ListFiles("R5CT1234ABC", "/sdcard")
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+	if len(files) != 4 {
+		t.Fatalf("expected 4 entries, got %d: %+v", len(files), files)
+	}
+
+	byName := make(map[string]FileInfo)
+	for _, f := range files {
+		byName[f.Name] = f
+	}
+	if f := byName["notes.txt"]; f.IsDir || f.Size != 1832 || f.Path != "/sdcard/notes.txt" {
+		t.Errorf("notes.txt = %+v", f)
+	}
+	if f := byName["DCIM"]; !f.IsDir {
+		t.Errorf("DCIM should be a directory: %+v", f)
+	}
+	if f, ok := byName["storage"]; !ok || !f.IsDir {
+		t.Errorf("symlink should be listed as a directory without its target: %+v", byName)
+	}
+}
+
+func TestParseActivitiesFromDumpsys(t *testing.T) {
+	const output = `Activity Resolver Table:
+  Non-Data Actions:
+      android.intent.action.MAIN:
+        5d6e7f8 com.example.app/.MainActivity filter 1a2b3c4
+      com.example.app.OPEN_DETAIL:
+        9a8b7c6 com.example.app/com.example.app.detail.DetailActivity filter 2b3c4d5
+        5d6e7f8 com.example.app/.MainActivity filter 3c4d5e6
+
+Packages:
+  Package [com.example.app] (8a1b2c3):
+`
+	a := &App{}
+	got := a.parseActivitiesFromDumpsys(output, "com.example.app")
+	want := []string{"com.example.app/com.example.app.MainActivity", "com.example.app/com.example.app.detail.DetailActivity"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("parseActivitiesFromDumpsys() = %v, want %v", got, want)
+	}
+}
+
+func TestParseLabelFromAapt(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name: "default label",
+			output: `package: name='com.example.app' versionCode='42' versionName='1.4.2'
+application-label:'Example'
+application-label-zh-CN:'示例'`,
+			want: "Example",
+		},
+		{
+			name: "localized only, prefers en",
+			output: `application-label-de:'Beispiel'
+application-label-en:'Example'`,
+			want: "Example",
+		},
+		{
+			name:   "application line",
+			output: `application: label='Example App' icon='res/mipmap-anydpi-v26/ic_launcher.xml'`,
+			want:   "Example App",
+		},
+		{
+			name:   "no label",
+			output: `package: name='com.example.app'`,
+			want:   "",
+		},
+	}
+
+	a := &App{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.parseLabelFromAapt(tt.output); got != tt.want {
+				t.Errorf("parseLabelFromAapt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
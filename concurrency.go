@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+
+	"Gaze/pkg/cache"
+)
+
+const (
+	defaultThumbnailConcurrency = 10
+	defaultAaptConcurrency      = 2
+	defaultShellConcurrency     = 8
+
+	maxConcurrency = 64
+)
+
+// GetConcurrency returns the effective worker pool sizes
+func (a *App) GetConcurrency() ConcurrencySettings {
+	c := ConcurrencySettings{
+		Thumbnails: defaultThumbnailConcurrency,
+		Aapt:       defaultAaptConcurrency,
+		Shell:      defaultShellConcurrency,
+	}
+	if a.cacheService == nil {
+		return c
+	}
+
+	stored := a.cacheService.GetConcurrency()
+	if stored.Thumbnails > 0 {
+		c.Thumbnails = stored.Thumbnails
+	}
+	if stored.Aapt > 0 {
+		c.Aapt = stored.Aapt
+	}
+	if stored.Shell > 0 {
+		c.Shell = stored.Shell
+	}
+	return c
+}
+
+// SetConcurrency sets the worker pool sizes for label loading, aapt APK pulls and
+// shell fan-out. Passing 0 for a value restores its default.
+func (a *App) SetConcurrency(thumbnails, aapt, shell int) (ConcurrencySettings, error) {
+	for name, v := range map[string]int{"thumbnails": thumbnails, "aapt": aapt, "shell": shell} {
+		if v < 0 || v > maxConcurrency {
+			return a.GetConcurrency(), fmt.Errorf("%s concurrency must be between 0 and %d, got %d", name, maxConcurrency, v)
+		}
+	}
+	if a.cacheService == nil {
+		return a.GetConcurrency(), fmt.Errorf("settings are not available")
+	}
+
+	a.cacheService.SetConcurrency(cache.Concurrency{
+		Thumbnails: thumbnails,
+		Aapt:       aapt,
+		Shell:      shell,
+	})
+	go a.saveSettings()
+
+	c := a.GetConcurrency()
+	a.Log("Concurrency set: thumbnails=%d aapt=%d shell=%d", c.Thumbnails, c.Aapt, c.Shell)
+	return c, nil
+}
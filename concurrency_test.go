@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestGetConcurrency_Defaults(t *testing.T) {
+	app := newTestApp(nil)
+	c := app.GetConcurrency()
+	if c.Thumbnails != defaultThumbnailConcurrency || c.Aapt != defaultAaptConcurrency || c.Shell != defaultShellConcurrency {
+		t.Errorf("unexpected defaults without settings: %+v", c)
+	}
+}
+
+func TestSetConcurrency(t *testing.T) {
+	app := newOutputTestApp(t)
+
+	c, err := app.SetConcurrency(4, 0, 16)
+	if err != nil {
+		t.Fatalf("SetConcurrency: %v", err)
+	}
+	// 0 restores the default for that pool
+	if c.Thumbnails != 4 || c.Aapt != defaultAaptConcurrency || c.Shell != 16 {
+		t.Errorf("unexpected settings: %+v", c)
+	}
+	if got := app.GetConcurrency(); got != c {
+		t.Errorf("GetConcurrency() = %+v, want %+v", got, c)
+	}
+
+	for _, tc := range []struct{ thumbnails, aapt, shell int }{
+		{-1, 2, 8},
+		{10, maxConcurrency + 1, 8},
+		{10, 2, -5},
+	} {
+		if _, err := app.SetConcurrency(tc.thumbnails, tc.aapt, tc.shell); err == nil {
+			t.Errorf("SetConcurrency(%d, %d, %d) expected error", tc.thumbnails, tc.aapt, tc.shell)
+		}
+	}
+	if got := app.GetConcurrency(); got != c {
+		t.Errorf("rejected values must not change settings, got %+v", got)
+	}
+
+	if _, err := newTestApp(nil).SetConcurrency(1, 1, 1); err == nil {
+		t.Error("expected error when settings are unavailable")
+	}
+}
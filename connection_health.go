@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// Connection health
+// ========================================
+
+// Connection Monitor State
+var (
+	connectionMonitorCancels = make(map[string]context.CancelFunc)
+	connectionMonitorMu      sync.Mutex
+)
+
+var (
+	pingTimeout            = 5 * time.Second
+	connectionPollInterval = 3 * time.Second
+)
+
+// Number of recent pings the stability rating is based on
+const connectionHealthWindow = 10
+
+// ConnectionHealth is one "connection-health" sample
+type ConnectionHealth struct {
+	DeviceId  string `json:"deviceId"`
+	LatencyMs int64  `json:"latencyMs"` // -1 when the ping failed
+	Error     string `json:"error,omitempty"`
+	AvgMs     int64  `json:"avgMs"`     // Mean latency of the successful pings in the window
+	Stability int    `json:"stability"` // Percentage of successful pings in the window
+	Rating    string `json:"rating"`    // excellent, good, fair, poor
+	Time      int64  `json:"time"`
+}
+
+// PingDevice measures the round trip of a trivial shell command in milliseconds
+func (a *App) PingDevice(deviceId string) (int64, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return 0, err
+	}
+	return a.pingDevice(context.Background(), deviceId)
+}
+
+func (a *App) pingDevice(ctx context.Context, deviceId string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	start := time.Now()
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "echo", "ok")
+	elapsed := time.Since(start).Milliseconds()
+	if ctx.Err() == context.DeadlineExceeded {
+		return 0, fmt.Errorf("device did not answer within %v", pingTimeout)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("ping failed: %w", err)
+	}
+	if strings.TrimSpace(string(out)) != "ok" {
+		return 0, fmt.Errorf("unexpected ping reply: %q", strings.TrimSpace(string(out)))
+	}
+	return elapsed, nil
+}
+
+// StartConnectionMonitor pings a device every few seconds and emits "connection-health"
+// events, giving early warning before a wireless connection drops entirely
+func (a *App) StartConnectionMonitor(deviceId string) {
+	a.StopConnectionMonitor(deviceId)
+
+	connectionMonitorMu.Lock()
+	ctx, cancel := context.WithCancel(a.ctx)
+	connectionMonitorCancels[deviceId] = cancel
+	connectionMonitorMu.Unlock()
+	a.registerMonitor("connection", deviceId, func() { a.StopConnectionMonitor(deviceId) })
+
+	go func() {
+		ticker := time.NewTicker(connectionPollInterval)
+		defer ticker.Stop()
+
+		var window []int64
+		for {
+			latency, err := a.pingDevice(ctx, deviceId)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				latency = -1
+			}
+			window = append(window, latency)
+			if len(window) > connectionHealthWindow {
+				window = window[1:]
+			}
+
+			health := rateConnection(window)
+			health.DeviceId = deviceId
+			health.LatencyMs = latency
+			health.Time = time.Now().UnixMilli()
+			if err != nil {
+				health.Error = err.Error()
+			}
+			if !a.mcpMode {
+				wailsRuntime.EventsEmit(a.ctx, "connection-health", health)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// StopConnectionMonitor stops the connection monitor of a device
+func (a *App) StopConnectionMonitor(deviceId string) {
+	connectionMonitorMu.Lock()
+	defer connectionMonitorMu.Unlock()
+	if cancel, ok := connectionMonitorCancels[deviceId]; ok {
+		cancel()
+		delete(connectionMonitorCancels, deviceId)
+	}
+	unregisterMonitor("connection", deviceId)
+}
+
+// StopAllConnectionMonitors stops all connection monitoring
+func (a *App) StopAllConnectionMonitors() {
+	connectionMonitorMu.Lock()
+	defer connectionMonitorMu.Unlock()
+	for id, cancel := range connectionMonitorCancels {
+		cancel()
+		delete(connectionMonitorCancels, id)
+	}
+	unregisterMonitorKind("connection")
+}
+
+// rateConnection scores recent ping latencies (-1 = failed). Lost pings weigh most;
+// a connection that answers but slowly is rated down one step at a time.
+func rateConnection(window []int64) ConnectionHealth {
+	var ok, total int64
+	for _, l := range window {
+		if l >= 0 {
+			ok++
+			total += l
+		}
+	}
+	health := ConnectionHealth{Rating: "poor", AvgMs: -1}
+	if len(window) == 0 {
+		return health
+	}
+	health.Stability = int(ok * 100 / int64(len(window)))
+	if ok > 0 {
+		health.AvgMs = total / ok
+	}
+
+	switch {
+	case health.Stability < 70:
+		health.Rating = "poor"
+	case health.Stability < 90 || health.AvgMs > 500:
+		health.Rating = "fair"
+	case health.AvgMs > 150:
+		health.Rating = "good"
+	default:
+		health.Rating = "excellent"
+	}
+	return health
+}
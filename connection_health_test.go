@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRateConnection(t *testing.T) {
+	cases := []struct {
+		window    []int64
+		rating    string
+		stability int
+	}{
+		{[]int64{20, 30, 25}, "excellent", 100},
+		{[]int64{200, 220, 180}, "good", 100},
+		{[]int64{40, 40, 40, 40, 40, 40, 40, 40, -1, -1}, "fair", 80},
+		{[]int64{600, 700}, "fair", 100},
+		{[]int64{40, -1, 40, -1}, "poor", 50},
+		{nil, "poor", 0},
+	}
+	for _, c := range cases {
+		got := rateConnection(c.window)
+		if got.Rating != c.rating || got.Stability != c.stability {
+			t.Errorf("rateConnection(%v) = %s/%d, want %s/%d", c.window, got.Rating, got.Stability, c.rating, c.stability)
+		}
+	}
+}
+
+func TestPingDevice(t *testing.T) {
+	app := newTestApp(map[string]string{"-s R5CT1234ABC shell echo ok": "ok\n"})
+	if ms, err := app.pingDevice(context.Background(), "R5CT1234ABC"); err != nil || ms < 0 {
+		t.Errorf("pingDevice = %d, %v", ms, err)
+	}
+	if _, err := app.pingDevice(context.Background(), "emulator-5554"); err == nil {
+		t.Error("expected an error for a device that doesn't answer")
+	}
+}
@@ -3,14 +3,18 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"regexp"
 	"runtime"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -47,8 +51,68 @@ func ValidateDeviceID(deviceId string) error {
 	return nil
 }
 
+// ErrDeviceMismatch 表示 deviceId 当前对应的设备与调用方预期的序列号不一致
+var ErrDeviceMismatch = errors.New("device mismatch")
+
+// ErrDeviceNotFound 表示 adb 当前列出的设备中没有指定的序列号或 ID
+var ErrDeviceNotFound = errors.New("device not found")
+
+// verifyExpectedSerial 在执行破坏性操作前确认 deviceId 仍指向调用方显示的那台设备
+// expectedSerial 为空时跳过校验
+func (a *App) verifyExpectedSerial(deviceId, expectedSerial string) error {
+	expectedSerial = strings.TrimSpace(expectedSerial)
+	if expectedSerial == "" {
+		return nil
+	}
+
+	// Always ask the device: adb reuses transport IDs, so a cached serial may belong
+	// to whichever phone held this ID before
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "getprop", "ro.serialno")
+	if err != nil {
+		return fmt.Errorf("%w: could not read serial of %s: %v", ErrDeviceMismatch, deviceId, err)
+	}
+	serial := strings.TrimSpace(string(out))
+	if serial != "" {
+		a.idToSerialMu.Lock()
+		a.idToSerial[deviceId] = serial
+		a.idToSerialMu.Unlock()
+	} else {
+		// Emulators and some ROMs have no ro.serialno; GetDevices reports the ID as the serial
+		serial = deviceId
+	}
+
+	if serial != expectedSerial {
+		return fmt.Errorf("%w: %s is %s, expected %s", ErrDeviceMismatch, deviceId, serial, expectedSerial)
+	}
+	return nil
+}
+
 // GetDevices returns a list of connected ADB devices
 func (a *App) GetDevices(forceLog bool) ([]Device, error) {
+	return a.scanDevices(forceLog, "")
+}
+
+// GetDevice returns the single device with the given serial or adb ID. Aliases such as
+// a wireless address resolve to their device. Cheaper than GetDevices: only IDs that may
+// belong to the device are asked for their serial, and only its metadata is fetched.
+func (a *App) GetDevice(serial string) (Device, error) {
+	serial = strings.TrimSpace(serial)
+	if serial == "" {
+		return Device{}, fmt.Errorf("serial is required")
+	}
+	devices, err := a.scanDevices(false, serial)
+	if err != nil {
+		return Device{}, err
+	}
+	return devices[0], nil
+}
+
+// scanDevices enumerates and groups adb devices. With only set, the result is narrowed to
+// the device matching that serial or ID, and the full-scan side effects (reconnects,
+// ID cache rebuild, state notifications) are skipped.
+func (a *App) scanDevices(forceLog bool, only string) ([]Device, error) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
@@ -60,8 +124,7 @@ func (a *App) GetDevices(forceLog bool) ([]Device, error) {
 	}
 
 	// 1. Get raw output from adb devices -l
-	cmd := a.newAdbCommand(ctx, "devices", "-l")
-	output, err := cmd.CombinedOutput()
+	output, err := a.runAdbCombined(ctx, "devices", "-l")
 	if err != nil {
 		return nil, fmt.Errorf("failed to run adb devices (path: %s): %w, output: %s", a.adbPath, err, string(output))
 	}
@@ -106,6 +169,10 @@ func (a *App) GetDevices(forceLog bool) ([]Device, error) {
 				id:    parts[0],
 				state: parts[1],
 			}
+			// Linux without udev rules: "SERIAL no permissions (missing udev rules? ...); see [url] usb:1-1"
+			if strings.Contains(line, "no permissions") {
+				node.state = "no_permissions"
+			}
 			// Parse properties
 			for _, p := range parts[2:] {
 				if strings.Contains(p, ":") {
@@ -126,7 +193,7 @@ func (a *App) GetDevices(forceLog bool) ([]Device, error) {
 			nodes = append(nodes, node)
 
 			// OPTIMIZATION: If a wireless device is offline, try to reconnect it
-			if node.isWireless && node.state == "offline" {
+			if only == "" && node.isWireless && node.state == "offline" {
 				a.tryAutoReconnect(node.id)
 			}
 		}
@@ -134,7 +201,7 @@ func (a *App) GetDevices(forceLog bool) ([]Device, error) {
 
 	// 3.5. Proactively reconnect to recently active wireless devices missing from the current list
 	for _, hd := range historyDevices {
-		if hd.WifiAddr != "" && time.Since(time.Unix(hd.LastSeen, 0)) < 15*time.Minute {
+		if only == "" && hd.WifiAddr != "" && time.Since(time.Unix(hd.LastSeen, 0)) < 15*time.Minute {
 			found := false
 			for _, n := range nodes {
 				if n.id == hd.WifiAddr {
@@ -148,117 +215,173 @@ func (a *App) GetDevices(forceLog bool) ([]Device, error) {
 		}
 	}
 
-	// Regex for mDNS serial extraction
-	mdnsRe := regexp.MustCompile(`adb-([a-zA-Z0-9]+)-`)
+	// Regexes for mDNS serial extraction, tried in order
+	mdnsPatterns := a.mdnsSerialRegexps()
 
-	// 4. Phase 1: Resolve "True Serial" for every node
+	// A single-device lookup only resolves nodes that may be the device: IDs never seen
+	// before, the ID asked for, and IDs last seen with the wanted serial. The cache only
+	// narrows the candidates; their serials are re-read because adb reuses IDs (wireless
+	// addresses, emulator ports). If the narrowed pass finds nothing, every node is resolved.
+	var knownSerials map[string]string
+	if only != "" {
+		a.idToSerialMu.RLock()
+		knownSerials = make(map[string]string, len(a.idToSerial))
+		for id, serial := range a.idToSerial {
+			knownSerials[id] = serial
+		}
+		a.idToSerialMu.RUnlock()
+	}
+	wanted := knownSerials[only]
+	isCandidate := func(id string) bool {
+		cached, ok := knownSerials[id]
+		return !ok || id == only || cached == only || (wanted != "" && cached == wanted)
+	}
+
+	allNodes := nodes
+	narrow := only != ""
+	var finalDevices []*Device
 	var wg sync.WaitGroup
-	for _, n := range nodes {
-		wg.Add(1)
-		go func(node *adbNode) {
-			defer wg.Done()
-
-			// A. If already authorised, ask the device
-			if node.state == "device" {
-				sCtx, sCancel := context.WithTimeout(ctx, 3*time.Second)
-				defer sCancel()
-				c := a.newAdbCommand(sCtx, "-s", node.id, "shell", "getprop ro.serialno")
-				out, err := c.Output()
-				if err == nil {
-					s := strings.TrimSpace(string(out))
-					if s != "" {
-						node.serial = s
-						return
-					}
+	for {
+		nodes = allNodes
+		if narrow {
+			nodes = nil
+			for _, n := range allNodes {
+				if isCandidate(n.id) {
+					nodes = append(nodes, n)
 				}
 			}
+		}
 
-			// B. Extract from mDNS ID if possible (format: adb-SERIAL-...)
-			if node.isMDNS {
-				matches := mdnsRe.FindStringSubmatch(node.id)
-				if len(matches) > 1 {
-					node.serial = matches[1]
-					return
+		// 4. Phase 1: Resolve "True Serial" for every node
+		for _, n := range nodes {
+			wg.Add(1)
+			go func(node *adbNode) {
+				defer wg.Done()
+				if node.serial != "" {
+					return // resolved by the narrowed pass
 				}
-			}
 
-			// C. Try History by current ID
-			if h, ok := historyByID[node.id]; ok && h.Serial != "" {
-				node.serial = h.Serial
-				return
-			}
+				// A. If already authorised, ask the device
+				if node.state == "device" {
+					sCtx, sCancel := context.WithTimeout(ctx, 3*time.Second)
+					defer sCancel()
+					out, _, err := a.runAdbWithRetry(sCtx, "-s", node.id, "shell", "getprop ro.serialno")
+					if err == nil {
+						s := strings.TrimSpace(string(out))
+						if s != "" {
+							node.serial = s
+							return
+						}
+					}
+				}
 
-			// D. Fallback: use ID as serial for non-wireless or unknown
-			if !node.isWireless {
-				node.serial = node.id
-			}
-		}(n)
-	}
-	wg.Wait()
+				// B. Extract from mDNS ID if possible (format: adb-SERIAL-...)
+				if node.isMDNS {
+					if s := extractMDNSSerial(node.id, mdnsPatterns); s != "" {
+						node.serial = s
+						return
+					}
+				}
 
-	// 5. Phase 2: Grouping by resolved Serial
-	deviceMap := make(map[string]*Device)
-	var finalDevices []*Device
+				// C. Try History by current ID
+				if h, ok := historyByID[node.id]; ok && h.Serial != "" {
+					node.serial = h.Serial
+					return
+				}
 
-	// Sort nodes to ensure stable primary ID selection (prefer wired)
-	sort.Slice(nodes, func(i, j int) bool {
-		if nodes[i].hasUSB != nodes[j].hasUSB {
-			return nodes[i].hasUSB
-		}
-		if nodes[i].state != nodes[j].state {
-			return nodes[i].state == "device"
+				// D. Fallback: use ID as serial for non-wireless or unknown
+				if !node.isWireless {
+					node.serial = node.id
+				}
+			}(n)
 		}
-		return !nodes[i].isMDNS
-	})
+		wg.Wait()
 
-	for _, n := range nodes {
-		serialKey := n.serial
-		if serialKey == "" {
-			serialKey = n.id
-		}
+		// 5. Phase 2: Grouping by resolved Serial
+		deviceMap := make(map[string]*Device)
+		finalDevices = nil
 
-		d, exists := deviceMap[serialKey]
-		if !exists {
-			d = &Device{
-				ID:     n.id,
-				Serial: serialKey,
-				State:  n.state,
-				IDs:    []string{n.id},
-				Model:  strings.TrimSpace(strings.ReplaceAll(n.model, "_", " ")),
+		// Sort nodes to ensure stable primary ID selection (prefer wired)
+		sort.Slice(nodes, func(i, j int) bool {
+			if nodes[i].hasUSB != nodes[j].hasUSB {
+				return nodes[i].hasUSB
 			}
-			if n.isWireless {
-				d.Type = "wireless"
-				d.WifiAddr = n.id
-			} else {
-				d.Type = "wired"
+			if nodes[i].state != nodes[j].state {
+				return nodes[i].state == "device"
 			}
-			deviceMap[serialKey] = d
-			finalDevices = append(finalDevices, d)
-		} else {
-			d.IDs = append(d.IDs, n.id)
-			if n.state == "device" {
-				if d.State != "device" || n.hasUSB {
-					d.State = "device"
-					d.ID = n.id
-				}
+			return !nodes[i].isMDNS
+		})
+
+		for _, n := range nodes {
+			serialKey := n.serial
+			if serialKey == "" {
+				serialKey = n.id
 			}
-			if n.isWireless {
-				if !strings.Contains(d.WifiAddr, ":") || strings.Contains(n.id, ":") {
-					d.WifiAddr = n.id
+
+			d, exists := deviceMap[serialKey]
+			if !exists {
+				d = &Device{
+					ID:     n.id,
+					Serial: serialKey,
+					State:  n.state,
+					IDs:    []string{n.id},
+					Model:  strings.TrimSpace(strings.ReplaceAll(n.model, "_", " ")),
 				}
-				if d.Type == "wired" {
-					d.Type = "both"
-				} else if d.Type == "" {
+				d.Hint = deviceStateHints[n.state]
+				if n.isWireless {
 					d.Type = "wireless"
-				}
-			} else if n.hasUSB {
-				if d.Type == "wireless" {
-					d.Type = "both"
-				} else if d.Type == "" {
+					d.WifiAddr = n.id
+				} else {
 					d.Type = "wired"
 				}
+				deviceMap[serialKey] = d
+				finalDevices = append(finalDevices, d)
+			} else {
+				d.IDs = append(d.IDs, n.id)
+				if n.state == "device" {
+					if d.State != "device" || n.hasUSB {
+						d.State = "device"
+						d.ID = n.id
+						d.Hint = ""
+					}
+				}
+				if n.isWireless {
+					if !strings.Contains(d.WifiAddr, ":") || strings.Contains(n.id, ":") {
+						d.WifiAddr = n.id
+					}
+					if d.Type == "wired" {
+						d.Type = "both"
+					} else if d.Type == "" {
+						d.Type = "wireless"
+					}
+				} else if n.hasUSB {
+					if d.Type == "wireless" {
+						d.Type = "both"
+					} else if d.Type == "" {
+						d.Type = "wired"
+					}
+				}
 			}
 		}
+
+		if only == "" {
+			break
+		}
+		var match *Device
+		for _, d := range finalDevices {
+			if d.Serial == only || slices.Contains(d.IDs, only) {
+				match = d
+				break
+			}
+		}
+		if match != nil {
+			finalDevices = []*Device{match}
+			break
+		}
+		if !narrow || len(nodes) == len(allNodes) {
+			return nil, fmt.Errorf("%w: %s", ErrDeviceNotFound, only)
+		}
+		narrow = false
 	}
 
 	// 6. Phase 3: Final Polishing (Metadata & History)
@@ -302,8 +425,7 @@ func (a *App) GetDevices(forceLog bool) ([]Device, error) {
 				defer wg.Done()
 				pCtx, pCancel := context.WithTimeout(ctx, 5*time.Second)
 				defer pCancel()
-				cmd := a.newAdbCommand(pCtx, "-s", d.ID, "shell", "getprop ro.product.manufacturer; getprop ro.product.model")
-				out, err := cmd.Output()
+				out, _, err := a.runAdbWithRetry(pCtx, "-s", d.ID, "shell", "getprop ro.product.manufacturer; getprop ro.product.model")
 				if err == nil {
 					parts := strings.Split(string(out), "\n")
 					if len(parts) >= 1 && strings.TrimSpace(parts[0]) != "" {
@@ -336,7 +458,13 @@ func (a *App) GetDevices(forceLog bool) ([]Device, error) {
 	}
 
 	a.idToSerialMu.Lock()
-	a.idToSerial = newIdToSerial
+	if only == "" {
+		a.idToSerial = newIdToSerial
+	} else {
+		for id, serial := range newIdToSerial {
+			a.idToSerial[id] = serial
+		}
+	}
 	a.idToSerialMu.Unlock()
 
 	// 7. Populating Metadata and Sorting
@@ -365,9 +493,12 @@ func (a *App) GetDevices(forceLog bool) ([]Device, error) {
 		return finalDevices[i].LastActive > finalDevices[j].LastActive
 	})
 
-	if forceLog || len(finalDevices) != a.lastDevCount {
-		a.Log("GetDevices returning %d devices (prev: %d)", len(finalDevices), a.lastDevCount)
-		a.lastDevCount = len(finalDevices)
+	if only == "" {
+		if forceLog || len(finalDevices) != a.lastDevCount {
+			a.Log("GetDevices returning %d devices (prev: %d)", len(finalDevices), a.lastDevCount)
+			a.lastDevCount = len(finalDevices)
+		}
+		a.notifyDeviceStateProblems(finalDevices)
 	}
 
 	result := make([]Device, len(finalDevices))
@@ -378,6 +509,50 @@ func (a *App) GetDevices(forceLog bool) ([]Device, error) {
 	return result, nil
 }
 
+// deviceStateHints holds actionable advice for states in which the device can't be used
+var deviceStateHints = map[string]string{
+	"no_permissions": "adb cannot open this USB device. On Linux, add a udev rule for the device's vendor ID (or install android-udev-rules), make sure your user is in the plugdev group, then replug the device.",
+	"unauthorized":   "Unlock the device and accept the \"Allow USB debugging\" prompt for this computer. If no prompt appears, use \"Revoke USB debugging authorizations\" in Developer options and reconnect.",
+}
+
+// deviceStateEvents maps those states to the event emitted when a device enters them
+var deviceStateEvents = map[string]string{
+	"no_permissions": "device-no-permissions",
+	"unauthorized":   "device-unauthorized",
+}
+
+var (
+	problemDevices   = make(map[string]string) // device id -> state
+	problemDevicesMu sync.Mutex
+)
+
+// notifyDeviceStateProblems emits the state's event (e.g. "device-no-permissions")
+// once each time a device enters a problem state, rather than on every poll
+func (a *App) notifyDeviceStateProblems(devices []*Device) {
+	problemDevicesMu.Lock()
+	defer problemDevicesMu.Unlock()
+
+	current := make(map[string]string)
+	for _, d := range devices {
+		event, ok := deviceStateEvents[d.State]
+		if !ok {
+			continue
+		}
+		current[d.ID] = d.State
+		if problemDevices[d.ID] == d.State {
+			continue
+		}
+		LogWarn("device").Str("deviceId", d.ID).Str("state", d.State).Msg("Device is not usable")
+		if !a.mcpMode && a.ctx != nil {
+			wailsRuntime.EventsEmit(a.ctx, event, map[string]string{
+				"deviceId": d.ID,
+				"hint":     d.Hint,
+			})
+		}
+	}
+	problemDevices = current
+}
+
 // GetDeviceInfo returns detailed information about a device
 func (a *App) GetDeviceInfo(deviceId string) (DeviceInfo, error) {
 	var info DeviceInfo
@@ -462,8 +637,7 @@ func (a *App) AdbPair(address string, code string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	cmd := a.newAdbCommand(ctx, "pair", address, code)
-	output, err := cmd.CombinedOutput()
+	output, err := a.runAdbCombined(ctx, "pair", address, code)
 	if err != nil {
 		return string(output), fmt.Errorf("pairing failed: %w, output: %s", err, string(output))
 	}
@@ -492,6 +666,7 @@ func (a *App) AdbConnect(address string) (string, error) {
 			"success": false,
 			"error":   err.Error(),
 		})
+		a.recordConnectResult(address, false)
 		return string(output), fmt.Errorf("connection failed: %w, output: %s", err, string(output))
 	}
 
@@ -500,6 +675,13 @@ func (a *App) AdbConnect(address string) (string, error) {
 		"success": true,
 		"output":  string(output),
 	})
+	// adb exits 0 on "failed to connect", so the outcome is read from the output
+	connected := strings.Contains(string(output), "connected to")
+	if connected {
+		// A manual connect revives a device auto-reconnect had given up on
+		a.resetReconnectState(address)
+	}
+	a.recordConnectResult(address, connected)
 	return string(output), nil
 }
 
@@ -535,6 +717,21 @@ func (a *App) AdbDisconnect(address string) (string, error) {
 	return "disconnected", nil
 }
 
+// getDeviceSdkInt returns the Android API level (ro.build.version.sdk) of the device
+func (a *App) getDeviceSdkInt(deviceId string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "getprop", "ro.build.version.sdk")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read SDK version: %w", err)
+	}
+	sdk, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected SDK version %q", strings.TrimSpace(string(out)))
+	}
+	return sdk, nil
+}
+
 // GetDeviceIP gets the local IP address of the device
 func (a *App) GetDeviceIP(deviceId string) (string, error) {
 	if deviceId == "" {
@@ -558,7 +755,13 @@ func (a *App) GetDeviceIP(deviceId string) (string, error) {
 }
 
 // SwitchToWireless enables TCP/IP mode on the device and connects to it
+// Devices already on wireless debugging are switched over their current connection,
+// so no USB cable is needed.
 func (a *App) SwitchToWireless(deviceId string) (string, error) {
+	if info, err := a.GetWirelessInfo(deviceId); err == nil && info.Wireless {
+		return a.ChangeWirelessPort(deviceId, 5555)
+	}
+
 	ip, err := a.GetDeviceIP(deviceId)
 	if err != nil {
 		return "", err
@@ -583,10 +786,12 @@ func (a *App) RestartAdbServer() (string, error) {
 	a.Log("Restarting ADB server, cleaning up all ADB-dependent processes...")
 
 	// Stop all ADB-dependent long-running processes
-	a.StopLogcat()
+	a.StopAllLogcat()
 	a.stopAllTouchRecordings()
 	a.StopAllDeviceStateMonitors()
 	a.StopAllNetworkMonitors()
+	a.StopAllBatteryMonitors()
+	a.StopAllConnectionMonitors()
 
 	// Kill scrcpy mirroring processes
 	a.scrcpyMu.Lock()
@@ -603,6 +808,7 @@ func (a *App) RestartAdbServer() (string, error) {
 		}
 		delete(a.scrcpyRecordCmd, id)
 	}
+	a.syncSleepInhibitLocked()
 	a.scrcpyMu.Unlock()
 
 	if runtime.GOOS == "windows" {
@@ -640,6 +846,9 @@ func (a *App) RunAdbCommandWithContext(ctx context.Context, deviceId string, ful
 	if fullCmd == "" {
 		return "", nil
 	}
+	if err := a.checkSafeMode(deviceId, fullCmd); err != nil {
+		return "", err
+	}
 
 	var args []string
 	args = append(args, "-s", deviceId)
@@ -651,8 +860,7 @@ func (a *App) RunAdbCommandWithContext(ctx context.Context, deviceId string, ful
 		args = append(args, strings.Fields(fullCmd)...)
 	}
 
-	cmd := a.newAdbCommand(ctx, args...)
-	output, err := cmd.CombinedOutput()
+	output, err := a.runAdbCombined(ctx, args...)
 	res := string(output)
 	if err != nil {
 		return res, fmt.Errorf("command failed: %w, output: %s", err, res)
@@ -808,30 +1016,6 @@ func (a *App) StartWirelessServer() (string, error) {
 	return a.localAddr, nil
 }
 
-// tryAutoReconnect attempts to reconnect to a wireless device if it's offline
-func (a *App) tryAutoReconnect(address string) {
-	if address == "" || (!strings.Contains(address, ":") && !strings.Contains(address, "._tcp")) {
-		return
-	}
-
-	a.reconnectMu.Lock()
-	last, ok := a.reconnectCooldown[address]
-	if ok && time.Since(last) < 30*time.Second {
-		a.reconnectMu.Unlock()
-		return
-	}
-	a.reconnectCooldown[address] = time.Now()
-	a.reconnectMu.Unlock()
-
-	go func() {
-		a.Log("Auto-reconnecting to wireless device: %s", address)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		cmd := a.newAdbCommand(ctx, "connect", address)
-		_ = cmd.Run()
-	}()
-}
-
 // History management functions
 
 func (a *App) loadHistoryInternal() []HistoryDevice {
@@ -955,6 +1139,29 @@ func (a *App) TogglePinDevice(serial string) {
 	go a.saveSettings()
 }
 
+// pinnedDevice returns the pinned device if it is currently connected and usable
+func (a *App) pinnedDevice() (Device, error) {
+	if a.cacheService == nil {
+		return Device{}, fmt.Errorf("no device is pinned")
+	}
+	serial := a.cacheService.GetPinnedSerial()
+	if serial == "" {
+		return Device{}, fmt.Errorf("no device is pinned")
+	}
+
+	d, err := a.GetDevice(serial)
+	if errors.Is(err, ErrDeviceNotFound) {
+		return Device{}, fmt.Errorf("pinned device %s is not connected", serial)
+	}
+	if err != nil {
+		return Device{}, err
+	}
+	if d.State != "device" {
+		return Device{}, fmt.Errorf("pinned device %s is %s", serial, d.State)
+	}
+	return d, nil
+}
+
 // StartDeviceMonitor starts monitoring device connections using adb track-devices
 // It emits "devices-changed" events when devices connect/disconnect
 func (a *App) StartDeviceMonitor() {
@@ -1051,15 +1258,16 @@ func (a *App) runDeviceMonitor(ctx context.Context) {
 			var length int
 			fmt.Sscanf(string(buf), "%04x", &length)
 
+			data := make([]byte, length)
 			if length > 0 {
 				// Read device data
-				data := make([]byte, length)
-				_, err := stdout.Read(data)
-				if err != nil {
+				if _, err := io.ReadFull(stdout, data); err != nil {
 					break
 				}
 			}
 
+			a.syncAutoSessions(parseTrackDevices(string(data)))
+
 			// Emit event (debounced)
 			emitDevicesChanged()
 		}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// Per-device busy registry
+// ========================================
+//
+// Operations that contend for the same device resources acquire the device
+// under an operation name before they start. Only pairs listed in
+// deviceOpConflicts are mutually exclusive; anything else is merely recorded
+// so the UI can show what is running.
+
+// ErrDeviceBusy is returned (wrapped) when a conflicting operation is already running
+var ErrDeviceBusy = errors.New("device is busy")
+
+// Operation names used with AcquireDevice
+const (
+	DeviceOpUIDump         = "ui_dump"
+	DeviceOpTouchRecording = "touch_recording"
+)
+
+// deviceOpConflicts lists, per operation, the operations it cannot run alongside
+var deviceOpConflicts = map[string][]string{
+	DeviceOpUIDump:         {DeviceOpTouchRecording},
+	DeviceOpTouchRecording: {DeviceOpUIDump},
+}
+
+var (
+	deviceBusyOps = make(map[string]map[string]int) // deviceID -> operation -> holders
+	deviceBusyMu  sync.Mutex
+)
+
+// AcquireDevice marks an operation as running on a device.
+// It fails with ErrDeviceBusy if a mutually-exclusive operation is already running.
+// Every successful call must be paired with ReleaseDevice.
+func (a *App) AcquireDevice(deviceId, operation string) error {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return fmt.Errorf("invalid device ID: %w", err)
+	}
+	if operation == "" {
+		return fmt.Errorf("operation is required")
+	}
+
+	if err := acquireDeviceOp(deviceId, operation); err != nil {
+		return err
+	}
+	a.emitDeviceBusyState(deviceId)
+	return nil
+}
+
+// ReleaseDevice ends an operation started with AcquireDevice
+func (a *App) ReleaseDevice(deviceId, operation string) {
+	if releaseDeviceOp(deviceId, operation) {
+		a.emitDeviceBusyState(deviceId)
+	}
+}
+
+// GetDeviceBusyState returns the operations currently running on a device, sorted by name
+func (a *App) GetDeviceBusyState(deviceId string) []string {
+	deviceBusyMu.Lock()
+	defer deviceBusyMu.Unlock()
+	return busyOpsLocked(deviceId)
+}
+
+func acquireDeviceOp(deviceID, operation string) error {
+	deviceBusyMu.Lock()
+	defer deviceBusyMu.Unlock()
+
+	ops := deviceBusyOps[deviceID]
+	for _, other := range deviceOpConflicts[operation] {
+		if ops[other] > 0 {
+			return fmt.Errorf("%w: %s is in progress", ErrDeviceBusy, other)
+		}
+	}
+
+	if ops == nil {
+		ops = make(map[string]int)
+		deviceBusyOps[deviceID] = ops
+	}
+	ops[operation]++
+	return nil
+}
+
+// releaseDeviceOp returns false if the operation wasn't held
+func releaseDeviceOp(deviceID, operation string) bool {
+	deviceBusyMu.Lock()
+	defer deviceBusyMu.Unlock()
+
+	ops := deviceBusyOps[deviceID]
+	if ops[operation] == 0 {
+		return false
+	}
+	ops[operation]--
+	if ops[operation] == 0 {
+		delete(ops, operation)
+	}
+	if len(ops) == 0 {
+		delete(deviceBusyOps, deviceID)
+	}
+	return true
+}
+
+func busyOpsLocked(deviceID string) []string {
+	ops := make([]string, 0, len(deviceBusyOps[deviceID]))
+	for op := range deviceBusyOps[deviceID] {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+func (a *App) emitDeviceBusyState(deviceID string) {
+	if a.mcpMode || a.ctx == nil {
+		return
+	}
+	wailsRuntime.EventsEmit(a.ctx, "device-busy-changed", map[string]interface{}{
+		"deviceId":   deviceID,
+		"operations": a.GetDeviceBusyState(deviceID),
+	})
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDeviceBusyRegistry(t *testing.T) {
+	app := &App{mcpMode: true}
+	const dev = "busy-test-device"
+
+	if err := app.AcquireDevice(dev, DeviceOpUIDump); err != nil {
+		t.Fatalf("acquire ui_dump: %v", err)
+	}
+	// Concurrent dumps don't conflict with each other
+	if err := app.AcquireDevice(dev, DeviceOpUIDump); err != nil {
+		t.Fatalf("second ui_dump: %v", err)
+	}
+
+	err := app.AcquireDevice(dev, DeviceOpTouchRecording)
+	if !errors.Is(err, ErrDeviceBusy) {
+		t.Fatalf("expected ErrDeviceBusy, got %v", err)
+	}
+	if want := "device is busy: ui_dump is in progress"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err.Error(), want)
+	}
+
+	// Unrelated operations are only recorded
+	if err := app.AcquireDevice(dev, "install"); err != nil {
+		t.Fatalf("acquire install: %v", err)
+	}
+	if got, want := app.GetDeviceBusyState(dev), []string{"install", "ui_dump"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("busy state = %v, want %v", got, want)
+	}
+
+	app.ReleaseDevice(dev, DeviceOpUIDump)
+	if err := app.AcquireDevice(dev, DeviceOpTouchRecording); err == nil {
+		t.Fatal("touch recording acquired while one ui_dump still held")
+	}
+	app.ReleaseDevice(dev, DeviceOpUIDump)
+	app.ReleaseDevice(dev, DeviceOpUIDump) // extra release is a no-op
+
+	if err := app.AcquireDevice(dev, DeviceOpTouchRecording); err != nil {
+		t.Fatalf("acquire touch_recording after release: %v", err)
+	}
+	app.ReleaseDevice(dev, DeviceOpTouchRecording)
+	app.ReleaseDevice(dev, "install")
+
+	if got := app.GetDeviceBusyState(dev); len(got) != 0 {
+		t.Errorf("expected idle device, got %v", got)
+	}
+}
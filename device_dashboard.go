@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ========================================
+// Device dashboard
+// ========================================
+
+// dashboardFetchTimeout bounds each part of the dashboard on its own, so one slow
+// dumpsys doesn't hold back the rest
+const dashboardFetchTimeout = 3 * time.Second
+
+// StorageUsage is the space on the device's /data partition
+type StorageUsage struct {
+	TotalBytes  int64   `json:"totalBytes"`
+	FreeBytes   int64   `json:"freeBytes"`
+	FreePercent float64 `json:"freePercent"`
+}
+
+// DeviceDashboard is a compact snapshot of a device for the header panel. Parts that
+// failed or timed out are left empty and listed in Errors by name.
+type DeviceDashboard struct {
+	DeviceID      string            `json:"deviceId"`
+	Model         string            `json:"model"`
+	Brand         string            `json:"brand"`
+	AndroidVer    string            `json:"androidVer"`
+	SDK           string            `json:"sdk"`
+	Battery       *BatteryState     `json:"battery,omitempty"`
+	ForegroundApp string            `json:"foregroundApp"`
+	Storage       *StorageUsage     `json:"storage,omitempty"`
+	Screen        *ScreenState      `json:"screen,omitempty"`
+	Errors        map[string]string `json:"errors,omitempty"`
+	CollectedAt   int64             `json:"collectedAt"` // Unix milliseconds
+}
+
+// GetDeviceDashboard gathers basic info, battery, foreground app, storage and screen
+// state concurrently in one call
+func (a *App) GetDeviceDashboard(deviceId string) (DeviceDashboard, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return DeviceDashboard{}, err
+	}
+
+	d := DeviceDashboard{DeviceID: deviceId}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	fetch := func(name string, fn func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), dashboardFetchTimeout)
+			defer cancel()
+			if err := fn(ctx); err != nil {
+				mu.Lock()
+				if d.Errors == nil {
+					d.Errors = make(map[string]string)
+				}
+				d.Errors[name] = err.Error()
+				mu.Unlock()
+			}
+		}()
+	}
+
+	fetch("info", func(ctx context.Context) error {
+		out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "getprop ro.product.model; getprop ro.product.brand; getprop ro.build.version.release; getprop ro.build.version.sdk")
+		if err != nil {
+			return err
+		}
+		props := strings.Split(strings.ReplaceAll(string(out), "\r", ""), "\n")
+		for len(props) < 4 {
+			props = append(props, "")
+		}
+		mu.Lock()
+		d.Model, d.Brand, d.AndroidVer, d.SDK = props[0], props[1], props[2], props[3]
+		mu.Unlock()
+		return nil
+	})
+	fetch("battery", func(ctx context.Context) error {
+		out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "dumpsys", "battery")
+		if err != nil {
+			return err
+		}
+		battery := parseBatteryDump(string(out))
+		mu.Lock()
+		d.Battery = battery
+		mu.Unlock()
+		return nil
+	})
+	fetch("foregroundApp", func(ctx context.Context) error {
+		pkg := a.getForegroundPackage(ctx, deviceId)
+		if pkg == "" {
+			return fmt.Errorf("could not determine the foreground app")
+		}
+		mu.Lock()
+		d.ForegroundApp = pkg
+		mu.Unlock()
+		return nil
+	})
+	fetch("storage", func(ctx context.Context) error {
+		out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "df", "-k", "/data")
+		if err != nil {
+			return err
+		}
+		usage, err := parseDfUsage(string(out))
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		d.Storage = usage
+		mu.Unlock()
+		return nil
+	})
+	fetch("screen", func(ctx context.Context) error {
+		state, err := a.getScreenState(ctx, deviceId)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		d.Screen = &state
+		mu.Unlock()
+		return nil
+	})
+
+	wg.Wait()
+	d.CollectedAt = time.Now().UnixMilli()
+	if len(d.Errors) == 5 {
+		return d, fmt.Errorf("device %s did not respond", deviceId)
+	}
+	return d, nil
+}
+
+// parseDfUsage reads the last line of `df -k <path>`:
+//
+//	Filesystem       1K-blocks     Used Available Use% Mounted on
+//	/dev/block/dm-40 115574536 45200128  70243336  40% /data
+func parseDfUsage(output string) (*StorageUsage, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 6 || fields[0] == "Filesystem" {
+		return nil, fmt.Errorf("unexpected df output: %q", strings.TrimSpace(output))
+	}
+	total, err1 := strconv.ParseInt(fields[1], 10, 64)
+	avail, err2 := strconv.ParseInt(fields[3], 10, 64)
+	if err1 != nil || err2 != nil || total <= 0 {
+		return nil, fmt.Errorf("unexpected df output: %q", strings.TrimSpace(output))
+	}
+	return &StorageUsage{
+		TotalBytes:  total * 1024,
+		FreeBytes:   avail * 1024,
+		FreePercent: float64(avail) * 100 / float64(total),
+	}, nil
+}
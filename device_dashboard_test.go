@@ -0,0 +1,48 @@
+package main
+
+import "testing"
+
+func TestParseDfUsage(t *testing.T) {
+	out := "Filesystem       1K-blocks     Used Available Use% Mounted on\n/dev/block/dm-40 100000000 75000000  25000000  75% /data\n"
+	usage, err := parseDfUsage(out)
+	if err != nil {
+		t.Fatalf("parseDfUsage: %v", err)
+	}
+	if usage.TotalBytes != 100000000*1024 || usage.FreeBytes != 25000000*1024 {
+		t.Errorf("usage = %+v", usage)
+	}
+	if usage.FreePercent != 25 {
+		t.Errorf("free percent = %v, want 25", usage.FreePercent)
+	}
+
+	for _, bad := range []string{"", "df: /data: Permission denied", "Filesystem 1K-blocks Used Available Use% Mounted on"} {
+		if _, err := parseDfUsage(bad); err == nil {
+			t.Errorf("parseDfUsage(%q) should fail", bad)
+		}
+	}
+}
+
+func TestGetDeviceDashboardPartialFailure(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell getprop ro.product.model; getprop ro.product.brand; getprop ro.build.version.release; getprop ro.build.version.sdk": "SM-G991B\nsamsung\n14\n34\n",
+		"-s R5CT1234ABC shell dumpsys battery": "Current Battery Service state:\n  AC powered: false\n  USB powered: true\n  status: 2\n  level: 87\n",
+		"-s R5CT1234ABC shell df -k /data":     "Filesystem 1K-blocks Used Available Use% Mounted on\n/dev/block/dm-40 1000 600 400 60% /data\n",
+	})
+
+	d, err := app.GetDeviceDashboard("R5CT1234ABC")
+	if err != nil {
+		t.Fatalf("GetDeviceDashboard: %v", err)
+	}
+	if d.Model != "SM-G991B" || d.SDK != "34" {
+		t.Errorf("info = %q %q", d.Model, d.SDK)
+	}
+	if d.Battery == nil || d.Battery.Level != 87 || d.Battery.Status != "charging" {
+		t.Errorf("battery = %+v", d.Battery)
+	}
+	if d.Storage == nil || d.Storage.FreePercent != 40 {
+		t.Errorf("storage = %+v", d.Storage)
+	}
+	if _, ok := d.Errors["foregroundApp"]; !ok {
+		t.Errorf("foreground app failure should be reported, errors = %v", d.Errors)
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os/exec"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -46,19 +48,19 @@ type DeviceMonitor struct {
 	logcatCmd    *exec.Cmd
 	logcatCancel context.CancelFunc
 
-	// Touch 事件监听
-	touchCmd    *exec.Cmd
-	touchCancel context.CancelFunc
+	// Touch 事件监听 (共享 getevent 流的取消订阅函数)
+	touchCancel func()
 }
 
 // BatteryState 电池状态
 type BatteryState struct {
-	Level       int    `json:"level"`
-	Status      string `json:"status"` // charging, discharging, full, not_charging
-	Temperature int    `json:"temperature"`
-	Voltage     int    `json:"voltage"`
-	Health      string `json:"health"`
-	Plugged     string `json:"plugged"` // ac, usb, wireless, none
+	Level       int    `json:"level"`       // Percent, -1 when unknown
+	Status      string `json:"status"`      // charging, discharging, full, not_charging, unknown
+	Charging    bool   `json:"charging"`    // Charging or full while plugged in
+	Temperature int    `json:"temperature"` // Tenths of a degree Celsius, as dumpsys reports it
+	Voltage     int    `json:"voltage"`     // Millivolts
+	Health      string `json:"health"`      // good, overheat, dead, over_voltage, failure, cold, unknown
+	Plugged     string `json:"plugged"`     // ac, usb, wireless, dock, none
 }
 
 // NetworkState 网络状态
@@ -77,6 +79,7 @@ type ScreenState struct {
 	Brightness  int    `json:"brightness"`
 	Orientation string `json:"orientation"` // portrait, landscape
 	Locked      bool   `json:"locked"`
+	Interactive bool   `json:"interactive"` // Awake, as opposed to dozing/dreaming with the display on
 }
 
 // ========================================
@@ -105,7 +108,7 @@ func (m *DeviceMonitor) Start() {
 	go m.watchAppEvents()
 
 	// 启动触摸事件监听
-	go m.watchTouchEvents()
+	m.watchTouchEvents()
 }
 
 // Stop 停止监控
@@ -120,9 +123,6 @@ func (m *DeviceMonitor) Stop() {
 	if m.touchCancel != nil {
 		m.touchCancel()
 	}
-	if m.touchCmd != nil && m.touchCmd.Process != nil {
-		_ = m.touchCmd.Process.Kill()
-	}
 }
 
 // ========================================
@@ -165,7 +165,7 @@ func (m *DeviceMonitor) checkBattery() {
 	}
 
 	state := parseBatteryDump(string(output))
-	if state == nil {
+	if state.Level < 0 {
 		return
 	}
 
@@ -384,44 +384,15 @@ func (m *DeviceMonitor) processAppLogLine(line string) {
 // Touch Events via getevent
 // ========================================
 
-// watchTouchEvents 监听触摸事件
+// watchTouchEvents 监听触摸事件 (订阅设备共享的 getevent 流)
 func (m *DeviceMonitor) watchTouchEvents() {
 	LogDebug("device_monitor").Msgf("[DeviceMonitor] watchTouchEvents starting for device: %s", m.deviceID)
-	ctx, cancel := context.WithCancel(m.ctx)
-	m.touchCancel = cancel
-
-	// 使用 getevent 监听触摸事件
-	// -l: 使用标签而不是数字
-	// -t: 显示时间戳
-	cmd := m.app.newAdbCommand(ctx, "-s", m.deviceID, "shell", "getevent", "-lt")
-	m.touchCmd = cmd
-
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		LogDebug("device_monitor").Msgf("[DeviceMonitor] watchTouchEvents stdout pipe error: %v", err)
-		return
-	}
 
-	if err := cmd.Start(); err != nil {
-		LogDebug("device_monitor").Msgf("[DeviceMonitor] watchTouchEvents start error: %v", err)
-		return
-	}
-
-	LogDebug("device_monitor").Msgf("[DeviceMonitor] watchTouchEvents getevent started successfully")
-
-	reader := bufio.NewReader(stdout)
 	var currentTouch *touchState
 	lineCount := 0
 
-	for {
-		line, err := reader.ReadString('\n')
-		if err != nil {
-			LogDebug("device_monitor").Msgf("[DeviceMonitor] watchTouchEvents read error: %v", err)
-			break
-		}
-
+	onLine := func(line string) {
 		lineCount++
-		line = strings.TrimSpace(line)
 
 		// 解析触摸事件
 		// 格式: [timestamp] /dev/input/eventX: EV_ABS ABS_MT_POSITION_X value
@@ -458,8 +429,125 @@ func (m *DeviceMonitor) watchTouchEvents() {
 			}
 		}
 	}
+	onEnd := func() {
+		LogDebug("device_monitor").Msgf("[DeviceMonitor] watchTouchEvents ended, total lines: %d", lineCount)
+	}
+
+	m.touchCancel = m.app.subscribeTouchStream(m.deviceID, onLine, onEnd)
+}
+
+// ========================================
+// Shared getevent stream
+// ========================================
+//
+// One `getevent -lt` per device, fanned out to every subscriber (device monitors and
+// the passive input monitor), so the device never runs duplicate streams.
+
+type touchStreamSubscriber struct {
+	onLine func(line string)
+	onEnd  func()
+}
+
+type touchStream struct {
+	cancel context.CancelFunc
+	subs   map[int]touchStreamSubscriber
+	nextID int
+}
+
+var (
+	touchStreams   = make(map[string]*touchStream)
+	touchStreamsMu sync.Mutex
+)
+
+// subscribeTouchStream delivers every getevent line of the device to onLine, starting
+// the stream for the first subscriber. onEnd runs if the stream dies (e.g. the device
+// disconnects). The returned func unsubscribes and stops the stream after the last one.
+func (a *App) subscribeTouchStream(deviceID string, onLine func(line string), onEnd func()) func() {
+	touchStreamsMu.Lock()
+	defer touchStreamsMu.Unlock()
+
+	s, ok := touchStreams[deviceID]
+	if !ok {
+		ctx, cancel := context.WithCancel(a.ctx)
+		s = &touchStream{cancel: cancel, subs: make(map[int]touchStreamSubscriber)}
+		touchStreams[deviceID] = s
+		go a.runTouchStream(ctx, deviceID, s)
+	}
+	id := s.nextID
+	s.nextID++
+	s.subs[id] = touchStreamSubscriber{onLine: onLine, onEnd: onEnd}
+
+	return func() {
+		touchStreamsMu.Lock()
+		defer touchStreamsMu.Unlock()
+		delete(s.subs, id)
+		if len(s.subs) == 0 && touchStreams[deviceID] == s {
+			delete(touchStreams, deviceID)
+			s.cancel()
+		}
+	}
+}
+
+// runTouchStream runs getevent for the device until the stream is cancelled or dies
+func (a *App) runTouchStream(ctx context.Context, deviceID string, s *touchStream) {
+	// 使用 getevent 监听所有输入设备 (触摸屏和硬件按键)
+	// -l: 使用标签而不是数字
+	// -t: 显示时间戳
+	cmd := a.newAdbCommand(ctx, "-s", deviceID, "shell", "getevent", "-lt")
+	stdout, err := cmd.StdoutPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		LogDebug("device_monitor").Msgf("[DeviceMonitor] getevent start error for %s: %v", deviceID, err)
+	} else {
+		s.pump(stdout)
+		_ = cmd.Wait()
+	}
 
-	LogDebug("device_monitor").Msgf("[DeviceMonitor] watchTouchEvents ended, total lines: %d", lineCount)
+	touchStreamsMu.Lock()
+	if touchStreams[deviceID] == s {
+		delete(touchStreams, deviceID)
+	}
+	touchStreamsMu.Unlock()
+	s.cancel()
+	s.end()
+}
+
+// pump forwards each line read from r to the current subscribers
+func (s *touchStream) pump(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		for _, sub := range s.snapshot() {
+			sub.onLine(line)
+		}
+	}
+}
+
+// end notifies the remaining subscribers that no more lines will arrive
+func (s *touchStream) end() {
+	for _, sub := range s.snapshot() {
+		if sub.onEnd != nil {
+			sub.onEnd()
+		}
+	}
+}
+
+// snapshot copies the subscribers so callbacks run without holding touchStreamsMu
+func (s *touchStream) snapshot() []touchStreamSubscriber {
+	touchStreamsMu.Lock()
+	defer touchStreamsMu.Unlock()
+	ids := make([]int, 0, len(s.subs))
+	for id := range s.subs {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	subs := make([]touchStreamSubscriber, 0, len(ids))
+	for _, id := range ids {
+		subs = append(subs, s.subs[id])
+	}
+	return subs
 }
 
 // touchState 触摸状态
@@ -490,8 +578,8 @@ func (m *DeviceMonitor) emitTouchEvent(x, y int, action string, duration int64)
 		return
 	}
 
-	// Skip if touch recording is active for this device to avoid duplicate events
-	if IsTouchRecordingActive(m.deviceID) {
+	// Skip if touch recording or the input monitor is active for this device to avoid duplicate events
+	if IsTouchRecordingActive(m.deviceID) || IsInputMonitorActive(m.deviceID) {
 		return
 	}
 
@@ -732,50 +820,58 @@ func (m *DeviceMonitor) emitProcessDied(pkg, pid string) {
 // Parsers
 // ========================================
 
+// batteryFieldRegex matches "key: value" lines; OEM builds add fields and vary case
+var batteryFieldRegex = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z ]*?)\s*[:=]\s*(.*?)\s*$`)
+
+var batteryStatusNames = map[int]string{2: "charging", 3: "discharging", 4: "not_charging", 5: "full"}
+
+var batteryHealthNames = map[int]string{2: "good", 3: "overheat", 4: "dead", 5: "over_voltage", 6: "failure", 7: "cold"}
+
+// parseBatteryDump 解析 `dumpsys battery`。缺失或未知的字段保留为 Level -1、
+// Status/Health "unknown"，温度和电压为 0
 func parseBatteryDump(output string) *BatteryState {
-	state := &BatteryState{}
-	lines := strings.Split(output, "\n")
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "level:") {
-			fmt.Sscanf(line, "level: %d", &state.Level)
-		} else if strings.HasPrefix(line, "status:") {
-			var status int
-			fmt.Sscanf(line, "status: %d", &status)
-			switch status {
-			case 2:
-				state.Status = "charging"
-			case 3:
-				state.Status = "discharging"
-			case 4:
-				state.Status = "not_charging"
-			case 5:
-				state.Status = "full"
-			default:
-				state.Status = "unknown"
+	state := &BatteryState{Level: -1, Status: "unknown", Health: "unknown", Plugged: "none"}
+	scale := 100
+	for _, line := range strings.Split(output, "\n") {
+		m := batteryFieldRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		key, value := strings.ToLower(m[1]), m[2]
+		n, numErr := strconv.Atoi(value)
+		switch key {
+		case "level":
+			if numErr == nil {
+				state.Level = n
+			}
+		case "scale":
+			if numErr == nil && n > 0 {
+				scale = n
 			}
-		} else if strings.HasPrefix(line, "temperature:") {
-			fmt.Sscanf(line, "temperature: %d", &state.Temperature)
-		} else if strings.HasPrefix(line, "voltage:") {
-			fmt.Sscanf(line, "voltage: %d", &state.Voltage)
-		} else if strings.HasPrefix(line, "AC powered:") && strings.Contains(line, "true") {
-			state.Plugged = "ac"
-		} else if strings.HasPrefix(line, "USB powered:") && strings.Contains(line, "true") {
-			if state.Plugged == "" {
-				state.Plugged = "usb"
+		case "status":
+			if name, ok := batteryStatusNames[n]; ok && numErr == nil {
+				state.Status = name
 			}
-		} else if strings.HasPrefix(line, "Wireless powered:") && strings.Contains(line, "true") {
-			if state.Plugged == "" {
-				state.Plugged = "wireless"
+		case "health":
+			if name, ok := batteryHealthNames[n]; ok && numErr == nil {
+				state.Health = name
+			}
+		case "temperature":
+			if numErr == nil {
+				state.Temperature = n
+			}
+		case "voltage":
+			state.Voltage = normalizeMillivolts(value)
+		case "ac powered", "usb powered", "wireless powered", "dock powered":
+			if value == "true" && state.Plugged == "none" {
+				state.Plugged = strings.TrimSuffix(key, " powered")
 			}
 		}
 	}
-
-	if state.Plugged == "" {
-		state.Plugged = "none"
+	if state.Level >= 0 && scale != 100 {
+		state.Level = state.Level * 100 / scale
 	}
-
+	state.Charging = state.Status == "charging" || (state.Status == "full" && state.Plugged != "none")
 	return state
 }
 
@@ -868,6 +964,7 @@ func (a *App) StartDeviceStateMonitor(deviceID string) {
 	monitor := NewDeviceMonitor(a, deviceID)
 	deviceStateMonitors[deviceID] = monitor
 	monitor.Start()
+	a.registerMonitor("device_state", deviceID, func() { a.StopDeviceStateMonitor(deviceID) })
 }
 
 // StopDeviceStateMonitor 停止设备状态监控
@@ -879,6 +976,7 @@ func (a *App) StopDeviceStateMonitor(deviceID string) {
 		m.Stop()
 		delete(deviceStateMonitors, deviceID)
 	}
+	unregisterMonitor("device_state", deviceID)
 }
 
 // StopAllDeviceStateMonitors 停止所有设备状态监控
@@ -890,4 +988,5 @@ func (a *App) StopAllDeviceStateMonitors() {
 		m.Stop()
 	}
 	deviceStateMonitors = make(map[string]*DeviceMonitor)
+	unregisterMonitorKind("device_state")
 }
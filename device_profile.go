@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// DeviceProfile is a named set of display/system settings applied to devices in one
+// step. Nil (or empty) fields are left unchanged on the device.
+type DeviceProfile struct {
+	Name            string   `json:"name"`
+	FontScale       *float64 `json:"fontScale,omitempty"`       // 1.0 = default
+	Density         *int     `json:"density,omitempty"`         // dpi; 0 resets to the physical density
+	ScreenTimeoutMs *int     `json:"screenTimeoutMs,omitempty"` // screen_off_timeout
+	StayAwake       *bool    `json:"stayAwake,omitempty"`       // stay awake while charging
+	Brightness      *int     `json:"brightness,omitempty"`      // 0-255; turns adaptive brightness off
+	DemoMode        *bool    `json:"demoMode,omitempty"`        // SystemUI demo mode (clean status bar)
+	Locale          string   `json:"locale,omitempty"`          // BCP-47 tag, e.g. "en-US"; applied on the next reboot
+}
+
+// ProfileSettingResult is the outcome of applying one setting on one device
+type ProfileSettingResult struct {
+	Setting        string `json:"setting"`
+	Success        bool   `json:"success"`
+	RebootRequired bool   `json:"rebootRequired,omitempty"` // written, but only takes effect after a reboot
+	Unsupported    bool   `json:"unsupported,omitempty"`    // the device doesn't support this setting
+	Output         string `json:"output,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// DeviceProfileResult collects the per-setting results for one device
+type DeviceProfileResult struct {
+	DeviceID       string                 `json:"deviceId"`
+	Success        bool                   `json:"success"`                  // every setting applied
+	RebootRequired bool                   `json:"rebootRequired,omitempty"` // some applied setting needs a reboot
+	Settings       []ProfileSettingResult `json:"settings"`
+}
+
+// profileStep is one setting and the shell commands that apply it. The commands run
+// one at a time so a failure isn't masked by a later command succeeding.
+type profileStep struct {
+	setting     string
+	commands    []string
+	needsReboot bool
+}
+
+var localeTagRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// profileUnsupportedMarkers are shell outputs meaning the device lacks the command or setting
+var profileUnsupportedMarkers = []string{
+	"Unknown command",
+	": not found",
+	"Unknown option",
+}
+
+// profileCommands turns a profile into the shell commands for each setting it sets
+func profileCommands(p DeviceProfile) ([]profileStep, error) {
+	var steps []profileStep
+
+	if p.FontScale != nil {
+		if *p.FontScale <= 0 || *p.FontScale > 3 {
+			return nil, fmt.Errorf("font scale must be between 0 and 3, got %v", *p.FontScale)
+		}
+		steps = append(steps, profileStep{setting: "fontScale", commands: []string{"settings put system font_scale " + strconv.FormatFloat(*p.FontScale, 'f', -1, 64)}})
+	}
+	if p.Density != nil {
+		if *p.Density < 0 {
+			return nil, fmt.Errorf("density must not be negative, got %d", *p.Density)
+		}
+		cmd := "wm density reset"
+		if *p.Density > 0 {
+			cmd = "wm density " + strconv.Itoa(*p.Density)
+		}
+		steps = append(steps, profileStep{setting: "density", commands: []string{cmd}})
+	}
+	if p.ScreenTimeoutMs != nil {
+		if *p.ScreenTimeoutMs <= 0 {
+			return nil, fmt.Errorf("screen timeout must be positive, got %d", *p.ScreenTimeoutMs)
+		}
+		steps = append(steps, profileStep{setting: "screenTimeout", commands: []string{"settings put system screen_off_timeout " + strconv.Itoa(*p.ScreenTimeoutMs)}})
+	}
+	if p.StayAwake != nil {
+		steps = append(steps, profileStep{setting: "stayAwake", commands: []string{"svc power stayon " + strconv.FormatBool(*p.StayAwake)}})
+	}
+	if p.Brightness != nil {
+		if *p.Brightness < 0 || *p.Brightness > 255 {
+			return nil, fmt.Errorf("brightness must be between 0 and 255, got %d", *p.Brightness)
+		}
+		steps = append(steps, profileStep{setting: "brightness", commands: []string{
+			"settings put system screen_brightness_mode 0",
+			"settings put system screen_brightness " + strconv.Itoa(*p.Brightness),
+		}})
+	}
+	if p.DemoMode != nil {
+		cmds := []string{"am broadcast -a com.android.systemui.demo -e command exit"}
+		if *p.DemoMode {
+			cmds = []string{
+				"settings put global sysui_demo_allowed 1",
+				"am broadcast -a com.android.systemui.demo -e command enter",
+				"am broadcast -a com.android.systemui.demo -e command clock -e hhmm 1200",
+				"am broadcast -a com.android.systemui.demo -e command battery -e level 100 -e plugged false",
+				"am broadcast -a com.android.systemui.demo -e command network -e wifi show -e level 4",
+				"am broadcast -a com.android.systemui.demo -e command notifications -e visible false",
+			}
+		}
+		steps = append(steps, profileStep{setting: "demoMode", commands: cmds})
+	}
+	if p.Locale != "" {
+		if !localeTagRegex.MatchString(p.Locale) {
+			return nil, fmt.Errorf("invalid locale: %s", p.Locale)
+		}
+		// Without root the system only reads system_locales at boot
+		steps = append(steps, profileStep{setting: "locale", commands: []string{"settings put system system_locales " + p.Locale}, needsReboot: true})
+	}
+
+	return steps, nil
+}
+
+// ApplyDeviceProfile applies the profile to every device concurrently and returns
+// per-device, per-setting results. Settings on one device are applied in order.
+func (a *App) ApplyDeviceProfile(deviceIds []string, profile DeviceProfile) ([]DeviceProfileResult, error) {
+	steps, err := profileCommands(profile)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("profile %q does not set anything", profile.Name)
+	}
+
+	results := make([]DeviceProfileResult, len(deviceIds))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, a.GetConcurrency().Shell)
+
+	for i, deviceID := range deviceIds {
+		wg.Add(1)
+		go func(i int, devID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = a.applyProfileSteps(devID, steps)
+			if !a.mcpMode {
+				wailsRuntime.EventsEmit(a.ctx, "device-profile-progress", results[i])
+			}
+		}(i, deviceID)
+	}
+	wg.Wait()
+
+	a.Log("Applied device profile %q to %d device(s)", profile.Name, len(deviceIds))
+	return results, nil
+}
+
+func (a *App) applyProfileSteps(deviceId string, steps []profileStep) DeviceProfileResult {
+	result := DeviceProfileResult{DeviceID: deviceId, Success: true}
+
+	if err := ValidateDeviceID(deviceId); err != nil {
+		result.Success = false
+		result.Settings = append(result.Settings, ProfileSettingResult{Setting: "device", Error: err.Error()})
+		return result
+	}
+
+	for _, step := range steps {
+		sr := a.applyProfileStep(deviceId, step)
+		if !sr.Success {
+			result.Success = false
+		}
+		if sr.RebootRequired {
+			result.RebootRequired = true
+		}
+		result.Settings = append(result.Settings, sr)
+	}
+	return result
+}
+
+// applyProfileStep runs a setting's commands in order, stopping at the first failure.
+// Output showing the device lacks a command marks the setting unsupported.
+func (a *App) applyProfileStep(deviceId string, step profileStep) ProfileSettingResult {
+	sr := ProfileSettingResult{Setting: step.setting}
+	var outputs []string
+
+	for _, command := range step.commands {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", command)
+		cancel()
+
+		out := strings.TrimSpace(string(output))
+		if out != "" {
+			outputs = append(outputs, out)
+		}
+		sr.Output = strings.Join(outputs, "\n")
+
+		if profileOutputUnsupported(out) {
+			sr.Unsupported = true
+			sr.Error = fmt.Sprintf("%s: not supported on this device: %s", command, out)
+			return sr
+		}
+		if err != nil {
+			sr.Error = fmt.Sprintf("%s: %v", command, err)
+			return sr
+		}
+		if strings.Contains(out, "Exception") || strings.Contains(out, "Error:") {
+			sr.Error = fmt.Sprintf("%s: %s", command, out)
+			return sr
+		}
+	}
+	sr.Success = true
+	sr.RebootRequired = step.needsReboot
+	return sr
+}
+
+// profileOutputUnsupported reports whether command output says the command or setting doesn't exist
+func profileOutputUnsupported(out string) bool {
+	for _, marker := range profileUnsupportedMarkers {
+		if strings.Contains(out, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// getDeviceProfilesPath returns the path to the device profiles directory
+func (a *App) getDeviceProfilesPath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = os.TempDir()
+	}
+	profilesPath := filepath.Join(configDir, "Gaze", "device_profiles")
+	_ = os.MkdirAll(profilesPath, 0755)
+	return profilesPath
+}
+
+// SaveDeviceProfile saves a named device profile, replacing one with the same name
+func (a *App) SaveDeviceProfile(profile DeviceProfile) error {
+	if strings.TrimSpace(profile.Name) == "" {
+		return fmt.Errorf("profile name is required")
+	}
+	if _, err := profileCommands(profile); err != nil {
+		return err
+	}
+
+	safeName := regexp.MustCompile(`[^a-zA-Z0-9_-]`).ReplaceAllString(profile.Name, "_")
+	filePath := filepath.Join(a.getDeviceProfilesPath(), safeName+".json")
+
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal profile: %w", err)
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write profile file: %w", err)
+	}
+	return nil
+}
+
+// LoadDeviceProfiles loads all saved device profiles
+func (a *App) LoadDeviceProfiles() ([]DeviceProfile, error) {
+	profilesPath := a.getDeviceProfilesPath()
+
+	entries, err := os.ReadDir(profilesPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []DeviceProfile{}, nil
+		}
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	profiles := make([]DeviceProfile, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(profilesPath, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var profile DeviceProfile
+		if err := json.Unmarshal(data, &profile); err != nil {
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+// DeleteDeviceProfile deletes a saved device profile
+func (a *App) DeleteDeviceProfile(name string) error {
+	safeName := regexp.MustCompile(`[^a-zA-Z0-9_-]`).ReplaceAllString(name, "_")
+	filePath := filepath.Join(a.getDeviceProfilesPath(), safeName+".json")
+
+	if err := os.Remove(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("profile not found")
+		}
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	return nil
+}
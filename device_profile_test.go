@@ -0,0 +1,169 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestProfileCommands(t *testing.T) {
+	scale := 1.15
+	awake := true
+	steps, err := profileCommands(DeviceProfile{
+		Name:       "demo",
+		FontScale:  &scale,
+		Density:    intPtr(0),
+		StayAwake:  &awake,
+		Brightness: intPtr(128),
+	})
+	if err != nil {
+		t.Fatalf("profileCommands: %v", err)
+	}
+
+	got := make(map[string][]string)
+	for _, s := range steps {
+		got[s.setting] = s.commands
+	}
+	if c := got["fontScale"]; len(c) != 1 || c[0] != "settings put system font_scale 1.15" {
+		t.Errorf("fontScale = %v", c)
+	}
+	if c := got["density"]; len(c) != 1 || c[0] != "wm density reset" {
+		t.Errorf("density 0 should reset, got %v", c)
+	}
+	if c := got["brightness"]; len(c) != 2 || c[0] != "settings put system screen_brightness_mode 0" || c[1] != "settings put system screen_brightness 128" {
+		t.Errorf("brightness should be separate commands, got %v", c)
+	}
+	for setting, cmds := range got {
+		for _, c := range cmds {
+			if strings.Contains(c, ";") {
+				t.Errorf("%s: command %q chains several commands", setting, c)
+			}
+		}
+	}
+
+	if steps, err := profileCommands(DeviceProfile{Locale: "zh-CN"}); err != nil || len(steps) != 1 ||
+		steps[0].commands[0] != "settings put system system_locales zh-CN" || !steps[0].needsReboot {
+		t.Errorf("locale steps = %+v, %v", steps, err)
+	}
+
+	for _, bad := range []DeviceProfile{
+		{Locale: "en_US; reboot"},
+		{FontScale: new(float64)},
+		{Density: intPtr(-1)},
+		{ScreenTimeoutMs: intPtr(0)},
+		{Brightness: intPtr(256)},
+	} {
+		if _, err := profileCommands(bad); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestApplyDeviceProfile(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s dev1 shell settings put system screen_brightness_mode 0": "",
+		"-s dev1 shell settings put system screen_brightness 40":     "",
+		"-s dev1 shell settings put system screen_off_timeout 60000": "",
+		// dev2 rejects the brightness mode; the brightness value must not be written
+		"-s dev2 shell settings put system screen_brightness_mode 0": "java.lang.SecurityException: Permission denial",
+		"-s dev2 shell settings put system screen_off_timeout 60000": "",
+	})
+	app.mcpMode = true
+
+	results, err := app.ApplyDeviceProfile([]string{"dev1", "dev2"}, DeviceProfile{
+		Name:            "night",
+		Brightness:      intPtr(40),
+		ScreenTimeoutMs: intPtr(60000),
+	})
+	if err != nil {
+		t.Fatalf("ApplyDeviceProfile: %v", err)
+	}
+	if len(results) != 2 || results[0].DeviceID != "dev1" || !results[0].Success {
+		t.Fatalf("unexpected dev1 result: %+v", results)
+	}
+
+	dev2 := results[1]
+	if dev2.Success || len(dev2.Settings) != 2 {
+		t.Fatalf("unexpected dev2 result: %+v", dev2)
+	}
+	if !dev2.Settings[0].Success {
+		t.Errorf("screen timeout should still apply on dev2: %+v", dev2.Settings[0])
+	}
+	if dev2.Settings[1].Setting != "brightness" || dev2.Settings[1].Success || !strings.Contains(dev2.Settings[1].Error, "SecurityException") {
+		t.Errorf("brightness should fail on dev2: %+v", dev2.Settings[1])
+	}
+	for _, call := range app.runner.(*fakeRunner).calls {
+		if call == "-s dev2 shell settings put system screen_brightness 40" {
+			t.Error("brightness value must not be written after the mode failed")
+		}
+	}
+
+	if _, err := app.ApplyDeviceProfile([]string{"dev1"}, DeviceProfile{Name: "empty"}); err == nil {
+		t.Error("expected error for a profile that sets nothing")
+	}
+}
+
+func TestDeviceProfileStorage(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+	app := newTestApp(nil)
+
+	if err := app.SaveDeviceProfile(DeviceProfile{Name: "  "}); err == nil {
+		t.Error("expected error for an empty name")
+	}
+	if err := app.SaveDeviceProfile(DeviceProfile{Name: "bad", Brightness: intPtr(999)}); err == nil {
+		t.Error("expected error for an invalid profile")
+	}
+
+	if err := app.SaveDeviceProfile(DeviceProfile{Name: "Night mode", Brightness: intPtr(10)}); err != nil {
+		t.Fatalf("SaveDeviceProfile: %v", err)
+	}
+	if err := app.SaveDeviceProfile(DeviceProfile{Name: "Night mode", Brightness: intPtr(20)}); err != nil {
+		t.Fatalf("SaveDeviceProfile (replace): %v", err)
+	}
+
+	profiles, err := app.LoadDeviceProfiles()
+	if err != nil {
+		t.Fatalf("LoadDeviceProfiles: %v", err)
+	}
+	if len(profiles) != 1 || profiles[0].Name != "Night mode" || *profiles[0].Brightness != 20 {
+		t.Fatalf("unexpected profiles: %+v", profiles)
+	}
+
+	if err := app.DeleteDeviceProfile("Night mode"); err != nil {
+		t.Fatalf("DeleteDeviceProfile: %v", err)
+	}
+	if err := app.DeleteDeviceProfile("Night mode"); err == nil {
+		t.Error("expected error deleting a missing profile")
+	}
+	if profiles, _ := app.LoadDeviceProfiles(); len(profiles) != 0 {
+		t.Errorf("expected no profiles, got %+v", profiles)
+	}
+}
+
+func TestApplyDeviceProfileRebootAndUnsupported(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s dev1 shell settings put system system_locales ja-JP": "",
+		"-s dev1 shell svc power stayon true":                    "/system/bin/sh: svc: not found",
+	})
+	app.mcpMode = true
+
+	awake := true
+	results, err := app.ApplyDeviceProfile([]string{"dev1"}, DeviceProfile{Name: "lab", StayAwake: &awake, Locale: "ja-JP"})
+	if err != nil {
+		t.Fatalf("ApplyDeviceProfile: %v", err)
+	}
+	res := results[0]
+	if res.Success || !res.RebootRequired || len(res.Settings) != 2 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if s := res.Settings[0]; s.Setting != "stayAwake" || s.Success || !s.Unsupported {
+		t.Errorf("stayAwake should be reported unsupported: %+v", s)
+	}
+	if s := res.Settings[1]; s.Setting != "locale" || !s.Success || !s.RebootRequired {
+		t.Errorf("locale should apply and need a reboot: %+v", s)
+	}
+}
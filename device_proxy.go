@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeviceProxyResult reports the global HTTP proxy now configured on a device
+type DeviceProxyResult struct {
+	Value   string `json:"value"`             // http_proxy as read back from the device, ":0" when cleared
+	Warning string `json:"warning,omitempty"` // set when the proxy is unlikely to take effect
+}
+
+// SetDeviceProxy points the device's global HTTP proxy at host:port. An empty host
+// uses this machine's LAN address, so the device can reach the internal proxy over
+// Wi-Fi. Android only honours the global proxy on Wi-Fi, so a warning is returned
+// when the device is currently on mobile data.
+func (a *App) SetDeviceProxy(deviceId string, host string, port int) (DeviceProxyResult, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return DeviceProxyResult{}, err
+	}
+	host = strings.TrimSpace(host)
+	if host == "" {
+		host = a.GetLocalIP()
+		if host == "" {
+			return DeviceProxyResult{}, fmt.Errorf("could not find local IP")
+		}
+	}
+	if strings.ContainsAny(host, " \t:/") {
+		return DeviceProxyResult{}, fmt.Errorf("invalid proxy host: %q", host)
+	}
+	if port <= 0 || port > 65535 {
+		return DeviceProxyResult{}, fmt.Errorf("invalid proxy port: %d", port)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	value := net.JoinHostPort(host, strconv.Itoa(port))
+	result, err := a.putDeviceProxy(ctx, deviceId, value)
+	if err != nil {
+		return result, err
+	}
+	if a.deviceOnMobileData(ctx, deviceId) {
+		result.Warning = "device is on mobile data; the global HTTP proxy only applies to Wi-Fi connections"
+	}
+
+	a.Log("Device proxy on %s set to %s", deviceId, result.Value)
+	return result, nil
+}
+
+// ClearDeviceProxy removes the device's global HTTP proxy
+func (a *App) ClearDeviceProxy(deviceId string) (DeviceProxyResult, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return DeviceProxyResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Deleting the key usually leaves the old proxy active until reboot; ":0" takes effect at once
+	result, err := a.putDeviceProxy(ctx, deviceId, ":0")
+	if err != nil {
+		return result, err
+	}
+
+	a.Log("Device proxy on %s cleared", deviceId)
+	return result, nil
+}
+
+// putDeviceProxy writes global http_proxy and reads it back
+func (a *App) putDeviceProxy(ctx context.Context, deviceId, value string) (DeviceProxyResult, error) {
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "settings", "put", "global", "http_proxy", value)
+	outStr := strings.TrimSpace(string(output))
+	if err != nil || strings.Contains(outStr, "Exception") {
+		return DeviceProxyResult{}, fmt.Errorf("failed to set device proxy: %v %s", err, outStr)
+	}
+
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "settings", "get", "global", "http_proxy")
+	if err != nil {
+		return DeviceProxyResult{Value: value}, nil
+	}
+	current := strings.TrimSpace(string(out))
+	if current != value {
+		return DeviceProxyResult{Value: current}, fmt.Errorf("device proxy is %q after setting %q", current, value)
+	}
+	return DeviceProxyResult{Value: current}, nil
+}
+
+// deviceOnMobileData reports whether the device is connected over mobile data
+// rather than Wi-Fi. Lookup failures count as not mobile.
+func (a *App) deviceOnMobileData(ctx context.Context, deviceId string) bool {
+	wifiOut, _, _ := a.runAdb(ctx, "-s", deviceId, "shell", "dumpsys wifi | grep 'Wi-Fi is\\|mWifiInfo\\|SSID\\|RSSI'")
+	connOut, _, _ := a.runAdb(ctx, "-s", deviceId, "shell", "dumpsys connectivity | head -50")
+	state := parseNetworkState(string(wifiOut), string(connOut))
+	return state != nil && state.Type == "mobile"
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	testWifiDumpCmd = "-s dev1 shell dumpsys wifi | grep 'Wi-Fi is\\|mWifiInfo\\|SSID\\|RSSI'"
+	testConnDumpCmd = "-s dev1 shell dumpsys connectivity | head -50"
+)
+
+func TestSetDeviceProxy(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s dev1 shell settings put global http_proxy 192.168.1.20:8080": "",
+		"-s dev1 shell settings get global http_proxy":                   "192.168.1.20:8080\n",
+		testWifiDumpCmd: "Wi-Fi is enabled\nmWifiInfo SSID: \"Office\", RSSI: -50\n",
+		testConnDumpCmd: "Active default network: 100\n",
+	})
+
+	result, err := app.SetDeviceProxy("dev1", "192.168.1.20", 8080)
+	if err != nil {
+		t.Fatalf("SetDeviceProxy: %v", err)
+	}
+	if result.Value != "192.168.1.20:8080" || result.Warning != "" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestSetDeviceProxy_MobileDataWarning(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s dev1 shell settings put global http_proxy 10.0.0.2:9000": "",
+		"-s dev1 shell settings get global http_proxy":               "10.0.0.2:9000",
+		testWifiDumpCmd: "Wi-Fi is disabled\n",
+		testConnDumpCmd: "NetworkAgentInfo{ ni{[type: MOBILE[LTE], state: CONNECTED/CONNECTED]} }\n",
+	})
+
+	result, err := app.SetDeviceProxy("dev1", "10.0.0.2", 9000)
+	if err != nil {
+		t.Fatalf("SetDeviceProxy: %v", err)
+	}
+	if !strings.Contains(result.Warning, "mobile data") {
+		t.Errorf("expected mobile data warning, got %+v", result)
+	}
+}
+
+func TestSetDeviceProxy_Validation(t *testing.T) {
+	app := newTestApp(nil)
+	if _, err := app.SetDeviceProxy("dev1", "10.0.0.2", 0); err == nil {
+		t.Error("expected error for port 0")
+	}
+	if _, err := app.SetDeviceProxy("dev1", "10.0.0.2:80", 8080); err == nil {
+		t.Error("expected error for host with port")
+	}
+	if _, err := app.SetDeviceProxy("dev1", "10.0.0.2; reboot", 8080); err == nil {
+		t.Error("expected error for host with spaces")
+	}
+}
+
+func TestClearDeviceProxy(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s dev1 shell settings put global http_proxy :0": "",
+		"-s dev1 shell settings get global http_proxy":    ":0\n",
+	})
+
+	result, err := app.ClearDeviceProxy("dev1")
+	if err != nil {
+		t.Fatalf("ClearDeviceProxy: %v", err)
+	}
+	if result.Value != ":0" {
+		t.Errorf("expected :0, got %q", result.Value)
+	}
+}
+
+func TestClearDeviceProxy_NotApplied(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s dev1 shell settings put global http_proxy :0": "",
+		"-s dev1 shell settings get global http_proxy":    "10.0.0.2:9000\n",
+	})
+
+	if _, err := app.ClearDeviceProxy("dev1"); err == nil {
+		t.Error("expected error when the proxy did not change")
+	}
+}
+
+func TestSetupProxyForDevice(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s dev1 reverse tcp:8080 tcp:8080":                           "",
+		"-s dev1 shell settings put global http_proxy 127.0.0.1:8080": "",
+		"-s dev1 shell settings get global http_proxy":                "127.0.0.1:8080\n",
+	})
+	if err := app.SetupProxyForDevice("dev1", 8080); err != nil {
+		t.Fatalf("SetupProxyForDevice: %v", err)
+	}
+}
+
+func TestSetupProxyForDevice_NotAppliedRemovesReverse(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s dev1 reverse tcp:8080 tcp:8080":                           "",
+		"-s dev1 shell settings put global http_proxy 127.0.0.1:8080": "",
+		"-s dev1 shell settings get global http_proxy":                ":0\n",
+		"-s dev1 reverse --remove tcp:8080":                           "",
+	})
+	if err := app.SetupProxyForDevice("dev1", 8080); err == nil {
+		t.Fatal("expected an error when the proxy did not stick")
+	}
+	calls := app.runner.(*fakeRunner).calls
+	if calls[len(calls)-1] != "-s dev1 reverse --remove tcp:8080" {
+		t.Errorf("adb reverse should be removed after a failed setup, calls = %q", calls)
+	}
+}
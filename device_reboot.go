@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// rebootModes are the targets `adb reboot` accepts; "" is a normal reboot
+var rebootModes = map[string]bool{
+	"":           true,
+	"recovery":   true,
+	"bootloader": true,
+	"fastboot":   true,
+	"sideload":   true,
+}
+
+var (
+	// How long a rebooting device may stay listed before it drops off adb
+	rebootGoneTimeout = 30 * time.Second
+	// How long a normal reboot may take until the device is back
+	rebootBackTimeout = 3 * time.Minute
+	// Downtime after which a wireless device is assumed to need an adb connect
+	rebootReconnectAfter = 20 * time.Second
+	rebootPollInterval   = 2 * time.Second
+)
+
+// RebootDevice reboots a device, optionally into recovery, bootloader, fastboot or
+// sideload. After a normal reboot it watches for the device to come back in the
+// background and emits "device-rebooted" (or "device-reboot-timeout").
+// Wireless devices are reconnected once the expected downtime has passed.
+func (a *App) RebootDevice(deviceId, mode string) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if !rebootModes[mode] {
+		return "", fmt.Errorf("unsupported reboot mode %q (use recovery, bootloader, fastboot or sideload)", mode)
+	}
+	LogUserAction(ActionDeviceReboot, deviceId, map[string]interface{}{"mode": mode})
+
+	// Remember the boot id so a reboot that adb never saw drop off is still detected
+	bootID := ""
+	if mode == "" {
+		bootID = a.deviceBootID(deviceId)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	args := []string{"-s", deviceId, "reboot"}
+	if mode != "" {
+		args = append(args, mode)
+	}
+	output, err := a.runAdbCombined(ctx, args...)
+	outStr := strings.TrimSpace(string(output))
+	if err != nil {
+		return outStr, fmt.Errorf("failed to reboot: %w (%s)", err, outStr)
+	}
+	a.Log("Rebooting %s (mode: %q)", deviceId, mode)
+
+	if mode == "" {
+		go a.waitForReboot(deviceId, bootID, time.Now())
+	}
+	return outStr, nil
+}
+
+// waitForReboot waits for a rebooting device to leave and rejoin adb. The device
+// only counts as back once it was seen offline or reports a boot id other than
+// bootID, so one that keeps answering while it shuts down is not mistaken for
+// a finished reboot. It reports whether the device came back.
+func (a *App) waitForReboot(deviceId, bootID string, started time.Time) bool {
+	online := func() bool {
+		ctx, cancel := context.WithTimeout(context.Background(), rebootPollInterval)
+		defer cancel()
+		out, _, err := a.runAdb(ctx, "-s", deviceId, "get-state")
+		return err == nil && strings.TrimSpace(string(out)) == "device"
+	}
+	rebooted := func() bool {
+		if bootID == "" {
+			return false
+		}
+		current := a.deviceBootID(deviceId)
+		return current != "" && current != bootID
+	}
+
+	wireless := strings.Contains(deviceId, ":") || strings.Contains(deviceId, "._tcp")
+	if wireless {
+		// Earlier failures must not hold back reconnecting after a planned reboot
+		a.resetReconnectState(deviceId)
+	}
+
+	// The device keeps answering for a moment while it shuts down. A wireless
+	// connection can also linger after the device is gone, so reconnecting is
+	// timed from the drop or, failing that, from the end of the grace period.
+	dropped := false
+	goneAt := started.Add(rebootGoneTimeout)
+	backDeadline := started.Add(rebootBackTimeout)
+	for time.Now().Before(backDeadline) {
+		if online() {
+			if dropped || rebooted() {
+				a.Log("Device %s is back after reboot (%v)", deviceId, time.Since(started).Round(time.Second))
+				a.emitRebootEvent("device-rebooted", deviceId, started)
+				return true
+			}
+		} else if !dropped {
+			dropped = true
+			if now := time.Now(); now.Before(goneAt) {
+				goneAt = now
+			}
+		}
+		if wireless && time.Since(goneAt) >= rebootReconnectAfter {
+			a.tryAutoReconnect(deviceId)
+		}
+		time.Sleep(rebootPollInterval)
+	}
+
+	LogWarn("device").Str("device", deviceId).Msg("Device did not come back after reboot")
+	a.emitRebootEvent("device-reboot-timeout", deviceId, started)
+	return false
+}
+
+// deviceBootID returns the kernel's per-boot random id, or "" if it can't be read
+func (a *App) deviceBootID(deviceId string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), rebootPollInterval)
+	defer cancel()
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "cat", "/proc/sys/kernel/random/boot_id")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func (a *App) emitRebootEvent(name, deviceId string, started time.Time) {
+	if a.mcpMode || a.ctx == nil {
+		return
+	}
+	wailsRuntime.EventsEmit(a.ctx, name, map[string]interface{}{
+		"deviceId":   deviceId,
+		"durationMs": time.Since(started).Milliseconds(),
+	})
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRebootDeviceModes(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC reboot recovery": "",
+	})
+
+	if _, err := app.RebootDevice("R5CT1234ABC", "Recovery"); err != nil {
+		t.Errorf("reboot recovery: %v", err)
+	}
+	if _, err := app.RebootDevice("R5CT1234ABC", "download"); err == nil {
+		t.Error("expected an error for an unsupported mode")
+	}
+}
+
+func TestWaitForRebootWirelessReconnects(t *testing.T) {
+	defer func(gone, back, reconnect, poll time.Duration) {
+		rebootGoneTimeout, rebootBackTimeout, rebootReconnectAfter, rebootPollInterval = gone, back, reconnect, poll
+	}(rebootGoneTimeout, rebootBackTimeout, rebootReconnectAfter, rebootPollInterval)
+	rebootGoneTimeout = 50 * time.Millisecond
+	rebootBackTimeout = 300 * time.Millisecond
+	rebootReconnectAfter = 0
+	rebootPollInterval = 10 * time.Millisecond
+
+	runner := &fakeRunner{responses: map[string]string{}}
+	app := newTestApp(nil)
+	app.runner = runner
+
+	app.waitForReboot("192.168.1.20:5555", "", time.Now())
+
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	reconnected := false
+	for _, call := range runner.calls {
+		if strings.HasPrefix(call, "connect 192.168.1.20:5555") {
+			reconnected = true
+		}
+	}
+	if !reconnected {
+		t.Errorf("expected an adb connect for the wireless device, calls: %v", runner.calls)
+	}
+}
+
+func TestWaitForRebootRequiresRestart(t *testing.T) {
+	defer func(gone, back, poll time.Duration) {
+		rebootGoneTimeout, rebootBackTimeout, rebootPollInterval = gone, back, poll
+	}(rebootGoneTimeout, rebootBackTimeout, rebootPollInterval)
+	rebootGoneTimeout = 50 * time.Millisecond
+	rebootBackTimeout = 100 * time.Millisecond
+	rebootPollInterval = 10 * time.Millisecond
+
+	responses := map[string]string{
+		"-s R5CT1234ABC get-state":                                 "device\n",
+		"-s R5CT1234ABC shell cat /proc/sys/kernel/random/boot_id": "b1\n",
+	}
+
+	// Still online with the same boot id: the reboot never happened
+	if newTestApp(responses).waitForReboot("R5CT1234ABC", "b1", time.Now()) {
+		t.Error("a device that never restarted should not count as rebooted")
+	}
+	// Never dropped off adb, but the boot id changed
+	if !newTestApp(responses).waitForReboot("R5CT1234ABC", "b0", time.Now()) {
+		t.Error("a changed boot id should count as rebooted")
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ringPulses is how many vibration pulses RingDevice sends
+const ringPulses = 5
+
+// ringVibrateCommand returns a shell loop that buzzes the device in long pulses.
+// Android 12 moved the shell command to vibrator_manager; -f overrides silent/DND
+// settings so a muted phone in a rack still buzzes. Returns "" below Android 8.
+func ringVibrateCommand(sdk int) string {
+	var pulse string
+	switch {
+	case sdk >= 31:
+		pulse = "cmd vibrator_manager synced -f oneshot 800"
+	case sdk >= 26:
+		pulse = "cmd vibrator vibrate 800 gaze"
+	default:
+		return ""
+	}
+	return fmt.Sprintf("for i in $(seq %d); do %s; sleep 1.2; done", ringPulses, pulse)
+}
+
+// RingDevice makes a device identify itself: it wakes the screen, posts a notification
+// and vibrates in a strong pattern, so a list entry can be matched to a physical phone.
+func (a *App) RingDevice(deviceId string) error {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return err
+	}
+	a.updateLastActive(deviceId)
+
+	sdk, err := a.getDeviceSdkInt(deviceId)
+	if err != nil {
+		return err
+	}
+	vibrate := ringVibrateCommand(sdk)
+	if vibrate == "" {
+		return fmt.Errorf("vibration from the shell requires Android 8.0 or newer (device API level %d)", sdk)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// Best effort: the screen may already be on and `cmd notification` is missing on older builds
+	_, _ = a.runAdbCombined(ctx, "-s", deviceId, "shell", "input", "keyevent", "KEYCODE_WAKEUP")
+	_, _ = a.runAdbCombined(ctx, "-s", deviceId, "shell", "cmd", "notification", "post", "-t", "'Gaze'", "gaze_ring", "'This device was identified from Gaze'")
+
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", vibrate)
+	outStr := strings.TrimSpace(string(output))
+	if err != nil {
+		return fmt.Errorf("failed to vibrate device: %w (%s)", err, outStr)
+	}
+	if strings.Contains(outStr, "Unknown command") || strings.Contains(outStr, "Exception") {
+		return fmt.Errorf("failed to vibrate device: %s", outStr)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRingVibrateCommand(t *testing.T) {
+	if got := ringVibrateCommand(25); got != "" {
+		t.Errorf("API 25 should have no vibrate command, got %q", got)
+	}
+	if got := ringVibrateCommand(29); !strings.Contains(got, "cmd vibrator vibrate 800") {
+		t.Errorf("API 29 command = %q, want cmd vibrator", got)
+	}
+	got := ringVibrateCommand(34)
+	if !strings.Contains(got, "cmd vibrator_manager synced -f oneshot") {
+		t.Errorf("API 34 command = %q, want vibrator_manager", got)
+	}
+	if !strings.Contains(got, "seq 5") {
+		t.Errorf("API 34 command = %q, want %d pulses", got, ringPulses)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ========================================
+// Display settings
+// ========================================
+
+// SetNightMode switches the system dark theme: mode is "yes", "no" or "auto".
+// Returns the mode the device reports afterwards.
+func (a *App) SetNightMode(deviceId string, mode string) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode != "yes" && mode != "no" && mode != "auto" {
+		return "", fmt.Errorf("invalid night mode %q: use yes, no or auto", mode)
+	}
+
+	// The system-wide dark theme, and the uimode shell command that sets it, arrived in Android 10
+	sdk, err := a.getDeviceSdkInt(deviceId)
+	if err != nil {
+		return "", err
+	}
+	if sdk < 29 {
+		return "", fmt.Errorf("night mode from the shell requires Android 10 or newer (device API level %d)", sdk)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "cmd", "uimode", "night", mode)
+	outStr := strings.TrimSpace(string(output))
+	if err != nil {
+		return outStr, fmt.Errorf("failed to set night mode: %w (%s)", err, outStr)
+	}
+	if strings.Contains(outStr, "Unknown command") || strings.Contains(outStr, "Error") || strings.Contains(outStr, "Exception") {
+		return outStr, fmt.Errorf("failed to set night mode: %s", outStr)
+	}
+
+	a.Log("Night mode on %s set to %s: %s", deviceId, mode, outStr)
+	return outStr, nil
+}
+
+const (
+	maxScreenBrightness = 255
+	// Android ignores shorter timeouts on most builds; MaxInt32 is what "never" stores
+	minScreenTimeoutMs = 5000
+	maxScreenTimeoutMs = math.MaxInt32
+)
+
+// SetScreenBrightness turns auto-brightness off and sets the raw brightness (0-255).
+// Out-of-range levels are clamped and the value actually written is returned. Some
+// devices map this range onto their panel non-linearly, so equal steps may not look equal.
+func (a *App) SetScreenBrightness(deviceId string, level int) (int, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return 0, err
+	}
+	level = min(max(level, 0), maxScreenBrightness)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Adaptive brightness would overwrite the value as soon as the light sensor reports
+	if err := a.putSystemSetting(ctx, deviceId, "screen_brightness_mode", 0); err != nil {
+		return 0, err
+	}
+	if err := a.putSystemSetting(ctx, deviceId, "screen_brightness", level); err != nil {
+		return 0, err
+	}
+	return level, nil
+}
+
+// GetScreenBrightness returns the raw brightness setting (0-255)
+func (a *App) GetScreenBrightness(deviceId string) (int, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return a.getSystemSetting(ctx, deviceId, "screen_brightness")
+}
+
+// SetScreenTimeout sets how long the screen stays on without input, in milliseconds.
+// The value is clamped to 5 seconds..MaxInt32 (never) and the value written is returned.
+func (a *App) SetScreenTimeout(deviceId string, ms int) (int, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return 0, err
+	}
+	ms = min(max(ms, minScreenTimeoutMs), maxScreenTimeoutMs)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := a.putSystemSetting(ctx, deviceId, "screen_off_timeout", ms); err != nil {
+		return 0, err
+	}
+	return ms, nil
+}
+
+// GetScreenTimeout returns the screen-off timeout in milliseconds
+func (a *App) GetScreenTimeout(deviceId string) (int, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return a.getSystemSetting(ctx, deviceId, "screen_off_timeout")
+}
+
+// putSystemSetting writes an integer to the system settings namespace
+func (a *App) putSystemSetting(ctx context.Context, deviceId, key string, value int) error {
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "settings", "put", "system", key, strconv.Itoa(value))
+	outStr := strings.TrimSpace(string(output))
+	if err != nil || strings.Contains(outStr, "Exception") {
+		return fmt.Errorf("failed to set %s: %v %s", key, err, outStr)
+	}
+	return nil
+}
+
+// getSystemSetting reads an integer from the system settings namespace
+func (a *App) getSystemSetting(ctx context.Context, deviceId, key string) (int, error) {
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "settings", "get", "system", key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	value := strings.TrimSpace(string(out))
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not set on this device (got %q)", key, value)
+	}
+	return n, nil
+}
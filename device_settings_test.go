@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestSetNightMode(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell getprop ro.build.version.sdk":   "34\n",
+		"-s R5CT1234ABC shell cmd uimode night yes":           "Night mode: yes\n",
+		"-s emulator-5554 shell getprop ro.build.version.sdk": "28\n",
+	})
+
+	out, err := app.SetNightMode("R5CT1234ABC", "Yes")
+	if err != nil || out != "Night mode: yes" {
+		t.Errorf("SetNightMode = %q, %v", out, err)
+	}
+	if _, err := app.SetNightMode("R5CT1234ABC", "dark"); err == nil {
+		t.Error("expected an error for an invalid mode")
+	}
+	if _, err := app.SetNightMode("emulator-5554", "yes"); err == nil {
+		t.Error("expected an error below Android 10")
+	}
+}
+
+func TestSetScreenBrightnessClamps(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell settings put system screen_brightness_mode 0": "",
+		"-s R5CT1234ABC shell settings put system screen_brightness 255":    "",
+		"-s R5CT1234ABC shell settings get system screen_brightness":        "128\n",
+	})
+	if got, err := app.SetScreenBrightness("R5CT1234ABC", 400); err != nil || got != 255 {
+		t.Errorf("SetScreenBrightness = %d, %v", got, err)
+	}
+	if got, err := app.GetScreenBrightness("R5CT1234ABC"); err != nil || got != 128 {
+		t.Errorf("GetScreenBrightness = %d, %v", got, err)
+	}
+}
+
+func TestScreenTimeout(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell settings put system screen_off_timeout 5000": "",
+		"-s R5CT1234ABC shell settings get system screen_off_timeout":      "null\n",
+	})
+	if got, err := app.SetScreenTimeout("R5CT1234ABC", 100); err != nil || got != 5000 {
+		t.Errorf("SetScreenTimeout = %d, %v", got, err)
+	}
+	if _, err := app.GetScreenTimeout("R5CT1234ABC"); err == nil {
+		t.Error("expected an error for an unset value")
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifyExpectedSerial(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s transport:7 shell getprop ro.serialno": "R5CT1234ABC\n",
+	})
+
+	if err := app.verifyExpectedSerial("transport:7", ""); err != nil {
+		t.Errorf("empty expected serial should skip the check, got %v", err)
+	}
+	if err := app.verifyExpectedSerial("transport:7", " R5CT1234ABC "); err != nil {
+		t.Errorf("matching serial: %v", err)
+	}
+	if got := app.idToSerial["transport:7"]; got != "R5CT1234ABC" {
+		t.Errorf("expected the ID cache to be refreshed, got %q", got)
+	}
+
+	err := app.verifyExpectedSerial("transport:7", "OTHER999")
+	if !errors.Is(err, ErrDeviceMismatch) {
+		t.Errorf("expected ErrDeviceMismatch, got %v", err)
+	}
+
+	// Device unreachable: the check fails closed
+	if err := app.verifyExpectedSerial("transport:8", "R5CT1234ABC"); !errors.Is(err, ErrDeviceMismatch) {
+		t.Errorf("expected ErrDeviceMismatch when the serial can't be read, got %v", err)
+	}
+}
+
+func TestVerifyExpectedSerial_IgnoresStaleCache(t *testing.T) {
+	// The transport ID used to belong to another phone
+	app := newTestApp(map[string]string{
+		"-s transport:7 shell getprop ro.serialno": "NEWPHONE42\n",
+	})
+	app.idToSerial["transport:7"] = "R5CT1234ABC"
+
+	if err := app.verifyExpectedSerial("transport:7", "R5CT1234ABC"); !errors.Is(err, ErrDeviceMismatch) {
+		t.Errorf("a reused transport ID must not pass the check, got %v", err)
+	}
+	if err := app.verifyExpectedSerial("transport:7", "NEWPHONE42"); err != nil {
+		t.Errorf("the phone actually connected should pass, got %v", err)
+	}
+}
+
+func TestVerifyExpectedSerial_NoSerialProperty(t *testing.T) {
+	// Emulators report an empty ro.serialno, so GetDevices shows the adb ID as the serial
+	app := newTestApp(map[string]string{
+		"-s emulator-5554 shell getprop ro.serialno": "\n",
+	})
+
+	if err := app.verifyExpectedSerial("emulator-5554", "emulator-5554"); err != nil {
+		t.Errorf("the ID should stand in for a missing serial, got %v", err)
+	}
+	if err := app.verifyExpectedSerial("emulator-5554", "R5CT1234ABC"); !errors.Is(err, ErrDeviceMismatch) {
+		t.Errorf("expected ErrDeviceMismatch, got %v", err)
+	}
+	if _, cached := app.idToSerial["emulator-5554"]; cached {
+		t.Error("an empty serial should not be cached")
+	}
+}
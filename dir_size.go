@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dirSizeTimeout = 60 * time.Second
+
+// GetDirectorySize returns the total size in bytes of a directory tree on the device.
+// Symlinks are counted but not followed, so link loops can't recurse. Entries the shell
+// user can't read are left out of the total.
+func (a *App) GetDirectorySize(deviceId, pathStr string) (int64, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return 0, err
+	}
+	pathStr = path.Clean("/" + pathStr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), dirSizeTimeout)
+	defer cancel()
+
+	// Byte counts from -b are exact but older toybox and some busybox builds lack it;
+	// -k works everywhere and is rounded to 1K blocks
+	for _, variant := range []struct {
+		flag string
+		unit int64
+	}{{"-sb", 1}, {"-sk", 1024}} {
+		stdout, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "du", variant.flag, shellQuote(pathStr))
+		if ctx.Err() != nil {
+			return 0, fmt.Errorf("size calculation timed out after %v", dirSizeTimeout)
+		}
+		// du exits non-zero when part of the tree is unreadable but still prints a total
+		if size, ok := parseDuTotal(string(stdout)); ok {
+			return size * variant.unit, nil
+		}
+		if err == nil {
+			break
+		}
+	}
+	return 0, fmt.Errorf("failed to get size of %s", pathStr)
+}
+
+// parseDuTotal reads the size column of the last "<size>\t<path>" line
+func parseDuTotal(output string) (int64, bool) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		fields := strings.Fields(lines[i])
+		if len(fields) < 2 {
+			continue
+		}
+		if n, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
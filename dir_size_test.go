@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// duRunner answers du -sb with a usage error like toybox builds without -b
+type duRunner struct{ fakeRunner }
+
+func (d *duRunner) Run(ctx context.Context, name string, args []string) ([]byte, []byte, error) {
+	if strings.Contains(strings.Join(args, " "), "du -sb") {
+		return nil, []byte("du: Unknown option 'b'\n"), fmt.Errorf("exit status 1")
+	}
+	return d.fakeRunner.Run(ctx, name, args)
+}
+
+func TestGetDirectorySize(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell du -sb '/sdcard/DCIM'": "734003200\t/sdcard/DCIM\n",
+	})
+	size, err := app.GetDirectorySize("R5CT1234ABC", "/sdcard/DCIM/")
+	if err != nil || size != 734003200 {
+		t.Errorf("GetDirectorySize = %d, %v", size, err)
+	}
+}
+
+func TestGetDirectorySizeKilobyteFallback(t *testing.T) {
+	app := newTestApp(nil)
+	app.runner = &duRunner{fakeRunner{responses: map[string]string{
+		"-s R5CT1234ABC shell du -sk '/data/local/tmp'": "2048\t/data/local/tmp\n",
+	}}}
+	size, err := app.GetDirectorySize("R5CT1234ABC", "/data/local/tmp")
+	if err != nil || size != 2048*1024 {
+		t.Errorf("GetDirectorySize = %d, %v", size, err)
+	}
+}
+
+func TestParseDuTotal(t *testing.T) {
+	if n, ok := parseDuTotal("du: /sdcard/Android/data: Permission denied\n4096\t/sdcard\n"); !ok || n != 4096 {
+		t.Errorf("parseDuTotal = %d, %v", n, ok)
+	}
+	if _, ok := parseDuTotal("du: Unknown option 'b'\n"); ok {
+		t.Error("usage error parsed as a size")
+	}
+}
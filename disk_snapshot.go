@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ========================================
+// Directory snapshots - "what changed on disk"
+// ========================================
+
+const (
+	snapshotMaxFiles     = 5000             // Stop scanning after this many files
+	snapshotTimeout      = 60 * time.Second // Upper bound for a single scan
+	snapshotHashMaxBytes = 256 * 1024       // Only md5 files up to this size
+	snapshotHashBatch    = 50               // Files per md5sum invocation
+)
+
+// SnapshotFileEntry is a single file recorded in a directory snapshot
+type SnapshotFileEntry struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	ModTime string `json:"modTime"`
+	MD5     string `json:"md5,omitempty"` // Empty for large files
+}
+
+// DirectorySnapshot is a manifest of a directory tree at a point in time
+type DirectorySnapshot struct {
+	ID         string                       `json:"id"`
+	DeviceID   string                       `json:"deviceId"`
+	RemotePath string                       `json:"remotePath"`
+	CreatedAt  int64                        `json:"createdAt"`
+	Truncated  bool                         `json:"truncated"`
+	Files      map[string]SnapshotFileEntry `json:"files"`
+}
+
+// DirectorySnapshotDiff lists files added, modified and removed since a snapshot
+type DirectorySnapshotDiff struct {
+	SnapshotID string              `json:"snapshotId"`
+	RemotePath string              `json:"remotePath"`
+	Added      []SnapshotFileEntry `json:"added"`
+	Modified   []SnapshotFileEntry `json:"modified"`
+	Removed    []SnapshotFileEntry `json:"removed"`
+	Truncated  bool                `json:"truncated"` // Either scan hit the file cap or timeout
+}
+
+var (
+	dirSnapshots   = make(map[string]*DirectorySnapshot)
+	dirSnapshotsMu sync.Mutex
+)
+
+// SnapshotDirectory records a manifest (path, size, mtime, md5 of small files) of
+// a directory tree on the device and returns its snapshot ID
+func (a *App) SnapshotDirectory(deviceId, remotePath string) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+
+	remotePath = path.Clean("/" + remotePath)
+	files, truncated, err := a.scanDirectoryTree(deviceId, remotePath)
+	if err != nil {
+		return "", err
+	}
+
+	snap := &DirectorySnapshot{
+		ID:         uuid.New().String(),
+		DeviceID:   deviceId,
+		RemotePath: remotePath,
+		CreatedAt:  time.Now().UnixMilli(),
+		Truncated:  truncated,
+		Files:      files,
+	}
+
+	dirSnapshotsMu.Lock()
+	dirSnapshots[snap.ID] = snap
+	dirSnapshotsMu.Unlock()
+
+	a.Log("Snapshot %s: %d files under %s (truncated=%v)", snap.ID, len(files), remotePath, truncated)
+	return snap.ID, nil
+}
+
+// DiffDirectorySnapshot re-scans the snapshot's directory and reports what changed
+func (a *App) DiffDirectorySnapshot(deviceId, snapshotID string) (*DirectorySnapshotDiff, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return nil, err
+	}
+
+	dirSnapshotsMu.Lock()
+	snap, ok := dirSnapshots[snapshotID]
+	dirSnapshotsMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("snapshot not found: %s", snapshotID)
+	}
+	if snap.DeviceID != deviceId {
+		return nil, fmt.Errorf("snapshot %s was taken on device %s", snapshotID, snap.DeviceID)
+	}
+
+	current, truncated, err := a.scanDirectoryTree(deviceId, snap.RemotePath)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffSnapshotFiles(snap.Files, current)
+	diff.SnapshotID = snapshotID
+	diff.RemotePath = snap.RemotePath
+	diff.Truncated = snap.Truncated || truncated
+	return diff, nil
+}
+
+// DeleteDirectorySnapshot discards a snapshot
+func (a *App) DeleteDirectorySnapshot(snapshotID string) {
+	dirSnapshotsMu.Lock()
+	delete(dirSnapshots, snapshotID)
+	dirSnapshotsMu.Unlock()
+}
+
+// scanDirectoryTree walks remotePath breadth-first using listDir, bounded by
+// snapshotMaxFiles and snapshotTimeout. Symlinks are not followed.
+func (a *App) scanDirectoryTree(deviceId, remotePath string) (map[string]SnapshotFileEntry, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), snapshotTimeout)
+	defer cancel()
+
+	files := make(map[string]SnapshotFileEntry)
+	truncated := false
+	queue := []string{remotePath}
+
+	for len(queue) > 0 && !truncated {
+		if ctx.Err() != nil {
+			truncated = true
+			break
+		}
+
+		dir := queue[0]
+		queue = queue[1:]
+
+		entries, err := a.listDir(ctx, deviceId, dir)
+		if err != nil {
+			if dir == remotePath {
+				return nil, false, err
+			}
+			// Unreadable subdirectory (permission denied etc.) - skip it
+			continue
+		}
+
+		for _, f := range entries {
+			if strings.HasPrefix(f.Mode, "l") {
+				continue
+			}
+			if f.IsDir {
+				queue = append(queue, f.Path)
+				continue
+			}
+			files[f.Path] = SnapshotFileEntry{Path: f.Path, Size: f.Size, ModTime: f.ModTime}
+			if len(files) >= snapshotMaxFiles {
+				truncated = true
+				break
+			}
+		}
+	}
+
+	a.hashSnapshotFiles(ctx, deviceId, files)
+	return files, truncated, nil
+}
+
+// hashSnapshotFiles fills in MD5 for small files using batched md5sum calls
+func (a *App) hashSnapshotFiles(ctx context.Context, deviceId string, files map[string]SnapshotFileEntry) {
+	var small []string
+	for p, f := range files {
+		if f.Size <= snapshotHashMaxBytes {
+			small = append(small, p)
+		}
+	}
+	sort.Strings(small)
+
+	for start := 0; start < len(small); start += snapshotHashBatch {
+		if ctx.Err() != nil {
+			return
+		}
+		end := start + snapshotHashBatch
+		if end > len(small) {
+			end = len(small)
+		}
+
+		quoted := make([]string, 0, end-start)
+		for _, p := range small[start:end] {
+			quoted = append(quoted, shellQuote(p))
+		}
+		// md5sum exits non-zero if any file vanished; keep whatever it printed
+		out, _ := a.newAdbCommand(ctx, "-s", deviceId, "shell", "md5sum "+strings.Join(quoted, " ")+" 2>/dev/null").Output()
+		for p, sum := range parseMd5sumOutput(string(out)) {
+			if f, ok := files[p]; ok {
+				f.MD5 = sum
+				files[p] = f
+			}
+		}
+	}
+}
+
+// parseMd5sumOutput parses "<hash>  <path>" lines into path -> hash
+func parseMd5sumOutput(output string) map[string]string {
+	result := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if len(line) < 34 || line[32] != ' ' {
+			continue
+		}
+		result[strings.TrimLeft(line[33:], " *")] = line[:32]
+	}
+	return result
+}
+
+// diffSnapshotFiles compares two manifests. A file counts as modified when its
+// size, mtime or (if both sides were hashed) md5 differ.
+func diffSnapshotFiles(before, after map[string]SnapshotFileEntry) *DirectorySnapshotDiff {
+	diff := &DirectorySnapshotDiff{
+		Added:    []SnapshotFileEntry{},
+		Modified: []SnapshotFileEntry{},
+		Removed:  []SnapshotFileEntry{},
+	}
+
+	for p, cur := range after {
+		old, ok := before[p]
+		if !ok {
+			diff.Added = append(diff.Added, cur)
+			continue
+		}
+		if old.Size != cur.Size || old.ModTime != cur.ModTime ||
+			(old.MD5 != "" && cur.MD5 != "" && old.MD5 != cur.MD5) {
+			diff.Modified = append(diff.Modified, cur)
+		}
+	}
+	for p, old := range before {
+		if _, ok := after[p]; !ok {
+			diff.Removed = append(diff.Removed, old)
+		}
+	}
+
+	byPath := func(list []SnapshotFileEntry) {
+		sort.Slice(list, func(i, j int) bool { return list[i].Path < list[j].Path })
+	}
+	byPath(diff.Added)
+	byPath(diff.Modified)
+	byPath(diff.Removed)
+	return diff
+}
@@ -0,0 +1,48 @@
+package main
+
+import "testing"
+
+func TestParseMd5sumOutput(t *testing.T) {
+	output := "d41d8cd98f00b204e9800998ecf8427e  /sdcard/Android/data/com.example/cache/empty.tmp\r\n" +
+		"5d41402abc4b2a76b9719d911017c592  /sdcard/My Files/hello world.txt\n" +
+		"md5sum: /sdcard/gone.txt: No such file or directory\n" +
+		"\n"
+
+	got := parseMd5sumOutput(output)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries, got %d: %v", len(got), got)
+	}
+	if got["/sdcard/Android/data/com.example/cache/empty.tmp"] != "d41d8cd98f00b204e9800998ecf8427e" {
+		t.Errorf("unexpected hash for empty.tmp: %q", got["/sdcard/Android/data/com.example/cache/empty.tmp"])
+	}
+	if got["/sdcard/My Files/hello world.txt"] != "5d41402abc4b2a76b9719d911017c592" {
+		t.Errorf("path with spaces not parsed: %v", got)
+	}
+}
+
+func TestDiffSnapshotFiles(t *testing.T) {
+	before := map[string]SnapshotFileEntry{
+		"/data/a.txt": {Path: "/data/a.txt", Size: 10, ModTime: "2026-01-01 10:00", MD5: "aaa"},
+		"/data/b.txt": {Path: "/data/b.txt", Size: 20, ModTime: "2026-01-01 10:00", MD5: "bbb"},
+		"/data/c.txt": {Path: "/data/c.txt", Size: 30, ModTime: "2026-01-01 10:00"},
+		"/data/big":   {Path: "/data/big", Size: 1 << 30, ModTime: "2026-01-01 10:00"},
+	}
+	after := map[string]SnapshotFileEntry{
+		"/data/a.txt": {Path: "/data/a.txt", Size: 10, ModTime: "2026-01-01 10:00", MD5: "aaa"},
+		"/data/b.txt": {Path: "/data/b.txt", Size: 20, ModTime: "2026-01-01 10:00", MD5: "changed"},
+		"/data/big":   {Path: "/data/big", Size: 1 << 30, ModTime: "2026-01-01 10:05"},
+		"/data/d.txt": {Path: "/data/d.txt", Size: 5, ModTime: "2026-01-01 10:05", MD5: "ddd"},
+	}
+
+	diff := diffSnapshotFiles(before, after)
+
+	if len(diff.Added) != 1 || diff.Added[0].Path != "/data/d.txt" {
+		t.Errorf("Added = %v, want [/data/d.txt]", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Path != "/data/c.txt" {
+		t.Errorf("Removed = %v, want [/data/c.txt]", diff.Removed)
+	}
+	if len(diff.Modified) != 2 || diff.Modified[0].Path != "/data/b.txt" || diff.Modified[1].Path != "/data/big" {
+		t.Errorf("Modified = %v, want [/data/b.txt /data/big]", diff.Modified)
+	}
+}
@@ -42,6 +42,12 @@ type EventPipeline struct {
 	// 背压控制
 	backpressure *BackpressureController
 
+	// 按来源限流采样
+	sampler *SourceSampler
+
+	// 触摸 -> logcat/network 追踪关联窗口 (ms)，<= 0 表示关闭
+	traceWindowMs atomic.Int64
+
 	// 插件管理器
 	pluginManager *PluginManager
 
@@ -57,6 +63,11 @@ type SessionState struct {
 	EventCount   int64
 	LastEventAt  int64
 	RecentEvents *RingBuffer // 最近事件缓冲
+
+	// 当前触摸追踪: 窗口内的 logcat/network 事件继承该 trace_id
+	TraceID    string
+	TraceStart int64
+	TraceUntil int64
 }
 
 // RingBuffer 环形缓冲区
@@ -399,7 +410,7 @@ func (b *BackpressureController) GetStats() map[string]int64 {
 
 // NewEventPipeline 创建事件管道
 func NewEventPipeline(ctx, wailsCtx context.Context, store *EventStore, mcpMode bool) *EventPipeline {
-	return &EventPipeline{
+	p := &EventPipeline{
 		ctx:            ctx,
 		wailsCtx:       wailsCtx,
 		store:          store,
@@ -410,8 +421,11 @@ func NewEventPipeline(ctx, wailsCtx context.Context, store *EventStore, mcpMode
 		frontendBuffer: make([]UnifiedEvent, 0, 100),
 		timeIndexCache: NewTimeIndexLRUCache(DefaultTimeIndexCacheCapacity),
 		backpressure:   NewBackpressureController(2000),
+		sampler:        NewSourceSampler(),
 		stopChan:       make(chan struct{}),
 	}
+	p.traceWindowMs.Store(DefaultTraceWindowMs)
+	return p
 }
 
 // SetPluginManager 设置插件管理器
@@ -487,6 +501,14 @@ func (p *EventPipeline) Emit(event UnifiedEvent) {
 		event.Detail = event.Data
 	}
 
+	// 按来源采样，超出部分丢弃并计数
+	if !p.sampler.Allow(event) {
+		if p.store != nil {
+			p.store.RecordSampledOut(event.Source)
+		}
+		return
+	}
+
 	// 背压检查
 	if !p.backpressure.ShouldProcess(event) {
 		return
@@ -618,14 +640,17 @@ func (p *EventPipeline) processEvent(event UnifiedEvent) {
 			event.RelativeTime = 0
 		}
 
-		// 6. 更新 Session 状态
+		// 6. 触摸追踪关联
+		p.correlateTrace(state, &event)
+
+		// 7. 更新 Session 状态
 		state.EventCount++
 		state.LastEventAt = event.Timestamp
 		state.RecentEvents.Push(event)
 	}
 	p.sessionMu.Unlock()
 
-	// 7. 插件处理（生成派生事件）
+	// 8. 插件处理（生成派生事件）
 	if p.pluginManager != nil {
 		derivedEvents := p.pluginManager.ProcessEvent(event, sessionID)
 		if len(derivedEvents) > 0 {
@@ -641,13 +666,13 @@ func (p *EventPipeline) processEvent(event UnifiedEvent) {
 		log.Printf("[EventPipeline] ⚠️ pluginManager is nil, skipping plugin processing")
 	}
 
-	// 8. 更新时间索引
+	// 9. 更新时间索引
 	p.updateTimeIndex(event)
 
-	// 9. 写入存储
+	// 10. 写入存储
 	p.store.WriteEvent(event)
 
-	// 10. 添加到前端缓冲
+	// 11. 添加到前端缓冲
 	p.addToFrontendBuffer(event)
 }
 
@@ -921,6 +946,18 @@ func (p *EventPipeline) UpdateSessionVideoPath(sessionID, videoPath string) {
 	}
 }
 
+// UpdateSessionVideoTiming 更新 Session 视频的偏移和时长 (ms)
+func (p *EventPipeline) UpdateSessionVideoTiming(sessionID string, offset, duration int64) {
+	p.sessionMu.Lock()
+	defer p.sessionMu.Unlock()
+
+	if state := p.sessions[sessionID]; state != nil {
+		state.Session.VideoOffset = offset
+		state.Session.VideoDuration = duration
+		p.store.UpdateSession(state.Session)
+	}
+}
+
 // GetRecentEvents 获取最近事件 (从内存)
 func (p *EventPipeline) GetRecentEvents(sessionID string, count int) []UnifiedEvent {
 	p.sessionMu.RLock()
@@ -618,3 +618,92 @@ func TestIsCriticalEvent(t *testing.T) {
 		}
 	}
 }
+
+// ========================================
+// Trace Correlation Tests
+// ========================================
+
+func TestCorrelateTrace(t *testing.T) {
+	p := NewEventPipeline(nil, nil, nil, true)
+	p.SetTraceWindow(2000)
+	state := &SessionState{}
+
+	touch := UnifiedEvent{Source: SourceTouch, Type: "touch", Timestamp: 10000}
+	p.correlateTrace(state, &touch)
+	if touch.TraceID == "" {
+		t.Fatal("touch event should start a trace")
+	}
+
+	logLine := UnifiedEvent{Source: SourceLogcat, Timestamp: 11500}
+	p.correlateTrace(state, &logLine)
+	if logLine.TraceID != touch.TraceID {
+		t.Errorf("logcat within window: traceId = %q, want %q", logLine.TraceID, touch.TraceID)
+	}
+
+	perf := UnifiedEvent{Source: SourcePerf, Timestamp: 11500}
+	p.correlateTrace(state, &perf)
+	if perf.TraceID != "" {
+		t.Errorf("perf events should not be correlated, got %q", perf.TraceID)
+	}
+
+	late := UnifiedEvent{Source: SourceNetwork, Timestamp: 12001}
+	p.correlateTrace(state, &late)
+	if late.TraceID != "" {
+		t.Errorf("network after window should not be correlated, got %q", late.TraceID)
+	}
+
+	tagged := UnifiedEvent{Source: SourceNetwork, Timestamp: 11000, TraceID: "existing"}
+	p.correlateTrace(state, &tagged)
+	if tagged.TraceID != "existing" {
+		t.Errorf("existing trace id overwritten: %q", tagged.TraceID)
+	}
+
+	second := UnifiedEvent{Source: SourceTouch, Type: "touch", Timestamp: 13000}
+	p.correlateTrace(state, &second)
+	if second.TraceID == "" || second.TraceID == touch.TraceID {
+		t.Errorf("second touch should start a new trace, got %q", second.TraceID)
+	}
+
+	p.SetTraceWindow(0)
+	net := UnifiedEvent{Source: SourceNetwork, Timestamp: 13100}
+	p.correlateTrace(state, &net)
+	if net.TraceID != "" {
+		t.Errorf("correlation disabled, got %q", net.TraceID)
+	}
+}
+
+func TestSourceSampler(t *testing.T) {
+	s := NewSourceSampler()
+	s.SetLimit(SourceLogcat, 2)
+	start := time.Now()
+
+	logLine := UnifiedEvent{Source: SourceLogcat, Level: LevelInfo}
+	kept := 0
+	for i := 0; i < 5; i++ {
+		if s.allowAt(logLine, start) {
+			kept++
+		}
+	}
+	if kept != 2 {
+		t.Errorf("kept %d logcat events in one second, want 2", kept)
+	}
+
+	if !s.allowAt(UnifiedEvent{Source: SourceLogcat, Level: LevelError}, start) {
+		t.Error("errors must never be sampled out")
+	}
+	if !s.allowAt(UnifiedEvent{Source: SourceNetwork, Level: LevelInfo}, start) {
+		t.Error("sources without a limit must pass")
+	}
+
+	// A new window restores the budget
+	if !s.allowAt(logLine, start.Add(time.Second)) {
+		t.Error("limit should reset after one second")
+	}
+
+	s.SetLimit(SourceLogcat, 0)
+	for i := 0; i < 5; i++ {
+		if !s.allowAt(logLine, start.Add(time.Second)) {
+			t.Fatal("removed limit still drops events")
+		}
+	}
+}
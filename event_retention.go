@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// ========================================
+// Event store retention policy
+// ========================================
+
+// RetentionPolicy bounds how much session history the event store keeps.
+// A zero field disables that limit.
+type RetentionPolicy struct {
+	MaxAgeDays     int `json:"maxAgeDays"`
+	MaxTotalSizeMB int `json:"maxTotalSizeMB"`
+}
+
+// RetentionResult reports what one enforcement pass removed
+type RetentionResult struct {
+	SessionsRemoved int   `json:"sessionsRemoved"`
+	BytesFreed      int64 `json:"bytesFreed"`
+	Vacuumed        bool  `json:"vacuumed"`
+}
+
+var (
+	// retentionInterval is how often the background worker enforces the policy
+	retentionInterval = time.Hour
+	// retentionVacuumMinBytes is how much must be freed before the file is compacted
+	retentionVacuumMinBytes int64 = 32 << 20
+)
+
+// SetRetentionPolicy replaces the policy the background worker enforces
+func (s *EventStore) SetRetentionPolicy(policy RetentionPolicy) {
+	s.retentionMu.Lock()
+	s.retention = policy
+	s.retentionMu.Unlock()
+}
+
+// GetRetentionPolicy returns the active retention policy
+func (s *EventStore) GetRetentionPolicy() RetentionPolicy {
+	s.retentionMu.Lock()
+	defer s.retentionMu.Unlock()
+	return s.retention
+}
+
+// SetCleanupHandler registers a callback for passes that removed sessions
+func (s *EventStore) SetCleanupHandler(fn func(RetentionResult)) {
+	s.retentionMu.Lock()
+	s.onCleanup = fn
+	s.retentionMu.Unlock()
+}
+
+// startRetentionWorker periodically enforces the retention policy until Close
+func (s *EventStore) startRetentionWorker() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(retentionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.EnforceRetention(); err != nil {
+					LogWarn("event_store").Err(err).Msg("Retention cleanup failed")
+				}
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// enforceRetentionAsync runs one enforcement pass in the background; Close waits for it
+func (s *EventStore) enforceRetentionAsync() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if _, err := s.EnforceRetention(); err != nil {
+			LogWarn("event_store").Err(err).Msg("Retention cleanup failed")
+		}
+	}()
+}
+
+// EnforceRetention deletes finished sessions older than MaxAgeDays, then the oldest
+// finished sessions until the database's live data fits in MaxTotalSizeMB. Active
+// sessions are never removed. The file is vacuumed when enough space was freed.
+func (s *EventStore) EnforceRetention() (RetentionResult, error) {
+	s.retentionRunMu.Lock()
+	defer s.retentionRunMu.Unlock()
+
+	var result RetentionResult
+	policy := s.GetRetentionPolicy()
+	if policy.MaxAgeDays <= 0 && policy.MaxTotalSizeMB <= 0 {
+		return result, nil
+	}
+
+	before, err := s.liveDataSize()
+	if err != nil {
+		return result, err
+	}
+
+	if policy.MaxAgeDays > 0 {
+		cutoff := time.Now().Add(-time.Duration(policy.MaxAgeDays) * 24 * time.Hour).UnixMilli()
+		ids, err := s.finishedSessionIDs(cutoff)
+		if err != nil {
+			return result, err
+		}
+		for _, id := range ids {
+			if s.deleteSessionForRetention(id) {
+				result.SessionsRemoved++
+			}
+		}
+	}
+
+	if policy.MaxTotalSizeMB > 0 {
+		limit := int64(policy.MaxTotalSizeMB) << 20
+		ids, err := s.finishedSessionIDs(0)
+		if err != nil {
+			return result, err
+		}
+		for _, id := range ids {
+			size, err := s.liveDataSize()
+			if err != nil {
+				return result, err
+			}
+			if size <= limit {
+				break
+			}
+			if s.deleteSessionForRetention(id) {
+				result.SessionsRemoved++
+			}
+		}
+	}
+
+	if result.SessionsRemoved == 0 {
+		return result, nil
+	}
+
+	if after, err := s.liveDataSize(); err == nil && after < before {
+		result.BytesFreed = before - after
+	}
+	if result.BytesFreed >= retentionVacuumMinBytes || result.BytesFreed*4 >= before {
+		if err := s.VacuumDatabase(); err != nil {
+			LogWarn("event_store").Err(err).Msg("Vacuum after retention cleanup failed")
+		} else {
+			result.Vacuumed = true
+		}
+	}
+
+	LogInfo("event_store").
+		Int("sessionsRemoved", result.SessionsRemoved).
+		Int64("bytesFreed", result.BytesFreed).
+		Bool("vacuumed", result.Vacuumed).
+		Msg("Retention cleanup finished")
+
+	s.retentionMu.Lock()
+	onCleanup := s.onCleanup
+	s.retentionMu.Unlock()
+	if onCleanup != nil {
+		onCleanup(result)
+	}
+	return result, nil
+}
+
+// finishedSessionIDs lists ended sessions, oldest first. With endedBefore > 0 only
+// sessions that ended before that time (Unix ms) are returned.
+func (s *EventStore) finishedSessionIDs(endedBefore int64) ([]string, error) {
+	query := `SELECT id FROM sessions WHERE end_time > 0`
+	var args []interface{}
+	if endedBefore > 0 {
+		query += ` AND end_time < ?`
+		args = append(args, endedBefore)
+	}
+	query += ` ORDER BY start_time ASC`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// deleteSessionForRetention deletes one session, logging rather than aborting the
+// pass when it can't be removed (e.g. still referenced by assertion set results)
+func (s *EventStore) deleteSessionForRetention(id string) bool {
+	if err := s.DeleteSession(id); err != nil {
+		LogWarn("event_store").Err(err).Str("sessionId", id).Msg("Retention cleanup could not delete session")
+		return false
+	}
+	return true
+}
+
+// liveDataSize returns the bytes in use by the database, excluding free pages
+func (s *EventStore) liveDataSize() (int64, error) {
+	var pageCount, freePages, pageSize int64
+	if err := s.db.QueryRow(`PRAGMA page_count`).Scan(&pageCount); err != nil {
+		return 0, fmt.Errorf("read page count: %w", err)
+	}
+	if err := s.db.QueryRow(`PRAGMA freelist_count`).Scan(&freePages); err != nil {
+		return 0, fmt.Errorf("read freelist count: %w", err)
+	}
+	if err := s.db.QueryRow(`PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("read page size: %w", err)
+	}
+	return (pageCount - freePages) * pageSize, nil
+}
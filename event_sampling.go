@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ========================================
+// Per-source ingestion sampling
+// ========================================
+
+// Sources that can be rate limited with SetSourceSampling
+var samplingSources = []EventSource{
+	SourceLogcat, SourceNetwork, SourceDevice, SourceApp, SourceUI, SourceTouch,
+	SourceWorkflow, SourcePerf, SourceSystem, SourceAssertion, SourcePlugin,
+}
+
+// SourceSampler caps how many events per second each source may feed into the pipeline.
+// Unlike backpressure it applies at all loads, so a noisy source can be throttled
+// without disabling it.
+type SourceSampler struct {
+	mu          sync.Mutex
+	limits      map[EventSource]int
+	counts      map[EventSource]int
+	windowStart time.Time
+}
+
+func NewSourceSampler() *SourceSampler {
+	return &SourceSampler{
+		limits: make(map[EventSource]int),
+		counts: make(map[EventSource]int),
+	}
+}
+
+// SetLimit sets the max events per second of a source; <= 0 removes the limit
+func (s *SourceSampler) SetLimit(source EventSource, maxPerSecond int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if maxPerSecond <= 0 {
+		delete(s.limits, source)
+		return
+	}
+	s.limits[source] = maxPerSecond
+}
+
+// Allow reports whether the event fits into its source's budget for the current second.
+// Errors and session lifecycle events always pass.
+func (s *SourceSampler) Allow(event UnifiedEvent) bool {
+	return s.allowAt(event, time.Now())
+}
+
+func (s *SourceSampler) allowAt(event UnifiedEvent, now time.Time) bool {
+	if event.Level == LevelError || event.Level == LevelFatal ||
+		event.Type == "session_start" || event.Type == "session_end" {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	limit, ok := s.limits[event.Source]
+	if !ok {
+		return true
+	}
+	if now.Sub(s.windowStart) >= time.Second {
+		s.windowStart = now
+		clear(s.counts)
+	}
+	if s.counts[event.Source] >= limit {
+		return false
+	}
+	s.counts[event.Source]++
+	return true
+}
+
+// SetSourceSampling limits how many events per second a source may emit
+func (p *EventPipeline) SetSourceSampling(source EventSource, maxPerSecond int) {
+	p.sampler.SetLimit(source, maxPerSecond)
+}
+
+// GetSourceSampling returns the per-source ingestion limits (events/s)
+func (a *App) GetSourceSampling() map[string]int {
+	if a.cacheService == nil {
+		return map[string]int{}
+	}
+	return a.cacheService.GetSourceSampling()
+}
+
+// SetSourceSampling rate limits one event source at ingestion. Events beyond maxPerSecond
+// are dropped and counted in the store stats; errors are always kept. Passing 0 removes
+// the limit.
+func (a *App) SetSourceSampling(source EventSource, maxPerSecond int) error {
+	if maxPerSecond < 0 {
+		return fmt.Errorf("max events per second must not be negative, got %d", maxPerSecond)
+	}
+	known := false
+	for _, s := range samplingSources {
+		if s == source {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("unknown event source: %q", source)
+	}
+	if a.cacheService == nil {
+		return fmt.Errorf("settings are not available")
+	}
+
+	a.cacheService.SetSourceSampling(string(source), maxPerSecond)
+	go a.saveSettings()
+
+	if a.eventPipeline != nil {
+		a.eventPipeline.SetSourceSampling(source, maxPerSecond)
+	}
+	if maxPerSecond == 0 {
+		a.Log("Sampling for %s events disabled", source)
+	} else {
+		a.Log("Sampling %s events at %d/s", source, maxPerSecond)
+	}
+	return nil
+}
+
+// applySourceSampling loads the saved limits into a new pipeline
+func (a *App) applySourceSampling() {
+	for source, limit := range a.GetSourceSampling() {
+		a.eventPipeline.SetSourceSampling(EventSource(source), limit)
+	}
+}
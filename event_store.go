@@ -36,6 +36,16 @@ type EventStore struct {
 	// FTS 支持标志（缓存初始化时的检查结果）
 	hasFTS bool
 
+	// 被来源采样丢弃的事件数 (仅内存，进程内累计)
+	sampledOut   map[EventSource]int64
+	sampledOutMu sync.Mutex
+
+	// 保留策略 (后台定期清理)
+	retention      RetentionPolicy
+	onCleanup      func(RetentionResult)
+	retentionMu    sync.Mutex
+	retentionRunMu sync.Mutex // 串行化清理过程
+
 	// 预编译语句
 	stmtInsertEvent        *sql.Stmt
 	stmtInsertEventData    *sql.Stmt
@@ -359,6 +369,9 @@ func NewEventStore(dataDir string) (*EventStore, error) {
 	// 启动后台写入
 	store.startBackgroundWriter()
 
+	// 启动保留策略清理
+	store.startRetentionWorker()
+
 	return store, nil
 }
 
@@ -762,6 +775,93 @@ func (s *EventStore) ListSessions(deviceID string, limit int) ([]DeviceSession,
 	return sessions, rows.Err()
 }
 
+// SessionListResult 分页的 Session 列表
+type SessionListResult struct {
+	Sessions []DeviceSession `json:"sessions"`
+	Total    int             `json:"total"`
+	HasMore  bool            `json:"hasMore"`
+}
+
+// escapeLikePattern 转义 LIKE 通配符，使用户输入按字面匹配；需配合 ESCAPE '\' 使用
+func escapeLikePattern(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListSessionsFiltered 按设备、开始时间范围 (Unix 秒)、状态和名称分页列出 Sessions。
+// 零值/空字符串表示不过滤该条件
+func (s *EventStore) ListSessionsFiltered(deviceID string, startUnix, endUnix int64, status, searchName string, limit, offset int) (*SessionListResult, error) {
+	var conditions []string
+	var args []interface{}
+
+	if deviceID != "" {
+		conditions = append(conditions, "device_id = ?")
+		args = append(args, deviceID)
+	}
+	if startUnix > 0 {
+		conditions = append(conditions, "start_time >= ?")
+		args = append(args, startUnix*1000)
+	}
+	if endUnix > 0 {
+		conditions = append(conditions, "start_time <= ?")
+		args = append(args, endUnix*1000)
+	}
+	if status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, status)
+	}
+	if searchName != "" {
+		conditions = append(conditions, `name LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLikePattern(searchName)+"%")
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM sessions"+whereClause, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("count query: %w", err)
+	}
+
+	query := `
+		SELECT id, device_id, type, name, start_time, end_time, status, event_count,
+			video_path, video_duration, video_offset, metadata
+		FROM sessions` + whereClause + ` ORDER BY start_time DESC`
+	if limit > 0 {
+		query += fmt.Sprintf(` LIMIT %d`, limit)
+		if offset > 0 {
+			query += fmt.Sprintf(` OFFSET %d`, offset)
+		}
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []DeviceSession{}
+	for rows.Next() {
+		session, err := s.scanSessionRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &SessionListResult{
+		Sessions: sessions,
+		Total:    total,
+		HasMore:  offset+len(sessions) < total,
+	}, nil
+}
+
 // DeleteSession 删除 Session
 func (s *EventStore) DeleteSession(id string) error {
 	_, err := s.db.Exec(`DELETE FROM sessions WHERE id = ?`, id)
@@ -1071,6 +1171,81 @@ func (s *EventStore) QueryEvents(q EventQuery) (*EventQueryResult, error) {
 	}, nil
 }
 
+// SearchAllSessions 跨所有 Session 全文搜索事件 (最新的在前)
+// 结果不含 event_data，附带所属 Session 名称以便跳转
+func (s *EventStore) SearchAllSessions(text string, limit int) ([]UnifiedEvent, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return []UnifiedEvent{}, nil
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+
+	// 与 QueryEvents 相同的深度搜索: title + summary + event_data.data
+	searchPattern := "%" + text + "%"
+	var searchCondition string
+	var args []interface{}
+	if s.hasFTS {
+		// 作为短语匹配，避免用户输入被解析为 FTS5 查询语法
+		phrase := `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+		searchCondition = "(e.id IN (SELECT id FROM events_fts WHERE events_fts MATCH ?) OR COALESCE(ed.data, '') LIKE ?)"
+		args = []interface{}{phrase, searchPattern}
+	} else {
+		searchCondition = "(e.title LIKE ? OR COALESCE(e.summary, '') LIKE ? OR COALESCE(ed.data, '') LIKE ?)"
+		args = []interface{}{searchPattern, searchPattern, searchPattern}
+	}
+
+	query := fmt.Sprintf(`
+		SELECT DISTINCT e.id, e.session_id, e.device_id, e.timestamp, e.relative_time, e.duration,
+			e.source, e.category, e.type, e.level, e.title, e.summary,
+			e.parent_id, e.step_id, e.trace_id,
+			e.aggregate_count, e.aggregate_first, e.aggregate_last,
+			COALESCE(s.name, '')
+		FROM events e
+		LEFT JOIN event_data ed ON e.id = ed.event_id
+		LEFT JOIN sessions s ON e.session_id = s.id
+		WHERE %s
+		ORDER BY e.timestamp DESC
+		LIMIT %d
+	`, searchCondition, limit)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []UnifiedEvent{}
+	for rows.Next() {
+		var event UnifiedEvent
+		var summary, parentID, stepID, traceID sql.NullString
+		var source, category, level string
+
+		if err := rows.Scan(
+			&event.ID, &event.SessionID, &event.DeviceID,
+			&event.Timestamp, &event.RelativeTime, &event.Duration,
+			&source, &category, &event.Type, &level,
+			&event.Title, &summary,
+			&parentID, &stepID, &traceID,
+			&event.AggregateCount, &event.AggregateFirst, &event.AggregateLast,
+			&event.SessionName,
+		); err != nil {
+			return nil, err
+		}
+
+		event.Source = EventSource(source)
+		event.Category = EventCategory(category)
+		event.Level = EventLevel(level)
+		event.Summary = summary.String
+		event.ParentID = parentID.String
+		event.StepID = stepID.String
+		event.TraceID = traceID.String
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
 // GetEvent 获取单个事件
 func (s *EventStore) GetEvent(id string) (*UnifiedEvent, error) {
 	row := s.db.QueryRow(`
@@ -1417,9 +1592,31 @@ func (s *EventStore) GetStoreStats() map[string]interface{} {
 	s.db.QueryRow(`SELECT COUNT(*) FROM sessions WHERE status = 'active'`).Scan(&activeSessions)
 	stats["activeSessions"] = activeSessions
 
+	// Events dropped by per-source sampling since startup
+	s.sampledOutMu.Lock()
+	sampledOut := make(map[string]int64, len(s.sampledOut))
+	var sampledOutTotal int64
+	for source, n := range s.sampledOut {
+		sampledOut[string(source)] = n
+		sampledOutTotal += n
+	}
+	s.sampledOutMu.Unlock()
+	stats["sampledOut"] = sampledOut
+	stats["sampledOutTotal"] = sampledOutTotal
+
 	return stats
 }
 
+// RecordSampledOut counts an event of the source that was dropped by sampling
+func (s *EventStore) RecordSampledOut(source EventSource) {
+	s.sampledOutMu.Lock()
+	if s.sampledOut == nil {
+		s.sampledOut = make(map[EventSource]int64)
+	}
+	s.sampledOut[source]++
+	s.sampledOutMu.Unlock()
+}
+
 // GetEventTypes 获取 Session 中所有事件类型
 func (s *EventStore) GetEventTypes(sessionID string) ([]string, error) {
 	rows, err := s.db.Query(`
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -200,6 +203,76 @@ func TestListSessions(t *testing.T) {
 	}
 }
 
+// TestListSessionsFiltered tests date range, status, name filters and pagination
+func TestListSessionsFiltered(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		status := "completed"
+		if i == 5 {
+			status = "active"
+		}
+		deviceID := "device-a"
+		if i%2 == 1 {
+			deviceID = "device-b"
+		}
+		session := &DeviceSession{
+			ID:        uuid.New().String(),
+			DeviceID:  deviceID,
+			Type:      "manual",
+			Name:      fmt.Sprintf("Login flow %d", i),
+			StartTime: base.AddDate(0, 0, i).UnixMilli(),
+			Status:    status,
+		}
+		if i == 2 {
+			session.Name = "Checkout 100%"
+		}
+		if err := store.CreateSession(session); err != nil {
+			t.Fatalf("Failed to create session %d: %v", i, err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		deviceID   string
+		start, end int64
+		status     string
+		search     string
+		limit      int
+		offset     int
+		wantLen    int
+		wantTotal  int
+		wantMore   bool
+	}{
+		{name: "all", wantLen: 6, wantTotal: 6},
+		{name: "device", deviceID: "device-a", wantLen: 3, wantTotal: 3},
+		{name: "date range", start: base.AddDate(0, 0, 1).Unix(), end: base.AddDate(0, 0, 3).Unix(), wantLen: 3, wantTotal: 3},
+		{name: "status", status: "active", wantLen: 1, wantTotal: 1},
+		{name: "name search", search: "login", wantLen: 5, wantTotal: 5},
+		{name: "percent is literal", search: "%", wantLen: 1, wantTotal: 1},
+		{name: "underscore is literal", search: "_", wantLen: 0, wantTotal: 0},
+		{name: "literal suffix", search: "100%", wantLen: 1, wantTotal: 1},
+		{name: "combined", deviceID: "device-a", search: "Login", start: base.Unix(), end: base.AddDate(0, 0, 3).Unix(), wantLen: 1, wantTotal: 1},
+		{name: "first page", limit: 4, wantLen: 4, wantTotal: 6, wantMore: true},
+		{name: "last page", limit: 4, offset: 4, wantLen: 2, wantTotal: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := store.ListSessionsFiltered(tt.deviceID, tt.start, tt.end, tt.status, tt.search, tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("ListSessionsFiltered: %v", err)
+			}
+			if len(result.Sessions) != tt.wantLen || result.Total != tt.wantTotal || result.HasMore != tt.wantMore {
+				t.Errorf("got len=%d total=%d hasMore=%v, want len=%d total=%d hasMore=%v",
+					len(result.Sessions), result.Total, result.HasMore, tt.wantLen, tt.wantTotal, tt.wantMore)
+			}
+		})
+	}
+}
+
 // TestEventWriteAndQuery tests writing and querying events
 func TestEventWriteAndQuery(t *testing.T) {
 	store, cleanup := setupTestStore(t)
@@ -650,3 +723,161 @@ func TestDataDirectoryCreation(t *testing.T) {
 		t.Fatal("Data directory should be created")
 	}
 }
+
+// TestSearchAllSessions tests searching events across sessions
+func TestSearchAllSessions(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	base := time.Now().UnixMilli()
+	sessions := []*DeviceSession{
+		{ID: uuid.New().String(), DeviceID: "dev-a", Type: "manual", Name: "Login flow", StartTime: base, Status: "completed"},
+		{ID: uuid.New().String(), DeviceID: "dev-b", Type: "auto", Name: "Checkout", StartTime: base, Status: "active"},
+	}
+	for _, s := range sessions {
+		if err := store.CreateSession(s); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+	}
+
+	write := func(session *DeviceSession, offset int64, title, data string) {
+		event := UnifiedEvent{
+			ID:           uuid.New().String(),
+			SessionID:    session.ID,
+			DeviceID:     session.DeviceID,
+			Timestamp:    base + offset,
+			RelativeTime: offset,
+			Source:       SourceLogcat,
+			Category:     CategoryLog,
+			Type:         "logcat",
+			Level:        LevelError,
+			Title:        title,
+		}
+		if data != "" {
+			event.Data = json.RawMessage(data)
+		}
+		store.WriteEvent(event)
+	}
+	write(sessions[0], 100, "NullPointerException in LoginActivity", "")
+	write(sessions[0], 200, "GC freed 12MB", "")
+	write(sessions[1], 300, "Payment failed", `{"message":"NullPointerException at CartAdapter"}`)
+	store.Flush()
+	time.Sleep(100 * time.Millisecond)
+
+	results, err := store.SearchAllSessions("NullPointerException", 10)
+	if err != nil {
+		t.Fatalf("SearchAllSessions failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 matches, got %d", len(results))
+	}
+	// Newest first, with session names attached
+	if results[0].SessionID != sessions[1].ID || results[0].SessionName != "Checkout" {
+		t.Errorf("Unexpected first result: %s / %s", results[0].SessionID, results[0].SessionName)
+	}
+	if results[1].SessionName != "Login flow" {
+		t.Errorf("Expected session name Login flow, got %q", results[1].SessionName)
+	}
+
+	if results, _ := store.SearchAllSessions("NullPointerException", 1); len(results) != 1 {
+		t.Errorf("Expected limit to cap results at 1, got %d", len(results))
+	}
+	if results, _ := store.SearchAllSessions(`"quoted" AND (`, 10); len(results) != 0 {
+		t.Errorf("Expected no matches, got %d", len(results))
+	}
+	if results, _ := store.SearchAllSessions("  ", 10); len(results) != 0 {
+		t.Errorf("Expected blank query to return nothing, got %d", len(results))
+	}
+}
+
+// TestEnforceRetention tests age- and size-based session cleanup
+func TestEnforceRetention(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	var cleanups []RetentionResult
+	store.SetCleanupHandler(func(r RetentionResult) { cleanups = append(cleanups, r) })
+
+	now := time.Now().UnixMilli()
+	day := int64(24 * time.Hour / time.Millisecond)
+	newSession := func(name string, start, end int64, payloadKB int) *DeviceSession {
+		session := &DeviceSession{
+			ID:        uuid.New().String(),
+			DeviceID:  "retention-device",
+			Type:      "manual",
+			Name:      name,
+			StartTime: start,
+			EndTime:   end,
+			Status:    "completed",
+		}
+		if end == 0 {
+			session.Status = "active"
+		}
+		if err := store.CreateSession(session); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		// Event data is gzip-compressed, so use random bytes to control the stored size
+		for i := 0; i < payloadKB/50; i++ {
+			blob := make([]byte, 50*1024)
+			rand.Read(blob)
+			payload := fmt.Sprintf(`{"blob":%q}`, hex.EncodeToString(blob))
+			event := UnifiedEvent{
+				ID:        uuid.New().String(),
+				SessionID: session.ID,
+				DeviceID:  session.DeviceID,
+				Timestamp: start + int64(i),
+				Source:    SourceNetwork,
+				Category:  CategoryNetwork,
+				Type:      "network_request",
+				Level:     LevelInfo,
+				Title:     "GET /payload",
+				Data:      json.RawMessage(payload),
+			}
+			if err := store.WriteEventDirect(event); err != nil {
+				t.Fatalf("Failed to write event: %v", err)
+			}
+		}
+		return session
+	}
+
+	stale := newSession("Stale", now-10*day, now-9*day, 0)
+	oldest := newSession("Oldest", now-3000, now-2900, 1500)
+	middle := newSession("Middle", now-2000, now-1900, 1500)
+	newest := newSession("Newest", now-1000, now-900, 1500)
+	active := newSession("Active", now-5*day, 0, 0)
+
+	// No policy: nothing happens
+	if result, err := store.EnforceRetention(); err != nil || result.SessionsRemoved != 0 {
+		t.Fatalf("Expected no-op without a policy, got %+v, %v", result, err)
+	}
+
+	store.SetRetentionPolicy(RetentionPolicy{MaxAgeDays: 7, MaxTotalSizeMB: 2})
+	result, err := store.EnforceRetention()
+	if err != nil {
+		t.Fatalf("EnforceRetention failed: %v", err)
+	}
+	if result.SessionsRemoved != 3 {
+		t.Errorf("Expected 3 sessions removed, got %d", result.SessionsRemoved)
+	}
+	if result.BytesFreed < 2<<20 {
+		t.Errorf("Expected at least 2MB freed, got %d", result.BytesFreed)
+	}
+	if !result.Vacuumed {
+		t.Error("Expected database to be vacuumed after freeing most of it")
+	}
+
+	for _, s := range []*DeviceSession{stale, oldest, middle} {
+		if got, _ := store.GetSession(s.ID); got != nil {
+			t.Errorf("Expected session %s to be removed", s.Name)
+		}
+	}
+	for _, s := range []*DeviceSession{newest, active} {
+		if got, _ := store.GetSession(s.ID); got == nil {
+			t.Errorf("Expected session %s to be kept", s.Name)
+		}
+	}
+
+	if len(cleanups) != 1 || cleanups[0] != result {
+		t.Errorf("Expected cleanup handler to receive the result once, got %+v", cleanups)
+	}
+}
@@ -65,6 +65,9 @@ type UnifiedEvent struct {
 	SessionID string `json:"sessionId"` // 所属会话
 	DeviceID  string `json:"deviceId"`  // 设备 ID
 
+	// 所属会话名称 (仅跨 Session 搜索结果填充，不存储)
+	SessionName string `json:"sessionName,omitempty"`
+
 	// === 时间字段 ===
 	Timestamp    int64 `json:"timestamp"`          // Unix 毫秒 (绝对时间)
 	RelativeTime int64 `json:"relativeTime"`       // 相对 Session 开始的毫秒偏移
@@ -139,7 +142,7 @@ type MonitorConfig struct {
 type DeviceSession struct {
 	ID         string `json:"id"`
 	DeviceID   string `json:"deviceId"`
-	Type       string `json:"type"` // "manual", "workflow", "recording", "debug", "auto"
+	Type       string `json:"type"` // "manual", "workflow", "recording", "mirror", "debug", "auto"
 	Name       string `json:"name"`
 	StartTime  int64  `json:"startTime"` // Unix ms
 	EndTime    int64  `json:"endTime"`   // 0 = active
@@ -283,6 +286,10 @@ var EventRegistry = map[string]EventTypeInfo{
 		Type: "gesture", Source: SourceTouch, Category: CategoryInteraction,
 		Description: "Recognized gesture",
 	},
+	"key": {
+		Type: "key", Source: SourceTouch, Category: CategoryInteraction,
+		Description: "Hardware key press",
+	},
 
 	// === Workflow 事件 ===
 	"workflow_start": {
@@ -345,6 +352,10 @@ var EventRegistry = map[string]EventTypeInfo{
 		Type: "recording_end", Source: SourceSystem, Category: CategoryState,
 		Description: "Screen recording ended",
 	},
+	"screenshot": {
+		Type: "screenshot", Source: SourceSystem, Category: CategoryState,
+		Description: "Screenshot saved on the host",
+	},
 }
 
 // ParseEventLevel converts a string level (e.g. from old SessionEvent) to EventLevel.
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// Directory download as archive
+// ========================================
+
+// How often "pull-archive-progress" is emitted while the archive streams in
+const archiveProgressInterval = 250 * time.Millisecond
+
+// DownloadDirectoryAsZip downloads a device directory as a single .tar.gz, which is far
+// faster than pulling thousands of small files one by one. The tarball is streamed from
+// `tar` on the device; devices without gzip send a plain tar that is compressed on the
+// host. Without tar on the device the directory is pulled recursively instead and the
+// local directory is returned. If savePath is empty, a save dialog is shown; cancelling
+// it returns an empty path. Progress is reported as received bytes through
+// "pull-archive-progress" events.
+func (a *App) DownloadDirectoryAsZip(deviceId, remoteDir, savePath string) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+	a.updateLastActive(deviceId)
+	remoteDir = path.Clean("/" + remoteDir)
+	if remoteDir == "/" {
+		return "", fmt.Errorf("refusing to archive the whole filesystem")
+	}
+	base := path.Base(remoteDir)
+
+	if savePath == "" {
+		if a.ctx == nil || a.mcpMode {
+			return "", fmt.Errorf("save path is required")
+		}
+		var err error
+		savePath, err = wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
+			DefaultFilename:  base + ".tar.gz",
+			Title:            "Download Folder",
+			DefaultDirectory: a.outputDir(OutputDownloads),
+			Filters: []wailsRuntime.FileFilter{
+				{DisplayName: "Gzipped tar (*.tar.gz)", Pattern: "*.tar.gz;*.tgz"},
+			},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to open save dialog: %w", err)
+		}
+		if savePath == "" {
+			return "", nil // User cancelled
+		}
+	}
+	savePath = archiveSavePath(savePath)
+	if err := os.MkdirAll(filepath.Dir(savePath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	hasTar, hasGzip := a.deviceArchiveTools(deviceId)
+	if !hasTar {
+		localDir := strings.TrimSuffix(savePath, ".tar.gz")
+		a.Log("tar not found on %s, pulling %s recursively", deviceId, remoteDir)
+		output, err := a.runAdbCombined(nil, "-s", deviceId, "pull", remoteDir, localDir)
+		if err != nil {
+			return "", fmt.Errorf("failed to pull %s: %w, output: %s", remoteDir, err, strings.TrimSpace(string(output)))
+		}
+		return localDir, nil
+	}
+
+	// stderr is dropped on the device: exec-out would mix it into the tarball
+	flags := "cf"
+	if hasGzip {
+		flags = "czf"
+	}
+	script := fmt.Sprintf("tar %s - -C %s %s 2>/dev/null", flags, shellQuote(path.Dir(remoteDir)), shellQuote(base))
+
+	if err := a.streamArchive(deviceId, remoteDir, script, savePath, !hasGzip); err != nil {
+		os.Remove(savePath)
+		return "", err
+	}
+	return savePath, nil
+}
+
+// archiveSavePath makes sure the archive ends in .tar.gz
+func archiveSavePath(savePath string) string {
+	lower := strings.ToLower(savePath)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"):
+		return savePath
+	case strings.HasSuffix(lower, ".tgz"):
+		return savePath[:len(savePath)-len(".tgz")] + ".tar.gz"
+	}
+	return savePath + ".tar.gz"
+}
+
+// deviceArchiveTools reports whether the device shell has tar and gzip
+func (a *App) deviceArchiveTools(deviceId string) (hasTar, hasGzip bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, _, _ := a.runAdb(ctx, "-s", deviceId, "shell", "command -v tar; command -v gzip")
+	for _, line := range strings.Split(string(out), "\n") {
+		switch path.Base(strings.TrimSpace(line)) {
+		case "tar":
+			hasTar = true
+		case "gzip":
+			hasGzip = true
+		}
+	}
+	return hasTar, hasGzip
+}
+
+// streamArchive runs script through exec-out and writes its output to savePath,
+// gzipping it on the host when compressLocally is set
+func (a *App) streamArchive(deviceId, remoteDir, script, savePath string, compressLocally bool) error {
+	f, err := os.Create(savePath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	var dst io.Writer = f
+	var gz *gzip.Writer
+	if compressLocally {
+		gz = gzip.NewWriter(f)
+		dst = gz
+	}
+
+	counter := &archiveProgressWriter{emit: func(received int64) {
+		if a.mcpMode || a.ctx == nil {
+			return
+		}
+		wailsRuntime.EventsEmit(a.ctx, "pull-archive-progress", map[string]interface{}{
+			"deviceId":  deviceId,
+			"remoteDir": remoteDir,
+			"savePath":  savePath,
+			"bytes":     received,
+		})
+	}}
+
+	cmd := a.newAdbCommand(nil, "-s", deviceId, "exec-out", script)
+	cmd.Stdout = io.MultiWriter(dst, counter)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+	counter.flush()
+
+	if gz != nil {
+		if err := gz.Close(); err != nil && runErr == nil {
+			runErr = err
+		}
+	}
+	if runErr != nil {
+		return fmt.Errorf("failed to archive %s: %w, output: %s", remoteDir, runErr, strings.TrimSpace(stderr.String()))
+	}
+	if counter.total() == 0 {
+		return fmt.Errorf("failed to archive %s: the device sent no data (is the folder readable?)", remoteDir)
+	}
+
+	a.Log("Archived %s from %s to %s (%d bytes received)", remoteDir, deviceId, savePath, counter.total())
+	return nil
+}
+
+// archiveProgressWriter counts bytes and calls emit at most every archiveProgressInterval
+type archiveProgressWriter struct {
+	mu       sync.Mutex
+	received int64
+	last     time.Time
+	emit     func(received int64)
+}
+
+func (w *archiveProgressWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	w.received += int64(len(p))
+	received := w.received
+	due := time.Since(w.last) >= archiveProgressInterval
+	if due {
+		w.last = time.Now()
+	}
+	w.mu.Unlock()
+	if due {
+		w.emit(received)
+	}
+	return len(p), nil
+}
+
+// flush emits the final byte count
+func (w *archiveProgressWriter) flush() {
+	w.emit(w.total())
+}
+
+func (w *archiveProgressWriter) total() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.received
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestArchiveSavePath(t *testing.T) {
+	cases := map[string]string{
+		"/tmp/data":        "/tmp/data.tar.gz",
+		"/tmp/data.tar.gz": "/tmp/data.tar.gz",
+		"/tmp/data.TGZ":    "/tmp/data.tar.gz",
+	}
+	for in, want := range cases {
+		if got := archiveSavePath(in); got != want {
+			t.Errorf("archiveSavePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDeviceArchiveTools(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell command -v tar; command -v gzip":   "/system/bin/tar\n/system/bin/gzip\n",
+		"-s emulator-5554 shell command -v tar; command -v gzip": "/system/bin/tar\n",
+	})
+	if tar, gz := app.deviceArchiveTools("R5CT1234ABC"); !tar || !gz {
+		t.Errorf("R5CT1234ABC: tar=%v gzip=%v", tar, gz)
+	}
+	if tar, gz := app.deviceArchiveTools("emulator-5554"); !tar || gz {
+		t.Errorf("emulator-5554: tar=%v gzip=%v", tar, gz)
+	}
+}
+
+func TestArchiveProgressWriter(t *testing.T) {
+	var emitted []int64
+	w := &archiveProgressWriter{emit: func(n int64) { emitted = append(emitted, n) }}
+	w.Write(make([]byte, 100))
+	w.Write(make([]byte, 50)) // within the interval, not emitted
+	w.flush()
+	if len(emitted) != 2 || emitted[0] != 100 || emitted[1] != 150 {
+		t.Errorf("emitted = %v", emitted)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultPreviewBytes = 64 * 1024
+	maxPreviewBytes     = 1024 * 1024
+	// Printed instead of content when the path isn't a readable regular file
+	previewUnreadableMarker = "__gaze_preview_unreadable__"
+)
+
+// ErrBinaryFile is returned by PreviewTextFile for content that isn't UTF-8 text;
+// the frontend falls back to OpenFileOnHost
+var ErrBinaryFile = errors.New("binary file")
+
+// PreviewTextFile returns up to maxBytes from the start of a text file on the device
+func (a *App) PreviewTextFile(deviceId, remotePath string, maxBytes int) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultPreviewBytes
+	}
+	if maxBytes > maxPreviewBytes {
+		maxBytes = maxPreviewBytes
+	}
+	remotePath = path.Clean("/" + remotePath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// exec-out keeps the bytes as they are; shell may turn LF into CRLF
+	quoted := shellQuote(remotePath)
+	script := fmt.Sprintf("if [ -f %s ] && [ -r %s ]; then head -c %d %s; else echo %s; fi",
+		quoted, quoted, maxBytes, quoted, previewUnreadableMarker)
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "exec-out", script)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", remotePath, err)
+	}
+	if string(bytes.TrimSpace(out)) == previewUnreadableMarker {
+		return "", fmt.Errorf("%s is not a readable file", remotePath)
+	}
+	return decodePreviewText(out, len(out) >= maxBytes)
+}
+
+// decodePreviewText checks that data is UTF-8 text. A truncated read may end in the
+// middle of a multi-byte character, which is dropped rather than counted as binary.
+func decodePreviewText(data []byte, truncated bool) (string, error) {
+	if bytes.IndexByte(data, 0) >= 0 {
+		return "", ErrBinaryFile
+	}
+	if truncated {
+		for i := 0; i < utf8.UTFMax-1 && len(data) > 0; i++ {
+			if r, size := utf8.DecodeLastRune(data); r != utf8.RuneError || size != 1 {
+				break
+			}
+			data = data[:len(data)-1]
+		}
+	}
+	if !utf8.Valid(data) {
+		return "", ErrBinaryFile
+	}
+	return string(data), nil
+}
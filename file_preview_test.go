@@ -0,0 +1,37 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodePreviewText(t *testing.T) {
+	if got, err := decodePreviewText([]byte("key=value\n"), false); err != nil || got != "key=value\n" {
+		t.Errorf("plain text = %q, %v", got, err)
+	}
+	// "é" cut in half by head -c
+	if got, err := decodePreviewText([]byte("caf\xc3"), true); err != nil || got != "caf" {
+		t.Errorf("truncated rune = %q, %v", got, err)
+	}
+	if _, err := decodePreviewText([]byte("caf\xc3"), false); !errors.Is(err, ErrBinaryFile) {
+		t.Errorf("invalid UTF-8 at EOF: err = %v", err)
+	}
+	if _, err := decodePreviewText([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), true); !errors.Is(err, ErrBinaryFile) {
+		t.Errorf("png: err = %v", err)
+	}
+}
+
+func TestPreviewTextFile(t *testing.T) {
+	script := "if [ -f '/sdcard/a.txt' ] && [ -r '/sdcard/a.txt' ]; then head -c 65536 '/sdcard/a.txt'; else echo __gaze_preview_unreadable__; fi"
+	dirScript := "if [ -f '/sdcard' ] && [ -r '/sdcard' ]; then head -c 65536 '/sdcard'; else echo __gaze_preview_unreadable__; fi"
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC exec-out " + script:    "hello\r\nworld\n",
+		"-s R5CT1234ABC exec-out " + dirScript: "__gaze_preview_unreadable__\n",
+	})
+	if got, err := app.PreviewTextFile("R5CT1234ABC", "/sdcard/a.txt", 0); err != nil || got != "hello\r\nworld\n" {
+		t.Errorf("PreviewTextFile = %q, %v", got, err)
+	}
+	if _, err := app.PreviewTextFile("R5CT1234ABC", "/sdcard", 0); err == nil {
+		t.Error("expected an error for a directory")
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// Push with progress
+// ========================================
+
+// pushProgressInterval is how often the size of the upload on the device is polled.
+// adb only prints its own "[ NN%]" progress to a terminal, so it can't be read from a pipe.
+var pushProgressInterval = 500 * time.Millisecond
+
+// remotePushedBytes returns how much of an upload has reached the device: the file size
+// for a file, the disk usage (KiB granularity) for a directory
+func (a *App) remotePushedBytes(ctx context.Context, deviceId, remotePath string, isDir bool) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	command := "stat -c %s " + shellQuote(remotePath)
+	if isDir {
+		command = "du -sk " + shellQuote(remotePath)
+	}
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", command)
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("no size for %s", remotePath)
+	}
+	n, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("no size for %s: %s", remotePath, strings.TrimSpace(string(out)))
+	}
+	if isDir {
+		n *= 1024
+	}
+	return n, nil
+}
+
+// pushPercent converts bytes on the device into a percentage, holding at 99 until adb exits
+func pushPercent(done, total int64) int {
+	if total <= 0 {
+		return 0
+	}
+	pct := int(done * 100 / total)
+	if pct > 99 {
+		pct = 99
+	}
+	if pct < 0 {
+		pct = 0
+	}
+	return pct
+}
+
+// localPathSize returns the size of a file, or the total size of the files in a directory
+func localPathSize(localPath string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(localPath, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
+
+// PushFile uploads a local file or directory (recursively) into remoteDir on the device,
+// creating remoteDir first. Progress is reported through "push-progress" events and
+// the push can be aborted with CancelPush. Returns the remote path of the upload.
+func (a *App) PushFile(deviceId, localPath, remoteDir string) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+	a.updateLastActive(deviceId)
+
+	localPath = filepath.Clean(localPath)
+	totalBytes, err := localPathSize(localPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", localPath, err)
+	}
+	remoteDir = path.Clean("/" + remoteDir)
+	remotePath := path.Join(remoteDir, filepath.Base(localPath))
+
+	if err := a.Mkdir(deviceId, remoteDir); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", remoteDir, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The trailing slash makes adb put files and directories inside remoteDir
+	cmd := a.newAdbCommand(ctx, "-s", deviceId, "push", localPath, remoteDir+"/")
+
+	emit := func(percent int, pushed int64) {
+		if a.mcpMode || a.ctx == nil {
+			return
+		}
+		wailsRuntime.EventsEmit(a.ctx, "push-progress", map[string]interface{}{
+			"deviceId":   deviceId,
+			"localPath":  localPath,
+			"remotePath": remotePath,
+			"percent":    percent,
+			"bytes":      pushed,
+			"totalBytes": totalBytes,
+		})
+	}
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	// Start under the lock so CancelPush never sees a half-started process
+	a.pushMu.Lock()
+	if _, busy := a.pushCmds[localPath]; busy {
+		a.pushMu.Unlock()
+		return "", fmt.Errorf("%s is already being pushed", localPath)
+	}
+	if err := cmd.Start(); err != nil {
+		a.pushMu.Unlock()
+		return "", fmt.Errorf("failed to start adb push: %w", err)
+	}
+	a.pushCmds[localPath] = cmd
+	a.pushMu.Unlock()
+
+	defer func() {
+		a.pushMu.Lock()
+		if a.pushCmds[localPath] == cmd {
+			delete(a.pushCmds, localPath)
+		}
+		a.pushMu.Unlock()
+	}()
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	emit(0, 0)
+	isDir := false
+	if info, err := os.Stat(localPath); err == nil {
+		isDir = info.IsDir()
+	}
+	ticker := time.NewTicker(pushProgressInterval)
+	defer ticker.Stop()
+
+	lastPercent := 0
+	var waitErr error
+poll:
+	for {
+		select {
+		case waitErr = <-done:
+			break poll
+		case <-ticker.C:
+			n, err := a.remotePushedBytes(ctx, deviceId, remotePath, isDir)
+			if err != nil {
+				continue // not created on the device yet
+			}
+			if pct := pushPercent(n, totalBytes); pct > lastPercent {
+				lastPercent = pct
+				emit(pct, n)
+			}
+		}
+	}
+
+	if waitErr != nil {
+		a.pushMu.Lock()
+		cancelled := a.pushCmds[localPath] != cmd // CancelPush removed it
+		a.pushMu.Unlock()
+		if cancelled {
+			return "", fmt.Errorf("push cancelled")
+		}
+		return "", fmt.Errorf("failed to push %s: %w, output: %s", localPath, waitErr, strings.TrimSpace(output.String()))
+	}
+	emit(100, totalBytes)
+
+	a.Log("Pushed %s to %s (%d bytes)", localPath, remotePath, totalBytes)
+	return remotePath, nil
+}
+
+// CancelPush kills the adb push of localPath, if one is running
+func (a *App) CancelPush(localPath string) {
+	localPath = filepath.Clean(localPath)
+	a.pushMu.Lock()
+	defer a.pushMu.Unlock()
+	if cmd, exists := a.pushCmds[localPath]; exists {
+		if cmd.Process != nil {
+			_ = cmd.Process.Kill()
+		}
+		delete(a.pushCmds, localPath)
+	}
+}
+
+// stopAllPushCommands kills all in-flight adb push commands.
+func (a *App) stopAllPushCommands() {
+	a.pushMu.Lock()
+	defer a.pushMu.Unlock()
+
+	for localPath, cmd := range a.pushCmds {
+		if cmd != nil && cmd.Process != nil {
+			_ = cmd.Process.Kill()
+			LogInfo("shutdown").Str("path", localPath).Msg("Killed push command")
+		}
+	}
+	a.pushCmds = make(map[string]*exec.Cmd)
+}
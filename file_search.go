@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSearchResults = 200
+	maxSearchResults     = 2000
+	searchTimeout        = 30 * time.Second
+)
+
+// SearchFiles finds files and directories under rootPath whose name matches the
+// find(1) glob pattern (e.g. "*.apk"). Unreadable directories are skipped. If the
+// search times out, whatever was found so far is returned.
+func (a *App) SearchFiles(deviceId, rootPath, pattern string, maxResults int) ([]FileInfo, error) {
+	a.updateLastActive(deviceId)
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return nil, err
+	}
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, fmt.Errorf("search pattern is required")
+	}
+	if maxResults <= 0 {
+		maxResults = defaultSearchResults
+	}
+	if maxResults > maxSearchResults {
+		maxResults = maxSearchResults
+	}
+	rootPath = path.Clean("/" + rootPath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), searchTimeout)
+	defer cancel()
+
+	// ls each hit so the results carry size, mode and time like ListFiles entries.
+	// Sockets, pipes and device nodes are left out; permission errors go to /dev/null.
+	script := fmt.Sprintf(`find %s \( -type f -o -type d -o -type l \) -name %s 2>/dev/null | head -n %d | while IFS= read -r f; do ls -ld "$f"; done`,
+		shellQuote(rootPath), shellQuote(pattern), maxResults)
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", script)
+
+	results := parseSearchOutput(string(output))
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			if len(results) > 0 {
+				return results, nil
+			}
+			return nil, fmt.Errorf("search timed out after %v", searchTimeout)
+		}
+		if len(results) == 0 {
+			return nil, fmt.Errorf("failed to search files: %w (output: %s)", err, strings.TrimSpace(string(output)))
+		}
+	}
+	return results, nil
+}
+
+// parseSearchOutput parses `ls -ld <absolute path>` lines, one per search hit
+func parseSearchOutput(output string) []FileInfo {
+	files := parseLsOutput(output, "/")
+	results := make([]FileInfo, 0, len(files))
+	for _, f := range files {
+		if strings.Contains(f.Name, "Permission denied") || !strings.HasPrefix(f.Name, "/") {
+			continue
+		}
+		f.Path = path.Clean(f.Name)
+		f.Name = path.Base(f.Path)
+		results = append(results, f)
+	}
+	return results
+}
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestSearchFiles(t *testing.T) {
+	script := `find '/sdcard' \( -type f -o -type d -o -type l \) -name '*.apk' 2>/dev/null | head -n 200 | while IFS= read -r f; do ls -ld "$f"; done`
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell " + script: "-rw-rw---- 1 u0_a123 media_rw 52428800 2024-03-05 09:01 /sdcard/Download/my app.apk\n" +
+			"ls: /sdcard/Android/data/x: Permission denied\n" +
+			"drwxrws--- 2 u0_a123 media_rw 3452 2024-03-04 18:22 /sdcard/Backups/old.apk\n",
+	})
+
+	files, err := app.SearchFiles("R5CT1234ABC", "/sdcard/", "*.apk", 0)
+	if err != nil {
+		t.Fatalf("SearchFiles: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("got %d results: %+v", len(files), files)
+	}
+	if files[0].Name != "my app.apk" || files[0].Path != "/sdcard/Download/my app.apk" || files[0].Size != 52428800 {
+		t.Errorf("first result = %+v", files[0])
+	}
+	if !files[1].IsDir || files[1].Path != "/sdcard/Backups/old.apk" {
+		t.Errorf("second result = %+v", files[1])
+	}
+
+	if _, err := app.SearchFiles("R5CT1234ABC", "/sdcard", " ", 0); err == nil {
+		t.Error("expected an error for an empty pattern")
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// transferHashes are tried in order; old devices may ship only one of the tools,
+// sometimes only as a toybox or busybox applet
+var transferHashes = []struct {
+	tool   string
+	newFn  func() hash.Hash
+	hexLen int
+}{
+	{"md5sum", md5.New, 32},
+	{"sha1sum", sha1.New, 40},
+}
+
+// VerifyTransfer compares the checksum of a local file with the same file on the
+// device, returning true when they match
+func (a *App) VerifyTransfer(deviceId, localPath, remotePath string) (bool, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return false, err
+	}
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", localPath, err)
+	}
+	if info.IsDir() {
+		return false, fmt.Errorf("only files can be verified")
+	}
+	remotePath = path.Clean("/" + remotePath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	for _, h := range transferHashes {
+		remote, ok := a.remoteChecksum(ctx, deviceId, remotePath, h.tool, h.hexLen)
+		if !ok {
+			continue
+		}
+		local, err := fileChecksum(localPath, h.newFn())
+		if err != nil {
+			return false, err
+		}
+		match := local == remote
+		if !match {
+			LogWarn("files").Str("device", deviceId).Str("local", localPath).Str("remote", remotePath).
+				Str("algorithm", h.tool).Str("localHash", local).Str("remoteHash", remote).Msg("Transfer checksum mismatch")
+		}
+		return match, nil
+	}
+	if ctx.Err() != nil {
+		return false, fmt.Errorf("checksum timed out")
+	}
+	return false, fmt.Errorf("could not checksum %s on the device (no md5sum or sha1sum, or the file is unreadable)", remotePath)
+}
+
+// remoteChecksum runs tool on the device, falling back to the toybox and busybox applets
+func (a *App) remoteChecksum(ctx context.Context, deviceId, remotePath, tool string, hexLen int) (string, bool) {
+	q := shellQuote(remotePath)
+	script := fmt.Sprintf("%[1]s %[2]s 2>/dev/null || toybox %[1]s %[2]s 2>/dev/null || busybox %[1]s %[2]s 2>/dev/null", tool, q)
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", script)
+	if err != nil && len(out) == 0 {
+		return "", false
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 || len(fields[0]) != hexLen {
+		return "", false
+	}
+	sum := strings.ToLower(fields[0])
+	if _, err := hex.DecodeString(sum); err != nil {
+		return "", false
+	}
+	return sum, true
+}
+
+// fileChecksum hashes a local file and returns the lowercase hex digest
+func fileChecksum(localPath string, h hash.Hash) (string, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", localPath, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", localPath, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyTransfer(t *testing.T) {
+	local := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(local, []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	md5Script := "md5sum '/sdcard/hello.txt' 2>/dev/null || toybox md5sum '/sdcard/hello.txt' 2>/dev/null || busybox md5sum '/sdcard/hello.txt' 2>/dev/null"
+	sha1Script := "sha1sum '/sdcard/old.txt' 2>/dev/null || toybox sha1sum '/sdcard/old.txt' 2>/dev/null || busybox sha1sum '/sdcard/old.txt' 2>/dev/null"
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell " + md5Script: "b1946ac92492d2347c6235b4d2611184  /sdcard/hello.txt\n",
+		// No md5sum on this device, sha1sum of different content
+		"-s R5CT1234ABC shell " + sha1Script: "0000000000000000000000000000000000000000  /sdcard/old.txt\n",
+	})
+
+	if ok, err := app.VerifyTransfer("R5CT1234ABC", local, "/sdcard/hello.txt"); err != nil || !ok {
+		t.Errorf("matching md5: ok=%v err=%v", ok, err)
+	}
+	if ok, err := app.VerifyTransfer("R5CT1234ABC", local, "/sdcard/old.txt"); err != nil || ok {
+		t.Errorf("sha1 fallback mismatch: ok=%v err=%v", ok, err)
+	}
+	if _, err := app.VerifyTransfer("R5CT1234ABC", local, "/sdcard/missing.txt"); err == nil {
+		t.Error("expected an error when the device can't checksum the file")
+	}
+}
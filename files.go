@@ -16,7 +16,9 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 
 	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
@@ -27,41 +29,54 @@ func (a *App) ListFiles(deviceId, pathStr string) ([]FileInfo, error) {
 	if err := ValidateDeviceID(deviceId); err != nil {
 		return nil, err
 	}
+	return a.listDir(nil, deviceId, pathStr)
+}
 
+// listDir lists a single directory; ctx may be nil
+func (a *App) listDir(ctx context.Context, deviceId, pathStr string) ([]FileInfo, error) {
 	pathStr = path.Clean("/" + pathStr)
 	cmdPath := pathStr
 	if cmdPath != "/" {
 		cmdPath += "/"
 	}
 
-	cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "ls", "-la", "\""+cmdPath+"\"")
-	output, err := cmd.CombinedOutput()
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "ls", "-la", shellQuote(cmdPath))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list files: %w (output: %s)", err, string(output))
 	}
 
-	dateTimeRegex := regexp.MustCompile(`(\d{4}-\d{2}-\d{2}\s+\d{2}:\d{2})|([A-Z][a-z]{2}\s+\d{1,2}\s+(\d{2}:\d{2}|\d{4}))`)
+	return parseLsOutput(string(output), pathStr), nil
+}
+
+var (
+	// Anchors on the timestamp column, which is followed by exactly one space and the name:
+	// toybox "2024-03-05 09:01", toolbox "2015-03-05 09:01", busybox "Mar  5 09:01" / "Mar  5  2023"
+	lsDateTimeRegex = regexp.MustCompile(`\s((?:\d{4}-\d{2}-\d{2}\s+\d{2}:\d{2}(?::\d{2}(?:\.\d+)?)?(?:\s+[+-]\d{4})?)|(?:[A-Z][a-z]{2}\s+\d{1,2}\s+(?:\d{2}:\d{2}|\d{4}))) `)
+	lsModeRegex     = regexp.MustCompile(`^[-dlcbps?][-rwxsStTl?]{9}[+.@]?$`)
+)
 
-	lines := strings.Split(string(output), "\n")
+// parseLsOutput parses `ls -la` output from toybox, toolbox or busybox. Fields before
+// the timestamp vary (toolbox has no link count, and no size for directories and
+// links), so the line is split at the timestamp and the name is taken verbatim after
+// it, keeping leading, trailing and embedded spaces.
+func parseLsOutput(output, dirPath string) []FileInfo {
 	var files []FileInfo
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "total ") {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "total ") {
 			continue
 		}
 
-		loc := dateTimeRegex.FindStringIndex(line)
+		loc := lsDateTimeRegex.FindStringSubmatchIndex(line)
 		if loc == nil {
 			continue
 		}
 
-		modTime := line[loc[0]:loc[1]]
-		afterDateTime := strings.TrimSpace(line[loc[1]:])
-		beforeDateTime := strings.TrimSpace(line[:loc[0]])
-		beforeParts := strings.Fields(beforeDateTime)
-
-		if len(beforeParts) < 1 {
+		modTime := line[loc[2]:loc[3]]
+		name := line[loc[1]:]
+		beforeParts := strings.Fields(line[:loc[0]])
+		if len(beforeParts) < 1 || !lsModeRegex.MatchString(beforeParts[0]) {
 			continue
 		}
 
@@ -69,12 +84,12 @@ func (a *App) ListFiles(deviceId, pathStr string) ([]FileInfo, error) {
 		isDir := strings.HasPrefix(mode, "d")
 		isLink := strings.HasPrefix(mode, "l")
 
+		// Symlinks report the target length and device nodes "major, minor"; neither is a file size
 		var size int64
-		if len(beforeParts) >= 1 {
-			fmt.Sscanf(beforeParts[len(beforeParts)-1], "%d", &size)
+		if mode[0] == '-' || isDir {
+			size, _ = strconv.ParseInt(beforeParts[len(beforeParts)-1], 10, 64)
 		}
 
-		name := afterDateTime
 		if isLink {
 			arrowIdx := strings.Index(name, " -> ")
 			if arrowIdx != -1 {
@@ -83,26 +98,26 @@ func (a *App) ListFiles(deviceId, pathStr string) ([]FileInfo, error) {
 			isDir = true
 		}
 
-		cleanName := strings.TrimSpace(name)
-		if cleanName == "." || cleanName == ".." || cleanName == "" || cleanName == "?" {
+		if name == "." || name == ".." || name == "" || name == "?" {
 			continue
 		}
 
-		if cleanName == path.Base(pathStr) || cleanName == pathStr {
+		// Listing a symlink without following it prints the path itself
+		if name == dirPath || name == dirPath+"/" {
 			continue
 		}
 
 		files = append(files, FileInfo{
-			Name:    cleanName,
+			Name:    name,
 			Size:    size,
 			Mode:    mode,
 			ModTime: modTime,
 			IsDir:   isDir,
-			Path:    path.Join(pathStr, cleanName),
+			Path:    path.Join(dirPath, name),
 		})
 	}
 
-	return files, nil
+	return files
 }
 
 // DownloadFile pulls a file from the device to a user-selected local path
@@ -112,11 +127,7 @@ func (a *App) DownloadFile(deviceId, remotePath string) (string, error) {
 	}
 
 	fileName := path.Base(remotePath)
-	defaultDir, _ := os.UserHomeDir()
-	downloadsDir := filepath.Join(defaultDir, "Downloads")
-	if _, err := os.Stat(downloadsDir); err == nil {
-		defaultDir = downloadsDir
-	}
+	defaultDir := a.outputDir(OutputDownloads)
 
 	savePath, err := wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
 		DefaultFilename:  fileName,
@@ -157,12 +168,15 @@ func (a *App) UploadFile(deviceId, localPath, remotePath string) error {
 	return nil
 }
 
-// DeleteFile deletes a file or directory on the device
-func (a *App) DeleteFile(deviceId, pathStr string) error {
+// DeleteFile deletes a file or directory on the device. See UninstallApp for expectedSerial.
+func (a *App) DeleteFile(deviceId, pathStr, expectedSerial string) error {
 	a.updateLastActive(deviceId)
 	if deviceId == "" {
 		return fmt.Errorf("no device specified")
 	}
+	if err := a.verifyExpectedSerial(deviceId, expectedSerial); err != nil {
+		return err
+	}
 	pathStr = path.Clean("/" + pathStr)
 	cmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "rm", "-rf", "\""+pathStr+"\"")
 	output, err := cmd.CombinedOutput()
@@ -279,11 +293,21 @@ func (a *App) GetThumbnail(deviceId, remotePath, modTime string) (string, error)
 	if deviceId == "" {
 		return "", fmt.Errorf("no device specified")
 	}
+	return a.thumbnail(nil, deviceId, remotePath, modTime)
+}
 
-	ext := strings.ToLower(filepath.Ext(remotePath))
-	isImage := ext == ".jpg" || ext == ".jpeg" || ext == ".png" || ext == ".webp" || ext == ".gif"
-	isVideo := ext == ".mp4" || ext == ".mkv" || ext == ".mov" || ext == ".avi"
+// thumbnailMediaType reports whether a file name is an image or video GetThumbnail supports
+func thumbnailMediaType(name string) (isImage, isVideo bool) {
+	ext := strings.ToLower(filepath.Ext(name))
+	isImage = ext == ".jpg" || ext == ".jpeg" || ext == ".png" || ext == ".webp" || ext == ".gif"
+	isVideo = ext == ".mp4" || ext == ".mkv" || ext == ".mov" || ext == ".avi"
+	return
+}
 
+// thumbnail returns a cached thumbnail or pulls the file and generates one; ctx may be nil
+func (a *App) thumbnail(ctx context.Context, deviceId, remotePath, modTime string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(remotePath))
+	isImage, isVideo := thumbnailMediaType(remotePath)
 	if !isImage && !isVideo {
 		return "", fmt.Errorf("unsupported file type")
 	}
@@ -307,9 +331,8 @@ func (a *App) GetThumbnail(deviceId, remotePath, modTime string) (string, error)
 	localPath := filepath.Join(tmpDir, cacheKey+ext)
 	defer os.Remove(localPath)
 
-	pullCmd := a.newAdbCommand(nil, "-s", deviceId, "pull", remotePath, localPath)
-	if err := pullCmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to pull file: %w", err)
+	if output, err := a.runAdbCombined(ctx, "-s", deviceId, "pull", remotePath, localPath); err != nil {
+		return "", fmt.Errorf("failed to pull file: %w (output: %s)", err, strings.TrimSpace(string(output)))
 	}
 
 	var thumbData []byte
@@ -330,6 +353,110 @@ func (a *App) GetThumbnail(deviceId, remotePath, modTime string) (string, error)
 	return "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(thumbData), nil
 }
 
+// Running pregeneration passes, one per device
+var (
+	thumbnailPregens   = make(map[string]*thumbnailPregen)
+	thumbnailPregensMu sync.Mutex
+)
+
+type thumbnailPregen struct {
+	cancel context.CancelFunc
+}
+
+// PregenerateThumbnails generates and caches thumbnails for every image and video in
+// remoteDir in the background, emitting "thumbnail-ready" as each one completes.
+// Starting a pass cancels the device's previous one. Returns the number of media files.
+func (a *App) PregenerateThumbnails(deviceId, remoteDir string) (int, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return 0, err
+	}
+	files, err := a.listDir(nil, deviceId, remoteDir)
+	if err != nil {
+		return 0, err
+	}
+
+	var media []FileInfo
+	for _, f := range files {
+		if f.IsDir {
+			continue
+		}
+		if isImage, isVideo := thumbnailMediaType(f.Name); isImage || isVideo {
+			media = append(media, f)
+		}
+	}
+
+	parent := a.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	pg := &thumbnailPregen{cancel: cancel}
+
+	thumbnailPregensMu.Lock()
+	if prev, ok := thumbnailPregens[deviceId]; ok {
+		prev.cancel()
+	}
+	thumbnailPregens[deviceId] = pg
+	thumbnailPregensMu.Unlock()
+
+	go func() {
+		defer func() {
+			thumbnailPregensMu.Lock()
+			if thumbnailPregens[deviceId] == pg {
+				delete(thumbnailPregens, deviceId)
+			}
+			thumbnailPregensMu.Unlock()
+			cancel()
+		}()
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, a.GetConcurrency().Thumbnails)
+		for _, f := range media {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				break
+			}
+			wg.Add(1)
+			go func(f FileInfo) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				thumb, err := a.thumbnail(ctx, deviceId, f.Path, f.ModTime)
+				if err != nil {
+					if ctx.Err() == nil {
+						LogDebug("files").Err(err).Str("path", f.Path).Msg("Thumbnail pregeneration failed")
+					}
+					return
+				}
+				if ctx.Err() == nil && !a.mcpMode && a.ctx != nil {
+					wailsRuntime.EventsEmit(a.ctx, "thumbnail-ready", map[string]string{
+						"deviceId":  deviceId,
+						"path":      f.Path,
+						"thumbnail": thumb,
+					})
+				}
+			}(f)
+		}
+		wg.Wait()
+		LogDebug("files").Str("deviceId", deviceId).Str("dir", remoteDir).Int("files", len(media)).Bool("cancelled", ctx.Err() != nil).Msg("Thumbnail pregeneration finished")
+	}()
+
+	return len(media), nil
+}
+
+// CancelThumbnailPregeneration stops the device's running pregeneration pass, if any
+func (a *App) CancelThumbnailPregeneration(deviceId string) {
+	thumbnailPregensMu.Lock()
+	defer thumbnailPregensMu.Unlock()
+	if pg, ok := thumbnailPregens[deviceId]; ok {
+		pg.cancel()
+		delete(thumbnailPregens, deviceId)
+	}
+}
+
 func (a *App) generateImageThumbnail(path string) ([]byte, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -389,3 +516,8 @@ func (a *App) generateVideoThumbnail(localPath string) ([]byte, error) {
 
 	return os.ReadFile(tmpThumb)
 }
+
+// shellQuote single-quotes a string for use in a device shell command line
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// TestParseLsOutputGolden parses captured `ls -la` output from testdata/ls/*.txt and
+// compares it with the matching .golden.json. Run with -update after intended changes.
+func TestParseLsOutputGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "ls", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no testdata/ls inputs found")
+	}
+
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".txt")
+		t.Run(name, func(t *testing.T) {
+			raw, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := json.MarshalIndent(parseLsOutput(string(raw), "/sdcard"), "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			goldenPath := strings.TrimSuffix(input, ".txt") + ".golden.json"
+			if *updateGolden {
+				if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("missing golden file (run with -update): %v", err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("parseLsOutput mismatch for %s\ngot:\n%s\nwant:\n%s", input, got, want)
+			}
+		})
+	}
+}
+
+func TestRemotePushedBytes(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s dev1 shell stat -c %s '/sdcard/Download/a.mp4'": "524288\n",
+		"-s dev1 shell du -sk '/sdcard/Download/photos'":    "1536\t/sdcard/Download/photos\n",
+		"-s dev1 shell stat -c %s '/sdcard/Download/b.mp4'": "stat: '/sdcard/Download/b.mp4': No such file or directory\n",
+	})
+
+	if n, err := app.remotePushedBytes(context.Background(), "dev1", "/sdcard/Download/a.mp4", false); err != nil || n != 524288 {
+		t.Errorf("file size = %d, %v; want 524288", n, err)
+	}
+	if n, err := app.remotePushedBytes(context.Background(), "dev1", "/sdcard/Download/photos", true); err != nil || n != 1536*1024 {
+		t.Errorf("dir size = %d, %v; want %d", n, err, 1536*1024)
+	}
+	if _, err := app.remotePushedBytes(context.Background(), "dev1", "/sdcard/Download/b.mp4", false); err == nil {
+		t.Error("expected an error before the file exists")
+	}
+}
+
+func TestPushPercent(t *testing.T) {
+	cases := []struct {
+		done, total int64
+		want        int
+	}{
+		{0, 1000, 0},
+		{420, 1000, 42},
+		{1000, 1000, 99}, // 100 only once adb exits
+		{1100, 1000, 99}, // du rounds up to whole KiB
+		{10, 0, 0},
+	}
+	for _, c := range cases {
+		if got := pushPercent(c.done, c.total); got != c.want {
+			t.Errorf("pushPercent(%d, %d) = %d, want %d", c.done, c.total, got, c.want)
+		}
+	}
+}
+
+func TestLocalPathSize(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.txt"), make([]byte, 100), 0644)
+	os.MkdirAll(filepath.Join(dir, "sub", "deeper"), 0755)
+	os.WriteFile(filepath.Join(dir, "sub", "deeper", "b.bin"), make([]byte, 250), 0644)
+
+	if n, err := localPathSize(dir); err != nil || n != 350 {
+		t.Errorf("dir size = %d, %v; want 350", n, err)
+	}
+	if n, err := localPathSize(filepath.Join(dir, "a.txt")); err != nil || n != 100 {
+		t.Errorf("file size = %d, %v; want 100", n, err)
+	}
+	if _, err := localPathSize(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing path")
+	}
+}
+
+const pregenLsOutput = `total 48
+drwxrwx--x  2 root    sdcard_rw    4096 2024-03-02 18:40 Camera
+-rw-rw----  1 u0_a123 sdcard_rw    2048 2024-03-05 09:01 IMG_0001.jpg
+-rw-rw----  1 u0_a123 sdcard_rw    2048 2024-03-05 09:02 Screenshot.PNG
+-rw-rw----  1 u0_a123 sdcard_rw    1832 2024-03-05 09:03 notes.txt
+`
+
+// pregenRunner lists pregenLsOutput and answers pulls with a small PNG. With block
+// set, pulls wait until their context is cancelled instead.
+type pregenRunner struct {
+	block bool
+
+	mu     sync.Mutex
+	pulls  []string
+	active int
+}
+
+func (r *pregenRunner) Run(ctx context.Context, name string, args []string) ([]byte, []byte, error) {
+	key := strings.Join(args, " ")
+	if strings.HasPrefix(key, "-s R5CT1234ABC shell ls -la ") {
+		return []byte(pregenLsOutput), nil, nil
+	}
+	if len(args) != 5 || args[2] != "pull" {
+		return nil, nil, fmt.Errorf("pregenRunner: unexpected command %q", key)
+	}
+
+	r.mu.Lock()
+	r.pulls = append(r.pulls, args[3])
+	r.active++
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.active--
+		r.mu.Unlock()
+	}()
+
+	if r.block {
+		<-ctx.Done()
+		return nil, nil, ctx.Err()
+	}
+	f, err := os.Create(args[4])
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return nil, nil, png.Encode(f, image.NewRGBA(image.Rect(0, 0, 4, 4)))
+}
+
+func (r *pregenRunner) snapshot() (pulls []string, active int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.pulls...), r.active
+}
+
+// waitFor polls cond until it holds or the deadline passes
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func pregenRunning(deviceId string) bool {
+	thumbnailPregensMu.Lock()
+	defer thumbnailPregensMu.Unlock()
+	_, ok := thumbnailPregens[deviceId]
+	return ok
+}
+
+func TestPregenerateThumbnails(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+
+	runner := &pregenRunner{}
+	a := newTestApp(nil)
+	a.runner = runner
+
+	if _, err := a.PregenerateThumbnails("bad id;", "/sdcard/DCIM"); err == nil {
+		t.Error("expected an error for an invalid device ID")
+	}
+
+	n, err := a.PregenerateThumbnails("R5CT1234ABC", "/sdcard/DCIM")
+	if err != nil {
+		t.Fatalf("PregenerateThumbnails: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("media files = %d, want 2", n)
+	}
+	waitFor(t, "the pass to finish", func() bool { return !pregenRunning("R5CT1234ABC") })
+
+	pulls, _ := runner.snapshot()
+	got := strings.Join(pulls, ",")
+	if len(pulls) != 2 || !strings.Contains(got, "/sdcard/DCIM/IMG_0001.jpg") || !strings.Contains(got, "/sdcard/DCIM/Screenshot.PNG") {
+		t.Errorf("pulled %v, want only the two images", pulls)
+	}
+
+	userConfig, err := os.UserConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached, _ := filepath.Glob(filepath.Join(userConfig, "Gaze", "thumbnails", "*.jpg"))
+	if len(cached) != 2 {
+		t.Errorf("cached thumbnails = %d, want 2", len(cached))
+	}
+
+	// A second pass is served from the cache without pulling again
+	if _, err := a.PregenerateThumbnails("R5CT1234ABC", "/sdcard/DCIM"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the cached pass to finish", func() bool { return !pregenRunning("R5CT1234ABC") })
+	if pulls, _ := runner.snapshot(); len(pulls) != 2 {
+		t.Errorf("cached pass pulled again: %v", pulls)
+	}
+}
+
+func TestPregenerateThumbnailsCancel(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+
+	runner := &pregenRunner{block: true}
+	a := newTestApp(nil)
+	a.runner = runner
+
+	if _, err := a.PregenerateThumbnails("R5CT1234ABC", "/sdcard/DCIM"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the first pass to start pulling", func() bool { _, active := runner.snapshot(); return active > 0 })
+	thumbnailPregensMu.Lock()
+	first := thumbnailPregens["R5CT1234ABC"]
+	thumbnailPregensMu.Unlock()
+
+	// Starting another pass for the device cancels the first
+	if _, err := a.PregenerateThumbnails("R5CT1234ABC", "/sdcard/DCIM"); err != nil {
+		t.Fatal(err)
+	}
+	thumbnailPregensMu.Lock()
+	second := thumbnailPregens["R5CT1234ABC"]
+	thumbnailPregensMu.Unlock()
+	if second == nil || second == first {
+		t.Fatal("the new pass should replace the running one")
+	}
+
+	a.CancelThumbnailPregeneration("R5CT1234ABC")
+	if pregenRunning("R5CT1234ABC") {
+		t.Error("cancel should remove the running pass")
+	}
+	waitFor(t, "cancelled pulls to return", func() bool { _, active := runner.snapshot(); return active == 0 })
+	a.CancelThumbnailPregeneration("R5CT1234ABC") // no pass running: no-op
+}
@@ -63,7 +63,7 @@ import {
 } from "./stores";
 import CommandPalette from "./components/CommandPalette";
 // @ts-ignore
-import { OpenPath, SetProxyDevice } from "../wailsjs/go/main/App";
+import { OpenPath, SetProxyDevice, GetBinaryExtractErrors } from "../wailsjs/go/main/App";
 
 // @ts-ignore
 const BrowserOpenURL = (window as any).runtime.BrowserOpenURL;
@@ -175,6 +175,18 @@ function App() {
       });
     });
 
+    // Warn if a bundled binary could not be updated (e.g. adb.exe still in use on Windows)
+    GetBinaryExtractErrors().then((errs) => {
+      const names = Object.keys(errs || {});
+      if (names.length > 0) {
+        notification.warning({
+          message: t("app.binary_extract_failed"),
+          description: t("app.binary_extract_failed_desc", { names: names.join(", ") }),
+          duration: 0,
+        });
+      }
+    }).catch(() => {});
+
     // UI events subscription
     const unsubUI = subscribeUIEvents((deviceId) => {
       setSelectedDevice(deviceId);
@@ -203,6 +215,17 @@ function App() {
     return () => unregister();
   }, [showAbout]);
 
+  // Wireless auto-reconnect gave up on a device
+  useEffect(() => {
+    const unregister = EventsOn("wireless-give-up", (stats: any) => {
+      message.warning({
+        content: t("app.wireless_give_up", { address: stats.address, count: stats.failures }),
+        duration: 8,
+      });
+    });
+    return () => unregister();
+  }, [t]);
+
   // Screenshot progress listener
   useEffect(() => {
     const msgKey = "screenshot-msg";
@@ -217,6 +240,9 @@ function App() {
         case "screenshot_off":
           message.warning({ content: t("app.screenshot_off"), key: msgKey });
           break;
+        case "screenshot_secure":
+          message.warning({ content: t("app.screenshot_secure"), key: msgKey, duration: 6 });
+          break;
         default:
           message.loading({ content: t(`app.${stepKey}`), key: msgKey, duration: 0 });
       }
@@ -224,6 +250,14 @@ function App() {
     return () => unregister();
   }, [t]);
 
+  // Corrupt screencap output (CRLF-mangled PNG) that was fixed up automatically
+  useEffect(() => {
+    const unregister = EventsOn("screenshot-corrected", () => {
+      message.warning({ content: t("app.screenshot_corrected"), key: "screenshot-corrected", duration: 5 });
+    });
+    return () => unregister();
+  }, [t]);
+
   // Duration update timer
   useEffect(() => {
     const timer = setInterval(updateDurations, 1000);
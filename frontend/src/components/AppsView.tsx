@@ -1,9 +1,10 @@
-import React, { useEffect, useRef } from "react";
+import React, { useEffect, useRef, useState } from "react";
 import {
   Button,
   Space,
   Input,
   Radio,
+  Select,
   Tag,
   Dropdown,
   theme,
@@ -28,6 +29,7 @@ import {
   SettingOutlined,
   CloudUploadOutlined,
   LoadingOutlined,
+  PauseCircleOutlined,
 } from "@ant-design/icons";
 import DeviceSelector from "./DeviceSelector";
 import AppInfoModal from "./AppInfoModal";
@@ -43,9 +45,14 @@ import {
   StartApp,
   EnableApp,
   DisableApp,
+  SuspendApp,
+  UnsuspendApp,
+  IsAppSuspended,
   ExportAPK,
   OpenSettings,
   InstallPackage,
+  InstallAPKs,
+  ListUsers,
 } from "../../wailsjs/go/main/App";
 // @ts-ignore
 import { OnFileDrop, OnFileDropOff } from "../../wailsjs/runtime/runtime";
@@ -57,10 +64,21 @@ const AppsView: React.FC = () => {
   const { token } = theme.useToken();
   const { isDark } = useTheme();
   const { modal, message } = App.useApp();
-  const { selectedDevice } = useDeviceStore();
+  const { selectedDevice, devices } = useDeviceStore();
+  // Serial shown to the user; the backend refuses destructive actions if the id now maps elsewhere
+  const selectedSerial = devices.find((d) => d.id === selectedDevice)?.serial || "";
   const { setSelectedPackage, setLogFilter, toggleLogcat, stopLogcat, isLogging } = useLogcatStore();
   const { setSelectedKey } = useUIStore();
   const containerRef = useRef<HTMLDivElement>(null);
+  // Android user / work profile the package operations target (0 = owner)
+  const [users, setUsers] = useState<main.AndroidUser[]>([]);
+  const [selectedUser, setSelectedUser] = useState(0);
+  // Suspend state per package, queried when its action menu opens
+  const [suspended, setSuspended] = useState<Record<string, boolean>>({});
+
+  useEffect(() => {
+    setSuspended({});
+  }, [selectedDevice]);
 
   // Use appsStore instead of useState
   const {
@@ -120,7 +138,7 @@ const AppsView: React.FC = () => {
 
     try {
       console.log("[AppsView] Calling InstallPackage...");
-      const result = await InstallPackage(selectedDevice, packagePath);
+      const result = await InstallPackage(selectedDevice, packagePath, selectedUser);
       console.log("[AppsView] InstallPackage result:", result);
       if (result.toLowerCase().includes("success")) {
         message.success(t("apps.install_success", { name: fileName }) || `Successfully installed ${fileName}`);
@@ -140,6 +158,31 @@ const AppsView: React.FC = () => {
     }
   };
 
+  // Install several dropped APKs in one batch; split APKs are installed together
+  const handleInstallAPKs = async (apkPaths: string[]) => {
+    if (!selectedDevice) {
+      message.error(t("apps.no_device_selected") || "Please select a device first");
+      return;
+    }
+
+    setIsInstalling(true, `${apkPaths.length} APKs`);
+    try {
+      const summary = await InstallAPKs(selectedDevice, apkPaths);
+      if (summary.succeeded > 0) {
+        message.success(t("apps.install_batch_result", { succeeded: summary.succeeded, total: summary.total }));
+        setTimeout(() => fetchPackages(typeFilter, selectedDevice), 1000);
+      }
+      for (const r of summary.results.filter((r) => !r.success)) {
+        const fileName = r.path.split(/[\\/]/).pop() || r.path;
+        message.error(`${t("apps.install_failed")}: ${fileName} (${r.reason})`, 8);
+      }
+    } catch (err) {
+      message.error(t("apps.install_failed") + ": " + String(err));
+    } finally {
+      setIsInstalling(false);
+    }
+  };
+
   // Listen for file drop events
   useEffect(() => {
     const handleFileDrop = (_x: number, _y: number, paths: string[]) => {
@@ -149,19 +192,28 @@ const AppsView: React.FC = () => {
       // Filter for installable package files (APK, XAPK, AAB)
       const packageFiles = paths.filter(path => {
         const lowerPath = path.toLowerCase();
-        return lowerPath.endsWith(".apk") || lowerPath.endsWith(".xapk") || lowerPath.endsWith(".aab");
+        return lowerPath.endsWith(".apk") || lowerPath.endsWith(".xapk") || lowerPath.endsWith(".apks") || lowerPath.endsWith(".aab");
       });
       
       console.log("[AppsView] Filtered package files:", packageFiles);
       
       if (packageFiles.length === 0) {
-        message.warning(t("apps.no_package_files") || "No installable files found (supported: .apk, .xapk, .aab)");
+        message.warning(t("apps.no_package_files") || "No installable files found (supported: .apk, .apks, .xapk, .aab)");
         return;
       }
 
+      // Several plain APKs for the device owner go through one batch install, which
+      // detects split APKs; everything else is installed one by one
+      const apkFiles = packageFiles.filter((p) => p.toLowerCase().endsWith(".apk"));
+      const batchAPKs = selectedUser === 0 && apkFiles.length > 1;
+      const singleFiles = batchAPKs ? packageFiles.filter((p) => !apkFiles.includes(p)) : packageFiles;
+
       // Install package files sequentially
       const installSequentially = async () => {
-        for (const packagePath of packageFiles) {
+        if (batchAPKs) {
+          await handleInstallAPKs(apkFiles);
+        }
+        for (const packagePath of singleFiles) {
           console.log("[AppsView] Installing:", packagePath);
           await handleInstallPackage(packagePath);
         }
@@ -178,7 +230,7 @@ const AppsView: React.FC = () => {
       console.log("[AppsView] Unregistering OnFileDrop handler");
       OnFileDropOff();
     };
-  }, [selectedDevice, typeFilter, t]);
+  }, [selectedDevice, typeFilter, selectedUser, t]);
 
   // Handle drag visual feedback
   useEffect(() => {
@@ -246,13 +298,13 @@ const AppsView: React.FC = () => {
     };
   }, []);
 
-  const fetchPackages = async (packageType?: string, deviceId?: string) => {
+  const fetchPackages = async (packageType?: string, deviceId?: string, userId?: number) => {
     const targetDevice = deviceId || selectedDevice;
     if (!targetDevice) return;
     const typeToFetch = packageType || typeFilter;
     setAppsLoading(true);
     try {
-      const res = await ListPackages(targetDevice, typeToFetch);
+      const res = await ListPackages(targetDevice, typeToFetch, userId ?? selectedUser);
       if (typeToFetch === "all") {
         setPackages(res || []);
       } else if (typeToFetch === "system") {
@@ -269,14 +321,19 @@ const AppsView: React.FC = () => {
   };
 
   useEffect(() => {
+    setSelectedUser(0);
+    setUsers([]);
     if (selectedDevice) {
-      fetchPackages();
+      fetchPackages(undefined, selectedDevice, 0);
+      ListUsers(selectedDevice)
+        .then((res: main.AndroidUser[]) => setUsers(res || []))
+        .catch(() => setUsers([]));
     }
   }, [selectedDevice]);
 
   const handleUninstall = async (packageName: string) => {
     try {
-      await UninstallApp(selectedDevice, packageName);
+      await UninstallApp(selectedDevice, packageName, selectedSerial, selectedUser);
       message.success(t("app.uninstall_success", { name: packageName }));
       fetchPackages(typeFilter, selectedDevice);
     } catch (err) {
@@ -286,7 +343,7 @@ const AppsView: React.FC = () => {
 
   const handleClearData = async (packageName: string) => {
     try {
-      await ClearAppData(selectedDevice, packageName);
+      await ClearAppData(selectedDevice, packageName, selectedSerial);
       message.success(t("app.clear_data_success", { name: packageName }));
     } catch (err) {
       message.error(t("app.clear_data_failed") + ": " + String(err));
@@ -318,10 +375,10 @@ const AppsView: React.FC = () => {
   const handleToggleState = async (packageName: string, currentState: string) => {
     try {
       if (currentState === "enabled") {
-        await DisableApp(selectedDevice, packageName);
+        await DisableApp(selectedDevice, packageName, selectedUser);
         message.success(t("app.disabled_success", { name: packageName }));
       } else {
-        await EnableApp(selectedDevice, packageName);
+        await EnableApp(selectedDevice, packageName, selectedUser);
         message.success(t("app.enabled_success", { name: packageName }));
       }
       fetchPackages(typeFilter, selectedDevice);
@@ -330,6 +387,30 @@ const AppsView: React.FC = () => {
     }
   };
 
+  const refreshSuspended = async (packageName: string) => {
+    try {
+      const isSuspended = await IsAppSuspended(selectedDevice, packageName);
+      setSuspended((prev) => ({ ...prev, [packageName]: isSuspended }));
+    } catch {
+      // Leave the state unknown; the menu then offers neither action
+    }
+  };
+
+  const handleSuspend = async (packageName: string, suspend: boolean) => {
+    try {
+      if (suspend) {
+        await SuspendApp(selectedDevice, packageName);
+        message.success(t("app.suspended_success", { name: packageName }));
+      } else {
+        await UnsuspendApp(selectedDevice, packageName);
+        message.success(t("app.unsuspended_success", { name: packageName }));
+      }
+      setSuspended((prev) => ({ ...prev, [packageName]: suspend }));
+    } catch (err) {
+      message.error(t("app.change_state_failed") + ": " + String(err));
+    }
+  };
+
   const handleExportAPK = async (packageName: string) => {
     const hideMessage = message.loading(t("app.exporting", { name: packageName }), 0);
     try {
@@ -505,6 +586,26 @@ const AppsView: React.FC = () => {
             <Dropdown
               menu={{
                 items: [
+                  record.name in suspended
+                    ? suspended[record.name]
+                      ? {
+                          key: "unsuspend",
+                          icon: <PlayCircleOutlined />,
+                          label: t("apps.unsuspend"),
+                          onClick: () => handleSuspend(record.name, false),
+                        }
+                      : {
+                          key: "suspend",
+                          icon: <PauseCircleOutlined />,
+                          label: t("apps.suspend"),
+                          onClick: () => handleSuspend(record.name, true),
+                        }
+                    : {
+                        key: "suspend-loading",
+                        icon: <LoadingOutlined />,
+                        label: t("common.loading"),
+                        disabled: true,
+                      },
                   {
                     key: "clear",
                     icon: <ClearOutlined />,
@@ -540,6 +641,7 @@ const AppsView: React.FC = () => {
                 ],
               }}
               trigger={["click"]}
+              onOpenChange={(open) => open && refreshSuspended(record.name)}
             >
               <Button size="small" icon={<MoreOutlined />} />
             </Dropdown>
@@ -599,7 +701,7 @@ const AppsView: React.FC = () => {
                 {t("apps.drop_package_here") || "Drop files here to install"}
               </div>
               <div style={{ marginTop: 8, fontSize: 14, color: token.colorTextSecondary }}>
-                {t("apps.drop_package_hint") || "Supports .apk, .xapk, .aab files"}
+                {t("apps.drop_package_hint") || "Supports .apk, .apks, .xapk, .aab files"}
               </div>
             </>
           )}
@@ -636,6 +738,20 @@ const AppsView: React.FC = () => {
           <Radio.Button value="user">{t("apps.user")}</Radio.Button>
           <Radio.Button value="system">{t("apps.system")}</Radio.Button>
         </Radio.Group>
+        {users.length > 1 && (
+          <Select
+            value={selectedUser}
+            style={{ minWidth: 160 }}
+            onChange={(userId: number) => {
+              setSelectedUser(userId);
+              fetchPackages(typeFilter, selectedDevice, userId);
+            }}
+            options={users.map((u) => ({
+              value: u.id,
+              label: u.workProfile ? `${u.name} (${t("apps.work_profile")})` : `${u.name} (${u.id})`,
+            }))}
+          />
+        )}
         <Button
           icon={<ReloadOutlined />}
           onClick={() => fetchPackages()}
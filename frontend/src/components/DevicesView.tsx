@@ -1,5 +1,5 @@
-import React from "react";
-import { Button, Tag, Space, Tooltip, theme, message, Checkbox } from "antd";
+import React, { useState } from "react";
+import { Button, Tag, Space, Tooltip, theme, message, Checkbox, Modal, InputNumber } from "antd";
 import VirtualTable from "./VirtualTable";
 import { useTranslation } from "react-i18next";
 import {
@@ -25,11 +25,13 @@ import {
   ArrowDownOutlined,
   ArrowUpOutlined,
   ThunderboltOutlined,
+  WarningOutlined,
+  SwapOutlined,
 } from "@ant-design/icons";
 import { useDeviceStore, useMirrorStore, useUIStore, VIEW_KEYS, Device } from "../stores";
 import BatchOperationModal from "./BatchOperationModal";
 // @ts-ignore
-import { StartNetworkMonitor, StopNetworkMonitor, StopAllNetworkMonitors } from "../../wailsjs/go/main/App";
+import { StartNetworkMonitor, StopNetworkMonitor, StopAllNetworkMonitors, GetWirelessInfo, ChangeWirelessPort } from "../../wailsjs/go/main/App";
 // @ts-ignore
 import { EventsOn } from "../../wailsjs/runtime/runtime";
 
@@ -73,6 +75,11 @@ const DevicesView: React.FC<DevicesViewProps> = ({
   const { mirrorStatuses, recordStatuses } = useMirrorStore();
   const { setSelectedKey } = useUIStore();
 
+  // Change wireless port dialog
+  const [portTarget, setPortTarget] = useState<{ deviceId: string; address: string } | null>(null);
+  const [newPort, setNewPort] = useState<number | null>(null);
+  const [changingPort, setChangingPort] = useState(false);
+
   // Wrapper functions with message feedback
   const handleFetchDeviceInfoWithFeedback = async (deviceId: string) => {
     try {
@@ -94,6 +101,31 @@ const DevicesView: React.FC<DevicesViewProps> = ({
     }
   };
 
+  const handleOpenChangePort = async (deviceId: string) => {
+    try {
+      const info = await GetWirelessInfo(deviceId);
+      setPortTarget({ deviceId, address: info.address });
+      setNewPort(info.mode === "tcpip" && info.port ? info.port : 5555);
+    } catch (err) {
+      message.error(t("devices.wireless_info_failed") + ": " + String(err));
+    }
+  };
+
+  const handleChangePort = async () => {
+    if (!portTarget || !newPort) return;
+    setChangingPort(true);
+    try {
+      await ChangeWirelessPort(portTarget.deviceId, newPort);
+      message.success(t("devices.port_changed", { port: newPort }));
+      setPortTarget(null);
+      fetchDevices();
+    } catch (err) {
+      message.error(t("devices.change_port_failed") + ": " + String(err));
+    } finally {
+      setChangingPort(false);
+    }
+  };
+
   const handleAdbConnectWithFeedback = async (address: string) => {
     try {
       await handleAdbConnect(address);
@@ -354,6 +386,7 @@ const DevicesView: React.FC<DevicesViewProps> = ({
             device: { color: "green", icon: <CheckCircleOutlined />, text: t("devices.online") },
             offline: { color: "default", icon: <StopOutlined />, text: t("devices.offline") },
             unauthorized: { color: "red", icon: <CloseCircleOutlined />, text: t("devices.unauthorized") },
+            no_permissions: { color: "orange", icon: <WarningOutlined />, text: `${t("devices.no_permissions")}: ${t("devices.no_permissions_hint")}` },
           }[state] || { color: "red", icon: <CloseCircleOutlined />, text: state };
 
         const formatDuration = (seconds: number) => {
@@ -482,6 +515,18 @@ const DevicesView: React.FC<DevicesViewProps> = ({
                     />
                   </Tooltip>
                 )}
+                {(record.type === "wireless" || record.type === "both") && (
+                  <Tooltip title={t("devices.change_wireless_port")}>
+                    <Button
+                      size="small"
+                      icon={<SwapOutlined />}
+                      onClick={() => {
+                        const wirelessId = record.ids.find((id: string) => id.includes(":") || id.startsWith("adb-"));
+                        if (wirelessId) handleOpenChangePort(wirelessId);
+                      }}
+                    />
+                  </Tooltip>
+                )}
                 {(record.type === "wireless" || record.type === "both") && (
                   <Tooltip title={t("devices.disconnect_wireless")}>
                     <Button
@@ -612,6 +657,30 @@ const DevicesView: React.FC<DevicesViewProps> = ({
         selectedDeviceIds={Array.from(selectedDevices)}
         devices={devices}
       />
+
+      <Modal
+        title={t("devices.change_wireless_port")}
+        open={!!portTarget}
+        onCancel={() => setPortTarget(null)}
+        onOk={handleChangePort}
+        confirmLoading={changingPort}
+        okButtonProps={{ disabled: !newPort }}
+      >
+        <div style={{ marginBottom: 12 }}>
+          {t("devices.current_address")}: {portTarget?.address || "-"}
+        </div>
+        <InputNumber
+          min={1024}
+          max={65535}
+          value={newPort}
+          onChange={(v) => setNewPort(v)}
+          addonBefore={t("devices.new_port")}
+          style={{ width: "100%" }}
+        />
+        <div style={{ marginTop: 8, fontSize: 12, color: token.colorTextSecondary }}>
+          {t("devices.change_port_hint")}
+        </div>
+      </Modal>
     </div>
   );
 };
@@ -187,7 +187,8 @@ const FilesView: React.FC<FilesViewProps> = ({
   const { t } = useTranslation();
   const { token } = theme.useToken();
   const { modal, message } = App.useApp();
-  const { selectedDevice } = useDeviceStore();
+  const { selectedDevice, devices } = useDeviceStore();
+  const selectedSerial = devices.find((d) => d.id === selectedDevice)?.serial || "";
 
   // Use filesStore instead of useState
   const {
@@ -438,7 +439,7 @@ const FilesView: React.FC<FilesViewProps> = ({
           }
           break;
         case "delete":
-          await DeleteFile(selectedDevice, file.path);
+          await DeleteFile(selectedDevice, file.path, selectedSerial);
           message.success(t("app.delete_success", { name: file.name }));
           fetchFiles(currentPath);
           break;
@@ -87,7 +87,7 @@ export default function LogcatView() {
     const fetchPackageList = async () => {
       if (!selectedDevice) return;
       try {
-        const res = await ListPackages(selectedDevice, "user");
+        const res = await ListPackages(selectedDevice, "user", 0);
         setPackages(res || []);
       } catch (err) {
         console.error("Failed to fetch packages for logcat:", err);
@@ -1,5 +1,5 @@
-import React, { useEffect, useRef } from "react";
-import { Button, Space, Tag, Card, Switch, Tooltip, Slider, Select, message, theme } from "antd";
+import React, { useEffect, useRef, useState } from "react";
+import { Button, Space, Tag, Card, Switch, Tooltip, Slider, Select, Modal, message, theme } from "antd";
 import { useTranslation } from "react-i18next";
 import {
   StopOutlined,
@@ -12,8 +12,11 @@ import {
   FolderOpenOutlined,
   ScissorOutlined,
   ReloadOutlined,
+  CodeOutlined,
+  EditOutlined,
 } from "@ant-design/icons";
 import DeviceSelector from "./DeviceSelector";
+import ScreenshotAnnotateModal from "./ScreenshotAnnotateModal";
 import { useDeviceStore, useMirrorStore, Device } from "../stores";
 // @ts-ignore
 import { main } from "../types/wails-models";
@@ -32,6 +35,9 @@ import {
   ListCameras,
   ListDisplays,
   GetDeviceInfo,
+  GetRestartMirrorOnReconnect,
+  GetScrcpyArgsPreview,
+  SetRestartMirrorOnReconnect,
 } from "../../wailsjs/go/main/App";
 
 const { Option } = Select;
@@ -74,6 +80,7 @@ const MirrorView: React.FC = () => {
     stayAwake: true,
     turnScreenOff: false,
     noAudio: false,
+    audioOnly: false,
     alwaysOnTop: false,
     showTouches: false,
     fullscreen: false,
@@ -125,26 +132,63 @@ const MirrorView: React.FC = () => {
   const currentMirrorStatus = mirrorStatuses[selectedDevice] || { isMirroring: false, duration: 0 };
   const currentRecordStatus = recordStatuses[selectedDevice] || { isRecording: false, duration: 0, recordPath: "" };
 
+  const [restartOnReconnect, setRestartOnReconnect] = useState(false);
+  const [lastScreenshot, setLastScreenshot] = useState("");
+  const [annotateOpen, setAnnotateOpen] = useState(false);
+
   useEffect(() => {
     if (selectedDevice) {
       fetchDeviceCapabilities();
     }
   }, [selectedDevice]);
 
+  useEffect(() => {
+    if (!selectedDevice) return;
+    GetRestartMirrorOnReconnect(selectedDevice)
+      .then((v: boolean) => setRestartOnReconnect(v))
+      .catch(() => setRestartOnReconnect(false));
+  }, [selectedDevice]);
+
+  const handleRestartOnReconnectChange = async (v: boolean) => {
+    try {
+      await SetRestartMirrorOnReconnect(selectedDevice, v);
+      setRestartOnReconnect(v);
+    } catch (err) {
+      message.error(String(err));
+    }
+  };
+
   useEffect(() => {
     const handleScrcpyFailed = (data: any) => {
       if (data.deviceId === selectedDevice || !selectedDevice) {
+        const code = data.code || 'UNKNOWN';
+        const reason = t(`app.scrcpy_error.${code}`, { defaultValue: data.message || data.error });
+        const suggestion = t(`app.scrcpy_suggestion.${code}`, { defaultValue: data.suggestion || '' });
         message.error({
-          content: `${t("app.scrcpy_failed")}: ${data.error}`,
+          content: (
+            <div style={{ textAlign: 'left' }}>
+              <div>{`${t("app.scrcpy_failed")}: ${reason}`}</div>
+              {suggestion && <div style={{ marginTop: 4 }}>{suggestion}</div>}
+              {data.error && <div style={{ marginTop: 4, fontSize: 12, opacity: 0.65, whiteSpace: 'pre-wrap' }}>{data.error}</div>}
+            </div>
+          ),
           duration: 10,
           style: { marginTop: '20vh' }
         });
       }
     };
 
+    const handleAutoRestarted = (data: any) => {
+      if (data.deviceId === selectedDevice || !selectedDevice) {
+        message.info(t("mirror.auto_restarted", { seconds: Math.round((data.downtimeMs || 0) / 1000) }));
+      }
+    };
+
     EventsOn("scrcpy-failed", handleScrcpyFailed);
+    EventsOn("scrcpy-auto-restarted", handleAutoRestarted);
     return () => {
       EventsOff("scrcpy-failed");
+      EventsOff("scrcpy-auto-restarted");
     };
   }, [selectedDevice, t]);
 
@@ -214,6 +258,25 @@ const MirrorView: React.FC = () => {
     setDeviceShouldRecord(selectedDevice, val);
   };
 
+  const handleShowCommand = async () => {
+    if (!selectedDevice) return;
+    try {
+      const args: string[] = await GetScrcpyArgsPreview(selectedDevice, currentConfig, "mirror");
+      const command = args.map((a) => (/[\s"']/.test(a) ? `"${a.replace(/"/g, '\\"')}"` : a)).join(" ");
+      Modal.info({
+        title: t("mirror.command_preview"),
+        width: 640,
+        content: (
+          <pre style={{ whiteSpace: "pre-wrap", wordBreak: "break-all", fontSize: 12, margin: 0 }}>{command}</pre>
+        ),
+        okText: t("common.copy"),
+        onOk: () => navigator.clipboard.writeText(command),
+      });
+    } catch (err) {
+      message.error(String(err));
+    }
+  };
+
   const handleStartScrcpy = async (deviceId: string, overrideConfig?: main.ScrcpyConfig) => {
     try {
       let config = { ...(overrideConfig || currentConfig) };
@@ -259,7 +322,8 @@ const MirrorView: React.FC = () => {
       if (!defaultPath) return;
 
       // The global listener will handle the toasts based on events emitted by TakeScreenshot
-      await TakeScreenshot(selectedDevice, defaultPath);
+      const savedPath = await TakeScreenshot(selectedDevice, defaultPath);
+      if (savedPath) setLastScreenshot(savedPath);
       // Immediately refresh to show re-ordered device list
       await fetchDevices();
     } catch (err) {
@@ -400,6 +464,16 @@ const MirrorView: React.FC = () => {
           >
             {t("mirror.screenshot")}
           </Button>
+          <Button
+            size="large"
+            icon={<EditOutlined />}
+            onClick={() => setAnnotateOpen(true)}
+            disabled={!lastScreenshot}
+            style={{ height: "40px", borderRadius: "8px" }}
+            title={lastScreenshot}
+          >
+            {t("mirror.annotate")}
+          </Button>
         </Space>
       </div>
 
@@ -724,11 +798,23 @@ const MirrorView: React.FC = () => {
                   <Switch
                     size="small"
                     checked={currentConfig.noAudio}
+                    disabled={currentConfig.audioOnly}
                     onChange={(v) =>
                       updateScrcpyConfig({ ...currentConfig, noAudio: v })
                     }
                   />
                 </div>
+                <div className="setting-item">
+                  <span>{t("mirror.audio_only")}</span>
+                  <Switch
+                    size="small"
+                    checked={currentConfig.audioOnly}
+                    disabled={currentConfig.noAudio}
+                    onChange={(v) =>
+                      updateScrcpyConfig({ ...currentConfig, audioOnly: v })
+                    }
+                  />
+                </div>
                 <div className="setting-item">
                   <span>{t("mirror.audio_codec")}</span>
                   <Select
@@ -903,6 +989,17 @@ const MirrorView: React.FC = () => {
                     }
                   />
                 </div>
+                <div className="setting-item">
+                  <Tooltip title={t("mirror.restart_on_reconnect_desc")}>
+                    <span>{t("mirror.restart_on_reconnect")}</span>
+                  </Tooltip>
+                  <Switch
+                    size="small"
+                    checked={restartOnReconnect}
+                    disabled={!selectedDevice}
+                    onChange={handleRestartOnReconnectChange}
+                  />
+                </div>
               </Space>
             </Card>
 
@@ -972,14 +1069,25 @@ const MirrorView: React.FC = () => {
                 </Space>
               }
               extra={
-                <Tooltip title={t("common.refresh")}>
-                  <Button 
-                    type="text" 
-                    size="small" 
-                    icon={<ReloadOutlined />} 
-                    onClick={fetchDeviceCapabilities}
-                  />
-                </Tooltip>
+                <Space size={0}>
+                  <Tooltip title={t("mirror.command_preview")}>
+                    <Button
+                      type="text"
+                      size="small"
+                      icon={<CodeOutlined />}
+                      disabled={!selectedDevice}
+                      onClick={handleShowCommand}
+                    />
+                  </Tooltip>
+                  <Tooltip title={t("common.refresh")}>
+                    <Button 
+                      type="text" 
+                      size="small" 
+                      icon={<ReloadOutlined />} 
+                      onClick={fetchDeviceCapabilities}
+                    />
+                  </Tooltip>
+                </Space>
               }
               size="small"
             >
@@ -1146,6 +1254,11 @@ const MirrorView: React.FC = () => {
           </div>
         </div>
       </div>
+      <ScreenshotAnnotateModal
+        open={annotateOpen}
+        imagePath={lastScreenshot}
+        onClose={() => setAnnotateOpen(false)}
+      />
     </div>
   );
 };
@@ -43,8 +43,11 @@ import {
   CloseOutlined,
   CheckOutlined,
   ClockCircleOutlined,
+  CodeOutlined,
+  ImportOutlined,
 } from "@ant-design/icons";
 import DeviceSelector from "./DeviceSelector";
+import { EventsOn, EventsOff } from "../../wailsjs/runtime/runtime";
 import { useDeviceStore, useAutomationStore, TouchScript } from "../stores";
 import { convertScriptToWorkflow } from "../stores/automationStore";
 import { formatDurationMMSS } from "../stores/eventTypes";
@@ -76,6 +79,8 @@ const RecordingView: React.FC = () => {
     deleteScript,
     deleteScripts,
     renameScript,
+    exportScriptAsShell,
+    importGeteventScript,
     setCurrentScript,
     updateRecordingDuration,
     subscribeToEvents,
@@ -147,6 +152,20 @@ const RecordingView: React.FC = () => {
     };
   }, [isRecording]);
 
+  useEffect(() => {
+    const handleCheckpointFailed = (data: any) => {
+      message.warning(t("recording.editor.checkpoint_failed", {
+        index: data.eventIndex + 1,
+        selector: `${data.selector?.type}=${data.selector?.value}`,
+      }));
+    };
+
+    EventsOn("playback-checkpoint-failed", handleCheckpointFailed);
+    return () => {
+      EventsOff("playback-checkpoint-failed");
+    };
+  }, [t]);
+
   const handleStartRecording = async (mode: 'fast' | 'precise' = 'fast') => {
     if (!selectedDevice) {
       message.warning(t("app.select_device"));
@@ -250,6 +269,32 @@ const RecordingView: React.FC = () => {
     }
   };
 
+  const handleImportGetevent = async () => {
+    if (!selectedDevice) {
+      message.warning(t("app.select_device"));
+      return;
+    }
+    try {
+      const path = await (window as any).go.main.App.SelectFileForBatch();
+      if (!path) return;
+      // Coordinates are mapped onto the selected device's screen
+      const resolution = await (window as any).go.main.App.GetDeviceResolution(selectedDevice);
+      const script = await importGeteventScript(path, resolution);
+      message.success(t("automation.getevent_imported", { name: script.name }));
+    } catch (err) {
+      message.error(String(err));
+    }
+  };
+
+  const handleExportShell = async (name: string) => {
+    try {
+      await exportScriptAsShell(name);
+      message.success(t("automation.shell_exported"));
+    } catch (err) {
+      message.error(String(err));
+    }
+  };
+
   const handleExecuteSingleEvent = async (event: any, script: TouchScript) => {
     if (!selectedDevice) {
       message.warning(t("app.select_device"));
@@ -775,6 +820,52 @@ const RecordingView: React.FC = () => {
                                   />
                                 </Space>
                               )}
+                              {event.type !== 'wait' && (
+                                <Space size={4} wrap>
+                                  <Tooltip title={t("recording.editor.checkpoint_tip")}>
+                                    <span>{t("recording.editor.checkpoint")}:</span>
+                                  </Tooltip>
+                                  <Select
+                                    size="small" style={{ width: 110 }}
+                                    value={event.checkpoint?.selector?.type || ''}
+                                    onChange={(v: string) => updateScriptEvent(idx, {
+                                      checkpoint: v
+                                        ? main.PlaybackCheckpoint.createFrom({ ...event.checkpoint, selector: { type: v, value: event.checkpoint?.selector?.value || '' } })
+                                        : null,
+                                    })}
+                                    options={[
+                                      { value: '', label: t("recording.editor.checkpoint_none") },
+                                      { value: 'text', label: 'text' },
+                                      { value: 'id', label: 'id' },
+                                      { value: 'description', label: 'description' },
+                                      { value: 'contains', label: 'contains' },
+                                    ]}
+                                  />
+                                  {event.checkpoint && (
+                                    <>
+                                      <Input
+                                        size="small" style={{ width: 140 }}
+                                        value={event.checkpoint.selector?.value}
+                                        onChange={(e) => updateScriptEvent(idx, {
+                                          checkpoint: main.PlaybackCheckpoint.createFrom({ ...event.checkpoint, selector: { ...event.checkpoint!.selector, value: e.target.value } }),
+                                        })}
+                                      />
+                                      <Select
+                                        size="small" style={{ width: 100 }}
+                                        value={event.checkpoint.onFailure || 'stop'}
+                                        onChange={(v: string) => updateScriptEvent(idx, {
+                                          checkpoint: main.PlaybackCheckpoint.createFrom({ ...event.checkpoint, onFailure: v }),
+                                        })}
+                                        options={[
+                                          { value: 'stop', label: t("recording.editor.on_failure_stop") },
+                                          { value: 'pause', label: t("recording.editor.on_failure_pause") },
+                                          { value: 'continue', label: t("recording.editor.on_failure_continue") },
+                                        ]}
+                                      />
+                                    </>
+                                  )}
+                                </Space>
+                              )}
                               <Button
                                 type="text" size="small"
                                 icon={<CheckOutlined />}
@@ -820,11 +911,23 @@ const RecordingView: React.FC = () => {
                       </Popconfirm>
                     )}
                   </Space>
-                  <Tooltip title={t("recording.scaling_info")}>
-                    <Tag icon={<InfoCircleOutlined />} color="blue" style={{ margin: 0 }}>
-                      {t("recording.auto_scaling")}
-                    </Tag>
-                  </Tooltip>
+                  <Space size={4}>
+                    <Tooltip title={t("automation.import_getevent_tip")}>
+                      <Button
+                        size="small"
+                        icon={<ImportOutlined />}
+                        onClick={handleImportGetevent}
+                        disabled={isRecording || isPlaying}
+                      >
+                        {t("automation.import_getevent")}
+                      </Button>
+                    </Tooltip>
+                    <Tooltip title={t("recording.scaling_info")}>
+                      <Tag icon={<InfoCircleOutlined />} color="blue" style={{ margin: 0 }}>
+                        {t("recording.auto_scaling")}
+                      </Tag>
+                    </Tooltip>
+                  </Space>
                 </div>
               }
               size="small"
@@ -954,6 +1057,17 @@ const RecordingView: React.FC = () => {
                           disabled={isRecording || isPlaying}
                         />
                       </Tooltip>
+                      <Tooltip title={t("automation.export_shell")}>
+                        <Button
+                          type="text"
+                          size="small"
+                          icon={<CodeOutlined />}
+                          onClick={(e) => {
+                            e.stopPropagation();
+                            handleExportShell(script.name);
+                          }}
+                        />
+                      </Tooltip>
                       <Popconfirm
                         title={t("recording.delete_confirm", { name: script.name })}
                         onConfirm={() => handleDeleteScript(script.name)}
@@ -0,0 +1,216 @@
+import React, { useEffect, useRef, useState } from "react";
+import { Modal, Radio, Input, Button, Space, Spin, message, theme } from "antd";
+import { useTranslation } from "react-i18next";
+import { UndoOutlined, BorderOutlined, ArrowRightOutlined, FontSizeOutlined } from "@ant-design/icons";
+// @ts-ignore
+import { main } from "../types/wails-models";
+// @ts-ignore
+import { AnnotateScreenshot, ReadImageFileAsDataURL, OpenPath } from "../../wailsjs/go/main/App";
+
+type Tool = "rect" | "arrow" | "text";
+
+const COLORS = ["#FF3B30", "#FFCC00", "#34C759", "#007AFF", "#FFFFFF"];
+const DEFAULT_FONT_SIZE = 32;
+
+interface ScreenshotAnnotateModalProps {
+  open: boolean;
+  imagePath: string;
+  onClose: () => void;
+}
+
+// Lets the user draw boxes, arrows and labels over a screenshot. Shapes are kept in
+// image pixels and rendered onto the file by AnnotateScreenshot on save.
+const ScreenshotAnnotateModal: React.FC<ScreenshotAnnotateModalProps> = ({ open, imagePath, onClose }) => {
+  const { t } = useTranslation();
+  const { token } = theme.useToken();
+  const imgRef = useRef<HTMLImageElement>(null);
+  const [dataUrl, setDataUrl] = useState<string>("");
+  const [natural, setNatural] = useState({ w: 0, h: 0 });
+  const [tool, setTool] = useState<Tool>("rect");
+  const [color, setColor] = useState(COLORS[0]);
+  const [label, setLabel] = useState("");
+  const [annotations, setAnnotations] = useState<main.Annotation[]>([]);
+  const [draft, setDraft] = useState<main.Annotation | null>(null);
+  const [saving, setSaving] = useState(false);
+
+  useEffect(() => {
+    if (!open || !imagePath) return;
+    setAnnotations([]);
+    setDraft(null);
+    setDataUrl("");
+    ReadImageFileAsDataURL(imagePath)
+      .then(setDataUrl)
+      .catch((err: any) => message.error(t("annotate.load_failed") + ": " + String(err)));
+  }, [open, imagePath]);
+
+  // Converts a pointer position to image pixels, clamped to the image bounds
+  const toImagePoint = (e: React.MouseEvent) => {
+    const rect = imgRef.current!.getBoundingClientRect();
+    const sx = natural.w / rect.width;
+    const sy = natural.h / rect.height;
+    const x = Math.round((e.clientX - rect.left) * sx);
+    const y = Math.round((e.clientY - rect.top) * sy);
+    return { x: Math.min(Math.max(x, 0), natural.w), y: Math.min(Math.max(y, 0), natural.h) };
+  };
+
+  const handleMouseDown = (e: React.MouseEvent) => {
+    if (!natural.w) return;
+    const p = toImagePoint(e);
+    if (tool === "text") {
+      if (!label.trim()) {
+        message.warning(t("annotate.text_required"));
+        return;
+      }
+      setAnnotations([...annotations, { type: "text", x: p.x, y: p.y, text: label, color, fontSize: DEFAULT_FONT_SIZE } as main.Annotation]);
+      return;
+    }
+    setDraft({ type: tool, x: p.x, y: p.y, x2: p.x, y2: p.y, color } as main.Annotation);
+  };
+
+  const handleMouseMove = (e: React.MouseEvent) => {
+    if (!draft) return;
+    const p = toImagePoint(e);
+    setDraft({ ...draft, x2: p.x, y2: p.y } as main.Annotation);
+  };
+
+  const handleMouseUp = () => {
+    if (!draft) return;
+    // A click without a drag has no size; the backend rejects those
+    if (draft.x !== draft.x2 || draft.y !== draft.y2) {
+      setAnnotations([...annotations, draft]);
+    }
+    setDraft(null);
+  };
+
+  const handleSave = async () => {
+    setSaving(true);
+    try {
+      const out = await AnnotateScreenshot(imagePath, annotations, "");
+      message.success({
+        content: (
+          <span>
+            {t("annotate.saved", { path: out })}{" "}
+            <a onClick={() => OpenPath(out)}>{t("common.open")}</a>
+          </span>
+        ),
+      });
+      onClose();
+    } catch (err) {
+      message.error(t("annotate.save_failed") + ": " + String(err));
+    } finally {
+      setSaving(false);
+    }
+  };
+
+  // Stroke width and font size are in image pixels, so the SVG shares the image's viewBox
+  const renderShape = (a: main.Annotation, key: React.Key) => {
+    const stroke = a.color || COLORS[0];
+    const width = a.width || 4;
+    switch (a.type) {
+      case "rect":
+        return (
+          <rect key={key} x={Math.min(a.x, a.x2!)} y={Math.min(a.y, a.y2!)}
+            width={Math.abs(a.x2! - a.x)} height={Math.abs(a.y2! - a.y)}
+            fill="none" stroke={stroke} strokeWidth={width} />
+        );
+      case "arrow":
+        return (
+          <line key={key} x1={a.x} y1={a.y} x2={a.x2} y2={a.y2}
+            stroke={stroke} strokeWidth={width} markerEnd={`url(#arrow-${stroke.slice(1)})`} />
+        );
+      default:
+        return (
+          <text key={key} x={a.x} y={a.y + (a.fontSize || DEFAULT_FONT_SIZE)}
+            fill={stroke} fontSize={a.fontSize || DEFAULT_FONT_SIZE}>{a.text}</text>
+        );
+    }
+  };
+
+  return (
+    <Modal
+      title={t("annotate.title")}
+      open={open}
+      onCancel={onClose}
+      width={720}
+      destroyOnHidden
+      footer={[
+        <Button key="cancel" onClick={onClose}>{t("common.cancel")}</Button>,
+        <Button key="save" type="primary" loading={saving} disabled={annotations.length === 0} onClick={handleSave}>
+          {t("common.save")}
+        </Button>,
+      ]}
+    >
+      <Space style={{ marginBottom: 12 }} wrap>
+        <Radio.Group value={tool} onChange={(e) => setTool(e.target.value)} optionType="button">
+          <Radio.Button value="rect"><BorderOutlined /> {t("annotate.rect")}</Radio.Button>
+          <Radio.Button value="arrow"><ArrowRightOutlined /> {t("annotate.arrow")}</Radio.Button>
+          <Radio.Button value="text"><FontSizeOutlined /> {t("annotate.text")}</Radio.Button>
+        </Radio.Group>
+        {tool === "text" && (
+          <Input
+            value={label}
+            onChange={(e) => setLabel(e.target.value)}
+            placeholder={t("annotate.text_placeholder")}
+            style={{ width: 180 }}
+          />
+        )}
+        <Space size={4}>
+          {COLORS.map((c) => (
+            <div
+              key={c}
+              onClick={() => setColor(c)}
+              style={{
+                width: 20,
+                height: 20,
+                borderRadius: 4,
+                cursor: "pointer",
+                backgroundColor: c,
+                border: `2px solid ${c === color ? token.colorPrimary : token.colorBorder}`,
+              }}
+            />
+          ))}
+        </Space>
+        <Button icon={<UndoOutlined />} disabled={annotations.length === 0}
+          onClick={() => setAnnotations(annotations.slice(0, -1))}>
+          {t("annotate.undo")}
+        </Button>
+      </Space>
+
+      {!dataUrl ? (
+        <div style={{ textAlign: "center", padding: 48 }}><Spin /></div>
+      ) : (
+        <div style={{ position: "relative", display: "inline-block", maxWidth: "100%", userSelect: "none" }}>
+          <img
+            ref={imgRef}
+            src={dataUrl}
+            draggable={false}
+            onLoad={(e) => setNatural({ w: e.currentTarget.naturalWidth, h: e.currentTarget.naturalHeight })}
+            style={{ display: "block", maxWidth: "100%", maxHeight: "60vh" }}
+          />
+          {natural.w > 0 && (
+            <svg
+              viewBox={`0 0 ${natural.w} ${natural.h}`}
+              style={{ position: "absolute", inset: 0, width: "100%", height: "100%", cursor: "crosshair" }}
+              onMouseDown={handleMouseDown}
+              onMouseMove={handleMouseMove}
+              onMouseUp={handleMouseUp}
+              onMouseLeave={handleMouseUp}
+            >
+              <defs>
+                {COLORS.map((c) => (
+                  <marker key={c} id={`arrow-${c.slice(1)}`} markerWidth="4" markerHeight="4" refX="3" refY="2" orient="auto">
+                    <path d="M0,0 L4,2 L0,4 z" fill={c} />
+                  </marker>
+                ))}
+              </defs>
+              {annotations.map(renderShape)}
+              {draft && renderShape(draft, "draft")}
+            </svg>
+          )}
+        </div>
+      )}
+    </Modal>
+  );
+};
+
+export default ScreenshotAnnotateModal;
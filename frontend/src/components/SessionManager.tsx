@@ -281,6 +281,40 @@ const SessionManager: React.FC<SessionManagerProps> = ({ style }) => {
     }
   }, [t, setExporting]);
 
+  // Export session as a standalone HTML report
+  const handleExportReport = useCallback(async (sessionId: string) => {
+    setExporting(true, sessionId);
+    try {
+      const result = await (window as any).go.main.App.ExportSessionReport(sessionId, '');
+      if (result) {
+        const fileName = result.split('/').pop() || result.split('\\').pop() || result;
+        notification.success({
+          message: t('session_manager.export_report_success'),
+          description: fileName,
+          btn: (
+            <Button
+              type="primary"
+              size="small"
+              icon={<FolderOpenOutlined />}
+              onClick={() => {
+                (window as any).go.main.App.ShowInFolder(result);
+                notification.destroy();
+              }}
+            >
+              {t('session_manager.open_folder')}
+            </Button>
+          ),
+          duration: 6,
+        });
+      }
+    } catch (err) {
+      console.error('Failed to export session report:', err);
+      message.error(t('session_manager.export_report_failed') + ': ' + (err as Error).message);
+    } finally {
+      setExporting(false);
+    }
+  }, [t, setExporting]);
+
   // Import session
   const handleImport = useCallback(async () => {
     setImporting(true);
@@ -471,6 +505,15 @@ const SessionManager: React.FC<SessionManagerProps> = ({ style }) => {
               onClick={() => handleExport(record.id)}
             />
           </Tooltip>
+          <Tooltip title={t('session_manager.export_report')}>
+            <Button
+              type="text"
+              size="small"
+              icon={<FileTextOutlined />}
+              disabled={isExporting && exportingSessionId === record.id}
+              onClick={() => handleExportReport(record.id)}
+            />
+          </Tooltip>
           <Tooltip title={t('session_manager.view_detail')}>
             <Button
               type="text"
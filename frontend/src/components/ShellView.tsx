@@ -1,16 +1,26 @@
-import React from "react";
-import { Button, Space, Input, message, theme } from "antd";
+import React, { useEffect, useState } from "react";
+import { Button, Space, Input, Switch, Tooltip, message, theme } from "antd";
 import { ClearOutlined } from "@ant-design/icons";
 import { useTranslation } from "react-i18next";
 import DeviceSelector from "./DeviceSelector";
 import { useDeviceStore, useShellStore } from "../stores";
 // @ts-ignore
-import { RunAdbCommand } from "../../wailsjs/go/main/App";
+import { RunAdbCommand, IsSafeModeEnabled, SetSafeMode, GetShellCommandCompletions } from "../../wailsjs/go/main/App";
 
 const ShellView: React.FC = () => {
   const { t } = useTranslation();
   const { token } = theme.useToken();
   const { selectedDevice } = useDeviceStore();
+  const [safeMode, setSafeMode] = useState(true);
+
+  useEffect(() => {
+    IsSafeModeEnabled().then(setSafeMode).catch(() => {});
+  }, []);
+
+  const handleSafeModeChange = async (checked: boolean) => {
+    await SetSafeMode(checked);
+    setSafeMode(checked);
+  };
 
   // Use shellStore instead of useState
   const {
@@ -49,8 +59,40 @@ const ShellView: React.FC = () => {
     }
   };
 
+  // Tab-completes the last word of a "shell ..." command; several matches are
+  // completed to their common prefix and listed in the output pane
+  const handleTabComplete = async () => {
+    const match = shellCmd.match(/^(\s*shell\s+)(.*)$/);
+    if (!selectedDevice || !match) return;
+    const [, prefix, partial] = match;
+
+    try {
+      const candidates: string[] = await GetShellCommandCompletions(selectedDevice, partial);
+      if (!candidates || candidates.length === 0) return;
+
+      const head = partial.slice(0, partial.lastIndexOf(" ") + 1);
+      const common = candidates.reduce((acc, c) => {
+        let i = 0;
+        while (i < acc.length && i < c.length && acc[i] === c[i]) i++;
+        return acc.slice(0, i);
+      });
+
+      if (candidates.length === 1) {
+        setShellCmd(prefix + head + common + (common.endsWith("/") ? "" : " "));
+      } else {
+        setShellCmd(prefix + head + common);
+        setShellOutput(candidates.join("  "));
+      }
+    } catch {
+      // Completion is best-effort
+    }
+  };
+
   const handleKeyDown = (e: React.KeyboardEvent) => {
-    if (e.key === "ArrowUp") {
+    if (e.key === "Tab") {
+      e.preventDefault();
+      handleTabComplete();
+    } else if (e.key === "ArrowUp") {
       e.preventDefault();
       navigateHistory('up');
     } else if (e.key === "ArrowDown") {
@@ -80,6 +122,12 @@ const ShellView: React.FC = () => {
       >
         <h2 style={{ margin: 0, color: token.colorText }}>{t("shell.title")}</h2>
         <Space>
+          <Tooltip title={t("shell.safe_mode_tooltip")}>
+            <Space size={4}>
+              <Switch size="small" checked={safeMode} onChange={handleSafeModeChange} />
+              <span style={{ color: token.colorTextSecondary }}>{t("shell.safe_mode")}</span>
+            </Space>
+          </Tooltip>
           <DeviceSelector />
           <Button icon={<ClearOutlined />} onClick={clearOutput}>
             {t("common.clear") || "Clear"}
@@ -83,6 +83,7 @@ const UIInspectorView: React.FC = () => {
         isFetchingHierarchy,
         fetchUIHierarchy,
         checkAndRefreshUIHierarchy,
+        deviceBusyOps,
     } = useAutomationStore();
 
     const { t } = useTranslation();
@@ -460,6 +461,7 @@ const UIInspectorView: React.FC = () => {
                                 icon={<ReloadOutlined />}
                                 size="small"
                                 loading={isFetchingHierarchy}
+                                disabled={!!selectedDevice && deviceBusyOps[selectedDevice]?.includes('touch_recording')}
                                 onClick={() => selectedDevice && fetchUIHierarchy(selectedDevice)}
                             >
                                 {t("common.refresh")}
@@ -31,6 +31,7 @@ import {
   StopOutlined,
   DeleteOutlined,
   SettingOutlined,
+  DownloadOutlined,
   ThunderboltOutlined,
 } from "@ant-design/icons";
 import {
@@ -87,6 +88,7 @@ const STEP_TYPE_META: Record<string, { icon: React.ReactNode; color: string }> =
   stop_app: { icon: <StopOutlined />, color: 'red' },
   clear_app: { icon: <DeleteOutlined />, color: 'orange' },
   open_settings: { icon: <SettingOutlined />, color: 'blue' },
+  install_if_missing: { icon: <DownloadOutlined />, color: 'green' },
   start_session: { icon: <PlayCircleOutlined />, color: 'cyan' },
   end_session: { icon: <StopOutlined />, color: 'volcano' },
 };
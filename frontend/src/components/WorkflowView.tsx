@@ -55,6 +55,7 @@ import {
   PicRightOutlined,
   LoadingOutlined,
   SettingOutlined,
+  DownloadOutlined,
   IdcardOutlined,
   ThunderboltOutlined,
   StepForwardOutlined,
@@ -141,6 +142,7 @@ const STEP_TYPES = {
     { key: 'stop_app', icon: <StopOutlined />, color: 'red' },
     { key: 'clear_app', icon: <DeleteOutlined />, color: 'orange' },
     { key: 'open_settings', icon: <SettingOutlined />, color: 'blue' },
+    { key: 'install_if_missing', icon: <DownloadOutlined />, color: 'green' },
   ],
   SESSION_CONTROL: [
     { key: 'start_session', icon: <PlayCircleOutlined />, color: 'cyan' },
@@ -650,7 +652,7 @@ const WorkflowView: React.FC = () => {
     if (!selectedDevice) return;
     setAppsLoading(true);
     try {
-      const res = await (window as any).go.main.App.ListPackages(selectedDevice, 'user');
+      const res = await (window as any).go.main.App.ListPackages(selectedDevice, 'user', 0);
       setPackages(res || []);
     } catch (err) {
       console.error("Failed to fetch packages:", err);
@@ -1173,6 +1175,7 @@ const WorkflowView: React.FC = () => {
       selectorValue: selector?.value,
       conditionType,
       value,
+      apkPath: step.app?.apkPath,
       timeout: step.common?.timeout,
       onError: step.common?.onError,
       loop: step.common?.loop,
@@ -1510,6 +1513,12 @@ const WorkflowView: React.FC = () => {
                     stepType === 'stop_app' ? 'stop' :
                     stepType === 'clear_app' ? 'clear' : 'settings',
           };
+        } else if (stepType === 'install_if_missing') {
+          app = {
+            packageName: values.value || '',
+            action: 'install',
+            apkPath: values.apkPath || '',
+          };
         } else if (stepType === 'branch') {
           branch = {
             condition: (values.conditionType || 'exists') as any,
@@ -1738,6 +1747,7 @@ const WorkflowView: React.FC = () => {
         EventsOff("task-paused");
         EventsOff("task-resumed");
         EventsOff("workflow-runtime-update");
+        EventsOff("step-skipped");
         // Clear the ref after cleanup
         cleanupEventsRef.current = null;
       };
@@ -1878,6 +1888,11 @@ const WorkflowView: React.FC = () => {
       EventsOn("task-paused", onPaused);
       EventsOn("task-resumed", onResumed);
       EventsOn("workflow-runtime-update", onRuntimeUpdate);
+      EventsOn("step-skipped", (data: any) => {
+        if (data.deviceId === deviceObj.id) {
+          setExecutionLogs(prev => [...prev, `[${new Date().toLocaleTimeString()}] ${t("workflow.step_skipped")}: ${data.reason}`]);
+        }
+      });
     });
 
     try {
@@ -1948,6 +1963,20 @@ const WorkflowView: React.FC = () => {
     }
   };
 
+  // Emergency stop: cancels running tasks on every device, not just this workflow's
+  const handleStopAllTasks = async () => {
+    try {
+      await (window as any).go.main.App.StopAllTasks();
+    } catch (err) {
+      message.error(String(err));
+      return;
+    }
+    if (isRunning) {
+      await handleStopWorkflow();
+    }
+    message.success(t("workflow.all_tasks_stopped"));
+  };
+
   const handleStopWorkflow = async () => {
     // Use the device that started the workflow, not the currently selected one
     const deviceId = runningDeviceIdRef.current || selectedDevice;
@@ -2279,6 +2308,7 @@ const WorkflowView: React.FC = () => {
         selectorValue: selector?.value,
         conditionType,
         value,
+        apkPath: step.app?.apkPath,
         timeout: step.common?.timeout,
         onError: step.common?.onError,
         loop: step.common?.loop,
@@ -2375,6 +2405,16 @@ const WorkflowView: React.FC = () => {
                   <Button danger icon={<StopOutlined />} onClick={handleStopWorkflow}>
                     {t("workflow.stop")}
                   </Button>
+                  <Popconfirm
+                    title={t("workflow.stop_all_confirm")}
+                    onConfirm={handleStopAllTasks}
+                    okText={t("common.ok")}
+                    cancelText={t("common.cancel")}
+                  >
+                    <Button danger type="primary" icon={<StopOutlined />}>
+                      {t("workflow.stop_all")}
+                    </Button>
+                  </Popconfirm>
                 </Space>
               ) : (
                 <Button type="primary" icon={<PlayCircleOutlined />} onClick={handleRunWorkflow} disabled={!selectedDevice}>
@@ -2865,7 +2905,7 @@ const WorkflowView: React.FC = () => {
                         const conditionType = getFieldValue('conditionType') || 'exists';
                         const needsSelector = ['click_element', 'long_click_element', 'input_text', 'swipe_element', 'wait_element', 'wait_gone', 'assert_element', 'branch', 'read_to_variable'].includes(type);
                         const isAppAction = ['launch_app', 'stop_app', 'clear_app', 'open_settings'].includes(type);
-                        const needsValue = ['set_variable', 'input_text', 'swipe_element', 'wait', 'adb', 'script', 'run_workflow'].includes(type) || isAppAction;
+                        const needsValue = ['set_variable', 'input_text', 'swipe_element', 'wait', 'adb', 'script', 'run_workflow', 'install_if_missing'].includes(type) || isAppAction;
                         const isWorkflow = type === 'run_workflow';
 
                         // For branch conditions, determine if we need value field
@@ -2966,7 +3006,8 @@ const WorkflowView: React.FC = () => {
                                   isBranch && conditionType === 'variable_equals' ? t("workflow.expected_value") :
                                     type === 'swipe_element' ? t("workflow.swipe_direction") :
                                       type === 'set_variable' ? t("workflow.variable_value") :
-                                        t("workflow.value")
+                                        type === 'install_if_missing' ? t("workflow.package_name") :
+                                          t("workflow.value")
                               }>
                                 {type === 'script' ? (
                                   <Select
@@ -3024,6 +3065,12 @@ const WorkflowView: React.FC = () => {
                               </Form.Item>
                             )}
 
+                            {type === 'install_if_missing' && (
+                              <Form.Item name="apkPath" label={t("workflow.apk_path")} rules={[{ required: true }]}>
+                                <Input placeholder="/path/to/app.apk" />
+                              </Form.Item>
+                            )}
+
                             {type === 'swipe_element' && (
                               <div style={{ display: 'flex', gap: 16 }}>
                                 <Form.Item name="swipeDistance" label={t("workflow.distance")} style={{ flex: 1 }}>
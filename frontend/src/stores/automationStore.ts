@@ -11,6 +11,8 @@ import {
   SaveTouchScript,
   DeleteTouchScript,
   RenameTouchScript,
+  ExportScriptAsShell,
+  ImportTouchScriptFromGetevent,
   SaveScriptTask,
   LoadScriptTasks,
   DeleteScriptTask,
@@ -228,6 +230,7 @@ export async function convertScriptToWorkflow(
 interface AutomationState {
   // State
   isRecording: boolean;
+  deviceBusyOps: Record<string, string[]>;
   isPlaying: boolean;
   recordingDeviceId: string | null;
   playingDeviceId: string | null;
@@ -244,6 +247,7 @@ interface AutomationState {
     totalLoops: number; 
     currentAction?: string 
   } | null;
+  taskPercent: number | null; // overall 0-100 across steps and loops
   isTaskRunning: boolean;
   isPaused: boolean;
   runningTaskName: string | null;
@@ -279,6 +283,8 @@ interface AutomationState {
   deleteScript: (name: string) => Promise<void>;
   deleteScripts: (names: string[]) => Promise<void>;
   renameScript: (oldName: string, newName: string) => Promise<void>;
+  exportScriptAsShell: (name: string) => Promise<void>;
+  importGeteventScript: (path: string, resolution: string) => Promise<main.TouchScript>;
   setCurrentScript: (script: main.TouchScript | null) => void;
   updateRecordingDuration: () => void;
 
@@ -323,7 +329,7 @@ interface AutomationState {
   // Script editing actions
   editingEventIndex: number | null;
   setEditingEventIndex: (index: number | null) => void;
-  updateScriptEvent: (index: number, updates: Partial<{ x: number; y: number; x2: number; y2: number; duration: number; type: string }>) => void;
+  updateScriptEvent: (index: number, updates: Partial<{ x: number; y: number; x2: number; y2: number; duration: number; type: string; checkpoint: main.PlaybackCheckpoint | null }>) => void;
   deleteScriptEvent: (index: number) => void;
   moveScriptEvent: (fromIndex: number, toIndex: number) => void;
   insertWaitEvent: (afterIndex: number, durationMs: number) => void;
@@ -335,6 +341,7 @@ export const useAutomationStore = create<AutomationState>()(
   immer((set, get) => ({
     // Initial state
     isRecording: false,
+    deviceBusyOps: {},
     isPlaying: false,
     recordingDeviceId: null,
     playingDeviceId: null,
@@ -346,6 +353,7 @@ export const useAutomationStore = create<AutomationState>()(
     tasks: [],
     playbackProgress: null,
     taskProgress: null,
+    taskPercent: null,
     isTaskRunning: false,
     isPaused: false,
     runningTaskName: null,
@@ -508,6 +516,26 @@ export const useAutomationStore = create<AutomationState>()(
       }
     },
 
+    exportScriptAsShell: async (name: string) => {
+      try {
+        await ExportScriptAsShell(name, '');
+      } catch (err) {
+        console.error('Failed to export script:', err);
+        throw err;
+      }
+    },
+
+    importGeteventScript: async (path: string, resolution: string) => {
+      try {
+        const script = await ImportTouchScriptFromGetevent(path, resolution);
+        await get().saveScript(script);
+        return script;
+      } catch (err) {
+        console.error('Failed to import getevent capture:', err);
+        throw err;
+      }
+    },
+
     loadTasks: async () => {
       try {
         const tasks = await LoadScriptTasks();
@@ -597,6 +625,7 @@ export const useAutomationStore = create<AutomationState>()(
           state.isPaused = false;
           state.runningTaskName = null;
           state.taskProgress = null;
+          state.taskPercent = null;
         });
       }
     },
@@ -794,7 +823,7 @@ export const useAutomationStore = create<AutomationState>()(
       });
     },
 
-    updateScriptEvent: (index: number, updates: Partial<{ x: number; y: number; x2: number; y2: number; duration: number; type: string }>) => {
+    updateScriptEvent: (index: number, updates: Partial<{ x: number; y: number; x2: number; y2: number; duration: number; type: string; checkpoint: main.PlaybackCheckpoint | null }>) => {
       set((state: AutomationState) => {
         // Determine which script to edit: currentScript (unsaved) or selectedScript (saved)
         const script = state.currentScript || state.selectedScript;
@@ -807,6 +836,7 @@ export const useAutomationStore = create<AutomationState>()(
         if (updates.y2 !== undefined) event.y2 = updates.y2;
         if (updates.duration !== undefined) event.duration = updates.duration;
         if (updates.type !== undefined) event.type = updates.type;
+        if (updates.checkpoint !== undefined) event.checkpoint = updates.checkpoint ?? undefined;
 
         state.isScriptDirty = true;
       });
@@ -949,6 +979,7 @@ export const useAutomationStore = create<AutomationState>()(
           state.isPaused = false;
           state.runningTaskName = null;
           state.taskProgress = null;
+          state.taskPercent = null;
           state.playingDeviceId = null;
         });
       };
@@ -972,6 +1003,12 @@ export const useAutomationStore = create<AutomationState>()(
         });
       };
 
+      const handleTaskProgress = (data: any) => {
+        set((state: AutomationState) => {
+          state.taskPercent = data.percent;
+        });
+      };
+
       const handleRecordingPausedForSelector = (data: any) => {
         set((state: AutomationState) => {
           state.isWaitingForSelector = true;
@@ -1006,6 +1043,16 @@ export const useAutomationStore = create<AutomationState>()(
         });
       });
 
+      const offDeviceBusy = EventsOn('device-busy-changed', (data: any) => {
+        set((state: AutomationState) => {
+          if (data.operations?.length) {
+            state.deviceBusyOps[data.deviceId] = data.operations;
+          } else {
+            delete state.deviceBusyOps[data.deviceId];
+          }
+        });
+      });
+
       EventsOn('touch-record-started', handleRecordStarted);
       EventsOn('touch-record-stopped', handleRecordStopped);
       EventsOn('touch-action-recorded', handleTouchActionRecorded);
@@ -1015,6 +1062,7 @@ export const useAutomationStore = create<AutomationState>()(
       EventsOn('task-started', handleTaskStarted);
       EventsOn('task-completed', handleTaskCompleted);
       EventsOn('task-step-running', handleTaskStepRunning);
+      EventsOn('task-progress', handleTaskProgress);
       EventsOn('task-paused', handleTaskPaused);
       EventsOn('task-resumed', handleTaskResumed);
       EventsOn('recording-paused-for-selector', handleRecordingPausedForSelector);
@@ -1030,6 +1078,7 @@ export const useAutomationStore = create<AutomationState>()(
         EventsOff('task-started');
         EventsOff('task-completed');
         EventsOff('task-step-running');
+        EventsOff('task-progress');
         EventsOff('task-paused');
         EventsOff('task-resumed');
         EventsOff('recording-paused-for-selector');
@@ -1037,6 +1086,7 @@ export const useAutomationStore = create<AutomationState>()(
         EventsOff('recording-pre-capture-started');
         EventsOff('recording-pre-capture-finished');
         EventsOff('recording-analysis-started');
+        EventsOff('device-busy-changed');
       };
     },
   }))
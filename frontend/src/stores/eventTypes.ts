@@ -346,6 +346,7 @@ export const eventTypeConfig: Record<string, { label: string; icon: string; icon
   // Recording
   recording_start: { label: 'Recording Start', icon: '🔴', iconComponent: React.createElement(FieldTimeOutlined, { style: { color: '#ff4d4f' } }) },
   recording_end: { label: 'Recording End', icon: '⏹️', iconComponent: React.createElement(PauseCircleOutlined) },
+  screenshot: { label: 'Screenshot', icon: '📷', iconComponent: React.createElement(PictureOutlined) },
 
   // Plugin - TikTok events
   tiktok_launch: { label: 'TikTok Launch', icon: '🚀', iconComponent: React.createElement(PlayCircleOutlined, { style: { color: '#ff6b35' } }) },
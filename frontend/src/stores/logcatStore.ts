@@ -147,6 +147,8 @@ interface LogcatState {
 // Local buffer to throttle updates (prevents 1000s of state updates per second)
 let logBuffer: ParsedLog[] = [];
 let flushTimerId: number | null = null;
+// Device whose logcat this store is streaming
+let loggingDeviceId = '';
 
 const MAX_LOGS = 50000; // Reduced from 200k to 50k for performance
 
@@ -278,6 +280,7 @@ export const useLogcatStore = create<LogcatState>()(
           state.logs = [];
           state.isLogging = true;
         });
+        loggingDeviceId = deviceId;
         
         // Reset buffer
         logBuffer = [];
@@ -304,8 +307,9 @@ export const useLogcatStore = create<LogcatState>()(
 
         // Subscribe to session events batch (unified event source)
         EventsOn('session-events-batch', (events: any[]) => {
-          // Filter for log events only
-          const logEvents = events.filter((e: any) => e.category === 'log');
+          // Filter for log events of the streamed device only; other devices may be
+          // streaming logcat at the same time
+          const logEvents = events.filter((e: any) => e.category === 'log' && e.deviceId === deviceId);
           if (logEvents.length === 0) return;
 
           // Access current filter state using get()
@@ -410,7 +414,10 @@ export const useLogcatStore = create<LogcatState>()(
     },
 
     stopLogcat: () => {
-      StopLogcat();
+      if (loggingDeviceId) {
+        StopLogcat(loggingDeviceId);
+        loggingDeviceId = '';
+      }
       EventsOff('session-events-batch');
 
       if (flushTimerId) {
@@ -303,7 +303,7 @@ export const usePerfStore = create<PerfState>()(
     fetchPackages: async (deviceId: string) => {
       set((s) => { s.packagesLoading = true; });
       try {
-        const res = await ListPackages(deviceId, 'user');
+        const res = await ListPackages(deviceId, 'user', 0);
         set((s) => {
           s.packages = res || [];
           s.packagesLoading = false;
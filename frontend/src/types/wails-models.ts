@@ -1,5 +1,25 @@
 export namespace main {
 	
+	export class AndroidUser {
+	    id: number;
+	    name: string;
+	    flags: number;
+	    running: boolean;
+	    workProfile: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AndroidUser(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.flags = source["flags"];
+	        this.running = source["running"];
+	        this.workProfile = source["workProfile"];
+	    }
+	}
 	export class AppPackage {
 	    name: string;
 	    label: string;
@@ -842,6 +862,7 @@ export namespace main {
 	    stayAwake: boolean;
 	    turnScreenOff: boolean;
 	    noAudio: boolean;
+	    audioOnly: boolean;
 	    alwaysOnTop: boolean;
 	    showTouches: boolean;
 	    fullscreen: boolean;
@@ -875,6 +896,7 @@ export namespace main {
 	        this.stayAwake = source["stayAwake"];
 	        this.turnScreenOff = source["turnScreenOff"];
 	        this.noAudio = source["noAudio"];
+	        this.audioOnly = source["audioOnly"];
 	        this.alwaysOnTop = source["alwaysOnTop"];
 	        this.showTouches = source["showTouches"];
 	        this.fullscreen = source["fullscreen"];
@@ -1281,6 +1303,41 @@ export namespace main {
 	    }
 	}
 	
+	export class PlaybackCheckpoint {
+	    selector: ElementSelector;
+	    timeoutMs?: number;
+	    onFailure?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlaybackCheckpoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.selector = this.convertValues(source["selector"], ElementSelector);
+	        this.timeoutMs = source["timeoutMs"];
+	        this.onFailure = source["onFailure"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
 	export class TouchEvent {
 	    timestamp: number;
 	    type: string;
@@ -1290,6 +1347,7 @@ export namespace main {
 	    y2?: number;
 	    duration?: number;
 	    selector?: ElementSelector;
+	    checkpoint?: PlaybackCheckpoint;
 	
 	    static createFrom(source: any = {}) {
 	        return new TouchEvent(source);
@@ -1305,6 +1363,7 @@ export namespace main {
 	        this.y2 = source["y2"];
 	        this.duration = source["duration"];
 	        this.selector = this.convertValues(source["selector"], ElementSelector);
+	        this.checkpoint = this.convertValues(source["checkpoint"], PlaybackCheckpoint);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -75,7 +75,8 @@ export interface ElementParams {
 
 export interface AppParams {
   packageName: string;
-  action: 'launch' | 'stop' | 'clear' | 'settings';
+  action: 'launch' | 'stop' | 'clear' | 'settings' | 'install';
+  apkPath?: string; // install_if_missing only
 }
 
 export interface BranchParams {
@@ -157,6 +158,7 @@ export type StepType =
   | 'stop_app'
   | 'clear_app'
   | 'open_settings'
+  | 'install_if_missing'
   | 'branch'
   | 'wait'
   | 'script'
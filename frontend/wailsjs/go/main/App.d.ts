@@ -7,6 +7,8 @@ import {context} from '../models';
 import {proxy} from '../models';
 import {http} from '../models';
 
+export function AcquireDevice(arg1:string,arg2:string):Promise<void>;
+
 export function AdbConnect(arg1:string):Promise<string>;
 
 export function AdbDisconnect(arg1:string):Promise<string>;
@@ -15,6 +17,8 @@ export function AdbPair(arg1:string,arg2:string):Promise<string>;
 
 export function AddBreakpointRule(arg1:main.BreakpointRule):Promise<string>;
 
+export function AddMDNSSerialPattern(arg1:string):Promise<Array<string>>;
+
 export function AddMapRemoteRule(arg1:string,arg2:string,arg3:string,arg4:string):Promise<string>;
 
 export function AddMockRule(arg1:main.MockRule):Promise<string>;
@@ -27,24 +31,46 @@ export function AddRewriteRule(arg1:string,arg2:string,arg3:string,arg4:string,a
 
 export function AnalyzeElementSelectors(arg1:string,arg2:number,arg3:number,arg4:time.Time):Promise<Array<main.SelectorSuggestion>>;
 
+export function AnnotateScreenshot(arg1:string,arg2:Array<main.Annotation>,arg3:string):Promise<string>;
+
+export function ApplyDeviceProfile(arg1:Array<string>,arg2:main.DeviceProfile):Promise<Array<main.DeviceProfileResult>>;
+
 export function AssertElementExists(arg1:string,arg2:types.ElementSelector):Promise<boolean>;
 
 export function AssertElementText(arg1:string,arg2:types.ElementSelector,arg3:string,arg4:boolean):Promise<boolean>;
 
+export function BatchScreenshot(arg1:Array<string>,arg2:string):Promise<main.BatchScreenshotResult>;
+
+export function CancelAdbPairingQR():Promise<void>;
+
 export function CancelOpenFile(arg1:string):Promise<void>;
 
+export function CancelPush(arg1:string):Promise<void>;
+
+export function CancelThumbnailPregeneration(arg1:string):Promise<void>;
+
+export function CaptureScreenshotOfPinnedDevice():Promise<string>;
+
+export function ChangeWirelessPort(arg1:string,arg2:number):Promise<string>;
+
 export function CheckCertTrust(arg1:string):Promise<string>;
 
 export function CleanupOldSessionData(arg1:number):Promise<number>;
 
 export function CleanupProxyForDevice(arg1:string,arg2:number):Promise<void>;
 
-export function ClearAppData(arg1:string,arg2:string):Promise<string>;
+export function ClearAppData(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function ClearDeviceProxy(arg1:string):Promise<main.DeviceProxyResult>;
+
+export function ClearProxyLog():Promise<void>;
 
 export function ClearTextViaADBKeyboard(arg1:string):Promise<void>;
 
 export function ClickElement(arg1:context.Context,arg2:string,arg3:types.ElementSelector,arg4:main.ElementActionConfig):Promise<void>;
 
+export function CompareSessions(arg1:string,arg2:string):Promise<main.SessionDiff>;
+
 export function CopyFile(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function CreateAssertionSet(arg1:string,arg2:string,arg3:Array<string>):Promise<string>;
@@ -59,7 +85,11 @@ export function CreateStoredAssertionJSON(arg1:string,arg2:boolean):Promise<void
 
 export function DeleteAssertionSet(arg1:string):Promise<void>;
 
-export function DeleteFile(arg1:string,arg2:string):Promise<void>;
+export function DeleteDeviceProfile(arg1:string):Promise<void>;
+
+export function DeleteDirectorySnapshot(arg1:string):Promise<void>;
+
+export function DeleteFile(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function DeletePlugin(arg1:string):Promise<void>;
 
@@ -75,13 +105,21 @@ export function DeleteTouchScript(arg1:string):Promise<void>;
 
 export function DeleteWorkflow(arg1:string):Promise<void>;
 
-export function DisableApp(arg1:string,arg2:string):Promise<string>;
+export function DiffDirectorySnapshot(arg1:string,arg2:string):Promise<main.DirectorySnapshotDiff>;
+
+export function DiffPackageLists(arg1:string,arg2:string):Promise<main.PackageListDiff>;
+
+export function DisableApp(arg1:string,arg2:string,arg3:number):Promise<string>;
+
+export function DownloadDirectoryAsZip(arg1:string,arg2:string,arg3:string):Promise<string>;
 
 export function DownloadFile(arg1:string,arg2:string):Promise<string>;
 
 export function EmitEvent(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:any):Promise<void>;
 
-export function EnableApp(arg1:string,arg2:string):Promise<string>;
+export function EnableApp(arg1:string,arg2:string,arg3:number):Promise<string>;
+
+export function EnableAutoSessions(arg1:boolean):Promise<void>;
 
 export function EndActiveSession(arg1:string,arg2:string):Promise<void>;
 
@@ -103,10 +141,22 @@ export function ExecuteStoredAssertionInSession(arg1:string,arg2:string,arg3:str
 
 export function ExportAPK(arg1:string,arg2:string):Promise<string>;
 
+export function ExportLogcatForForegroundApp(arg1:string,arg2:string,arg3:number):Promise<string>;
+
 export function ExportMockRules():Promise<string>;
 
+export function ExportPackageList(arg1:string,arg2:string,arg3:string,arg4:string):Promise<string>;
+
+export function ExportProxyHar(arg1:arg1:string):Promise<string>;
+
+export function ExportReproductionCase(arg1:string,arg2:string):Promise<string>;
+
+export function ExportScriptAsShell(arg1:string,arg2:string):Promise<void>;
+
 export function ExportSession(arg1:string):Promise<string>;
 
+export function ExportSessionReport(arg1:string,arg2:string):Promise<string>;
+
 export function ExportSessionToPath(arg1:string,arg2:string):Promise<string>;
 
 export function FindAllElementsBySelector(arg1:main.UINode,arg2:types.ElementSelector):Promise<Array<main.UINode>>;
@@ -121,8 +171,22 @@ export function ForceStopApp(arg1:string,arg2:string):Promise<string>;
 
 export function ForwardAllBreakpoints():Promise<void>;
 
+export function GenerateAdbPairingQR():Promise<main.AdbPairingQR>;
+
+export function GetActivityStack(arg1:string):Promise<Array<main.ActivityStackEntry>>;
+
+export function GetAdbPublicKey():Promise<main.AdbKeyInfo>;
+
+export function GetAdbRetries():Promise<number>;
+
+export function GetAdbServerAddress():Promise<main.AdbServerSettings>;
+
 export function GetAppInfo(arg1:string,arg2:string,arg3:boolean):Promise<main.AppPackage>;
 
+export function GetAppPermissionsDetailed(arg1:string,arg2:string):Promise<Array<main.AppPermission>>;
+
+export function GetAppPowerState(arg1:string,arg2:string):Promise<main.AppPowerState>;
+
 export function GetAppVersion():Promise<string>;
 
 export function GetAssertionSet(arg1:string):Promise<main.AssertionSet>;
@@ -133,10 +197,22 @@ export function GetAssertionSetResults(arg1:string,arg2:number):Promise<Array<ma
 
 export function GetBackendLogs():Promise<Array<string>>;
 
+export function GetBinaryExtractErrors():Promise<Record<string, string>>;
+
 export function GetBreakpointRules():Promise<Array<main.BreakpointRule>>;
 
+export function GetConcurrency():Promise<main.ConcurrencySettings>;
+
+export function GetDevice(arg1:string):Promise<main.Device>;
+
 export function GetDeviceActiveSession(arg1:string):Promise<main.DeviceSession>;
 
+export function GetDeviceBusyState(arg1:string):Promise<Array<string>>;
+
+export function GetDeviceClipboard(arg1:string):Promise<string>;
+
+export function GetDeviceDashboard(arg1:string):Promise<main.DeviceDashboard>;
+
 export function GetDeviceIP(arg1:string):Promise<string>;
 
 export function GetDeviceInfo(arg1:string):Promise<main.DeviceInfo>;
@@ -145,12 +221,16 @@ export function GetDeviceResolution(arg1:string):Promise<string>;
 
 export function GetDevices(arg1:boolean):Promise<Array<main.Device>>;
 
+export function GetDirectorySize(arg1:string,arg2:string):Promise<number>;
+
 export function GetElementProperties(arg1:string,arg2:types.ElementSelector):Promise<Record<string, any>>;
 
 export function GetElementsWithText(arg1:string,arg2:string):Promise<Array<Record<string, any>>>;
 
 export function GetEventSystemStats():Promise<Record<string, any>>;
 
+export function GetForegroundApp(arg1:string):Promise<main.ForegroundActivity>;
+
 export function GetHistoryDevices():Promise<Array<main.HistoryDevice>>;
 
 export function GetInstalledPackages(arg1:string,arg2:boolean):Promise<Array<string>>;
@@ -161,12 +241,24 @@ export function GetLogDir():Promise<string>;
 
 export function GetLogFilePath():Promise<string>;
 
+export function GetLogcatBuffering():Promise<main.LogcatBufferingSettings>;
+
+export function GetLogcatDevices():Promise<Array<string>>;
+
+export function GetMDNSSerialPatterns():Promise<Array<string>>;
+
 export function GetMITMBypassPatterns():Promise<Array<string>>;
 
 export function GetMapRemoteRules():Promise<Array<main.MapRemoteRule>>;
 
+export function GetMemoryBreakdown(arg1:string):Promise<main.MemoryBreakdown>;
+
 export function GetMockRules():Promise<Array<main.MockRule>>;
 
+export function GetOutputPath(arg1:string,arg2:string):Promise<string>;
+
+export function GetOutputSettings():Promise<main.OutputSettings>;
+
 export function GetPendingBreakpoints():Promise<Array<proxy.PendingBreakpointInfo>>;
 
 export function GetPerfMonitorConfig(arg1:string):Promise<main.PerfMonitorConfig>;
@@ -183,22 +275,48 @@ export function GetProtoMappings():Promise<Array<main.ProtoMapping>>;
 
 export function GetProtoMessageTypes():Promise<Array<string>>;
 
+export function GetProxyCaptureFilter():Promise<proxy.CaptureFilter>;
+
 export function GetProxyDevice():Promise<string>;
 
+export function GetProxyLogBufferSize():Promise<number>;
+
 export function GetProxySettings():Promise<Record<string, any>>;
 
 export function GetProxyStatus():Promise<boolean>;
 
+export function GetQuickConnectTargets():Promise<Array<main.QuickTarget>>;
+
 export function GetRecentSessionEvents(arg1:string,arg2:number):Promise<Array<main.UnifiedEvent>>;
 
+export function GetReconnectSettings():Promise<main.ReconnectSettings>;
+
+export function GetReconnectStats(arg1:string):Promise<main.ReconnectStats>;
+
 export function GetRecordingEventCount(arg1:string):Promise<number>;
 
 export function GetRecordingsDir():Promise<string>;
 
+export function GetRestartMirrorOnReconnect(arg1:string):Promise<boolean>;
+
+export function GetRetentionPolicy():Promise<main.RetentionPolicy>;
+
 export function GetRewriteRules():Promise<Array<main.RewriteRule>>;
 
 export function GetSampleEvents(arg1:string,arg2:Array<string>,arg3:Array<string>,arg4:number):Promise<Array<main.UnifiedEvent>>;
 
+export function GetScrcpyArgsPreview(arg1:string,arg2:main.ScrcpyConfig,arg3:string):Promise<Array<string>>;
+
+export function GetScrcpyConfig(arg1:string):Promise<main.ScrcpyConfig>;
+
+export function GetScreenBrightness(arg1:string):Promise<number>;
+
+export function GetScreenState(arg1:string):Promise<main.ScreenState>;
+
+export function GetScreenTimeout(arg1:string):Promise<number>;
+
+export function GetScreencapPNG(arg1:string):Promise<Array<number>>;
+
 export function GetSessionBookmarks(arg1:string):Promise<Array<main.Bookmark>>;
 
 export function GetSessionEventLevels(arg1:string):Promise<Array<string>>;
@@ -213,6 +331,12 @@ export function GetSessionTimeIndex(arg1:string):Promise<Array<main.TimeIndexEnt
 
 export function GetSessionVideoInfo(arg1:string):Promise<Record<string, any>>;
 
+export function GetSetting(arg1:string):Promise<string>;
+
+export function GetShellCommandCompletions(arg1:string,arg2:string):Promise<Array<string>>;
+
+export function GetSourceSampling():Promise<Record<string, number>>;
+
 export function GetStorageInfo():Promise<Record<string, any>>;
 
 export function GetStoredAssertion(arg1:string):Promise<main.StoredAssertion>;
@@ -221,10 +345,14 @@ export function GetStoredEvent(arg1:string):Promise<main.UnifiedEvent>;
 
 export function GetStoredSession(arg1:string):Promise<main.DeviceSession>;
 
+export function GetSupportedKeys():Promise<Array<string>>;
+
 export function GetThumbnail(arg1:string,arg2:string,arg3:string):Promise<string>;
 
 export function GetTouchInputDevice(arg1:string):Promise<string>;
 
+export function GetTraceWindowMs():Promise<number>;
+
 export function GetUIHierarchy(arg1:string):Promise<main.UIHierarchyResult>;
 
 export function GetUIHierarchyWithContext(arg1:context.Context,arg2:string):Promise<main.UIHierarchyResult>;
@@ -239,16 +367,22 @@ export function GetVideoServiceInfo():Promise<main.VideoServiceInfo>;
 
 export function GetVideoThumbnails(arg1:string,arg2:number,arg3:number):Promise<Array<main.VideoThumbnail>>;
 
+export function GetWirelessInfo(arg1:string):Promise<main.WirelessInfo>;
+
 export function GetWorkflow(arg1:string):Promise<types.Workflow>;
 
 export function GetWorkflowExecutionResult(arg1:string):Promise<types.WorkflowExecutionResult>;
 
+export function GrantPermission(arg1:string,arg2:string,arg3:string):Promise<string>;
+
 export function ImportMockRules(arg1:string):Promise<number>;
 
 export function ImportSession():Promise<string>;
 
 export function ImportSessionFromPath(arg1:string):Promise<string>;
 
+export function ImportTouchScriptFromGetevent(arg1:string,arg2:string):Promise<main.TouchScript>;
+
 export function InitializeWithoutGUI():Promise<void>;
 
 export function InputNodeText(arg1:string,arg2:string,arg3:string):Promise<void>;
@@ -261,9 +395,11 @@ export function InputTextViaADBKeyboard(arg1:string,arg2:string):Promise<void>;
 
 export function InstallAAB(arg1:string,arg2:string):Promise<string>;
 
-export function InstallAPK(arg1:string,arg2:string):Promise<string>;
+export function InstallAPK(arg1:string,arg2:string,arg3:number):Promise<string>;
+
+export function InstallAPKs(arg1:string,arg2:Array<string>):Promise<main.APKInstallSummary>;
 
-export function InstallPackage(arg1:string,arg2:string):Promise<string>;
+export function InstallPackage(arg1:string,arg2:string,arg3:number):Promise<string>;
 
 export function InstallProxyCert(arg1:string):Promise<string>;
 
@@ -273,8 +409,16 @@ export function IsADBKeyboardActive(arg1:string):Promise<boolean>;
 
 export function IsADBKeyboardInstalled(arg1:string):Promise<boolean>;
 
+export function IsAppInstalled(arg1:string,arg2:string):Promise<boolean>;
+
 export function IsAppRunning(arg1:string,arg2:string):Promise<boolean>;
 
+export function IsAppSuspended(arg1:string,arg2:string):Promise<boolean>;
+
+export function IsAutoSessionsEnabled():Promise<boolean>;
+
+export function IsHostSleepInhibited():Promise<boolean>;
+
 export function IsMCPMode():Promise<boolean>;
 
 export function IsPerfMonitorRunning(arg1:string):Promise<boolean>;
@@ -285,10 +429,14 @@ export function IsRecording(arg1:string):Promise<boolean>;
 
 export function IsRecordingTouch(arg1:string):Promise<boolean>;
 
+export function IsSafeModeEnabled():Promise<boolean>;
+
 export function IsTaskPaused(arg1:string):Promise<boolean>;
 
 export function IsWorkflowRunning(arg1:string):Promise<boolean>;
 
+export function LaunchWithDeepLink(arg1:string,arg2:string):Promise<string>;
+
 export function ListAssertionResults(arg1:string,arg2:number):Promise<Array<main.AssertionResult>>;
 
 export function ListAssertionSets():Promise<Array<main.AssertionSet>>;
@@ -301,7 +449,9 @@ export function ListFiles(arg1:string,arg2:string):Promise<Array<main.FileInfo>>
 
 export function ListLogFiles():Promise<Array<string>>;
 
-export function ListPackages(arg1:string,arg2:string):Promise<Array<main.AppPackage>>;
+export function ListMdnsServices():Promise<Array<main.MdnsService>>;
+
+export function ListPackages(arg1:string,arg2:string,arg3:number):Promise<Array<main.AppPackage>>;
 
 export function ListPlugins():Promise<Array<main.PluginMetadata>>;
 
@@ -311,8 +461,14 @@ export function ListStoredAssertions(arg1:string,arg2:string,arg3:boolean,arg4:n
 
 export function ListStoredSessions(arg1:string,arg2:number):Promise<Array<main.DeviceSession>>;
 
+export function ListStoredSessionsFiltered(arg1:string,arg2:number,arg3:number,arg4:string,arg5:string,arg6:number,arg7:number):Promise<main.SessionListResult>;
+
+export function ListUsers(arg1:string):Promise<Array<main.AndroidUser>>;
+
 export function LoadBreakpointRules():Promise<void>;
 
+export function LoadDeviceProfiles():Promise<Array<main.DeviceProfile>>;
+
 export function LoadMapRemoteRules():Promise<void>;
 
 export function LoadMockRules():Promise<void>;
@@ -339,12 +495,16 @@ export function LongClickElement(arg1:context.Context,arg2:string,arg3:types.Ele
 
 export function LongPressAtCoordinates(arg1:string,arg2:number,arg3:number,arg4:number):Promise<void>;
 
+export function MergeSplitAPKs(arg1:string,arg2:string,arg3:string):Promise<main.SplitAPKBundle>;
+
 export function Mkdir(arg1:string,arg2:string):Promise<void>;
 
 export function MoveFile(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function OpenDataDir():Promise<void>;
 
+export function OpenDeepLink(arg1:string,arg2:string):Promise<string>;
+
 export function OpenFile(arg1:string):Promise<void>;
 
 export function OpenFileOnHost(arg1:string,arg2:string):Promise<void>;
@@ -355,16 +515,34 @@ export function OpenPath(arg1:string):Promise<void>;
 
 export function OpenSettings(arg1:string,arg2:string,arg3:string):Promise<string>;
 
+export function PauseAllTasks():Promise<void>;
+
 export function PauseTask(arg1:string):Promise<void>;
 
 export function PerformNodeAction(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function PickPointOnScreen(arg1:string,arg2:number):Promise<Record<string, any>>;
 
+export function PingDevice(arg1:string):Promise<number>;
+
 export function PlayTouchScript(arg1:string,arg2:main.TouchScript):Promise<void>;
 
+export function PreauthorizeDevice(arg1:string):Promise<void>;
+
+export function PrefetchAppMetadata(arg1:string,arg2:Array<string>):Promise<void>;
+
+export function PregenerateThumbnails(arg1:string,arg2:string):Promise<number>;
+
+export function PressEnter(arg1:string):Promise<void>;
+
+export function PressTab(arg1:string):Promise<void>;
+
 export function PreviewAssertionMatch(arg1:string,arg2:Array<string>,arg3:string):Promise<number>;
 
+export function PreviewTextFile(arg1:string,arg2:string,arg3:number):Promise<string>;
+
+export function PushFile(arg1:string,arg2:string,arg3:string):Promise<string>;
+
 export function QuerySessionEvents(arg1:main.EventQuery):Promise<main.EventQueryResult>;
 
 export function QuickAssertCount(arg1:string,arg2:string,arg3:string,arg4:number,arg5:number):Promise<main.AssertionResult>;
@@ -377,14 +555,22 @@ export function QuickAssertNoErrors(arg1:string,arg2:string):Promise<main.Assert
 
 export function QuickAssertSequence(arg1:string,arg2:string,arg3:Array<string>):Promise<main.AssertionResult>;
 
+export function ReadImageFileAsDataURL(arg1:string):Promise<string>;
+
 export function ReadRecentLogs(arg1:number):Promise<Array<string>>;
 
 export function ReadVideoFileAsDataURL(arg1:string):Promise<string>;
 
+export function RebootDevice(arg1:string,arg2:string):Promise<string>;
+
+export function ReleaseDevice(arg1:string,arg2:string):Promise<void>;
+
 export function RemoveBreakpointRule(arg1:string):Promise<void>;
 
 export function RemoveHistoryDevice(arg1:string):Promise<void>;
 
+export function RemoveMDNSSerialPattern(arg1:string):Promise<Array<string>>;
+
 export function RemoveMapRemoteRule(arg1:string):Promise<void>;
 
 export function RemoveMockRule(arg1:string):Promise<void>;
@@ -399,14 +585,24 @@ export function RenameStoredSession(arg1:string,arg2:string):Promise<void>;
 
 export function RenameTouchScript(arg1:string,arg2:string):Promise<void>;
 
+export function ReplayProxyRequest(arg1:proxy.RequestLog):Promise<proxy.RequestLog>;
+
 export function ResendRequest(arg1:string,arg2:string,arg3:Record<string, string>,arg4:string):Promise<Record<string, any>>;
 
+export function ResetPermissions(arg1:string,arg2:string):Promise<string>;
+
 export function ResolveBreakpoint(arg1:string,arg2:string,arg3:Record<string, any>):Promise<void>;
 
 export function RestartAdbServer():Promise<string>;
 
+export function ResumeAllTasks():Promise<void>;
+
 export function ResumeTask(arg1:string):Promise<void>;
 
+export function RevokePermission(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function RingDevice(arg1:string):Promise<void>;
+
 export function RunAaptCommand(arg1:string,arg2:number):Promise<string>;
 
 export function RunAdbCommand(arg1:string,arg2:string):Promise<string>;
@@ -421,8 +617,12 @@ export function RunScriptTask(arg1:string,arg2:main.ScriptTask):Promise<void>;
 
 export function RunWorkflow(arg1:main.Device,arg2:types.Workflow):Promise<void>;
 
+export function SaveDeviceProfile(arg1:main.DeviceProfile):Promise<void>;
+
 export function SavePlugin(arg1:main.PluginSaveRequest):Promise<void>;
 
+export function SaveScrcpyConfig(arg1:string,arg2:main.ScrcpyConfig):Promise<void>;
+
 export function SaveScriptTask(arg1:main.ScriptTask):Promise<void>;
 
 export function SaveTouchScript(arg1:main.TouchScript):Promise<void>;
@@ -431,10 +631,14 @@ export function SaveWorkflow(arg1:types.Workflow):Promise<void>;
 
 export function ScrollToElement(arg1:context.Context,arg2:string,arg3:types.ElementSelector,arg4:string,arg5:number):Promise<void>;
 
+export function SearchAllSessions(arg1:string,arg2:number):Promise<Array<main.UnifiedEvent>>;
+
 export function SearchElementsAdvanced(arg1:main.UINode,arg2:string):Promise<Array<main.SearchResult>>;
 
 export function SearchElementsXPath(arg1:main.UINode,arg2:string):Promise<Array<main.SearchResult>>;
 
+export function SearchFiles(arg1:string,arg2:string,arg3:string,arg4:number):Promise<Array<main.FileInfo>>;
+
 export function SearchUIElements(arg1:string,arg2:string):Promise<Array<Record<string, any>>>;
 
 export function SelectAPKForBatch():Promise<string>;
@@ -445,22 +649,66 @@ export function SelectRecordPath(arg1:string):Promise<string>;
 
 export function SelectScreenshotPath(arg1:string):Promise<string>;
 
+export function SendBroadcast(arg1:string,arg2:string,arg3:Record<string, string>):Promise<string>;
+
+export function SendKeyEvent(arg1:string,arg2:string):Promise<void>;
+
 export function ServeVideoFile(arg1:http.ResponseWriter,arg2:http.Request,arg3:string):Promise<void>;
 
+export function SetAdbRetries(arg1:number):Promise<number>;
+
+export function SetAdbServerAddress(arg1:string,arg2:number):Promise<main.AdbServerSettings>;
+
+export function SetAppStandbyBucket(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function SetConcurrency(arg1:number,arg2:number,arg3:number):Promise<main.ConcurrencySettings>;
+
+export function SetDeviceClipboard(arg1:string,arg2:string):Promise<void>;
+
 export function SetDeviceNetworkLimit(arg1:string,arg2:number):Promise<string>;
 
+export function SetDeviceProxy(arg1:string,arg2:string,arg3:number):Promise<main.DeviceProxyResult>;
+
+export function SetLogcatBuffering(arg1:number,arg2:number):Promise<main.LogcatBufferingSettings>;
+
 export function SetMITMBypassPatterns(arg1:Array<string>):Promise<void>;
 
+export function SetNightMode(arg1:string,arg2:string):Promise<string>;
+
+export function SetOutputSettings(arg1:string,arg2:Record<string, string>):Promise<main.OutputSettings>;
+
+export function SetProxyCaptureFilter(arg1:Array<string>,arg2:Array<string>,arg3:Array<string>):Promise<void>;
+
 export function SetProxyDevice(arg1:string):Promise<void>;
 
 export function SetProxyLatency(arg1:number):Promise<void>;
 
 export function SetProxyLimit(arg1:number,arg2:number):Promise<void>;
 
+export function SetProxyLogBufferSize(arg1:arg1:number):Promise<void>;
+
 export function SetProxyMITM(arg1:boolean):Promise<void>;
 
 export function SetProxyWSEnabled(arg1:boolean):Promise<void>;
 
+export function SetReconnectSettings(arg1:number,arg2:number,arg3:number):Promise<main.ReconnectSettings>;
+
+export function SetRestartMirrorOnReconnect(arg1:string,arg2:boolean):Promise<void>;
+
+export function SetRetentionPolicy(arg1:number,arg2:number):Promise<void>;
+
+export function SetSafeMode(arg1:boolean):Promise<void>;
+
+export function SetScreenBrightness(arg1:string,arg2:number):Promise<number>;
+
+export function SetScreenTimeout(arg1:string,arg2:number):Promise<number>;
+
+export function SetSetting(arg1:string,arg2:string):Promise<void>;
+
+export function SetSourceSampling(arg1:string,arg2:number):Promise<void>;
+
+export function SetTraceWindowMs(arg1:number):Promise<number>;
+
 export function SetupBreakpointCallbacks():Promise<void>;
 
 export function SetupProxyForDevice(arg1:string,arg2:number):Promise<void>;
@@ -471,16 +719,30 @@ export function Shutdown(arg1:context.Context):Promise<void>;
 
 export function ShutdownWithoutGUI():Promise<void>;
 
+export function SnapshotDirectory(arg1:string,arg2:string):Promise<string>;
+
 export function StartActivity(arg1:string,arg2:string):Promise<string>;
 
+export function StartActivityWithIntent(arg1:string,arg2:main.IntentOptions):Promise<string>;
+
 export function StartApp(arg1:string,arg2:string):Promise<string>;
 
+export function StartBatteryMonitor(arg1:string):Promise<void>;
+
+export function StartConnectionMonitor(arg1:string):Promise<void>;
+
 export function StartDeviceMonitor():Promise<void>;
 
 export function StartDeviceStateMonitor(arg1:string):Promise<void>;
 
+export function StartInputMonitor(arg1:string):Promise<void>;
+
+export function StartListPackages(arg1:string,arg2:string,arg3:number):Promise<void>;
+
 export function StartLogcat(arg1:string,arg2:string,arg3:string,arg4:boolean,arg5:string,arg6:boolean):Promise<void>;
 
+export function StartLogcatToFile(arg1:string,arg2:string,arg3:string,arg4:boolean,arg5:string,arg6:boolean,arg7:string,arg8:number,arg9:number):Promise<string>;
+
 export function StartNetworkMonitor(arg1:string):Promise<void>;
 
 export function StartNewSession(arg1:string,arg2:string,arg3:string):Promise<string>;
@@ -501,17 +763,31 @@ export function StartWirelessServer():Promise<string>;
 
 export function StepNextWorkflow(arg1:string):Promise<types.WorkflowExecutionResult>;
 
+export function StopAllBatteryMonitors():Promise<void>;
+
+export function StopAllConnectionMonitors():Promise<void>;
+
 export function StopAllDeviceStateMonitors():Promise<void>;
 
+export function StopAllLogcat():Promise<void>;
+
 export function StopAllNetworkMonitors():Promise<void>;
 
 export function StopAllPerfMonitors():Promise<void>;
 
+export function StopAllTasks():Promise<void>;
+
+export function StopBatteryMonitor(arg1:string):Promise<void>;
+
+export function StopConnectionMonitor(arg1:string):Promise<void>;
+
 export function StopDeviceMonitor():Promise<void>;
 
 export function StopDeviceStateMonitor(arg1:string):Promise<void>;
 
-export function StopLogcat():Promise<void>;
+export function StopInputMonitor(arg1:string):Promise<void>;
+
+export function StopLogcat(arg1:string):Promise<void>;
 
 export function StopNetworkMonitor(arg1:string):Promise<void>;
 
@@ -533,6 +809,8 @@ export function StopWorkflow(arg1:main.Device):Promise<void>;
 
 export function SubmitSelectorChoice(arg1:string,arg2:string,arg3:string):Promise<void>;
 
+export function SuspendApp(arg1:string,arg2:string):Promise<string>;
+
 export function SwipeCoordinates(arg1:string,arg2:number,arg3:number,arg4:number,arg5:number,arg6:number):Promise<void>;
 
 export function SwipeOnElement(arg1:context.Context,arg2:string,arg3:types.ElementSelector,arg4:string,arg5:number,arg6:number,arg7:main.ElementActionConfig):Promise<void>;
@@ -541,6 +819,8 @@ export function SwitchToWireless(arg1:string):Promise<string>;
 
 export function TakeScreenshot(arg1:string,arg2:string):Promise<string>;
 
+export function TakeScreenshotDirect(arg1:string,arg2:string,arg3:boolean):Promise<string>;
+
 export function TapAtCoordinates(arg1:string,arg2:number,arg3:number):Promise<void>;
 
 export function TestPlugin(arg1:string,arg2:string):Promise<Array<main.UnifiedEvent>>;
@@ -551,6 +831,8 @@ export function TestPluginDetailed(arg1:string,arg2:string):Promise<main.PluginT
 
 export function TestPluginWithEventData(arg1:string,arg2:string):Promise<main.PluginTestResult>;
 
+export function ToggleBatteryOptimization(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
 export function ToggleBreakpointRule(arg1:string,arg2:boolean):Promise<void>;
 
 export function ToggleMapRemoteRule(arg1:string,arg2:boolean):Promise<void>;
@@ -563,7 +845,11 @@ export function TogglePlugin(arg1:string,arg2:boolean):Promise<void>;
 
 export function ToggleRewriteRule(arg1:string,arg2:boolean):Promise<void>;
 
-export function UninstallApp(arg1:string,arg2:string):Promise<string>;
+export function TypeText(arg1:string,arg2:string):Promise<void>;
+
+export function UninstallApp(arg1:string,arg2:string,arg3:string,arg4:number):Promise<string>;
+
+export function UnsuspendApp(arg1:string,arg2:string):Promise<string>;
 
 export function UpdateAssertionSet(arg1:string,arg2:string,arg3:string,arg4:Array<string>):Promise<void>;
 
@@ -585,6 +871,8 @@ export function UpdateStoredAssertionJSON(arg1:string,arg2:string):Promise<void>
 
 export function UploadFile(arg1:string,arg2:string,arg3:string):Promise<void>;
 
+export function VerifyTransfer(arg1:string,arg2:string,arg3:string):Promise<boolean>;
+
 export function WaitElementGone(arg1:context.Context,arg2:string,arg3:types.ElementSelector,arg4:number):Promise<void>;
 
 export function WaitForElement(arg1:context.Context,arg2:string,arg3:types.ElementSelector,arg4:number):Promise<void>;
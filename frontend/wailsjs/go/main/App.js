@@ -2,6 +2,10 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AcquireDevice(arg1, arg2) {
+  return window['go']['main']['App']['AcquireDevice'](arg1, arg2);
+}
+
 export function AdbConnect(arg1) {
   return window['go']['main']['App']['AdbConnect'](arg1);
 }
@@ -18,6 +22,10 @@ export function AddBreakpointRule(arg1) {
   return window['go']['main']['App']['AddBreakpointRule'](arg1);
 }
 
+export function AddMDNSSerialPattern(arg1) {
+  return window['go']['main']['App']['AddMDNSSerialPattern'](arg1);
+}
+
 export function AddMapRemoteRule(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['AddMapRemoteRule'](arg1, arg2, arg3, arg4);
 }
@@ -42,6 +50,14 @@ export function AnalyzeElementSelectors(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['AnalyzeElementSelectors'](arg1, arg2, arg3, arg4);
 }
 
+export function AnnotateScreenshot(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AnnotateScreenshot'](arg1, arg2, arg3);
+}
+
+export function ApplyDeviceProfile(arg1, arg2) {
+  return window['go']['main']['App']['ApplyDeviceProfile'](arg1, arg2);
+}
+
 export function AssertElementExists(arg1, arg2) {
   return window['go']['main']['App']['AssertElementExists'](arg1, arg2);
 }
@@ -50,10 +66,34 @@ export function AssertElementText(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['AssertElementText'](arg1, arg2, arg3, arg4);
 }
 
+export function BatchScreenshot(arg1, arg2) {
+  return window['go']['main']['App']['BatchScreenshot'](arg1, arg2);
+}
+
+export function CancelAdbPairingQR() {
+  return window['go']['main']['App']['CancelAdbPairingQR']();
+}
+
 export function CancelOpenFile(arg1) {
   return window['go']['main']['App']['CancelOpenFile'](arg1);
 }
 
+export function CancelPush(arg1) {
+  return window['go']['main']['App']['CancelPush'](arg1);
+}
+
+export function CancelThumbnailPregeneration(arg1) {
+  return window['go']['main']['App']['CancelThumbnailPregeneration'](arg1);
+}
+
+export function CaptureScreenshotOfPinnedDevice() {
+  return window['go']['main']['App']['CaptureScreenshotOfPinnedDevice']();
+}
+
+export function ChangeWirelessPort(arg1, arg2) {
+  return window['go']['main']['App']['ChangeWirelessPort'](arg1, arg2);
+}
+
 export function CheckCertTrust(arg1) {
   return window['go']['main']['App']['CheckCertTrust'](arg1);
 }
@@ -66,8 +106,16 @@ export function CleanupProxyForDevice(arg1, arg2) {
   return window['go']['main']['App']['CleanupProxyForDevice'](arg1, arg2);
 }
 
-export function ClearAppData(arg1, arg2) {
-  return window['go']['main']['App']['ClearAppData'](arg1, arg2);
+export function ClearAppData(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ClearAppData'](arg1, arg2, arg3);
+}
+
+export function ClearDeviceProxy(arg1) {
+  return window['go']['main']['App']['ClearDeviceProxy'](arg1);
+}
+
+export function ClearProxyLog() {
+  return window['go']['main']['App']['ClearProxyLog']();
 }
 
 export function ClearTextViaADBKeyboard(arg1) {
@@ -78,6 +126,10 @@ export function ClickElement(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['ClickElement'](arg1, arg2, arg3, arg4);
 }
 
+export function CompareSessions(arg1, arg2) {
+  return window['go']['main']['App']['CompareSessions'](arg1, arg2);
+}
+
 export function CopyFile(arg1, arg2, arg3) {
   return window['go']['main']['App']['CopyFile'](arg1, arg2, arg3);
 }
@@ -106,8 +158,16 @@ export function DeleteAssertionSet(arg1) {
   return window['go']['main']['App']['DeleteAssertionSet'](arg1);
 }
 
-export function DeleteFile(arg1, arg2) {
-  return window['go']['main']['App']['DeleteFile'](arg1, arg2);
+export function DeleteDeviceProfile(arg1) {
+  return window['go']['main']['App']['DeleteDeviceProfile'](arg1);
+}
+
+export function DeleteDirectorySnapshot(arg1) {
+  return window['go']['main']['App']['DeleteDirectorySnapshot'](arg1);
+}
+
+export function DeleteFile(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DeleteFile'](arg1, arg2, arg3);
 }
 
 export function DeletePlugin(arg1) {
@@ -138,8 +198,20 @@ export function DeleteWorkflow(arg1) {
   return window['go']['main']['App']['DeleteWorkflow'](arg1);
 }
 
-export function DisableApp(arg1, arg2) {
-  return window['go']['main']['App']['DisableApp'](arg1, arg2);
+export function DiffDirectorySnapshot(arg1, arg2) {
+  return window['go']['main']['App']['DiffDirectorySnapshot'](arg1, arg2);
+}
+
+export function DiffPackageLists(arg1, arg2) {
+  return window['go']['main']['App']['DiffPackageLists'](arg1, arg2);
+}
+
+export function DisableApp(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DisableApp'](arg1, arg2, arg3);
+}
+
+export function DownloadDirectoryAsZip(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DownloadDirectoryAsZip'](arg1, arg2, arg3);
 }
 
 export function DownloadFile(arg1, arg2) {
@@ -150,8 +222,12 @@ export function EmitEvent(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['EmitEvent'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
-export function EnableApp(arg1, arg2) {
-  return window['go']['main']['App']['EnableApp'](arg1, arg2);
+export function EnableApp(arg1, arg2, arg3) {
+  return window['go']['main']['App']['EnableApp'](arg1, arg2, arg3);
+}
+
+export function EnableAutoSessions(arg1) {
+  return window['go']['main']['App']['EnableAutoSessions'](arg1);
 }
 
 export function EndActiveSession(arg1, arg2) {
@@ -194,14 +270,38 @@ export function ExportAPK(arg1, arg2) {
   return window['go']['main']['App']['ExportAPK'](arg1, arg2);
 }
 
+export function ExportLogcatForForegroundApp(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportLogcatForForegroundApp'](arg1, arg2, arg3);
+}
+
 export function ExportMockRules() {
   return window['go']['main']['App']['ExportMockRules']();
 }
 
+export function ExportPackageList(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ExportPackageList'](arg1, arg2, arg3, arg4);
+}
+
+export function ExportProxyHar(arg1) {
+  return window['go']['main']['App']['ExportProxyHar'](arg1);
+}
+
+export function ExportReproductionCase(arg1, arg2) {
+  return window['go']['main']['App']['ExportReproductionCase'](arg1, arg2);
+}
+
+export function ExportScriptAsShell(arg1, arg2) {
+  return window['go']['main']['App']['ExportScriptAsShell'](arg1, arg2);
+}
+
 export function ExportSession(arg1) {
   return window['go']['main']['App']['ExportSession'](arg1);
 }
 
+export function ExportSessionReport(arg1, arg2) {
+  return window['go']['main']['App']['ExportSessionReport'](arg1, arg2);
+}
+
 export function ExportSessionToPath(arg1, arg2) {
   return window['go']['main']['App']['ExportSessionToPath'](arg1, arg2);
 }
@@ -230,10 +330,38 @@ export function ForwardAllBreakpoints() {
   return window['go']['main']['App']['ForwardAllBreakpoints']();
 }
 
+export function GenerateAdbPairingQR() {
+  return window['go']['main']['App']['GenerateAdbPairingQR']();
+}
+
+export function GetActivityStack(arg1) {
+  return window['go']['main']['App']['GetActivityStack'](arg1);
+}
+
+export function GetAdbPublicKey() {
+  return window['go']['main']['App']['GetAdbPublicKey']();
+}
+
+export function GetAdbRetries() {
+  return window['go']['main']['App']['GetAdbRetries']();
+}
+
+export function GetAdbServerAddress() {
+  return window['go']['main']['App']['GetAdbServerAddress']();
+}
+
 export function GetAppInfo(arg1, arg2, arg3) {
   return window['go']['main']['App']['GetAppInfo'](arg1, arg2, arg3);
 }
 
+export function GetAppPermissionsDetailed(arg1, arg2) {
+  return window['go']['main']['App']['GetAppPermissionsDetailed'](arg1, arg2);
+}
+
+export function GetAppPowerState(arg1, arg2) {
+  return window['go']['main']['App']['GetAppPowerState'](arg1, arg2);
+}
+
 export function GetAppVersion() {
   return window['go']['main']['App']['GetAppVersion']();
 }
@@ -254,14 +382,38 @@ export function GetBackendLogs() {
   return window['go']['main']['App']['GetBackendLogs']();
 }
 
+export function GetBinaryExtractErrors() {
+  return window['go']['main']['App']['GetBinaryExtractErrors']();
+}
+
 export function GetBreakpointRules() {
   return window['go']['main']['App']['GetBreakpointRules']();
 }
 
+export function GetConcurrency() {
+  return window['go']['main']['App']['GetConcurrency']();
+}
+
+export function GetDevice(arg1) {
+  return window['go']['main']['App']['GetDevice'](arg1);
+}
+
 export function GetDeviceActiveSession(arg1) {
   return window['go']['main']['App']['GetDeviceActiveSession'](arg1);
 }
 
+export function GetDeviceBusyState(arg1) {
+  return window['go']['main']['App']['GetDeviceBusyState'](arg1);
+}
+
+export function GetDeviceClipboard(arg1) {
+  return window['go']['main']['App']['GetDeviceClipboard'](arg1);
+}
+
+export function GetDeviceDashboard(arg1) {
+  return window['go']['main']['App']['GetDeviceDashboard'](arg1);
+}
+
 export function GetDeviceIP(arg1) {
   return window['go']['main']['App']['GetDeviceIP'](arg1);
 }
@@ -278,6 +430,10 @@ export function GetDevices(arg1) {
   return window['go']['main']['App']['GetDevices'](arg1);
 }
 
+export function GetDirectorySize(arg1, arg2) {
+  return window['go']['main']['App']['GetDirectorySize'](arg1, arg2);
+}
+
 export function GetElementProperties(arg1, arg2) {
   return window['go']['main']['App']['GetElementProperties'](arg1, arg2);
 }
@@ -290,6 +446,10 @@ export function GetEventSystemStats() {
   return window['go']['main']['App']['GetEventSystemStats']();
 }
 
+export function GetForegroundApp(arg1) {
+  return window['go']['main']['App']['GetForegroundApp'](arg1);
+}
+
 export function GetHistoryDevices() {
   return window['go']['main']['App']['GetHistoryDevices']();
 }
@@ -310,6 +470,18 @@ export function GetLogFilePath() {
   return window['go']['main']['App']['GetLogFilePath']();
 }
 
+export function GetLogcatBuffering() {
+  return window['go']['main']['App']['GetLogcatBuffering']();
+}
+
+export function GetLogcatDevices() {
+  return window['go']['main']['App']['GetLogcatDevices']();
+}
+
+export function GetMDNSSerialPatterns() {
+  return window['go']['main']['App']['GetMDNSSerialPatterns']();
+}
+
 export function GetMITMBypassPatterns() {
   return window['go']['main']['App']['GetMITMBypassPatterns']();
 }
@@ -318,10 +490,22 @@ export function GetMapRemoteRules() {
   return window['go']['main']['App']['GetMapRemoteRules']();
 }
 
+export function GetMemoryBreakdown(arg1) {
+  return window['go']['main']['App']['GetMemoryBreakdown'](arg1);
+}
+
 export function GetMockRules() {
   return window['go']['main']['App']['GetMockRules']();
 }
 
+export function GetOutputPath(arg1, arg2) {
+  return window['go']['main']['App']['GetOutputPath'](arg1, arg2);
+}
+
+export function GetOutputSettings() {
+  return window['go']['main']['App']['GetOutputSettings']();
+}
+
 export function GetPendingBreakpoints() {
   return window['go']['main']['App']['GetPendingBreakpoints']();
 }
@@ -354,10 +538,18 @@ export function GetProtoMessageTypes() {
   return window['go']['main']['App']['GetProtoMessageTypes']();
 }
 
+export function GetProxyCaptureFilter() {
+  return window['go']['main']['App']['GetProxyCaptureFilter']();
+}
+
 export function GetProxyDevice() {
   return window['go']['main']['App']['GetProxyDevice']();
 }
 
+export function GetProxyLogBufferSize() {
+  return window['go']['main']['App']['GetProxyLogBufferSize']();
+}
+
 export function GetProxySettings() {
   return window['go']['main']['App']['GetProxySettings']();
 }
@@ -366,10 +558,22 @@ export function GetProxyStatus() {
   return window['go']['main']['App']['GetProxyStatus']();
 }
 
+export function GetQuickConnectTargets() {
+  return window['go']['main']['App']['GetQuickConnectTargets']();
+}
+
 export function GetRecentSessionEvents(arg1, arg2) {
   return window['go']['main']['App']['GetRecentSessionEvents'](arg1, arg2);
 }
 
+export function GetReconnectSettings() {
+  return window['go']['main']['App']['GetReconnectSettings']();
+}
+
+export function GetReconnectStats(arg1) {
+  return window['go']['main']['App']['GetReconnectStats'](arg1);
+}
+
 export function GetRecordingEventCount(arg1) {
   return window['go']['main']['App']['GetRecordingEventCount'](arg1);
 }
@@ -378,6 +582,14 @@ export function GetRecordingsDir() {
   return window['go']['main']['App']['GetRecordingsDir']();
 }
 
+export function GetRestartMirrorOnReconnect(arg1) {
+  return window['go']['main']['App']['GetRestartMirrorOnReconnect'](arg1);
+}
+
+export function GetRetentionPolicy() {
+  return window['go']['main']['App']['GetRetentionPolicy']();
+}
+
 export function GetRewriteRules() {
   return window['go']['main']['App']['GetRewriteRules']();
 }
@@ -386,6 +598,30 @@ export function GetSampleEvents(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['GetSampleEvents'](arg1, arg2, arg3, arg4);
 }
 
+export function GetScrcpyArgsPreview(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetScrcpyArgsPreview'](arg1, arg2, arg3);
+}
+
+export function GetScrcpyConfig(arg1) {
+  return window['go']['main']['App']['GetScrcpyConfig'](arg1);
+}
+
+export function GetScreenBrightness(arg1) {
+  return window['go']['main']['App']['GetScreenBrightness'](arg1);
+}
+
+export function GetScreenState(arg1) {
+  return window['go']['main']['App']['GetScreenState'](arg1);
+}
+
+export function GetScreenTimeout(arg1) {
+  return window['go']['main']['App']['GetScreenTimeout'](arg1);
+}
+
+export function GetScreencapPNG(arg1) {
+  return window['go']['main']['App']['GetScreencapPNG'](arg1);
+}
+
 export function GetSessionBookmarks(arg1) {
   return window['go']['main']['App']['GetSessionBookmarks'](arg1);
 }
@@ -414,6 +650,18 @@ export function GetSessionVideoInfo(arg1) {
   return window['go']['main']['App']['GetSessionVideoInfo'](arg1);
 }
 
+export function GetSetting(arg1) {
+  return window['go']['main']['App']['GetSetting'](arg1);
+}
+
+export function GetShellCommandCompletions(arg1, arg2) {
+  return window['go']['main']['App']['GetShellCommandCompletions'](arg1, arg2);
+}
+
+export function GetSourceSampling() {
+  return window['go']['main']['App']['GetSourceSampling']();
+}
+
 export function GetStorageInfo() {
   return window['go']['main']['App']['GetStorageInfo']();
 }
@@ -430,6 +678,10 @@ export function GetStoredSession(arg1) {
   return window['go']['main']['App']['GetStoredSession'](arg1);
 }
 
+export function GetSupportedKeys() {
+  return window['go']['main']['App']['GetSupportedKeys']();
+}
+
 export function GetThumbnail(arg1, arg2, arg3) {
   return window['go']['main']['App']['GetThumbnail'](arg1, arg2, arg3);
 }
@@ -438,6 +690,10 @@ export function GetTouchInputDevice(arg1) {
   return window['go']['main']['App']['GetTouchInputDevice'](arg1);
 }
 
+export function GetTraceWindowMs() {
+  return window['go']['main']['App']['GetTraceWindowMs']();
+}
+
 export function GetUIHierarchy(arg1) {
   return window['go']['main']['App']['GetUIHierarchy'](arg1);
 }
@@ -466,6 +722,10 @@ export function GetVideoThumbnails(arg1, arg2, arg3) {
   return window['go']['main']['App']['GetVideoThumbnails'](arg1, arg2, arg3);
 }
 
+export function GetWirelessInfo(arg1) {
+  return window['go']['main']['App']['GetWirelessInfo'](arg1);
+}
+
 export function GetWorkflow(arg1) {
   return window['go']['main']['App']['GetWorkflow'](arg1);
 }
@@ -474,6 +734,10 @@ export function GetWorkflowExecutionResult(arg1) {
   return window['go']['main']['App']['GetWorkflowExecutionResult'](arg1);
 }
 
+export function GrantPermission(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GrantPermission'](arg1, arg2, arg3);
+}
+
 export function ImportMockRules(arg1) {
   return window['go']['main']['App']['ImportMockRules'](arg1);
 }
@@ -486,6 +750,10 @@ export function ImportSessionFromPath(arg1) {
   return window['go']['main']['App']['ImportSessionFromPath'](arg1);
 }
 
+export function ImportTouchScriptFromGetevent(arg1, arg2) {
+  return window['go']['main']['App']['ImportTouchScriptFromGetevent'](arg1, arg2);
+}
+
 export function InitializeWithoutGUI() {
   return window['go']['main']['App']['InitializeWithoutGUI']();
 }
@@ -510,12 +778,16 @@ export function InstallAAB(arg1, arg2) {
   return window['go']['main']['App']['InstallAAB'](arg1, arg2);
 }
 
-export function InstallAPK(arg1, arg2) {
-  return window['go']['main']['App']['InstallAPK'](arg1, arg2);
+export function InstallAPK(arg1, arg2, arg3) {
+  return window['go']['main']['App']['InstallAPK'](arg1, arg2, arg3);
+}
+
+export function InstallAPKs(arg1, arg2) {
+  return window['go']['main']['App']['InstallAPKs'](arg1, arg2);
 }
 
-export function InstallPackage(arg1, arg2) {
-  return window['go']['main']['App']['InstallPackage'](arg1, arg2);
+export function InstallPackage(arg1, arg2, arg3) {
+  return window['go']['main']['App']['InstallPackage'](arg1, arg2, arg3);
 }
 
 export function InstallProxyCert(arg1) {
@@ -534,10 +806,26 @@ export function IsADBKeyboardInstalled(arg1) {
   return window['go']['main']['App']['IsADBKeyboardInstalled'](arg1);
 }
 
+export function IsAppInstalled(arg1, arg2) {
+  return window['go']['main']['App']['IsAppInstalled'](arg1, arg2);
+}
+
 export function IsAppRunning(arg1, arg2) {
   return window['go']['main']['App']['IsAppRunning'](arg1, arg2);
 }
 
+export function IsAppSuspended(arg1, arg2) {
+  return window['go']['main']['App']['IsAppSuspended'](arg1, arg2);
+}
+
+export function IsAutoSessionsEnabled() {
+  return window['go']['main']['App']['IsAutoSessionsEnabled']();
+}
+
+export function IsHostSleepInhibited() {
+  return window['go']['main']['App']['IsHostSleepInhibited']();
+}
+
 export function IsMCPMode() {
   return window['go']['main']['App']['IsMCPMode']();
 }
@@ -558,6 +846,10 @@ export function IsRecordingTouch(arg1) {
   return window['go']['main']['App']['IsRecordingTouch'](arg1);
 }
 
+export function IsSafeModeEnabled() {
+  return window['go']['main']['App']['IsSafeModeEnabled']();
+}
+
 export function IsTaskPaused(arg1) {
   return window['go']['main']['App']['IsTaskPaused'](arg1);
 }
@@ -566,6 +858,10 @@ export function IsWorkflowRunning(arg1) {
   return window['go']['main']['App']['IsWorkflowRunning'](arg1);
 }
 
+export function LaunchWithDeepLink(arg1, arg2) {
+  return window['go']['main']['App']['LaunchWithDeepLink'](arg1, arg2);
+}
+
 export function ListAssertionResults(arg1, arg2) {
   return window['go']['main']['App']['ListAssertionResults'](arg1, arg2);
 }
@@ -590,8 +886,12 @@ export function ListLogFiles() {
   return window['go']['main']['App']['ListLogFiles']();
 }
 
-export function ListPackages(arg1, arg2) {
-  return window['go']['main']['App']['ListPackages'](arg1, arg2);
+export function ListMdnsServices() {
+  return window['go']['main']['App']['ListMdnsServices']();
+}
+
+export function ListPackages(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ListPackages'](arg1, arg2, arg3);
 }
 
 export function ListPlugins() {
@@ -610,10 +910,22 @@ export function ListStoredSessions(arg1, arg2) {
   return window['go']['main']['App']['ListStoredSessions'](arg1, arg2);
 }
 
+export function ListStoredSessionsFiltered(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
+  return window['go']['main']['App']['ListStoredSessionsFiltered'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
+}
+
+export function ListUsers(arg1) {
+  return window['go']['main']['App']['ListUsers'](arg1);
+}
+
 export function LoadBreakpointRules() {
   return window['go']['main']['App']['LoadBreakpointRules']();
 }
 
+export function LoadDeviceProfiles() {
+  return window['go']['main']['App']['LoadDeviceProfiles']();
+}
+
 export function LoadMapRemoteRules() {
   return window['go']['main']['App']['LoadMapRemoteRules']();
 }
@@ -666,6 +978,10 @@ export function LongPressAtCoordinates(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['LongPressAtCoordinates'](arg1, arg2, arg3, arg4);
 }
 
+export function MergeSplitAPKs(arg1, arg2, arg3) {
+  return window['go']['main']['App']['MergeSplitAPKs'](arg1, arg2, arg3);
+}
+
 export function Mkdir(arg1, arg2) {
   return window['go']['main']['App']['Mkdir'](arg1, arg2);
 }
@@ -678,6 +994,10 @@ export function OpenDataDir() {
   return window['go']['main']['App']['OpenDataDir']();
 }
 
+export function OpenDeepLink(arg1, arg2) {
+  return window['go']['main']['App']['OpenDeepLink'](arg1, arg2);
+}
+
 export function OpenFile(arg1) {
   return window['go']['main']['App']['OpenFile'](arg1);
 }
@@ -698,6 +1018,10 @@ export function OpenSettings(arg1, arg2, arg3) {
   return window['go']['main']['App']['OpenSettings'](arg1, arg2, arg3);
 }
 
+export function PauseAllTasks() {
+  return window['go']['main']['App']['PauseAllTasks']();
+}
+
 export function PauseTask(arg1) {
   return window['go']['main']['App']['PauseTask'](arg1);
 }
@@ -710,14 +1034,46 @@ export function PickPointOnScreen(arg1, arg2) {
   return window['go']['main']['App']['PickPointOnScreen'](arg1, arg2);
 }
 
+export function PingDevice(arg1) {
+  return window['go']['main']['App']['PingDevice'](arg1);
+}
+
 export function PlayTouchScript(arg1, arg2) {
   return window['go']['main']['App']['PlayTouchScript'](arg1, arg2);
 }
 
+export function PreauthorizeDevice(arg1) {
+  return window['go']['main']['App']['PreauthorizeDevice'](arg1);
+}
+
+export function PrefetchAppMetadata(arg1, arg2) {
+  return window['go']['main']['App']['PrefetchAppMetadata'](arg1, arg2);
+}
+
+export function PregenerateThumbnails(arg1, arg2) {
+  return window['go']['main']['App']['PregenerateThumbnails'](arg1, arg2);
+}
+
+export function PressEnter(arg1) {
+  return window['go']['main']['App']['PressEnter'](arg1);
+}
+
+export function PressTab(arg1) {
+  return window['go']['main']['App']['PressTab'](arg1);
+}
+
 export function PreviewAssertionMatch(arg1, arg2, arg3) {
   return window['go']['main']['App']['PreviewAssertionMatch'](arg1, arg2, arg3);
 }
 
+export function PreviewTextFile(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PreviewTextFile'](arg1, arg2, arg3);
+}
+
+export function PushFile(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PushFile'](arg1, arg2, arg3);
+}
+
 export function QuerySessionEvents(arg1) {
   return window['go']['main']['App']['QuerySessionEvents'](arg1);
 }
@@ -742,6 +1098,10 @@ export function QuickAssertSequence(arg1, arg2, arg3) {
   return window['go']['main']['App']['QuickAssertSequence'](arg1, arg2, arg3);
 }
 
+export function ReadImageFileAsDataURL(arg1) {
+  return window['go']['main']['App']['ReadImageFileAsDataURL'](arg1);
+}
+
 export function ReadRecentLogs(arg1) {
   return window['go']['main']['App']['ReadRecentLogs'](arg1);
 }
@@ -750,6 +1110,14 @@ export function ReadVideoFileAsDataURL(arg1) {
   return window['go']['main']['App']['ReadVideoFileAsDataURL'](arg1);
 }
 
+export function RebootDevice(arg1, arg2) {
+  return window['go']['main']['App']['RebootDevice'](arg1, arg2);
+}
+
+export function ReleaseDevice(arg1, arg2) {
+  return window['go']['main']['App']['ReleaseDevice'](arg1, arg2);
+}
+
 export function RemoveBreakpointRule(arg1) {
   return window['go']['main']['App']['RemoveBreakpointRule'](arg1);
 }
@@ -758,6 +1126,10 @@ export function RemoveHistoryDevice(arg1) {
   return window['go']['main']['App']['RemoveHistoryDevice'](arg1);
 }
 
+export function RemoveMDNSSerialPattern(arg1) {
+  return window['go']['main']['App']['RemoveMDNSSerialPattern'](arg1);
+}
+
 export function RemoveMapRemoteRule(arg1) {
   return window['go']['main']['App']['RemoveMapRemoteRule'](arg1);
 }
@@ -786,10 +1158,18 @@ export function RenameTouchScript(arg1, arg2) {
   return window['go']['main']['App']['RenameTouchScript'](arg1, arg2);
 }
 
+export function ReplayProxyRequest(arg1) {
+  return window['go']['main']['App']['ReplayProxyRequest'](arg1);
+}
+
 export function ResendRequest(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['ResendRequest'](arg1, arg2, arg3, arg4);
 }
 
+export function ResetPermissions(arg1, arg2) {
+  return window['go']['main']['App']['ResetPermissions'](arg1, arg2);
+}
+
 export function ResolveBreakpoint(arg1, arg2, arg3) {
   return window['go']['main']['App']['ResolveBreakpoint'](arg1, arg2, arg3);
 }
@@ -798,10 +1178,22 @@ export function RestartAdbServer() {
   return window['go']['main']['App']['RestartAdbServer']();
 }
 
+export function ResumeAllTasks() {
+  return window['go']['main']['App']['ResumeAllTasks']();
+}
+
 export function ResumeTask(arg1) {
   return window['go']['main']['App']['ResumeTask'](arg1);
 }
 
+export function RevokePermission(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RevokePermission'](arg1, arg2, arg3);
+}
+
+export function RingDevice(arg1) {
+  return window['go']['main']['App']['RingDevice'](arg1);
+}
+
 export function RunAaptCommand(arg1, arg2) {
   return window['go']['main']['App']['RunAaptCommand'](arg1, arg2);
 }
@@ -830,10 +1222,18 @@ export function RunWorkflow(arg1, arg2) {
   return window['go']['main']['App']['RunWorkflow'](arg1, arg2);
 }
 
+export function SaveDeviceProfile(arg1) {
+  return window['go']['main']['App']['SaveDeviceProfile'](arg1);
+}
+
 export function SavePlugin(arg1) {
   return window['go']['main']['App']['SavePlugin'](arg1);
 }
 
+export function SaveScrcpyConfig(arg1, arg2) {
+  return window['go']['main']['App']['SaveScrcpyConfig'](arg1, arg2);
+}
+
 export function SaveScriptTask(arg1) {
   return window['go']['main']['App']['SaveScriptTask'](arg1);
 }
@@ -850,6 +1250,10 @@ export function ScrollToElement(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['ScrollToElement'](arg1, arg2, arg3, arg4, arg5);
 }
 
+export function SearchAllSessions(arg1, arg2) {
+  return window['go']['main']['App']['SearchAllSessions'](arg1, arg2);
+}
+
 export function SearchElementsAdvanced(arg1, arg2) {
   return window['go']['main']['App']['SearchElementsAdvanced'](arg1, arg2);
 }
@@ -858,6 +1262,10 @@ export function SearchElementsXPath(arg1, arg2) {
   return window['go']['main']['App']['SearchElementsXPath'](arg1, arg2);
 }
 
+export function SearchFiles(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SearchFiles'](arg1, arg2, arg3, arg4);
+}
+
 export function SearchUIElements(arg1, arg2) {
   return window['go']['main']['App']['SearchUIElements'](arg1, arg2);
 }
@@ -878,18 +1286,66 @@ export function SelectScreenshotPath(arg1) {
   return window['go']['main']['App']['SelectScreenshotPath'](arg1);
 }
 
+export function SendBroadcast(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SendBroadcast'](arg1, arg2, arg3);
+}
+
+export function SendKeyEvent(arg1, arg2) {
+  return window['go']['main']['App']['SendKeyEvent'](arg1, arg2);
+}
+
 export function ServeVideoFile(arg1, arg2, arg3) {
   return window['go']['main']['App']['ServeVideoFile'](arg1, arg2, arg3);
 }
 
+export function SetAdbRetries(arg1) {
+  return window['go']['main']['App']['SetAdbRetries'](arg1);
+}
+
+export function SetAdbServerAddress(arg1, arg2) {
+  return window['go']['main']['App']['SetAdbServerAddress'](arg1, arg2);
+}
+
+export function SetAppStandbyBucket(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetAppStandbyBucket'](arg1, arg2, arg3);
+}
+
+export function SetConcurrency(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetConcurrency'](arg1, arg2, arg3);
+}
+
+export function SetDeviceClipboard(arg1, arg2) {
+  return window['go']['main']['App']['SetDeviceClipboard'](arg1, arg2);
+}
+
 export function SetDeviceNetworkLimit(arg1, arg2) {
   return window['go']['main']['App']['SetDeviceNetworkLimit'](arg1, arg2);
 }
 
+export function SetDeviceProxy(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetDeviceProxy'](arg1, arg2, arg3);
+}
+
+export function SetLogcatBuffering(arg1, arg2) {
+  return window['go']['main']['App']['SetLogcatBuffering'](arg1, arg2);
+}
+
 export function SetMITMBypassPatterns(arg1) {
   return window['go']['main']['App']['SetMITMBypassPatterns'](arg1);
 }
 
+export function SetNightMode(arg1, arg2) {
+  return window['go']['main']['App']['SetNightMode'](arg1, arg2);
+}
+
+export function SetOutputSettings(arg1, arg2) {
+  return window['go']['main']['App']['SetOutputSettings'](arg1, arg2);
+}
+
+export function SetProxyCaptureFilter(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetProxyCaptureFilter'](arg1, arg2, arg3);
+}
+
 export function SetProxyDevice(arg1) {
   return window['go']['main']['App']['SetProxyDevice'](arg1);
 }
@@ -902,6 +1358,10 @@ export function SetProxyLimit(arg1, arg2) {
   return window['go']['main']['App']['SetProxyLimit'](arg1, arg2);
 }
 
+export function SetProxyLogBufferSize(arg1) {
+  return window['go']['main']['App']['SetProxyLogBufferSize'](arg1);
+}
+
 export function SetProxyMITM(arg1) {
   return window['go']['main']['App']['SetProxyMITM'](arg1);
 }
@@ -910,6 +1370,42 @@ export function SetProxyWSEnabled(arg1) {
   return window['go']['main']['App']['SetProxyWSEnabled'](arg1);
 }
 
+export function SetReconnectSettings(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetReconnectSettings'](arg1, arg2, arg3);
+}
+
+export function SetRestartMirrorOnReconnect(arg1, arg2) {
+  return window['go']['main']['App']['SetRestartMirrorOnReconnect'](arg1, arg2);
+}
+
+export function SetRetentionPolicy(arg1, arg2) {
+  return window['go']['main']['App']['SetRetentionPolicy'](arg1, arg2);
+}
+
+export function SetSafeMode(arg1) {
+  return window['go']['main']['App']['SetSafeMode'](arg1);
+}
+
+export function SetScreenBrightness(arg1, arg2) {
+  return window['go']['main']['App']['SetScreenBrightness'](arg1, arg2);
+}
+
+export function SetScreenTimeout(arg1, arg2) {
+  return window['go']['main']['App']['SetScreenTimeout'](arg1, arg2);
+}
+
+export function SetSetting(arg1, arg2) {
+  return window['go']['main']['App']['SetSetting'](arg1, arg2);
+}
+
+export function SetSourceSampling(arg1, arg2) {
+  return window['go']['main']['App']['SetSourceSampling'](arg1, arg2);
+}
+
+export function SetTraceWindowMs(arg1) {
+  return window['go']['main']['App']['SetTraceWindowMs'](arg1);
+}
+
 export function SetupBreakpointCallbacks() {
   return window['go']['main']['App']['SetupBreakpointCallbacks']();
 }
@@ -930,14 +1426,30 @@ export function ShutdownWithoutGUI() {
   return window['go']['main']['App']['ShutdownWithoutGUI']();
 }
 
+export function SnapshotDirectory(arg1, arg2) {
+  return window['go']['main']['App']['SnapshotDirectory'](arg1, arg2);
+}
+
 export function StartActivity(arg1, arg2) {
   return window['go']['main']['App']['StartActivity'](arg1, arg2);
 }
 
+export function StartActivityWithIntent(arg1, arg2) {
+  return window['go']['main']['App']['StartActivityWithIntent'](arg1, arg2);
+}
+
 export function StartApp(arg1, arg2) {
   return window['go']['main']['App']['StartApp'](arg1, arg2);
 }
 
+export function StartBatteryMonitor(arg1) {
+  return window['go']['main']['App']['StartBatteryMonitor'](arg1);
+}
+
+export function StartConnectionMonitor(arg1) {
+  return window['go']['main']['App']['StartConnectionMonitor'](arg1);
+}
+
 export function StartDeviceMonitor() {
   return window['go']['main']['App']['StartDeviceMonitor']();
 }
@@ -946,10 +1458,22 @@ export function StartDeviceStateMonitor(arg1) {
   return window['go']['main']['App']['StartDeviceStateMonitor'](arg1);
 }
 
+export function StartInputMonitor(arg1) {
+  return window['go']['main']['App']['StartInputMonitor'](arg1);
+}
+
+export function StartListPackages(arg1, arg2, arg3) {
+  return window['go']['main']['App']['StartListPackages'](arg1, arg2, arg3);
+}
+
 export function StartLogcat(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['StartLogcat'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
+export function StartLogcatToFile(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9) {
+  return window['go']['main']['App']['StartLogcatToFile'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9);
+}
+
 export function StartNetworkMonitor(arg1) {
   return window['go']['main']['App']['StartNetworkMonitor'](arg1);
 }
@@ -990,10 +1514,22 @@ export function StepNextWorkflow(arg1) {
   return window['go']['main']['App']['StepNextWorkflow'](arg1);
 }
 
+export function StopAllBatteryMonitors() {
+  return window['go']['main']['App']['StopAllBatteryMonitors']();
+}
+
+export function StopAllConnectionMonitors() {
+  return window['go']['main']['App']['StopAllConnectionMonitors']();
+}
+
 export function StopAllDeviceStateMonitors() {
   return window['go']['main']['App']['StopAllDeviceStateMonitors']();
 }
 
+export function StopAllLogcat() {
+  return window['go']['main']['App']['StopAllLogcat']();
+}
+
 export function StopAllNetworkMonitors() {
   return window['go']['main']['App']['StopAllNetworkMonitors']();
 }
@@ -1002,6 +1538,18 @@ export function StopAllPerfMonitors() {
   return window['go']['main']['App']['StopAllPerfMonitors']();
 }
 
+export function StopAllTasks() {
+  return window['go']['main']['App']['StopAllTasks']();
+}
+
+export function StopBatteryMonitor(arg1) {
+  return window['go']['main']['App']['StopBatteryMonitor'](arg1);
+}
+
+export function StopConnectionMonitor(arg1) {
+  return window['go']['main']['App']['StopConnectionMonitor'](arg1);
+}
+
 export function StopDeviceMonitor() {
   return window['go']['main']['App']['StopDeviceMonitor']();
 }
@@ -1010,8 +1558,12 @@ export function StopDeviceStateMonitor(arg1) {
   return window['go']['main']['App']['StopDeviceStateMonitor'](arg1);
 }
 
-export function StopLogcat() {
-  return window['go']['main']['App']['StopLogcat']();
+export function StopInputMonitor(arg1) {
+  return window['go']['main']['App']['StopInputMonitor'](arg1);
+}
+
+export function StopLogcat(arg1) {
+  return window['go']['main']['App']['StopLogcat'](arg1);
 }
 
 export function StopNetworkMonitor(arg1) {
@@ -1054,6 +1606,10 @@ export function SubmitSelectorChoice(arg1, arg2, arg3) {
   return window['go']['main']['App']['SubmitSelectorChoice'](arg1, arg2, arg3);
 }
 
+export function SuspendApp(arg1, arg2) {
+  return window['go']['main']['App']['SuspendApp'](arg1, arg2);
+}
+
 export function SwipeCoordinates(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['SwipeCoordinates'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
@@ -1070,6 +1626,10 @@ export function TakeScreenshot(arg1, arg2) {
   return window['go']['main']['App']['TakeScreenshot'](arg1, arg2);
 }
 
+export function TakeScreenshotDirect(arg1, arg2, arg3) {
+  return window['go']['main']['App']['TakeScreenshotDirect'](arg1, arg2, arg3);
+}
+
 export function TapAtCoordinates(arg1, arg2, arg3) {
   return window['go']['main']['App']['TapAtCoordinates'](arg1, arg2, arg3);
 }
@@ -1090,6 +1650,10 @@ export function TestPluginWithEventData(arg1, arg2) {
   return window['go']['main']['App']['TestPluginWithEventData'](arg1, arg2);
 }
 
+export function ToggleBatteryOptimization(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ToggleBatteryOptimization'](arg1, arg2, arg3);
+}
+
 export function ToggleBreakpointRule(arg1, arg2) {
   return window['go']['main']['App']['ToggleBreakpointRule'](arg1, arg2);
 }
@@ -1114,8 +1678,16 @@ export function ToggleRewriteRule(arg1, arg2) {
   return window['go']['main']['App']['ToggleRewriteRule'](arg1, arg2);
 }
 
-export function UninstallApp(arg1, arg2) {
-  return window['go']['main']['App']['UninstallApp'](arg1, arg2);
+export function TypeText(arg1, arg2) {
+  return window['go']['main']['App']['TypeText'](arg1, arg2);
+}
+
+export function UninstallApp(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['UninstallApp'](arg1, arg2, arg3, arg4);
+}
+
+export function UnsuspendApp(arg1, arg2) {
+  return window['go']['main']['App']['UnsuspendApp'](arg1, arg2);
 }
 
 export function UpdateAssertionSet(arg1, arg2, arg3, arg4) {
@@ -1158,6 +1730,10 @@ export function UploadFile(arg1, arg2, arg3) {
   return window['go']['main']['App']['UploadFile'](arg1, arg2, arg3);
 }
 
+export function VerifyTransfer(arg1, arg2, arg3) {
+  return window['go']['main']['App']['VerifyTransfer'](arg1, arg2, arg3);
+}
+
 export function WaitElementGone(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['WaitElementGone'](arg1, arg2, arg3, arg4);
 }
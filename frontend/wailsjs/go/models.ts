@@ -129,6 +129,54 @@ export namespace http {
 
 export namespace main {
 	
+	export class AndroidUser {
+	    id: number;
+	    name: string;
+	    flags: number;
+	    running: boolean;
+	    workProfile: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AndroidUser(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.flags = source["flags"];
+	        this.running = source["running"];
+	        this.workProfile = source["workProfile"];
+	    }
+	}
+	export class Annotation {
+	    type: string;
+	    x: number;
+	    y: number;
+	    x2?: number;
+	    y2?: number;
+	    text?: string;
+	    color?: string;
+	    width?: number;
+	    fontSize?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Annotation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.x2 = source["x2"];
+	        this.y2 = source["y2"];
+	        this.text = source["text"];
+	        this.color = source["color"];
+	        this.width = source["width"];
+	        this.fontSize = source["fontSize"];
+	    }
+	}
 	export class AppPackage {
 	    name: string;
 	    label: string;
@@ -604,6 +652,7 @@ export namespace main {
 	    wifiAddr: string;
 	    lastActive: number;
 	    isPinned: boolean;
+	    hint?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new Device(source);
@@ -621,6 +670,7 @@ export namespace main {
 	        this.wifiAddr = source["wifiAddr"];
 	        this.lastActive = source["lastActive"];
 	        this.isPinned = source["isPinned"];
+	        this.hint = source["hint"];
 	    }
 	}
 	export class DeviceInfo {
@@ -914,6 +964,7 @@ export namespace main {
 	    id: string;
 	    sessionId: string;
 	    deviceId: string;
+	    sessionName?: string;
 	    timestamp: number;
 	    relativeTime: number;
 	    duration?: number;
@@ -946,6 +997,7 @@ export namespace main {
 	        this.id = source["id"];
 	        this.sessionId = source["sessionId"];
 	        this.deviceId = source["deviceId"];
+	        this.sessionName = source["sessionName"];
 	        this.timestamp = source["timestamp"];
 	        this.relativeTime = source["relativeTime"];
 	        this.duration = source["duration"];
@@ -1617,6 +1669,46 @@ export namespace main {
 	}
 	
 	
+	export class ReconnectSettings {
+	    reconnectCooldownSec: number;
+	    reconnectTimeoutSec: number;
+	    maxAttempts: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReconnectSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.reconnectCooldownSec = source["reconnectCooldownSec"];
+	        this.reconnectTimeoutSec = source["reconnectTimeoutSec"];
+	        this.maxAttempts = source["maxAttempts"];
+	    }
+	}
+	export class ReconnectStats {
+	    address: string;
+	    attempts: number;
+	    failures: number;
+	    lastAttempt: number;
+	    nextAttempt: number;
+	    lastError?: string;
+	    gaveUp: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReconnectStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = source["address"];
+	        this.attempts = source["attempts"];
+	        this.failures = source["failures"];
+	        this.lastAttempt = source["lastAttempt"];
+	        this.nextAttempt = source["nextAttempt"];
+	        this.lastError = source["lastError"];
+	        this.gaveUp = source["gaveUp"];
+	    }
+	}
 	export class RewriteRule {
 	    id: string;
 	    urlPattern: string;
@@ -1656,6 +1748,7 @@ export namespace main {
 	    stayAwake: boolean;
 	    turnScreenOff: boolean;
 	    noAudio: boolean;
+	    audioOnly: boolean;
 	    alwaysOnTop: boolean;
 	    showTouches: boolean;
 	    fullscreen: boolean;
@@ -1665,6 +1758,8 @@ export namespace main {
 	    videoCodec: string;
 	    audioCodec: string;
 	    recordPath: string;
+	    maxDuration: number;
+	    segmentDuration: number;
 	    displayId: number;
 	    videoSource: string;
 	    cameraId: string;
@@ -1689,6 +1784,7 @@ export namespace main {
 	        this.stayAwake = source["stayAwake"];
 	        this.turnScreenOff = source["turnScreenOff"];
 	        this.noAudio = source["noAudio"];
+	        this.audioOnly = source["audioOnly"];
 	        this.alwaysOnTop = source["alwaysOnTop"];
 	        this.showTouches = source["showTouches"];
 	        this.fullscreen = source["fullscreen"];
@@ -1698,6 +1794,8 @@ export namespace main {
 	        this.videoCodec = source["videoCodec"];
 	        this.audioCodec = source["audioCodec"];
 	        this.recordPath = source["recordPath"];
+	        this.maxDuration = source["maxDuration"];
+	        this.segmentDuration = source["segmentDuration"];
 	        this.displayId = source["displayId"];
 	        this.videoSource = source["videoSource"];
 	        this.cameraId = source["cameraId"];
@@ -1773,6 +1871,7 @@ export namespace main {
 	}
 	export class UINode {
 	    text: string;
+	    hint?: string;
 	    resourceId: string;
 	    class: string;
 	    package: string;
@@ -1797,6 +1896,7 @@ export namespace main {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.text = source["text"];
+	        this.hint = source["hint"];
 	        this.resourceId = source["resourceId"];
 	        this.class = source["class"];
 	        this.package = source["package"];
@@ -1967,6 +2067,41 @@ export namespace main {
 	    }
 	}
 	
+	export class PlaybackCheckpoint {
+	    selector: types.ElementSelector;
+	    timeoutMs?: number;
+	    onFailure?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlaybackCheckpoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.selector = this.convertValues(source["selector"], types.ElementSelector);
+	        this.timeoutMs = source["timeoutMs"];
+	        this.onFailure = source["onFailure"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
 	export class TouchEvent {
 	    timestamp: number;
 	    type: string;
@@ -1976,6 +2111,7 @@ export namespace main {
 	    y2?: number;
 	    duration?: number;
 	    selector?: types.ElementSelector;
+	    checkpoint?: PlaybackCheckpoint;
 	
 	    static createFrom(source: any = {}) {
 	        return new TouchEvent(source);
@@ -1991,6 +2127,7 @@ export namespace main {
 	        this.y2 = source["y2"];
 	        this.duration = source["duration"];
 	        this.selector = this.convertValues(source["selector"], types.ElementSelector);
+	        this.checkpoint = this.convertValues(source["checkpoint"], PlaybackCheckpoint);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -2153,39 +2290,42 @@ export namespace main {
 	        this.height = source["height"];
 	    }
 	}
-
-}
-
-export namespace multipart {
-	
-	export class FileHeader {
-	    Filename: string;
-	    Header: Record<string, Array<string>>;
-	    Size: number;
+	export class WirelessInfo {
+	    deviceId: string;
+	    ip: string;
+	    port: number;
+	    address: string;
+	    mode: string;
+	    wireless: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new FileHeader(source);
+	        return new WirelessInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.Filename = source["Filename"];
-	        this.Header = source["Header"];
-	        this.Size = source["Size"];
+	        this.deviceId = source["deviceId"];
+	        this.ip = source["ip"];
+	        this.port = source["port"];
+	        this.address = source["address"];
+	        this.mode = source["mode"];
+	        this.wireless = source["wireless"];
 	    }
 	}
-	export class Form {
-	    Value: Record<string, Array<string>>;
-	    File: Record<string, Array<FileHeader>>;
+	export class SessionListResult {
+	    sessions: DeviceSession[];
+	    total: number;
+	    hasMore: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Form(source);
+	        return new SessionListResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.Value = source["Value"];
-	        this.File = this.convertValues(source["File"], Array<FileHeader>, true);
+	        this.sessions = this.convertValues(source["sessions"], DeviceSession);
+	        this.total = source["total"];
+	        this.hasMore = source["hasMore"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -2206,90 +2346,84 @@ export namespace multipart {
 		    return a;
 		}
 	}
-
-}
-
-export namespace net {
-	
-	export class IPNet {
-	    IP: number[];
-	    Mask: number[];
-	
-	    static createFrom(source: any = {}) {
-	        return new IPNet(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.IP = source["IP"];
-	        this.Mask = source["Mask"];
-	    }
-	}
-
-}
-
-export namespace pkix {
-	
-	export class AttributeTypeAndValue {
-	    Type: number[];
-	    Value: any;
+	export class SessionDiffEvent {
+	    id: string;
+	    source: string;
+	    type: string;
+	    title: string;
+	    relativeTime: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new AttributeTypeAndValue(source);
+	        return new SessionDiffEvent(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.Type = source["Type"];
-	        this.Value = source["Value"];
+	        this.id = source["id"];
+	        this.source = source["source"];
+	        this.type = source["type"];
+	        this.title = source["title"];
+	        this.relativeTime = source["relativeTime"];
 	    }
 	}
-	export class Extension {
-	    Id: number[];
-	    Critical: boolean;
-	    Value: number[];
+	export class SessionTimingDiff {
+	    type: string;
+	    title: string;
+	    idA: string;
+	    idB: string;
+	    offsetA: number;
+	    offsetB: number;
+	    durationA: number;
+	    durationB: number;
+	    durationDelta: number;
+	    gapDelta: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Extension(source);
+	        return new SessionTimingDiff(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.Id = source["Id"];
-	        this.Critical = source["Critical"];
-	        this.Value = source["Value"];
+	        this.type = source["type"];
+	        this.title = source["title"];
+	        this.idA = source["idA"];
+	        this.idB = source["idB"];
+	        this.offsetA = source["offsetA"];
+	        this.offsetB = source["offsetB"];
+	        this.durationA = source["durationA"];
+	        this.durationB = source["durationB"];
+	        this.durationDelta = source["durationDelta"];
+	        this.gapDelta = source["gapDelta"];
 	    }
 	}
-	export class Name {
-	    Country: string[];
-	    Organization: string[];
-	    OrganizationalUnit: string[];
-	    Locality: string[];
-	    Province: string[];
-	    StreetAddress: string[];
-	    PostalCode: string[];
-	    SerialNumber: string;
-	    CommonName: string;
-	    Names: AttributeTypeAndValue[];
-	    ExtraNames: AttributeTypeAndValue[];
+	export class SessionDiff {
+	    sessionA: string;
+	    sessionB: string;
+	    eventsA: number;
+	    eventsB: number;
+	    matched: number;
+	    similarity: number;
+	    truncated: boolean;
+	    onlyInA: SessionDiffEvent[];
+	    onlyInB: SessionDiffEvent[];
+	    timingDiffs: SessionTimingDiff[];
 	
 	    static createFrom(source: any = {}) {
-	        return new Name(source);
+	        return new SessionDiff(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.Country = source["Country"];
-	        this.Organization = source["Organization"];
-	        this.OrganizationalUnit = source["OrganizationalUnit"];
-	        this.Locality = source["Locality"];
-	        this.Province = source["Province"];
-	        this.StreetAddress = source["StreetAddress"];
-	        this.PostalCode = source["PostalCode"];
-	        this.SerialNumber = source["SerialNumber"];
-	        this.CommonName = source["CommonName"];
-	        this.Names = this.convertValues(source["Names"], AttributeTypeAndValue);
-	        this.ExtraNames = this.convertValues(source["ExtraNames"], AttributeTypeAndValue);
+	        this.sessionA = source["sessionA"];
+	        this.sessionB = source["sessionB"];
+	        this.eventsA = source["eventsA"];
+	        this.eventsB = source["eventsB"];
+	        this.matched = source["matched"];
+	        this.similarity = source["similarity"];
+	        this.truncated = source["truncated"];
+	        this.onlyInA = this.convertValues(source["onlyInA"], SessionDiffEvent);
+	        this.onlyInB = this.convertValues(source["onlyInB"], SessionDiffEvent);
+	        this.timingDiffs = this.convertValues(source["timingDiffs"], SessionTimingDiff);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -2310,89 +2444,1070 @@ export namespace pkix {
 		    return a;
 		}
 	}
-
-}
-
-export namespace proxy {
-	
-	export class PendingBreakpointInfo {
-	    id: string;
-	    ruleId: string;
-	    phase: string;
-	    method: string;
-	    url: string;
-	    headers?: Record<string, Array<string>>;
-	    body?: string;
-	    statusCode?: number;
-	    respHeaders?: Record<string, Array<string>>;
-	    respBody?: string;
-	    createdAt: number;
+	export class BatchScreenshotResult {
+	    paths: string[];
+	    skipped: string[];
+	    errors: Record<string, string>;
 	
 	    static createFrom(source: any = {}) {
-	        return new PendingBreakpointInfo(source);
+	        return new BatchScreenshotResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.ruleId = source["ruleId"];
-	        this.phase = source["phase"];
-	        this.method = source["method"];
-	        this.url = source["url"];
-	        this.headers = source["headers"];
-	        this.body = source["body"];
-	        this.statusCode = source["statusCode"];
-	        this.respHeaders = source["respHeaders"];
-	        this.respBody = source["respBody"];
-	        this.createdAt = source["createdAt"];
+	        this.paths = source["paths"];
+	        this.skipped = source["skipped"];
+	        this.errors = source["errors"];
 	    }
 	}
-
-}
-
-export namespace time {
-	
-	export class Time {
-	
+	export class QuickTarget {
+	    address: string;
+	    serial: string;
+	    model: string;
+	    brand: string;
+	    lastSeen: number;
+	    successes: number;
+	    failures: number;
+	    score: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Time(source);
+	        return new QuickTarget(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	
+	        this.address = source["address"];
+	        this.serial = source["serial"];
+	        this.model = source["model"];
+	        this.brand = source["brand"];
+	        this.lastSeen = source["lastSeen"];
+	        this.successes = source["successes"];
+	        this.failures = source["failures"];
+	        this.score = source["score"];
 	    }
 	}
-
-}
-
-export namespace tls {
-	
-	export class ConnectionState {
-	    Version: number;
-	    HandshakeComplete: boolean;
-	    DidResume: boolean;
-	    CipherSuite: number;
-	    NegotiatedProtocol: string;
-	    NegotiatedProtocolIsMutual: boolean;
-	    ServerName: string;
-	    PeerCertificates: x509.Certificate[];
-	    VerifiedChains: x509.Certificate[][];
-	    SignedCertificateTimestamps: number[][];
-	    OCSPResponse: number[];
-	    TLSUnique: number[];
-	    ECHAccepted: boolean;
+	export class APKInstallResult {
+	    path: string;
+	    group: string[];
+	    success: boolean;
+	    reason: string;
+	    output: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ConnectionState(source);
+	        return new APKInstallResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.Version = source["Version"];
-	        this.HandshakeComplete = source["HandshakeComplete"];
-	        this.DidResume = source["DidResume"];
+	        this.path = source["path"];
+	        this.group = source["group"];
+	        this.success = source["success"];
+	        this.reason = source["reason"];
+	        this.output = source["output"];
+	    }
+	}
+	export class APKInstallSummary {
+	    total: number;
+	    succeeded: number;
+	    failed: number;
+	    results: APKInstallResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new APKInstallSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.total = source["total"];
+	        this.succeeded = source["succeeded"];
+	        this.failed = source["failed"];
+	        this.results = this.convertValues(source["results"], APKInstallResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MdnsService {
+	    name: string;
+	    type: string;
+	    address: string;
+	    serial: string;
+	    pairing: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MdnsService(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.address = source["address"];
+	        this.serial = source["serial"];
+	        this.pairing = source["pairing"];
+	    }
+	}
+	export class SplitAPKBundle {
+	    path: string;
+	    format: string;
+	    files: string[];
+	    merged: boolean;
+	    note?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SplitAPKBundle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.format = source["format"];
+	        this.files = source["files"];
+	        this.merged = source["merged"];
+	        this.note = source["note"];
+	    }
+	}
+	export class OutputSettings {
+	    directory: string;
+	    overrides: Record<string, string>;
+	    categories: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OutputSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.directory = source["directory"];
+	        this.overrides = source["overrides"];
+	        this.categories = source["categories"];
+	    }
+	}
+	export class AppPermission {
+	    name: string;
+	    group: string;
+	    protectionLevel?: string;
+	    requested: boolean;
+	    granted: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppPermission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.group = source["group"];
+	        this.protectionLevel = source["protectionLevel"];
+	        this.requested = source["requested"];
+	        this.granted = source["granted"];
+	    }
+	}
+	export class LogcatBufferingSettings {
+	    maxChunk: number;
+	    flushIntervalMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogcatBufferingSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxChunk = source["maxChunk"];
+	        this.flushIntervalMs = source["flushIntervalMs"];
+	    }
+	}
+	export class AdbServerSettings {
+	    host: string;
+	    port: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AdbServerSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.port = source["port"];
+	    }
+	}
+	export class BatteryState {
+	    level: number;
+	    status: string;
+	    charging: boolean;
+	    temperature: number;
+	    voltage: number;
+	    health: string;
+	    plugged: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BatteryState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.level = source["level"];
+	        this.status = source["status"];
+	        this.charging = source["charging"];
+	        this.temperature = source["temperature"];
+	        this.voltage = source["voltage"];
+	        this.health = source["health"];
+	        this.plugged = source["plugged"];
+	    }
+	}
+	export class ScreenState {
+	    on: boolean;
+	    brightness: number;
+	    orientation: string;
+	    locked: boolean;
+	    interactive: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScreenState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.on = source["on"];
+	        this.brightness = source["brightness"];
+	        this.orientation = source["orientation"];
+	        this.locked = source["locked"];
+	        this.interactive = source["interactive"];
+	    }
+	}
+	export class StorageUsage {
+	    totalBytes: number;
+	    freeBytes: number;
+	    freePercent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StorageUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.totalBytes = source["totalBytes"];
+	        this.freeBytes = source["freeBytes"];
+	        this.freePercent = source["freePercent"];
+	    }
+	}
+	export class DeviceDashboard {
+	    deviceId: string;
+	    model: string;
+	    brand: string;
+	    androidVer: string;
+	    sdk: string;
+	    battery?: BatteryState;
+	    foregroundApp: string;
+	    storage?: StorageUsage;
+	    screen?: ScreenState;
+	    errors?: Record<string, string>;
+	    collectedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeviceDashboard(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceId = source["deviceId"];
+	        this.model = source["model"];
+	        this.brand = source["brand"];
+	        this.androidVer = source["androidVer"];
+	        this.sdk = source["sdk"];
+	        this.battery = this.convertValues(source["battery"], BatteryState);
+	        this.foregroundApp = source["foregroundApp"];
+	        this.storage = this.convertValues(source["storage"], StorageUsage);
+	        this.screen = this.convertValues(source["screen"], ScreenState);
+	        this.errors = source["errors"];
+	        this.collectedAt = source["collectedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PackageListEntry {
+	    name: string;
+	    label: string;
+	    type: string;
+	    state: string;
+	    versionName: string;
+	    versionCode: string;
+	    targetSdk: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PackageListEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.label = source["label"];
+	        this.type = source["type"];
+	        this.state = source["state"];
+	        this.versionName = source["versionName"];
+	        this.versionCode = source["versionCode"];
+	        this.targetSdk = source["targetSdk"];
+	    }
+	}
+	export class PackageVersionDiff {
+	    name: string;
+	    label: string;
+	    versionCodeA: string;
+	    versionCodeB: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PackageVersionDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.label = source["label"];
+	        this.versionCodeA = source["versionCodeA"];
+	        this.versionCodeB = source["versionCodeB"];
+	    }
+	}
+	export class PackageListDiff {
+	    deviceA: string;
+	    deviceB: string;
+	    onlyA: PackageListEntry[];
+	    onlyB: PackageListEntry[];
+	    changed: PackageVersionDiff[];
+	    unchangedCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PackageListDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceA = source["deviceA"];
+	        this.deviceB = source["deviceB"];
+	        this.onlyA = this.convertValues(source["onlyA"], PackageListEntry);
+	        this.onlyB = this.convertValues(source["onlyB"], PackageListEntry);
+	        this.changed = this.convertValues(source["changed"], PackageVersionDiff);
+	        this.unchangedCount = source["unchangedCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AdbPairingQR {
+	    payload: string;
+	    name: string;
+	    password: string;
+	    expiresAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AdbPairingQR(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.payload = source["payload"];
+	        this.name = source["name"];
+	        this.password = source["password"];
+	        this.expiresAt = source["expiresAt"];
+	    }
+	}
+	export class AppPowerState {
+	    packageName: string;
+	    bucket: string;
+	    optimized: boolean;
+	    systemWhitelisted: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppPowerState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.packageName = source["packageName"];
+	        this.bucket = source["bucket"];
+	        this.optimized = source["optimized"];
+	        this.systemWhitelisted = source["systemWhitelisted"];
+	    }
+	}
+	export class ForegroundActivity {
+	    packageName: string;
+	    activity: string;
+	    displayId: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ForegroundActivity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.packageName = source["packageName"];
+	        this.activity = source["activity"];
+	        this.displayId = source["displayId"];
+	    }
+	}
+	export class IntentOptions {
+	    action: string;
+	    component: string;
+	    data: string;
+	    mimeType: string;
+	    categories: string[];
+	    flags: number;
+	    extras: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new IntentOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	        this.component = source["component"];
+	        this.data = source["data"];
+	        this.mimeType = source["mimeType"];
+	        this.categories = source["categories"];
+	        this.flags = source["flags"];
+	        this.extras = source["extras"];
+	    }
+	}
+	export class DeviceProxyResult {
+	    value: string;
+	    warning?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeviceProxyResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.warning = source["warning"];
+	    }
+	}
+	export class RetentionPolicy {
+	    maxAgeDays: number;
+	    maxTotalSizeMB: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RetentionPolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxAgeDays = source["maxAgeDays"];
+	        this.maxTotalSizeMB = source["maxTotalSizeMB"];
+	    }
+	}
+	export class ConcurrencySettings {
+	    thumbnails: number;
+	    aapt: number;
+	    shell: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConcurrencySettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.thumbnails = source["thumbnails"];
+	        this.aapt = source["aapt"];
+	        this.shell = source["shell"];
+	    }
+	}
+	export class SnapshotFileEntry {
+	    path: string;
+	    size: number;
+	    modTime: string;
+	    md5?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SnapshotFileEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.modTime = source["modTime"];
+	        this.md5 = source["md5"];
+	    }
+	}
+	export class DirectorySnapshotDiff {
+	    snapshotId: string;
+	    remotePath: string;
+	    added: SnapshotFileEntry[];
+	    modified: SnapshotFileEntry[];
+	    removed: SnapshotFileEntry[];
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DirectorySnapshotDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.snapshotId = source["snapshotId"];
+	        this.remotePath = source["remotePath"];
+	        this.added = this.convertValues(source["added"], SnapshotFileEntry);
+	        this.modified = this.convertValues(source["modified"], SnapshotFileEntry);
+	        this.removed = this.convertValues(source["removed"], SnapshotFileEntry);
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProcessMemory {
+	    name: string;
+	    pid: number;
+	    pssKb: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProcessMemory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.pid = source["pid"];
+	        this.pssKb = source["pssKb"];
+	    }
+	}
+	export class MemoryBreakdown {
+	    totalKb: number;
+	    usedKb: number;
+	    freeKb: number;
+	    cachedKb: number;
+	    lostKb: number;
+	    topProcesses: ProcessMemory[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MemoryBreakdown(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.totalKb = source["totalKb"];
+	        this.usedKb = source["usedKb"];
+	        this.freeKb = source["freeKb"];
+	        this.cachedKb = source["cachedKb"];
+	        this.lostKb = source["lostKb"];
+	        this.topProcesses = this.convertValues(source["topProcesses"], ProcessMemory);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ActivityStackEntry {
+	    packageName: string;
+	    activity: string;
+	    taskId: number;
+	    resumed: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ActivityStackEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.packageName = source["packageName"];
+	        this.activity = source["activity"];
+	        this.taskId = source["taskId"];
+	        this.resumed = source["resumed"];
+	    }
+	}
+	export class DeviceProfile {
+	    name: string;
+	    fontScale?: number;
+	    density?: number;
+	    screenTimeoutMs?: number;
+	    stayAwake?: boolean;
+	    brightness?: number;
+	    demoMode?: boolean;
+	    locale?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeviceProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.fontScale = source["fontScale"];
+	        this.density = source["density"];
+	        this.screenTimeoutMs = source["screenTimeoutMs"];
+	        this.stayAwake = source["stayAwake"];
+	        this.brightness = source["brightness"];
+	        this.demoMode = source["demoMode"];
+	        this.locale = source["locale"];
+	    }
+	}
+	export class ProfileSettingResult {
+	    setting: string;
+	    success: boolean;
+	    rebootRequired?: boolean;
+	    unsupported?: boolean;
+	    output?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileSettingResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.setting = source["setting"];
+	        this.success = source["success"];
+	        this.rebootRequired = source["rebootRequired"];
+	        this.unsupported = source["unsupported"];
+	        this.output = source["output"];
+	        this.error = source["error"];
+	    }
+	}
+	export class DeviceProfileResult {
+	    deviceId: string;
+	    success: boolean;
+	    rebootRequired?: boolean;
+	    settings: ProfileSettingResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DeviceProfileResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceId = source["deviceId"];
+	        this.success = source["success"];
+	        this.rebootRequired = source["rebootRequired"];
+	        this.settings = this.convertValues(source["settings"], ProfileSettingResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AdbKeyInfo {
+	    path: string;
+	    publicKey: string;
+	    fingerprint: string;
+	    comment: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AdbKeyInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.publicKey = source["publicKey"];
+	        this.fingerprint = source["fingerprint"];
+	        this.comment = source["comment"];
+	    }
+	}
+
+}
+
+export namespace multipart {
+	
+	export class FileHeader {
+	    Filename: string;
+	    Header: Record<string, Array<string>>;
+	    Size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileHeader(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Filename = source["Filename"];
+	        this.Header = source["Header"];
+	        this.Size = source["Size"];
+	    }
+	}
+	export class Form {
+	    Value: Record<string, Array<string>>;
+	    File: Record<string, Array<FileHeader>>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Form(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Value = source["Value"];
+	        this.File = this.convertValues(source["File"], Array<FileHeader>, true);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace net {
+	
+	export class IPNet {
+	    IP: number[];
+	    Mask: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new IPNet(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.IP = source["IP"];
+	        this.Mask = source["Mask"];
+	    }
+	}
+
+}
+
+export namespace pkix {
+	
+	export class AttributeTypeAndValue {
+	    Type: number[];
+	    Value: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AttributeTypeAndValue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Type = source["Type"];
+	        this.Value = source["Value"];
+	    }
+	}
+	export class Extension {
+	    Id: number[];
+	    Critical: boolean;
+	    Value: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Extension(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Id = source["Id"];
+	        this.Critical = source["Critical"];
+	        this.Value = source["Value"];
+	    }
+	}
+	export class Name {
+	    Country: string[];
+	    Organization: string[];
+	    OrganizationalUnit: string[];
+	    Locality: string[];
+	    Province: string[];
+	    StreetAddress: string[];
+	    PostalCode: string[];
+	    SerialNumber: string;
+	    CommonName: string;
+	    Names: AttributeTypeAndValue[];
+	    ExtraNames: AttributeTypeAndValue[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Name(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Country = source["Country"];
+	        this.Organization = source["Organization"];
+	        this.OrganizationalUnit = source["OrganizationalUnit"];
+	        this.Locality = source["Locality"];
+	        this.Province = source["Province"];
+	        this.StreetAddress = source["StreetAddress"];
+	        this.PostalCode = source["PostalCode"];
+	        this.SerialNumber = source["SerialNumber"];
+	        this.CommonName = source["CommonName"];
+	        this.Names = this.convertValues(source["Names"], AttributeTypeAndValue);
+	        this.ExtraNames = this.convertValues(source["ExtraNames"], AttributeTypeAndValue);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace proxy {
+	
+	export class PendingBreakpointInfo {
+	    id: string;
+	    ruleId: string;
+	    phase: string;
+	    method: string;
+	    url: string;
+	    headers?: Record<string, Array<string>>;
+	    body?: string;
+	    statusCode?: number;
+	    respHeaders?: Record<string, Array<string>>;
+	    respBody?: string;
+	    createdAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PendingBreakpointInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.ruleId = source["ruleId"];
+	        this.phase = source["phase"];
+	        this.method = source["method"];
+	        this.url = source["url"];
+	        this.headers = source["headers"];
+	        this.body = source["body"];
+	        this.statusCode = source["statusCode"];
+	        this.respHeaders = source["respHeaders"];
+	        this.respBody = source["respBody"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class CaptureFilter {
+	    includeHosts: string[];
+	    excludeHosts: string[];
+	    methods: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CaptureFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.includeHosts = source["includeHosts"];
+	        this.excludeHosts = source["excludeHosts"];
+	        this.methods = source["methods"];
+	    }
+	}
+	export class RequestLog {
+	    id: string;
+	    time: string;
+	    clientIp: string;
+	    method: string;
+	    url: string;
+	    isHttps: boolean;
+	    headers: Record<string, Array<string>>;
+	    previewBody: string;
+	    respHeaders: Record<string, Array<string>>;
+	    respBody: string;
+	    statusCode: number;
+	    contentType: string;
+	    bodySize: number;
+	    isWs: boolean;
+	    partialUpdate: boolean;
+	    mocked: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RequestLog(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.time = source["time"];
+	        this.clientIp = source["clientIp"];
+	        this.method = source["method"];
+	        this.url = source["url"];
+	        this.isHttps = source["isHttps"];
+	        this.headers = this.convertValues(source["headers"], Array<string>, true);
+	        this.previewBody = source["previewBody"];
+	        this.respHeaders = this.convertValues(source["respHeaders"], Array<string>, true);
+	        this.respBody = source["respBody"];
+	        this.statusCode = source["statusCode"];
+	        this.contentType = source["contentType"];
+	        this.bodySize = source["bodySize"];
+	        this.isWs = source["isWs"];
+	        this.partialUpdate = source["partialUpdate"];
+	        this.mocked = source["mocked"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace time {
+	
+	export class Time {
+	
+	
+	    static createFrom(source: any = {}) {
+	        return new Time(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	
+	    }
+	}
+
+}
+
+export namespace tls {
+	
+	export class ConnectionState {
+	    Version: number;
+	    HandshakeComplete: boolean;
+	    DidResume: boolean;
+	    CipherSuite: number;
+	    NegotiatedProtocol: string;
+	    NegotiatedProtocolIsMutual: boolean;
+	    ServerName: string;
+	    PeerCertificates: x509.Certificate[];
+	    VerifiedChains: x509.Certificate[][];
+	    SignedCertificateTimestamps: number[][];
+	    OCSPResponse: number[];
+	    TLSUnique: number[];
+	    ECHAccepted: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectionState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Version = source["Version"];
+	        this.HandshakeComplete = source["HandshakeComplete"];
+	        this.DidResume = source["DidResume"];
 	        this.CipherSuite = source["CipherSuite"];
 	        this.NegotiatedProtocol = source["NegotiatedProtocol"];
 	        this.NegotiatedProtocolIsMutual = source["NegotiatedProtocolIsMutual"];
@@ -2443,6 +3558,7 @@ export namespace types {
 	export class AppParams {
 	    packageName: string;
 	    action: string;
+	    apkPath?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new AppParams(source);
@@ -2452,6 +3568,7 @@ export namespace types {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.packageName = source["packageName"];
 	        this.action = source["action"];
+	        this.apkPath = source["apkPath"];
 	    }
 	}
 	export class ElementSelector {
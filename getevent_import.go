@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// [   1234.567890] /dev/input/event2: EV_ABS ABS_MT_POSITION_X 0000021c
+	geteventLabeledLine = regexp.MustCompile(`^\[\s*[\d.]+\]\s*(?:(/dev/input/\S+):\s*)?EV_\w+\s+\w+\s+(?:[0-9a-fA-F]+|DOWN|UP)\s*$`)
+	// Same line captured without -l: codes are printed as hex numbers
+	geteventNumericLine = regexp.MustCompile(`^(?:\[\s*[\d.]+\]\s*)?(?:/dev/input/\S+:\s*)?[0-9a-fA-F]{4}\s+[0-9a-fA-F]{4}\s+[0-9a-fA-F]{8}\s*$`)
+	// "getevent -lp" axis info, e.g. "ABS_MT_POSITION_X : value 0, min 0, max 1079, ..."
+	geteventAxisRange = regexp.MustCompile(`(ABS_MT_POSITION_[XY])\s*:.*?min\s+(-?\d+),\s+max\s+(-?\d+)`)
+)
+
+// ImportTouchScriptFromGetevent builds a touch script from a file of `adb shell getevent -lt`
+// output captured outside Gaze. resolution is the screen size of the recording device ("WxH").
+// If the file also contains `getevent -lp` output, its axis ranges are used for scaling;
+// otherwise touch coordinates are assumed to map 1:1 onto the screen.
+func (a *App) ImportTouchScriptFromGetevent(rawGeteventPath string, resolution string) (TouchScript, error) {
+	resolution = strings.TrimSpace(resolution)
+	if w, h, ok := parseResolution(resolution); !ok || w <= 0 || h <= 0 {
+		return TouchScript{}, fmt.Errorf("invalid resolution %q, expected WxH (e.g. 1080x2400)", resolution)
+	}
+
+	data, err := os.ReadFile(rawGeteventPath)
+	if err != nil {
+		return TouchScript{}, fmt.Errorf("failed to read getevent file: %w", err)
+	}
+
+	session, err := geteventSessionFromText(string(data), resolution)
+	if err != nil {
+		return TouchScript{}, err
+	}
+
+	script := a.parseRawEvents(session)
+	if len(script.Events) == 0 {
+		return TouchScript{}, fmt.Errorf("no touch gestures found in %s", filepath.Base(rawGeteventPath))
+	}
+	script.Name = strings.TrimSuffix(filepath.Base(rawGeteventPath), filepath.Ext(rawGeteventPath))
+	return *script, nil
+}
+
+// geteventSessionFromText validates getevent output and turns it into a recording session
+// that parseRawEvents can consume. When the capture covers several input devices, only
+// the first device that reports touch positions is kept.
+func geteventSessionFromText(text, resolution string) (*TouchRecordingSession, error) {
+	session := &TouchRecordingSession{
+		StartTime:     time.Now(),
+		Resolution:    resolution,
+		RawEvents:     make([]string, 0),
+		RecordingMode: "fast",
+	}
+
+	var labeled []string
+	numeric := 0
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := geteventAxisRange.FindStringSubmatch(line); m != nil {
+			lo, _ := strconv.Atoi(m[2])
+			hi, _ := strconv.Atoi(m[3])
+			if m[1] == "ABS_MT_POSITION_X" && session.MaxX == 0 {
+				session.MinX, session.MaxX = lo, hi
+			} else if m[1] == "ABS_MT_POSITION_Y" && session.MaxY == 0 {
+				session.MinY, session.MaxY = lo, hi
+			}
+			continue
+		}
+		if geteventLabeledLine.MatchString(line) {
+			labeled = append(labeled, line)
+		} else if geteventNumericLine.MatchString(line) {
+			numeric++
+		}
+	}
+
+	if len(labeled) == 0 {
+		if numeric > 0 {
+			return nil, fmt.Errorf("the file has numeric event codes; capture with `adb shell getevent -lt` so codes are labeled and timestamped")
+		}
+		if strings.Contains(text, "EV_ABS") {
+			return nil, fmt.Errorf("the file has no timestamps; capture with `adb shell getevent -lt`")
+		}
+		return nil, fmt.Errorf("the file does not look like `adb shell getevent -lt` output")
+	}
+
+	// Pick the touchscreen if lines carry device paths
+	touchDevice := ""
+	for _, line := range labeled {
+		if m := geteventLabeledLine.FindStringSubmatch(line); m[1] != "" && strings.Contains(line, "ABS_MT_POSITION_") {
+			touchDevice = m[1]
+			break
+		}
+	}
+	hasPosition := false
+	for _, line := range labeled {
+		if touchDevice != "" {
+			if m := geteventLabeledLine.FindStringSubmatch(line); m[1] != "" && m[1] != touchDevice {
+				continue
+			}
+		}
+		if strings.Contains(line, "ABS_MT_POSITION_") {
+			hasPosition = true
+		}
+		session.RawEvents = append(session.RawEvents, line)
+	}
+	if !hasPosition {
+		return nil, fmt.Errorf("no multi-touch position events (ABS_MT_POSITION_X/Y) found; was the capture taken while touching the screen?")
+	}
+
+	session.InputDevice = touchDevice
+	return session, nil
+}
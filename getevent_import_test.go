@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const geteventTapCapture = `add device 1: /dev/input/event3
+  name:     "fts_ts"
+  events:
+    ABS (0003): ABS_MT_POSITION_X    : value 0, min 0, max 2159, fuzz 0, flat 0, resolution 0
+                ABS_MT_POSITION_Y    : value 0, min 0, max 4799, fuzz 0, flat 0, resolution 0
+[   5021.100000] /dev/input/event1: EV_KEY       KEY_VOLUMEDOWN       DOWN
+[   5021.200000] /dev/input/event3: EV_ABS       ABS_MT_TRACKING_ID   00000012
+[   5021.200000] /dev/input/event3: EV_ABS       ABS_MT_POSITION_X    0000021c
+[   5021.200000] /dev/input/event3: EV_ABS       ABS_MT_POSITION_Y    00000960
+[   5021.200000] /dev/input/event3: EV_KEY       BTN_TOUCH            DOWN
+[   5021.200000] /dev/input/event3: EV_SYN       SYN_REPORT           00000000
+[   5021.280000] /dev/input/event3: EV_ABS       ABS_MT_TRACKING_ID   ffffffff
+[   5021.280000] /dev/input/event3: EV_KEY       BTN_TOUCH            UP
+[   5021.280000] /dev/input/event3: EV_SYN       SYN_REPORT           00000000
+[   5021.300000] /dev/input/event1: EV_KEY       KEY_VOLUMEDOWN       UP
+`
+
+func TestImportTouchScriptFromGetevent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "login_tap.txt")
+	if err := os.WriteFile(path, []byte(geteventTapCapture), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := &App{mcpMode: true}
+	script, err := app.ImportTouchScriptFromGetevent(path, "1080x2400")
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if script.Name != "login_tap" || script.Resolution != "1080x2400" {
+		t.Errorf("name/resolution = %q/%q", script.Name, script.Resolution)
+	}
+	if len(script.Events) != 1 {
+		t.Fatalf("expected 1 event, got %+v", script.Events)
+	}
+	// Touch range 0..2159 x 0..4799 scales by half onto 1080x2400
+	ev := script.Events[0]
+	if ev.Type != "tap" || ev.X != 270 || ev.Y != 1200 {
+		t.Errorf("event = %+v, want tap at 270,1200", ev)
+	}
+}
+
+func TestImportTouchScriptFromGeteventRejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"numeric codes", "/dev/input/event3: 0003 0035 0000021c\n/dev/input/event3: 0000 0000 00000000\n", "numeric event codes"},
+		{"no timestamps", "/dev/input/event3: EV_ABS ABS_MT_POSITION_X 0000021c\n", "no timestamps"},
+		{"unrelated", "hello world\n", "does not look like"},
+		{"keys only", "[ 1.000000] EV_KEY KEY_POWER DOWN\n[ 1.100000] EV_KEY KEY_POWER UP\n", "no multi-touch position events"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := geteventSessionFromText(tt.content, "1080x2400")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	app := &App{mcpMode: true}
+	if _, err := app.ImportTouchScriptFromGetevent("unused.txt", "1080"); err == nil || !strings.Contains(err.Error(), "invalid resolution") {
+		t.Errorf("bad resolution err = %v", err)
+	}
+}
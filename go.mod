@@ -17,6 +17,7 @@ require (
 	github.com/rs/zerolog v1.34.0
 	github.com/tidwall/gjson v1.18.0
 	github.com/wailsapp/wails/v2 v2.11.0
+	golang.org/x/image v0.18.0
 	golang.org/x/time v0.8.0
 	google.golang.org/protobuf v1.36.11
 )
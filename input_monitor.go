@@ -0,0 +1,305 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ========================================
+// Passive input monitor
+// ========================================
+//
+// Unlike touch recording, the input monitor doesn't build a script. It turns every tap,
+// swipe and hardware key press into a UnifiedEvent so the session timeline shows what
+// the user did alongside logs and network traffic.
+
+const (
+	inputSwipeMinDistancePx = 30  // Scaled movement below this is a tap
+	inputLongPressMs        = 500 // Taps held at least this long are long presses
+)
+
+type inputMonitor struct {
+	cancel func() // Unsubscribes from the shared getevent stream
+}
+
+var (
+	inputMonitors   = make(map[string]*inputMonitor)
+	inputMonitorsMu sync.Mutex
+)
+
+// IsInputMonitorActive returns true if the passive input monitor runs for the device.
+// Used by DeviceMonitor to avoid emitting duplicate touch events.
+func IsInputMonitorActive(deviceID string) bool {
+	inputMonitorsMu.Lock()
+	defer inputMonitorsMu.Unlock()
+	_, exists := inputMonitors[deviceID]
+	return exists
+}
+
+// StartInputMonitor subscribes to the device's getevent stream and emits taps, swipes
+// and key presses into the event pipeline until StopInputMonitor is called.
+func (a *App) StartInputMonitor(deviceId string) error {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return fmt.Errorf("invalid device ID: %w", err)
+	}
+	if IsInputMonitorActive(deviceId) {
+		return fmt.Errorf("input monitor already running on this device")
+	}
+
+	// Query the device before taking the lock; these are several adb round-trips
+	inputDevice, err := a.GetTouchInputDevice(deviceId)
+	if err != nil {
+		return fmt.Errorf("failed to find touch input device: %w", err)
+	}
+	parser := newInputEventParser(inputDevice)
+	parser.minX, parser.maxX, parser.minY, parser.maxY = a.getTouchAxisRange(deviceId, inputDevice)
+	resolution, _ := a.GetDeviceResolution(deviceId)
+	if parts := strings.Split(resolution, "x"); len(parts) == 2 {
+		parser.screenW, _ = strconv.Atoi(parts[0])
+		parser.screenH, _ = strconv.Atoi(parts[1])
+	}
+
+	inputMonitorsMu.Lock()
+	defer inputMonitorsMu.Unlock()
+
+	if _, exists := inputMonitors[deviceId]; exists {
+		return fmt.Errorf("input monitor already running on this device")
+	}
+
+	m := &inputMonitor{}
+	onLine := func(line string) {
+		for _, g := range parser.Feed(line) {
+			a.emitInputGesture(deviceId, g)
+		}
+	}
+	// The stream died (e.g. the device went away), so the monitor is gone too
+	onEnd := func() {
+		inputMonitorsMu.Lock()
+		if inputMonitors[deviceId] == m {
+			delete(inputMonitors, deviceId)
+			unregisterMonitor("input", deviceId)
+		}
+		inputMonitorsMu.Unlock()
+		LogInfo("input_monitor").Str("deviceId", deviceId).Msg("Input monitor stopped")
+	}
+	m.cancel = a.subscribeTouchStream(deviceId, onLine, onEnd)
+	inputMonitors[deviceId] = m
+	a.registerMonitor("input", deviceId, func() { a.StopInputMonitor(deviceId) })
+	LogInfo("input_monitor").Str("deviceId", deviceId).Str("inputDevice", inputDevice).Msg("Input monitor started")
+
+	return nil
+}
+
+// StopInputMonitor stops the passive input monitor of a device
+func (a *App) StopInputMonitor(deviceId string) {
+	inputMonitorsMu.Lock()
+	defer inputMonitorsMu.Unlock()
+
+	if m, ok := inputMonitors[deviceId]; ok {
+		m.cancel()
+		delete(inputMonitors, deviceId)
+	}
+	unregisterMonitor("input", deviceId)
+}
+
+// emitInputGesture sends a recognized gesture to the event pipeline
+func (a *App) emitInputGesture(deviceId string, g inputGesture) {
+	if a.eventPipeline == nil {
+		return
+	}
+	// Touch recording emits its own events for the same input
+	if IsTouchRecordingActive(deviceId) {
+		return
+	}
+
+	data := map[string]interface{}{
+		"action":   g.Kind,
+		"duration": g.DurationMs,
+		"source":   "input_monitor",
+	}
+	eventType := "touch"
+	var title string
+	switch g.Kind {
+	case "tap":
+		title = fmt.Sprintf("Tap at (%d, %d)", g.X, g.Y)
+	case "long_press":
+		title = fmt.Sprintf("Long press at (%d, %d) - %dms", g.X, g.Y, g.DurationMs)
+	case "swipe":
+		eventType = "gesture"
+		title = fmt.Sprintf("Swipe (%d, %d) → (%d, %d)", g.X, g.Y, g.X2, g.Y2)
+		data["x2"], data["y2"] = g.X2, g.Y2
+	case "key":
+		eventType = "key"
+		title = "Key " + g.Key
+		data["key"] = g.Key
+	}
+	if g.Kind != "key" {
+		data["x"], data["y"] = g.X, g.Y
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		raw = []byte("{}")
+	}
+	a.eventPipeline.Emit(UnifiedEvent{
+		DeviceID:  deviceId,
+		Timestamp: time.Now().UnixMilli(),
+		Duration:  g.DurationMs,
+		Source:    SourceTouch,
+		Category:  CategoryInteraction,
+		Type:      eventType,
+		Level:     LevelInfo,
+		Title:     title,
+		Data:      raw,
+	})
+}
+
+// inputGesture is a completed user action recognized from getevent output
+type inputGesture struct {
+	Kind       string // tap, long_press, swipe, key
+	X, Y       int    // Screen coordinates (start point for swipes)
+	X2, Y2     int    // Swipe end point
+	Key        string // e.g. KEY_BACK
+	DurationMs int64
+}
+
+// [  1234.567890] /dev/input/event2: EV_ABS       ABS_MT_POSITION_X    000001c2
+var geteventLineRe = regexp.MustCompile(`^\[\s*(\d+\.\d+)\]\s+(?:(/dev/input/\S+):\s+)?(EV_\w+)\s+(\S+)\s+(\S+)`)
+
+// inputEventParser turns `getevent -lt` lines into gestures. Touch axes are scaled from
+// the input device's range to screen pixels, the same way touch recording does.
+type inputEventParser struct {
+	touchDevice            string
+	minX, maxX, minY, maxY int
+	screenW, screenH       int
+	down                   bool
+	downAt                 float64
+	haveStart              bool
+	startX, startY         int
+	curX, curY             int
+	sawBtnTouch            bool
+	keyDownAt              map[string]float64
+}
+
+func newInputEventParser(touchDevice string) *inputEventParser {
+	return &inputEventParser{touchDevice: touchDevice, keyDownAt: make(map[string]float64)}
+}
+
+// Feed consumes one getevent line and returns any gestures it completes
+func (p *inputEventParser) Feed(line string) []inputGesture {
+	m := geteventLineRe.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return nil
+	}
+	ts, _ := strconv.ParseFloat(m[1], 64)
+	device, evType, code, value := m[2], m[3], m[4], m[5]
+
+	if evType == "EV_KEY" && strings.HasPrefix(code, "KEY_") {
+		return p.feedKey(ts, code, value)
+	}
+	// Touch data from other devices (styluses, sensors) is not ours to interpret
+	if device != "" && device != p.touchDevice {
+		return nil
+	}
+
+	switch {
+	case code == "ABS_MT_POSITION_X":
+		p.curX = parseGeteventValue(value)
+	case code == "ABS_MT_POSITION_Y":
+		p.curY = parseGeteventValue(value)
+	case code == "BTN_TOUCH":
+		p.sawBtnTouch = true
+		if value == "DOWN" || parseGeteventValue(value) == 1 {
+			p.touchDown(ts)
+		} else if value == "UP" || parseGeteventValue(value) == 0 {
+			return p.touchUp(ts)
+		}
+	case code == "ABS_MT_TRACKING_ID" && !p.sawBtnTouch:
+		// Devices without BTN_TOUCH signal contact via tracking IDs
+		if strings.EqualFold(value, "ffffffff") {
+			return p.touchUp(ts)
+		}
+		p.touchDown(ts)
+	case code == "SYN_REPORT":
+		if p.down && !p.haveStart {
+			p.startX, p.startY, p.haveStart = p.curX, p.curY, true
+		}
+	}
+	return nil
+}
+
+func (p *inputEventParser) feedKey(ts float64, code, value string) []inputGesture {
+	switch value {
+	case "DOWN":
+		p.keyDownAt[code] = ts
+	case "UP":
+		downAt, ok := p.keyDownAt[code]
+		if !ok {
+			return nil
+		}
+		delete(p.keyDownAt, code)
+		return []inputGesture{{Kind: "key", Key: code, DurationMs: geteventDurationMs(downAt, ts)}}
+	}
+	return nil
+}
+
+func (p *inputEventParser) touchDown(ts float64) {
+	if p.down {
+		return
+	}
+	p.down, p.downAt, p.haveStart = true, ts, false
+}
+
+func (p *inputEventParser) touchUp(ts float64) []inputGesture {
+	if !p.down {
+		return nil
+	}
+	p.down = false
+	if !p.haveStart {
+		p.startX, p.startY = p.curX, p.curY
+	}
+
+	g := inputGesture{DurationMs: geteventDurationMs(p.downAt, ts)}
+	g.X, g.Y = p.scale(p.startX, p.startY)
+	g.X2, g.Y2 = p.scale(p.curX, p.curY)
+
+	switch {
+	case math.Hypot(float64(g.X2-g.X), float64(g.Y2-g.Y)) >= inputSwipeMinDistancePx:
+		g.Kind = "swipe"
+	case g.DurationMs >= inputLongPressMs:
+		g.Kind = "long_press"
+	default:
+		g.Kind = "tap"
+	}
+	return []inputGesture{g}
+}
+
+func (p *inputEventParser) scale(x, y int) (int, int) {
+	if p.maxX > p.minX && p.screenW > 0 {
+		x = (x - p.minX) * p.screenW / (p.maxX - p.minX + 1)
+	}
+	if p.maxY > p.minY && p.screenH > 0 {
+		y = (y - p.minY) * p.screenH / (p.maxY - p.minY + 1)
+	}
+	return x, y
+}
+
+// geteventDurationMs converts the span between two getevent timestamps (seconds) to ms
+func geteventDurationMs(from, to float64) int64 {
+	return int64(math.Round((to - from) * 1000))
+}
+
+// parseGeteventValue parses a hex getevent value; labels such as DOWN return -1
+func parseGeteventValue(value string) int {
+	v, err := strconv.ParseInt(value, 16, 64)
+	if err != nil {
+		return -1
+	}
+	return int(int32(v))
+}
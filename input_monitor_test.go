@@ -0,0 +1,144 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func feedInputLines(p *inputEventParser, lines []string) []inputGesture {
+	var out []inputGesture
+	for _, l := range lines {
+		out = append(out, p.Feed(l)...)
+	}
+	return out
+}
+
+func newTestInputParser() *inputEventParser {
+	p := newInputEventParser("/dev/input/event2")
+	// Raw range twice the screen size to exercise scaling
+	p.maxX, p.maxY = 2159, 4799
+	p.screenW, p.screenH = 1080, 2400
+	return p
+}
+
+func TestInputEventParser_Tap(t *testing.T) {
+	p := newTestInputParser()
+	got := feedInputLines(p, []string{
+		"[   100.000000] /dev/input/event2: EV_ABS       ABS_MT_TRACKING_ID   00000042",
+		"[   100.000000] /dev/input/event2: EV_ABS       ABS_MT_POSITION_X    00000320",
+		"[   100.000000] /dev/input/event2: EV_ABS       ABS_MT_POSITION_Y    00000640",
+		"[   100.000000] /dev/input/event2: EV_KEY       BTN_TOUCH            DOWN",
+		"[   100.000000] /dev/input/event2: EV_SYN       SYN_REPORT           00000000",
+		"[   100.080000] /dev/input/event2: EV_ABS       ABS_MT_POSITION_X    00000322",
+		"[   100.080000] /dev/input/event2: EV_SYN       SYN_REPORT           00000000",
+		"[   100.120000] /dev/input/event2: EV_ABS       ABS_MT_TRACKING_ID   ffffffff",
+		"[   100.120000] /dev/input/event2: EV_KEY       BTN_TOUCH            UP",
+		"[   100.120000] /dev/input/event2: EV_SYN       SYN_REPORT           00000000",
+	})
+	if len(got) != 1 {
+		t.Fatalf("got %d gestures, want 1: %+v", len(got), got)
+	}
+	g := got[0]
+	if g.Kind != "tap" || g.X != 400 || g.Y != 800 || g.DurationMs != 120 {
+		t.Errorf("got %+v, want tap at (400, 800) lasting 120ms", g)
+	}
+}
+
+func TestInputEventParser_SwipeAndLongPress(t *testing.T) {
+	p := newTestInputParser()
+	// No BTN_TOUCH: contact is signalled by tracking IDs only
+	got := feedInputLines(p, []string{
+		"[   200.000000] /dev/input/event2: EV_ABS       ABS_MT_TRACKING_ID   00000001",
+		"[   200.000000] /dev/input/event2: EV_ABS       ABS_MT_POSITION_X    00000400",
+		"[   200.000000] /dev/input/event2: EV_ABS       ABS_MT_POSITION_Y    00000c80",
+		"[   200.000000] /dev/input/event2: EV_SYN       SYN_REPORT           00000000",
+		"[   200.200000] /dev/input/event2: EV_ABS       ABS_MT_POSITION_Y    00000640",
+		"[   200.200000] /dev/input/event2: EV_SYN       SYN_REPORT           00000000",
+		"[   200.250000] /dev/input/event2: EV_ABS       ABS_MT_TRACKING_ID   ffffffff",
+		"[   200.250000] /dev/input/event2: EV_SYN       SYN_REPORT           00000000",
+		"[   201.000000] /dev/input/event2: EV_ABS       ABS_MT_TRACKING_ID   00000002",
+		"[   201.000000] /dev/input/event2: EV_SYN       SYN_REPORT           00000000",
+		"[   201.700000] /dev/input/event2: EV_ABS       ABS_MT_TRACKING_ID   ffffffff",
+	})
+	if len(got) != 2 {
+		t.Fatalf("got %d gestures, want 2: %+v", len(got), got)
+	}
+	if s := got[0]; s.Kind != "swipe" || s.X != 512 || s.Y != 1600 || s.X2 != 512 || s.Y2 != 800 {
+		t.Errorf("got %+v, want swipe (512, 1600) -> (512, 800)", s)
+	}
+	if lp := got[1]; lp.Kind != "long_press" || lp.DurationMs != 700 {
+		t.Errorf("got %+v, want 700ms long press", lp)
+	}
+}
+
+func TestInputEventParser_KeysAndOtherDevices(t *testing.T) {
+	p := newTestInputParser()
+	got := feedInputLines(p, []string{
+		"[   300.000000] /dev/input/event0: EV_KEY       KEY_VOLUMEDOWN       DOWN",
+		"[   300.000000] /dev/input/event0: EV_SYN       SYN_REPORT           00000000",
+		"[   300.150000] /dev/input/event0: EV_KEY       KEY_VOLUMEDOWN       UP",
+		// Touch data from another device is ignored
+		"[   301.000000] /dev/input/event5: EV_KEY       BTN_TOUCH            DOWN",
+		"[   301.100000] /dev/input/event5: EV_KEY       BTN_TOUCH            UP",
+		"add device 1: /dev/input/event0",
+		"  name:     \"gpio-keys\"",
+	})
+	if len(got) != 1 {
+		t.Fatalf("got %d gestures, want 1: %+v", len(got), got)
+	}
+	if k := got[0]; k.Kind != "key" || k.Key != "KEY_VOLUMEDOWN" || k.DurationMs != 150 {
+		t.Errorf("got %+v, want KEY_VOLUMEDOWN lasting 150ms", k)
+	}
+}
+
+func TestTouchStream_FansOutLines(t *testing.T) {
+	s := &touchStream{cancel: func() {}, subs: make(map[int]touchStreamSubscriber)}
+	var first, second []string
+	ended := 0
+	s.subs[0] = touchStreamSubscriber{onLine: func(l string) { first = append(first, l) }, onEnd: func() { ended++ }}
+	s.subs[1] = touchStreamSubscriber{onLine: func(l string) { second = append(second, l) }}
+
+	s.pump(strings.NewReader("[   1.000000] EV_KEY KEY_BACK DOWN\n  [   1.100000] EV_KEY KEY_BACK UP  \n"))
+	s.end()
+
+	want := []string{"[   1.000000] EV_KEY KEY_BACK DOWN", "[   1.100000] EV_KEY KEY_BACK UP"}
+	if !reflect.DeepEqual(first, want) || !reflect.DeepEqual(second, want) {
+		t.Errorf("subscribers got %q and %q, want %q each", first, second, want)
+	}
+	if ended != 1 {
+		t.Errorf("onEnd ran %d times, want 1", ended)
+	}
+}
+
+func TestSubscribeTouchStream_SharesOneStream(t *testing.T) {
+	const deviceID = "touch-stream-test"
+	cancelled := 0
+	existing := &touchStream{cancel: func() { cancelled++ }, subs: make(map[int]touchStreamSubscriber)}
+	touchStreamsMu.Lock()
+	touchStreams[deviceID] = existing
+	touchStreamsMu.Unlock()
+
+	a := newTestApp(nil)
+	unsubMonitor := a.subscribeTouchStream(deviceID, func(string) {}, nil)
+	unsubInput := a.subscribeTouchStream(deviceID, func(string) {}, nil)
+
+	touchStreamsMu.Lock()
+	shared, subs := touchStreams[deviceID] == existing, len(existing.subs)
+	touchStreamsMu.Unlock()
+	if !shared || subs != 2 {
+		t.Fatalf("shared=%v subscribers=%d, want both subscribers on the existing stream", shared, subs)
+	}
+
+	unsubMonitor()
+	if cancelled != 0 {
+		t.Fatal("stream stopped while a subscriber remained")
+	}
+	unsubInput()
+	touchStreamsMu.Lock()
+	_, still := touchStreams[deviceID]
+	touchStreamsMu.Unlock()
+	if still || cancelled != 1 {
+		t.Errorf("after last unsubscribe: registered=%v cancelled=%d, want removed and cancelled once", still, cancelled)
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// Multi-APK install (drag and drop)
+// ========================================
+
+const apkInstallTimeout = 5 * time.Minute
+
+var (
+	// Install failure codes printed by adb, e.g. "Failure [INSTALL_FAILED_VERSION_DOWNGRADE: ...]"
+	installFailureCodePattern = regexp.MustCompile(`\b((?:INSTALL|DELETE)_[A-Z_]+)\b`)
+	aaptSplitPattern          = regexp.MustCompile(`package: name='[^']+'.*\bsplit='([^']+)'`)
+	aaptPackagePattern        = regexp.MustCompile(`package: name='([^']+)'`)
+)
+
+// APKInstallResult is the outcome for one file of an InstallAPKs batch
+type APKInstallResult struct {
+	Path    string   `json:"path"`
+	Group   []string `json:"group,omitempty"` // Files installed together as split APKs, base first
+	Success bool     `json:"success"`
+	Reason  string   `json:"reason,omitempty"` // Failure code such as INSTALL_FAILED_VERSION_DOWNGRADE
+	Output  string   `json:"output"`
+}
+
+// APKInstallSummary reports which files of an InstallAPKs batch succeeded
+type APKInstallSummary struct {
+	Total     int                `json:"total"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Results   []APKInstallResult `json:"results"`
+}
+
+// apkFile is a dropped APK with what aapt could tell about it
+type apkFile struct {
+	path    string
+	pkg     string // Empty when aapt is unavailable
+	isSplit bool
+}
+
+// InstallAPKs installs several APKs one after another. Files that belong to the same
+// app (by package name when aapt is available, otherwise by file name, e.g.
+// base.apk + split_config.arm64_v8a.apk or app.apk + app.config.xxhdpi.apk) are
+// installed together with install-multiple. Emits "install-progress" per file.
+func (a *App) InstallAPKs(deviceId string, paths []string) (APKInstallSummary, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return APKInstallSummary{}, err
+	}
+	if len(paths) == 0 {
+		return APKInstallSummary{}, fmt.Errorf("no APK files given")
+	}
+	a.updateLastActive(deviceId)
+
+	files := make([]apkFile, 0, len(paths))
+	for _, p := range paths {
+		if !strings.EqualFold(filepath.Ext(p), ".apk") {
+			return APKInstallSummary{}, fmt.Errorf("not an APK: %s", p)
+		}
+		f := apkFile{path: p}
+		f.pkg, f.isSplit = a.apkPackageInfo(p)
+		files = append(files, f)
+	}
+	groups := groupSplitAPKs(files)
+
+	summary := APKInstallSummary{Total: len(paths)}
+	done := 0
+	for _, group := range groups {
+		for _, p := range group {
+			a.emitInstallProgress(deviceId, p, done, len(paths), "installing", "")
+		}
+
+		output, err := a.installAPKGroup(deviceId, group)
+		success, reason := installOutcome(output, err)
+		if success {
+			a.Log("Installed %s on %s", strings.Join(group, ", "), deviceId)
+		} else {
+			a.Log("Failed to install %s on %s: %s", strings.Join(group, ", "), deviceId, reason)
+		}
+
+		for _, p := range group {
+			r := APKInstallResult{Path: p, Success: success, Reason: reason, Output: output}
+			if len(group) > 1 {
+				r.Group = group
+			}
+			summary.Results = append(summary.Results, r)
+			if success {
+				summary.Succeeded++
+			} else {
+				summary.Failed++
+			}
+			done++
+			status := "success"
+			if !success {
+				status = "failed"
+			}
+			a.emitInstallProgress(deviceId, p, done, len(paths), status, reason)
+		}
+	}
+	return summary, nil
+}
+
+func (a *App) installAPKGroup(deviceId string, group []string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), apkInstallTimeout)
+	defer cancel()
+
+	args := []string{"-s", deviceId, "install", "-r"}
+	if len(group) > 1 {
+		args = []string{"-s", deviceId, "install-multiple", "-r"}
+	}
+	output, err := a.runAdbCombined(ctx, append(args, group...)...)
+	return strings.TrimSpace(string(output)), err
+}
+
+func (a *App) emitInstallProgress(deviceId, path string, done, total int, status, reason string) {
+	if a.mcpMode || a.ctx == nil {
+		return
+	}
+	wailsRuntime.EventsEmit(a.ctx, "install-progress", map[string]interface{}{
+		"deviceId": deviceId,
+		"path":     path,
+		"done":     done,
+		"total":    total,
+		"status":   status, // "installing", "success" or "failed"
+		"reason":   reason,
+	})
+}
+
+// apkPackageInfo reads the package name and whether the file is a split APK via aapt
+func (a *App) apkPackageInfo(path string) (pkg string, isSplit bool) {
+	if a.aaptPath == "" {
+		return "", false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, _ := exec.CommandContext(ctx, a.aaptPath, "dump", "badging", path).Output()
+	return parseAPKBadging(string(out))
+}
+
+// parseAPKBadging extracts the package name and split marker from `aapt dump badging`
+func parseAPKBadging(badging string) (pkg string, isSplit bool) {
+	if m := aaptPackagePattern.FindStringSubmatch(badging); m != nil {
+		pkg = m[1]
+	}
+	return pkg, aaptSplitPattern.MatchString(badging)
+}
+
+// installOutcome decides whether adb install succeeded and extracts the failure code
+func installOutcome(output string, err error) (success bool, reason string) {
+	if err == nil && !strings.Contains(output, "Failure") {
+		return true, ""
+	}
+	if m := installFailureCodePattern.FindStringSubmatch(output); m != nil {
+		return false, m[1]
+	}
+	if err != nil {
+		return false, err.Error()
+	}
+	return false, output
+}
+
+// groupSplitAPKs groups files that make up one app, keeping the drop order of the first
+// file of each group. Files with a known package are grouped by package, but only when
+// at least one of them is a split (two versions of an app are installed separately);
+// otherwise files are grouped by splitBaseName within their directory.
+func groupSplitAPKs(files []apkFile) [][]string {
+	keyOf := func(f apkFile) string {
+		if f.pkg != "" {
+			return "pkg:" + f.pkg
+		}
+		return "name:" + filepath.Join(filepath.Dir(f.path), splitBaseName(filepath.Base(f.path)))
+	}
+
+	var order []string
+	members := make(map[string][]apkFile)
+	for _, f := range files {
+		k := keyOf(f)
+		if _, ok := members[k]; !ok {
+			order = append(order, k)
+		}
+		members[k] = append(members[k], f)
+	}
+
+	var groups [][]string
+	for _, k := range order {
+		fs := members[k]
+		hasSplit := false
+		for _, f := range fs {
+			hasSplit = hasSplit || f.isSplit
+		}
+		if strings.HasPrefix(k, "pkg:") && !hasSplit {
+			for _, f := range fs {
+				groups = append(groups, []string{f.path})
+			}
+			continue
+		}
+
+		// Base APK first; some devices reject install-multiple otherwise
+		sort.SliceStable(fs, func(i, j int) bool {
+			return isBaseAPK(fs[i]) && !isBaseAPK(fs[j])
+		})
+		group := make([]string, len(fs))
+		for i, f := range fs {
+			group[i] = f.path
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+func isBaseAPK(f apkFile) bool {
+	if f.pkg != "" {
+		return !f.isSplit
+	}
+	name := strings.ToLower(strings.TrimSuffix(filepath.Base(f.path), filepath.Ext(f.path)))
+	return name == "base" || splitBaseName(filepath.Base(f.path)) == name
+}
+
+// splitAPKMarkers separate an app's base name from a split suffix, as in
+// app.config.xxhdpi.apk or app.split_feature.apk
+var splitAPKMarkers = []string{".config.", ".split_"}
+
+// splitBaseName maps an APK file name to the app it belongs to: bundletool's
+// base.apk and split_*.apk share "base", and app.config.xxhdpi.apk belongs to app.apk.
+// Other dots are part of the name, so com.foo.apk and com.bar.apk stay apart.
+func splitBaseName(name string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, filepath.Ext(name)))
+	if name == "base" || strings.HasPrefix(name, "split_") {
+		return "base"
+	}
+	for _, marker := range splitAPKMarkers {
+		if i := strings.Index(name, marker); i > 0 {
+			return name[:i]
+		}
+	}
+	return name
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGroupSplitAPKs(t *testing.T) {
+	files := []apkFile{
+		{path: "/drop/split_config.arm64_v8a.apk"},
+		{path: "/drop/tool.apk"},
+		{path: "/drop/base.apk"},
+		{path: "/drop/game.config.xxhdpi.apk"},
+		{path: "/drop/game.apk"},
+		{path: "/other/base.apk"},
+		// Dotted names are distinct apps unless a split suffix follows
+		{path: "/drop/com.foo.apk"},
+		{path: "/drop/com.bar.apk"},
+		{path: "/drop/com.foo.split_camera.apk"},
+		// Two versions of one app are not splits of each other
+		{path: "/drop/chat-1.0.apk", pkg: "com.example.chat"},
+		{path: "/drop/chat-2.0.apk", pkg: "com.example.chat"},
+		// A split set recognised by aapt regardless of file names
+		{path: "/drop/x1.apk", pkg: "com.example.maps", isSplit: true},
+		{path: "/drop/x2.apk", pkg: "com.example.maps"},
+	}
+
+	var got []string
+	for _, g := range groupSplitAPKs(files) {
+		got = append(got, strings.Join(g, "+"))
+	}
+	want := []string{
+		"/drop/base.apk+/drop/split_config.arm64_v8a.apk",
+		"/drop/tool.apk",
+		"/drop/game.apk+/drop/game.config.xxhdpi.apk",
+		"/other/base.apk",
+		"/drop/com.foo.apk+/drop/com.foo.split_camera.apk",
+		"/drop/com.bar.apk",
+		"/drop/chat-1.0.apk",
+		"/drop/chat-2.0.apk",
+		"/drop/x2.apk+/drop/x1.apk",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("groups:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestInstallAPKs(t *testing.T) {
+	a := newTestApp(map[string]string{
+		"-s emulator-5554 install-multiple -r /drop/base.apk /drop/split_config.xxhdpi.apk": "Performing Streamed Install\nSuccess\n",
+		"-s emulator-5554 install -r /drop/old.apk":                                         "Performing Streamed Install\nadb: failed to install /drop/old.apk: Failure [INSTALL_FAILED_VERSION_DOWNGRADE: Downgrade detected: Update version code 3 is older than current 7]\n",
+		"-s emulator-5554 install -r /drop/ok.apk":                                          "Success\n",
+	})
+
+	summary, err := a.InstallAPKs("emulator-5554", []string{
+		"/drop/split_config.xxhdpi.apk", "/drop/old.apk", "/drop/base.apk", "/drop/ok.apk", "/drop/missing.apk",
+	})
+	if err != nil {
+		t.Fatalf("InstallAPKs: %v", err)
+	}
+	if summary.Total != 5 || summary.Succeeded != 3 || summary.Failed != 2 {
+		t.Errorf("summary = %d total, %d ok, %d failed", summary.Total, summary.Succeeded, summary.Failed)
+	}
+
+	byPath := make(map[string]APKInstallResult)
+	for _, r := range summary.Results {
+		byPath[r.Path] = r
+	}
+	if r := byPath["/drop/split_config.xxhdpi.apk"]; !r.Success || len(r.Group) != 2 {
+		t.Errorf("split result = %+v", r)
+	}
+	if r := byPath["/drop/old.apk"]; r.Success || r.Reason != "INSTALL_FAILED_VERSION_DOWNGRADE" {
+		t.Errorf("downgrade result = %+v", r)
+	}
+	if r := byPath["/drop/missing.apk"]; r.Success || r.Reason == "" {
+		t.Errorf("failed command should be reported: %+v", r)
+	}
+
+	if _, err := a.InstallAPKs("emulator-5554", []string{"/drop/app.xapk"}); err == nil {
+		t.Error("non-APK files should be rejected")
+	}
+}
+
+func TestParseAPKBadging(t *testing.T) {
+	pkg, split := parseAPKBadging("package: name='com.example.maps' versionCode='12' versionName='1.2' split='config.arm64_v8a'\nsdkVersion:'24'\n")
+	if pkg != "com.example.maps" || !split {
+		t.Errorf("split badging = %q, %v", pkg, split)
+	}
+	pkg, split = parseAPKBadging("package: name='com.example.maps' versionCode='12' versionName='1.2'\napplication-label:'Maps'\n")
+	if pkg != "com.example.maps" || split {
+		t.Errorf("base badging = %q, %v", pkg, split)
+	}
+}
+
+func TestNewSplitAPKBundle(t *testing.T) {
+	split := []string{
+		"/data/app/com.example.app-1/split_config.arm64_v8a.apk",
+		"/data/app/com.example.app-1/base.apk",
+	}
+	b := newSplitAPKBundle(split, "/tmp/out/example.apk")
+	if b.Path != "/tmp/out/example.apks" || b.Format != "apks" || b.Merged || b.Note == "" {
+		t.Errorf("split bundle = %+v", b)
+	}
+	if len(b.Files) != 2 || b.Files[0] != "base.apk" {
+		t.Errorf("split files = %v, want base.apk first", b.Files)
+	}
+
+	single := newSplitAPKBundle([]string{"/data/app/com.example.app-1/base.apk"}, "/tmp/out/example.apks")
+	if single.Path != "/tmp/out/example.apk" || single.Format != "apk" || !single.Merged || single.Note != "" {
+		t.Errorf("single bundle = %+v", single)
+	}
+
+	if got := newSplitAPKBundle(split, "/tmp/out/example.APKS").Path; got != "/tmp/out/example.APKS" {
+		t.Errorf("matching extension changed: %q", got)
+	}
+}
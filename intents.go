@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ========================================
+// Intents and broadcasts
+// ========================================
+
+// IntentOptions describes an intent for StartActivityWithIntent
+type IntentOptions struct {
+	Action     string            `json:"action"`
+	Component  string            `json:"component"` // "pkg/.Activity", optional
+	Data       string            `json:"data"`      // URI, optional
+	MimeType   string            `json:"mimeType"`
+	Categories []string          `json:"categories"`
+	Flags      int               `json:"flags"`  // Intent.FLAG_* bits
+	Extras     map[string]string `json:"extras"` // Types are inferred, see intentExtraFlag
+}
+
+var intentTokenRegex = regexp.MustCompile(`^[A-Za-z0-9_.$/:-]+$`)
+
+// SendBroadcast sends a broadcast with `am broadcast`. Extra types are inferred from
+// the values: true/false become booleans, whole numbers ints (or longs when they
+// don't fit), decimals floats and everything else strings.
+func (a *App) SendBroadcast(deviceId, action string, extras map[string]string) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+	action = strings.TrimSpace(action)
+	if action == "" || !intentTokenRegex.MatchString(action) {
+		return "", fmt.Errorf("invalid broadcast action: %q", action)
+	}
+	extraArgs, err := intentExtraArgs(extras)
+	if err != nil {
+		return "", err
+	}
+
+	args := append([]string{"am", "broadcast", "-a", action}, extraArgs...)
+	return a.runIntentCommand(deviceId, args, "send broadcast")
+}
+
+// StartActivityWithIntent starts an activity from a full intent description
+func (a *App) StartActivityWithIntent(deviceId string, intent IntentOptions) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+	args, err := intentArgs(intent)
+	if err != nil {
+		return "", err
+	}
+	return a.runIntentCommand(deviceId, append([]string{"am", "start"}, args...), "start activity")
+}
+
+// intentArgs turns IntentOptions into `am start` arguments
+func intentArgs(intent IntentOptions) ([]string, error) {
+	if intent.Action == "" && intent.Component == "" && intent.Data == "" {
+		return nil, fmt.Errorf("an intent needs an action, a component or data")
+	}
+
+	var args []string
+	if intent.Action != "" {
+		if !intentTokenRegex.MatchString(intent.Action) {
+			return nil, fmt.Errorf("invalid intent action: %q", intent.Action)
+		}
+		args = append(args, "-a", intent.Action)
+	}
+	if intent.Data != "" {
+		args = append(args, "-d", intent.Data)
+	}
+	if intent.MimeType != "" {
+		args = append(args, "-t", intent.MimeType)
+	}
+	for _, c := range intent.Categories {
+		if !intentTokenRegex.MatchString(c) {
+			return nil, fmt.Errorf("invalid intent category: %q", c)
+		}
+		args = append(args, "-c", c)
+	}
+	if intent.Flags != 0 {
+		args = append(args, "-f", fmt.Sprintf("0x%08x", uint32(intent.Flags)))
+	}
+	extraArgs, err := intentExtraArgs(intent.Extras)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, extraArgs...)
+	if intent.Component != "" {
+		if !intentTokenRegex.MatchString(intent.Component) || !strings.Contains(intent.Component, "/") {
+			return nil, fmt.Errorf("invalid component %q: use package/activity", intent.Component)
+		}
+		args = append(args, "-n", intent.Component)
+	}
+	return args, nil
+}
+
+// intentExtraArgs builds typed --ez/--ei/--el/--ef/--es arguments, sorted by key
+func intentExtraArgs(extras map[string]string) ([]string, error) {
+	keys := make([]string, 0, len(extras))
+	for k := range extras {
+		if strings.TrimSpace(k) == "" || strings.ContainsAny(k, " \t\n") {
+			return nil, fmt.Errorf("invalid extra key: %q", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var args []string
+	for _, k := range keys {
+		v := extras[k]
+		args = append(args, intentExtraFlag(v), k, v)
+	}
+	return args, nil
+}
+
+// intentExtraFlag infers the am extra type for a value. Values written with a leading
+// "+" or zero, like phone numbers and codes, stay strings so they reach the app as typed.
+func intentExtraFlag(value string) string {
+	if value == "true" || value == "false" {
+		return "--ez"
+	}
+	digits := strings.TrimPrefix(value, "-")
+	if strings.HasPrefix(value, "+") || (len(digits) > 1 && digits[0] == '0' && digits[1] >= '0' && digits[1] <= '9') {
+		return "--es"
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if n >= -1<<31 && n < 1<<31 {
+			return "--ei"
+		}
+		return "--el"
+	}
+	if strings.Contains(value, ".") {
+		if _, err := strconv.ParseFloat(value, 32); err == nil {
+			return "--ef"
+		}
+	}
+	return "--es"
+}
+
+// runIntentCommand runs an am command with every argument quoted for the device shell
+func (a *App) runIntentCommand(deviceId string, args []string, what string) (string, error) {
+	a.updateLastActive(deviceId)
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", strings.Join(quoted, " "))
+	outStr := strings.TrimSpace(string(output))
+	if err != nil {
+		return outStr, fmt.Errorf("failed to %s: %w", what, err)
+	}
+	if strings.Contains(outStr, "Error:") || strings.Contains(outStr, "Exception") {
+		return outStr, fmt.Errorf("failed to %s: %s", what, outStr)
+	}
+	return outStr, nil
+}
+
+// OpenDeepLink opens a custom-scheme deep link (myapp://path) or an https app link and
+// returns the activity that handled it. When nothing resolves the link the error wraps
+// ErrNoLinkHandler, so callers can tell it apart from a failed adb command.
+func (a *App) OpenDeepLink(deviceId, link string) (string, error) {
+	if err := validateDeepLink(link); err != nil {
+		return "", err
+	}
+	component, err := a.LaunchWithDeepLink(deviceId, strings.TrimSpace(link))
+	if err != nil {
+		return component, err
+	}
+	a.Log("Opened %s on %s: %s", link, deviceId, component)
+	return component, nil
+}
+
+// validateDeepLink requires an absolute URI without whitespace or control characters
+func validateDeepLink(link string) error {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return fmt.Errorf("no URL specified")
+	}
+	if strings.IndexFunc(link, func(r rune) bool { return r <= ' ' || r == 0x7f }) >= 0 {
+		return fmt.Errorf("invalid URL %q: contains whitespace or control characters", link)
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", link, err)
+	}
+	if u.Scheme == "" {
+		return fmt.Errorf("invalid URL %q: missing scheme (e.g. myapp:// or https://)", link)
+	}
+	if (u.Scheme == "http" || u.Scheme == "https") && u.Host == "" {
+		return fmt.Errorf("invalid URL %q: missing host", link)
+	}
+	return nil
+}
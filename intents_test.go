@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIntentExtraFlag(t *testing.T) {
+	cases := map[string]string{
+		"true":         "--ez",
+		"42":           "--ei",
+		"-7":           "--ei",
+		"5000000000":   "--el",
+		"3.5":          "--ef",
+		"1.2.3":        "--es",
+		"hello world":  "--es",
+		"":             "--es",
+		"0":            "--ei",
+		"0.5":          "--ef",
+		"007":          "--es",
+		"-01":          "--es",
+		"+14155550100": "--es",
+		"+5":           "--es",
+	}
+	for value, want := range cases {
+		if got := intentExtraFlag(value); got != want {
+			t.Errorf("intentExtraFlag(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestSendBroadcastQuotesArguments(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell 'am' 'broadcast' '-a' 'com.example.REFRESH' '--ei' 'count' '3' '--es' 'msg' 'it'\\''s; reboot'": "Broadcasting: Intent { act=com.example.REFRESH flg=0x400000 (has extras) }\nBroadcast completed: result=0\n",
+	})
+	out, err := app.SendBroadcast("R5CT1234ABC", "com.example.REFRESH", map[string]string{"msg": "it's; reboot", "count": "3"})
+	if err != nil {
+		t.Fatalf("SendBroadcast: %v", err)
+	}
+	if !strings.Contains(out, "Broadcast completed") {
+		t.Errorf("output = %q", out)
+	}
+	if _, err := app.SendBroadcast("R5CT1234ABC", "bad action; rm", nil); err == nil {
+		t.Error("expected an error for an invalid action")
+	}
+}
+
+func TestIntentArgs(t *testing.T) {
+	args, err := intentArgs(IntentOptions{
+		Action:     "android.intent.action.VIEW",
+		Data:       "myapp://item/42?ref=a&b=c",
+		Categories: []string{"android.intent.category.BROWSABLE"},
+		Flags:      0x10000000,
+		Extras:     map[string]string{"debug": "true"},
+		Component:  "com.example.app/.DeepLinkActivity",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "-a android.intent.action.VIEW -d myapp://item/42?ref=a&b=c -c android.intent.category.BROWSABLE -f 0x10000000 --ez debug true -n com.example.app/.DeepLinkActivity"
+	if got := strings.Join(args, " "); got != want {
+		t.Errorf("args =\n%s\nwant\n%s", got, want)
+	}
+	if _, err := intentArgs(IntentOptions{}); err == nil {
+		t.Error("expected an error for an empty intent")
+	}
+}
+
+func TestOpenDeepLink(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s R5CT1234ABC shell am start -W -a android.intent.action.VIEW -d 'myapp://item/42?a=1&b=2'": "Starting: Intent { act=android.intent.action.VIEW dat=myapp://item/42 }\nStatus: ok\nActivity: com.example.app/.DeepLinkActivity\n",
+		"-s R5CT1234ABC shell am start -W -a android.intent.action.VIEW -d 'nothing://here'":          "Starting: Intent { act=android.intent.action.VIEW dat=nothing://here }\nError: Activity not started, unable to resolve Intent { act=android.intent.action.VIEW dat=nothing://here flg=0x10000000 }\n",
+	})
+
+	got, err := app.OpenDeepLink("R5CT1234ABC", "myapp://item/42?a=1&b=2")
+	if err != nil || got != "com.example.app/.DeepLinkActivity" {
+		t.Errorf("OpenDeepLink = %q, %v", got, err)
+	}
+	if _, err := app.OpenDeepLink("R5CT1234ABC", "nothing://here"); !errors.Is(err, ErrNoLinkHandler) {
+		t.Errorf("unresolved link: err = %v, want ErrNoLinkHandler", err)
+	}
+	for _, bad := range []string{"", "no-scheme/path", "https://", "myapp://a b"} {
+		if _, err := app.OpenDeepLink("R5CT1234ABC", bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ========================================
+// Key events by name
+// ========================================
+
+// androidKeyCodes maps KeyEvent.KEYCODE_* names (without the prefix) to their codes
+var androidKeyCodes = map[string]int{
+	"HOME":               3,
+	"BACK":               4,
+	"CALL":               5,
+	"ENDCALL":            6,
+	"STAR":               17,
+	"POUND":              18,
+	"DPAD_UP":            19,
+	"DPAD_DOWN":          20,
+	"DPAD_LEFT":          21,
+	"DPAD_RIGHT":         22,
+	"DPAD_CENTER":        23,
+	"VOLUME_UP":          24,
+	"VOLUME_DOWN":        25,
+	"POWER":              26,
+	"CAMERA":             27,
+	"CLEAR":              28,
+	"TAB":                61,
+	"SPACE":              62,
+	"ENTER":              66,
+	"DEL":                67,
+	"MENU":               82,
+	"NOTIFICATION":       83,
+	"SEARCH":             84,
+	"MEDIA_PLAY_PAUSE":   85,
+	"MEDIA_STOP":         86,
+	"MEDIA_NEXT":         87,
+	"MEDIA_PREVIOUS":     88,
+	"MEDIA_REWIND":       89,
+	"MEDIA_FAST_FORWARD": 90,
+	"MUTE":               91,
+	"PAGE_UP":            92,
+	"PAGE_DOWN":          93,
+	"ESCAPE":             111,
+	"FORWARD_DEL":        112,
+	"SYSRQ":              120,
+	"MOVE_HOME":          122,
+	"MOVE_END":           123,
+	"MEDIA_PLAY":         126,
+	"MEDIA_PAUSE":        127,
+	"VOLUME_MUTE":        164,
+	"SETTINGS":           176,
+	"APP_SWITCH":         187,
+	"ASSIST":             219,
+	"BRIGHTNESS_DOWN":    220,
+	"BRIGHTNESS_UP":      221,
+	"SLEEP":              223,
+	"WAKEUP":             224,
+	"VOICE_ASSIST":       231,
+	"CUT":                277,
+	"COPY":               278,
+	"PASTE":              279,
+	"ALL_APPS":           284,
+}
+
+// keyNameAliases are friendlier names for keys whose Android name is obscure
+var keyNameAliases = map[string]string{
+	"RECENT":     "APP_SWITCH",
+	"RECENTS":    "APP_SWITCH",
+	"BACKSPACE":  "DEL",
+	"DELETE":     "DEL",
+	"SCREEN_ON":  "WAKEUP",
+	"SCREEN_OFF": "SLEEP",
+	"SCREENSHOT": "SYSRQ",
+	"OK":         "DPAD_CENTER",
+	"UP":         "DPAD_UP",
+	"DOWN":       "DPAD_DOWN",
+	"LEFT":       "DPAD_LEFT",
+	"RIGHT":      "DPAD_RIGHT",
+	"PLAY_PAUSE": "MEDIA_PLAY_PAUSE",
+}
+
+// resolveKeyCode turns a key name ("back", "KEYCODE_VOLUME_UP", "volume-up") or a raw
+// numeric code into an Android keycode. Digits are always raw codes, so the digit keys
+// are only reachable by their code (KEYCODE_0 is 7).
+func resolveKeyCode(key string) (int, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return 0, fmt.Errorf("key name is required")
+	}
+	if code, err := strconv.Atoi(key); err == nil {
+		if code < 0 || code > 999 {
+			return 0, fmt.Errorf("keycode out of range: %d", code)
+		}
+		return code, nil
+	}
+
+	name := strings.ToUpper(key)
+	name = strings.NewReplacer("-", "_", " ", "_").Replace(name)
+	name = strings.TrimPrefix(name, "KEYCODE_")
+	if alias, ok := keyNameAliases[name]; ok {
+		name = alias
+	}
+	if code, ok := androidKeyCodes[name]; ok {
+		return code, nil
+	}
+	// Single letters map onto KEYCODE_A..KEYCODE_Z
+	if len(name) == 1 && name[0] >= 'A' && name[0] <= 'Z' {
+		return 29 + int(name[0]-'A'), nil
+	}
+	return 0, fmt.Errorf("unknown key: %q", key)
+}
+
+// SendKeyEvent presses a key by name (e.g. "BACK", "HOME", "VOLUME_UP") or raw keycode
+func (a *App) SendKeyEvent(deviceId, keyName string) error {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return fmt.Errorf("invalid device ID: %w", err)
+	}
+	code, err := resolveKeyCode(keyName)
+	if err != nil {
+		return err
+	}
+	_, err = a.RunAdbCommand(deviceId, fmt.Sprintf("shell input keyevent %d", code))
+	return err
+}
+
+// GetSupportedKeys returns the key names SendKeyEvent understands, for building a picker.
+// Letters A-Z and raw numeric codes are accepted too but not listed.
+func (a *App) GetSupportedKeys() []string {
+	keys := make([]string, 0, len(androidKeyCodes)+len(keyNameAliases))
+	for name := range androidKeyCodes {
+		keys = append(keys, name)
+	}
+	for alias := range keyNameAliases {
+		keys = append(keys, alias)
+	}
+	sort.Strings(keys)
+	return keys
+}
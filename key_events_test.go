@@ -0,0 +1,82 @@
+package main
+
+import "testing"
+
+func TestResolveKeyCode(t *testing.T) {
+	tests := map[string]int{
+		"BACK":              4,
+		"home":              3,
+		"KEYCODE_VOLUME_UP": 24,
+		"volume-down":       25,
+		"recent":            187,
+		"enter":             66,
+		"a":                 29,
+		"Z":                 54,
+		"5":                 5, // raw code, not the digit key
+		"187":               187,
+	}
+	for key, want := range tests {
+		got, err := resolveKeyCode(key)
+		if err != nil || got != want {
+			t.Errorf("resolveKeyCode(%q) = %d, %v; want %d", key, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "NOPE", "-1", "1000"} {
+		if _, err := resolveKeyCode(bad); err == nil {
+			t.Errorf("resolveKeyCode(%q) should fail", bad)
+		}
+	}
+}
+
+func TestGetSupportedKeysResolve(t *testing.T) {
+	a := newTestApp(nil)
+	for _, key := range a.GetSupportedKeys() {
+		if _, err := resolveKeyCode(key); err != nil {
+			t.Errorf("listed key %q doesn't resolve: %v", key, err)
+		}
+	}
+}
+
+func TestSendKeyEvent(t *testing.T) {
+	a := newTestApp(map[string]string{
+		"-s dev1 shell input keyevent 66": "",
+		"-s dev1 shell input keyevent 61": "",
+		"-s dev1 shell input keyevent 4":  "",
+	})
+
+	if err := a.PressEnter("dev1"); err != nil {
+		t.Errorf("PressEnter() error = %v", err)
+	}
+	if err := a.PressTab("dev1"); err != nil {
+		t.Errorf("PressTab() error = %v", err)
+	}
+	if err := a.SendKeyEvent("dev1", "back"); err != nil {
+		t.Errorf("SendKeyEvent(back) error = %v", err)
+	}
+	want := []string{"-s dev1 shell input keyevent 66", "-s dev1 shell input keyevent 61", "-s dev1 shell input keyevent 4"}
+	calls := a.runner.(*fakeRunner).calls
+	if len(calls) != len(want) {
+		t.Fatalf("adb calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("call %d = %q, want %q", i, calls[i], want[i])
+		}
+	}
+
+	// Bad device IDs and unknown keys are rejected before adb runs
+	for _, id := range []string{"", "dev1; reboot"} {
+		if err := a.PressEnter(id); err == nil {
+			t.Errorf("PressEnter(%q) should fail", id)
+		}
+		if err := a.PressTab(id); err == nil {
+			t.Errorf("PressTab(%q) should fail", id)
+		}
+	}
+	if err := a.SendKeyEvent("dev1", "NOPE"); err == nil {
+		t.Error("SendKeyEvent(NOPE) should fail")
+	}
+	if got := len(a.runner.(*fakeRunner).calls); got != len(want) {
+		t.Errorf("rejected calls reached adb: %v", a.runner.(*fakeRunner).calls[len(want):])
+	}
+}
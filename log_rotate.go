@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ========================================
+// Rotating log file writer
+// ========================================
+
+const (
+	defaultLogRotateSizeMB = 50
+	defaultLogRotateKeep   = 5
+	maxLogRotateKeep       = 100
+	// Buffered lines reach the disk at least this often, so a crash loses at most this much
+	logFlushInterval = time.Second
+)
+
+// rotatingFileWriter is a buffered file writer that rolls path over to path.1, path.2, …
+// once it reaches maxBytes, keeping the newest keep rotated files
+type rotatingFileWriter struct {
+	path     string
+	maxBytes int64
+	keep     int
+
+	mu     sync.Mutex
+	file   *os.File
+	buf    *bufio.Writer
+	size   int64
+	closed bool
+	stop   chan struct{}
+}
+
+func newRotatingFileWriter(path string, maxBytes int64, keep int) (*rotatingFileWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	w := &rotatingFileWriter{path: path, maxBytes: maxBytes, keep: keep, stop: make(chan struct{})}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	go w.flushLoop()
+	return w, nil
+}
+
+func (w *rotatingFileWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	w.file, w.size = f, info.Size()
+	w.buf = bufio.NewWriterSize(f, 64*1024)
+	return nil
+}
+
+// Write appends p, rotating first if it would push the file past maxBytes
+func (w *rotatingFileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+	if w.size > 0 && w.size+int64(len(p)) > w.maxBytes {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.buf.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// rotate shifts path.N-1 -> path.N … path -> path.1 and drops files beyond keep.
+// Must be called with mu held.
+func (w *rotatingFileWriter) rotate() error {
+	if err := w.buf.Flush(); err != nil {
+		return fmt.Errorf("failed to flush log file: %w", err)
+	}
+	w.file.Close()
+
+	os.Remove(fmt.Sprintf("%s.%d", w.path, w.keep))
+	for i := w.keep - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", w.path, i), fmt.Sprintf("%s.%d", w.path, i+1))
+	}
+	if w.keep > 0 {
+		if err := os.Rename(w.path, w.path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	} else {
+		os.Remove(w.path)
+	}
+	return w.open()
+}
+
+// Flush writes buffered data to the file
+func (w *rotatingFileWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	return w.buf.Flush()
+}
+
+// Close flushes and closes the file. Safe to call more than once.
+func (w *rotatingFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	close(w.stop)
+	err := w.buf.Flush()
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (w *rotatingFileWriter) flushLoop() {
+	ticker := time.NewTicker(logFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if err := w.Flush(); err != nil {
+				LogWarn("logcat").Err(err).Str("path", w.path).Msg("Failed to flush log file")
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFileWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "logcat.txt")
+	w, err := newRotatingFileWriter(path, 20, 2)
+	if err != nil {
+		t.Fatalf("newRotatingFileWriter: %v", err)
+	}
+
+	// 10-byte lines: two fit per file, so six lines produce three generations
+	for _, line := range []string{"line-0001\n", "line-0002\n", "line-0003\n", "line-0004\n", "line-0005\n", "line-0006\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+	if _, err := w.Write([]byte("late\n")); err == nil {
+		t.Error("Write after Close should fail")
+	}
+
+	want := map[string]string{
+		path:        "line-0005\nline-0006\n",
+		path + ".1": "line-0003\nline-0004\n",
+		path + ".2": "line-0001\nline-0002\n",
+	}
+	for p, content := range want {
+		got, err := os.ReadFile(p)
+		if err != nil {
+			t.Errorf("read %s: %v", filepath.Base(p), err)
+			continue
+		}
+		if string(got) != content {
+			t.Errorf("%s = %q, want %q", filepath.Base(p), got, content)
+		}
+	}
+
+	// A further rotation drops the oldest file
+	w, err = newRotatingFileWriter(path, 20, 2)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	w.Write([]byte("line-0007\n"))
+	w.Close()
+	if got, _ := os.ReadFile(path + ".2"); !strings.HasPrefix(string(got), "line-0003") {
+		t.Errorf("oldest kept file = %q, want the line-0003 generation", got)
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("files beyond keep should be removed")
+	}
+}
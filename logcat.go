@@ -4,12 +4,17 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"Gaze/pkg/cache"
+
 	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
@@ -41,8 +46,151 @@ func logcatLevelToSessionLevel(androidLevel string) string {
 	}
 }
 
-// StartLogcat starts the logcat stream for a device
+// resolvePackageUid returns the app UID of a package, or "" if unknown
+func (a *App) resolvePackageUid(deviceId, packageName string) string {
+	uidOut, _, _ := a.runAdb(nil, "-s", deviceId, "shell", "pm list packages -U "+packageName)
+	uidStr := string(uidOut)
+	if strings.Contains(uidStr, "uid:") {
+		parts := strings.Split(uidStr, "uid:")
+		if len(parts) > 1 {
+			if fields := strings.Fields(parts[1]); len(fields) > 0 {
+				return strings.TrimSpace(fields[0])
+			}
+		}
+	}
+	return ""
+}
+
+// resolvePackagePids returns the running PIDs of a package (pgrep, pidof, then ps -A)
+func (a *App) resolvePackagePids(ctx context.Context, deviceId, packageName string) []string {
+	// Use a timeout context to prevent PID check commands from piling up
+	pidCtx, pidCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer pidCancel()
+
+	out, _, _ := a.runAdb(pidCtx, "-s", deviceId, "shell", "pgrep -f", packageName)
+	raw := strings.TrimSpace(string(out))
+
+	if raw == "" {
+		out2, _, _ := a.runAdb(pidCtx, "-s", deviceId, "shell", "pidof", packageName)
+		raw = strings.TrimSpace(string(out2))
+	}
+
+	if raw == "" {
+		out3, _, _ := a.runAdb(pidCtx, "-s", deviceId, "shell", "ps -A")
+		lines := strings.Split(string(out3), "\n")
+		var matchedPids []string
+		for _, line := range lines {
+			if strings.Contains(line, packageName) {
+				fields := strings.Fields(line)
+				if len(fields) > 1 {
+					matchedPids = append(matchedPids, fields[1])
+				}
+			}
+		}
+		raw = strings.Join(matchedPids, " ")
+	}
+
+	return strings.Fields(raw)
+}
+
+// logcatLineMatchesProcess reports whether a logcat line belongs to one of the
+// given PIDs (or the UID). No PIDs means the process isn't running: no match.
+func logcatLineMatchesProcess(line string, pids []string, uid string) bool {
+	if len(pids) == 0 {
+		return false
+	}
+	for _, pid := range pids {
+		if strings.Contains(line, "("+pid+")") ||
+			strings.Contains(line, "( "+pid+")") ||
+			strings.Contains(line, "("+pid+" )") ||
+			strings.Contains(line, "["+pid+"]") ||
+			strings.Contains(line, "[ "+pid+"]") ||
+			strings.Contains(line, " "+pid+":") ||
+			strings.Contains(line, "/"+pid+"(") ||
+			strings.Contains(line, " "+pid+" ") ||
+			strings.Contains(line, " "+pid+"):") ||
+			strings.Contains(line, " "+pid+":") {
+			return true
+		}
+	}
+	return uid != "" && strings.Contains(line, " "+uid+" ")
+}
+
+// logcatStream is the running logcat of one device
+type logcatStream struct {
+	cmd    *exec.Cmd
+	cancel context.CancelFunc
+	file   *rotatingFileWriter // Set while StartLogcatToFile persists the stream
+}
+
+// stop ends the stream and closes its file, if any
+func (s *logcatStream) stop() {
+	s.cancel()
+	if s.cmd.Process != nil {
+		_ = s.cmd.Process.Kill()
+	}
+	if s.file != nil {
+		if err := s.file.Close(); err != nil {
+			LogWarn("logcat").Err(err).Msg("Failed to close logcat file")
+		}
+	}
+}
+
+// emitLogcatStatus sends a status line (e.g. PID changes) of a device's stream to the UI
+func (a *App) emitLogcatStatus(deviceId, line string) {
+	if !a.mcpMode && a.ctx != nil {
+		wailsRuntime.EventsEmit(a.ctx, "logcat-data", map[string]interface{}{
+			"deviceId": deviceId,
+			"line":     line,
+		})
+	}
+}
+
+// StartLogcat starts the logcat stream for a device. Streams of other devices keep
+// running; a stream already running for this device is replaced.
 func (a *App) StartLogcat(deviceId, packageName, preFilter string, preUseRegex bool, excludeFilter string, excludeUseRegex bool) error {
+	return a.startLogcat(deviceId, packageName, preFilter, preUseRegex, excludeFilter, excludeUseRegex, nil)
+}
+
+// StartLogcatToFile is StartLogcat that also appends every shown line to savePath
+// (defaults to the logs output directory). The file rotates at maxSizeMB (default 50)
+// and the newest keepFiles rotated files are kept (default 5). Returns the file path.
+func (a *App) StartLogcatToFile(deviceId, packageName, preFilter string, preUseRegex bool, excludeFilter string, excludeUseRegex bool, savePath string, maxSizeMB, keepFiles int) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+	if maxSizeMB < 0 || keepFiles < 0 || keepFiles > maxLogRotateKeep {
+		return "", fmt.Errorf("invalid rotation: size %dMB, keep %d (max %d)", maxSizeMB, keepFiles, maxLogRotateKeep)
+	}
+	if maxSizeMB == 0 {
+		maxSizeMB = defaultLogRotateSizeMB
+	}
+	if keepFiles == 0 {
+		keepFiles = defaultLogRotateKeep
+	}
+	if savePath == "" {
+		name := packageName
+		if name == "" {
+			name = strings.NewReplacer(":", "_", "/", "_").Replace(deviceId)
+		}
+		savePath = a.GetOutputPath(OutputLogs, fmt.Sprintf("logcat_%s_%s.txt", name, time.Now().Format("20060102_150405")))
+	}
+
+	sink, err := newRotatingFileWriter(savePath, int64(maxSizeMB)*1024*1024, keepFiles)
+	if err != nil {
+		return "", err
+	}
+	if err := a.startLogcat(deviceId, packageName, preFilter, preUseRegex, excludeFilter, excludeUseRegex, sink); err != nil {
+		sink.Close()
+		return "", err
+	}
+	a.Log("Persisting logcat of %s to %s", deviceId, savePath)
+	return savePath, nil
+}
+
+// startLogcat runs the logcat stream; lines that pass the filters are also written to
+// sink when it is non-nil. The sink is closed when the stream stops.
+func (a *App) startLogcat(deviceId, packageName, preFilter string, preUseRegex bool, excludeFilter string, excludeUseRegex bool, sink *rotatingFileWriter) error {
 	// 验证 deviceId 格式
 	if err := ValidateDeviceID(deviceId); err != nil {
 		return err
@@ -50,12 +198,9 @@ func (a *App) StartLogcat(deviceId, packageName, preFilter string, preUseRegex b
 
 	a.updateLastActive(deviceId)
 
-	if a.logcatCmd != nil {
-		a.StopLogcat()
-	}
+	a.StopLogcat(deviceId)
 
 	ctx, cancel := context.WithCancel(a.ctx)
-	a.logcatCancel = cancel
 
 	var cmd *exec.Cmd
 	shellCmd := "logcat -v time"
@@ -83,35 +228,29 @@ func (a *App) StartLogcat(deviceId, packageName, preFilter string, preUseRegex b
 	} else {
 		cmd = a.newAdbCommand(ctx, "-s", deviceId, "logcat", "-v", "time")
 	}
-	a.logcatCmd = cmd
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		cancel()
-		a.logcatCmd = nil
 		return fmt.Errorf("failed to get stdout pipe: %w", err)
 	}
 
 	if err := cmd.Start(); err != nil {
 		cancel()
-		a.logcatCmd = nil
 		return fmt.Errorf("failed to start logcat: %w", err)
 	}
 
+	a.logcatMu.Lock()
+	a.logcatStreams[deviceId] = &logcatStream{cmd: cmd, cancel: cancel, file: sink}
+	a.logcatMu.Unlock()
+	a.registerMonitor("logcat", deviceId, func() { a.StopLogcat(deviceId) })
+
 	var currentPids []string
 	var currentUid string
 	var pidMutex sync.RWMutex
 
 	if packageName != "" {
-		uidCmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "pm list packages -U "+packageName)
-		uidOut, _ := uidCmd.Output()
-		uidStr := string(uidOut)
-		if strings.Contains(uidStr, "uid:") {
-			parts := strings.Split(uidStr, "uid:")
-			if len(parts) > 1 {
-				currentUid = strings.TrimSpace(strings.Fields(parts[1])[0])
-			}
-		}
+		currentUid = a.resolvePackageUid(deviceId, packageName)
 	}
 
 	if packageName != "" {
@@ -120,37 +259,7 @@ func (a *App) StartLogcat(deviceId, packageName, preFilter string, preUseRegex b
 			defer ticker.Stop()
 
 			checkPid := func() {
-				// Use a timeout context to prevent PID check commands from piling up
-				pidCtx, pidCancel := context.WithTimeout(ctx, 5*time.Second)
-				defer pidCancel()
-
-				c := a.newAdbCommand(pidCtx, "-s", deviceId, "shell", "pgrep -f", packageName)
-				out, _ := c.Output()
-				raw := strings.TrimSpace(string(out))
-
-				if raw == "" {
-					c2 := a.newAdbCommand(pidCtx, "-s", deviceId, "shell", "pidof", packageName)
-					out2, _ := c2.Output()
-					raw = strings.TrimSpace(string(out2))
-				}
-
-				if raw == "" {
-					c3 := a.newAdbCommand(pidCtx, "-s", deviceId, "shell", "ps -A")
-					out3, _ := c3.Output()
-					lines := strings.Split(string(out3), "\n")
-					var matchedPids []string
-					for _, line := range lines {
-						if strings.Contains(line, packageName) {
-							fields := strings.Fields(line)
-							if len(fields) > 1 {
-								matchedPids = append(matchedPids, fields[1])
-							}
-						}
-					}
-					raw = strings.Join(matchedPids, " ")
-				}
-
-				pids := strings.Fields(raw)
+				pids := a.resolvePackagePids(ctx, deviceId, packageName)
 
 				pidMutex.Lock()
 				changed := len(pids) != len(currentPids)
@@ -166,14 +275,9 @@ func (a *App) StartLogcat(deviceId, packageName, preFilter string, preUseRegex b
 				if changed {
 					currentPids = pids
 					if len(pids) > 0 {
-						status := fmt.Sprintf("--- Monitoring %s (UID: %s, PIDs: %s) ---", packageName, currentUid, strings.Join(pids, ", "))
-						if !a.mcpMode {
-							wailsRuntime.EventsEmit(a.ctx, "logcat-data", status)
-						}
+						a.emitLogcatStatus(deviceId, fmt.Sprintf("--- Monitoring %s (UID: %s, PIDs: %s) ---", packageName, currentUid, strings.Join(pids, ", ")))
 					} else {
-						if !a.mcpMode {
-							wailsRuntime.EventsEmit(a.ctx, "logcat-data", fmt.Sprintf("--- Waiting for %s processes... ---", packageName))
-						}
+						a.emitLogcatStatus(deviceId, fmt.Sprintf("--- Waiting for %s processes... ---", packageName))
 					}
 				}
 				pidMutex.Unlock()
@@ -199,6 +303,11 @@ func (a *App) StartLogcat(deviceId, packageName, preFilter string, preUseRegex b
 	go func() {
 		reader := bufio.NewReader(stdout)
 		defer close(logEvtChan)
+		if sink != nil {
+			// Flush even if logcat dies on its own, e.g. when the device disconnects
+			defer sink.Close()
+		}
+		sinkFailed := false
 
 		for {
 			line, err := reader.ReadString('\n')
@@ -212,36 +321,18 @@ func (a *App) StartLogcat(deviceId, packageName, preFilter string, preUseRegex b
 				uid := currentUid
 				pidMutex.RUnlock()
 
-				if len(pids) > 0 {
-					found := false
-					for _, pid := range pids {
-						if strings.Contains(line, "("+pid+")") ||
-							strings.Contains(line, "( "+pid+")") ||
-							strings.Contains(line, "("+pid+" )") ||
-							strings.Contains(line, "["+pid+"]") ||
-							strings.Contains(line, "[ "+pid+"]") ||
-							strings.Contains(line, " "+pid+":") ||
-							strings.Contains(line, "/"+pid+"(") ||
-							strings.Contains(line, " "+pid+" ") ||
-							strings.Contains(line, " "+pid+"):") ||
-							strings.Contains(line, " "+pid+":") {
-							found = true
-							break
-						}
-					}
-
-					if !found && uid != "" && strings.Contains(line, " "+uid+" ") {
-						found = true
-					}
-
-					if !found {
-						continue
-					}
-				} else {
+				if !logcatLineMatchesProcess(line, pids, uid) {
 					continue
 				}
 			}
 
+			if sink != nil && !sinkFailed {
+				if _, err := io.WriteString(sink, line); err != nil {
+					sinkFailed = true
+					LogWarn("logcat").Err(err).Str("path", sink.path).Msg("Failed to write logcat file, persistence stopped")
+				}
+			}
+
 			if level, tag, message, ok := parseLogcatLine(line); ok {
 				logEvtChan <- map[string]interface{}{
 					"tag":         tag,
@@ -255,14 +346,15 @@ func (a *App) StartLogcat(deviceId, packageName, preFilter string, preUseRegex b
 	}()
 
 	// Aggregator & Emitter Routine
+	buffering := a.GetLogcatBuffering()
 	go func() {
 		var buffer []map[string]interface{}
 		var lastTag string
 		var lastLevel string
 		var lastActivityTime time.Time
 
-		// Regular ticker as requested (300ms)
-		flushTicker := time.NewTicker(300 * time.Millisecond)
+		tick, flushInterval := logcatFlushTimings(buffering)
+		flushTicker := time.NewTicker(tick)
 		defer flushTicker.Stop()
 
 		flush := func() {
@@ -313,12 +405,12 @@ func (a *App) StartLogcat(deviceId, packageName, preFilter string, preUseRegex b
 				lastActivityTime = now
 
 				// Safety valve: Flush if buffer gets massive (e.g. infinite loop of same log)
-				if len(buffer) >= 2000 {
+				if len(buffer) >= buffering.MaxChunk {
 					flush()
 				}
 
 			case <-flushTicker.C:
-				// Ticker fires every 300ms.
+				// Ticker fires every few flush intervals (300ms by default).
 				// User requirement: "If aggregating (active), wait. If done/idle, flush."
 
 				// If buffer is empty, nothing to do.
@@ -327,14 +419,14 @@ func (a *App) StartLogcat(deviceId, packageName, preFilter string, preUseRegex b
 				}
 
 				// Check if we are "active"
-				// If we received a log recently (e.g. < 100ms ago), we assume we are in the middle of a burst.
+				// If we received a log within the flush interval, we assume we are in the middle of a burst.
 				// In this case, we SKIP the timer flush to avoid splitting the burst.
 				timeSinceLastLog := time.Since(lastActivityTime)
-				if timeSinceLastLog < 100*time.Millisecond {
+				if timeSinceLastLog < flushInterval {
 					continue
 				}
 
-				// If we haven't received logs for >100ms, assume the specific aggregation "block" has finished (paused).
+				// If we haven't received logs for a whole interval, assume the aggregation "block" has finished (paused).
 				flush()
 
 			case <-ctx.Done():
@@ -347,14 +439,225 @@ func (a *App) StartLogcat(deviceId, packageName, preFilter string, preUseRegex b
 	return nil
 }
 
-// StopLogcat stops the logcat stream
-func (a *App) StopLogcat() {
-	if a.logcatCancel != nil {
-		a.logcatCancel()
+const (
+	defaultLogcatMaxChunk        = 2000
+	defaultLogcatFlushIntervalMs = 100
+
+	maxLogcatChunk           = 50000
+	minLogcatFlushIntervalMs = 10
+	maxLogcatFlushIntervalMs = 5000
+
+	// The aggregator's ticker runs at this multiple of the flush interval, which keeps
+	// the original 300ms tick for the default 100ms quiet time
+	logcatTicksPerFlushInterval = 3
+)
+
+// logcatFlushTimings returns how often the aggregator checks for a finished burst and
+// how long the stream must be quiet before a batch is flushed
+func logcatFlushTimings(b LogcatBufferingSettings) (tick, idle time.Duration) {
+	idle = time.Duration(b.FlushIntervalMs) * time.Millisecond
+	return idle * logcatTicksPerFlushInterval, idle
+}
+
+// GetLogcatBuffering returns the effective logcat batching: how many lines one event
+// holds at most and how long the stream must be quiet before a batch is emitted
+func (a *App) GetLogcatBuffering() LogcatBufferingSettings {
+	b := LogcatBufferingSettings{
+		MaxChunk:        defaultLogcatMaxChunk,
+		FlushIntervalMs: defaultLogcatFlushIntervalMs,
+	}
+	if a.cacheService == nil {
+		return b
+	}
+
+	stored := a.cacheService.GetLogcatBuffering()
+	if stored.MaxChunk > 0 {
+		b.MaxChunk = stored.MaxChunk
+	}
+	if stored.FlushIntervalMs > 0 {
+		b.FlushIntervalMs = stored.FlushIntervalMs
+	}
+	return b
+}
+
+// SetLogcatBuffering tunes logcat batching. Larger chunks and intervals keep noisy logs
+// from flooding the frontend; a short interval shows single lines sooner, since batches
+// are checked every 3 intervals.
+// Passing 0 for a value restores its default. Applies to streams started afterwards.
+func (a *App) SetLogcatBuffering(maxChunk int, flushIntervalMs int) (LogcatBufferingSettings, error) {
+	if maxChunk < 0 || maxChunk > maxLogcatChunk {
+		return a.GetLogcatBuffering(), fmt.Errorf("max chunk must be between 0 and %d, got %d", maxLogcatChunk, maxChunk)
+	}
+	if flushIntervalMs != 0 && (flushIntervalMs < minLogcatFlushIntervalMs || flushIntervalMs > maxLogcatFlushIntervalMs) {
+		return a.GetLogcatBuffering(), fmt.Errorf("flush interval must be 0 or between %d and %d ms, got %d", minLogcatFlushIntervalMs, maxLogcatFlushIntervalMs, flushIntervalMs)
+	}
+	if a.cacheService == nil {
+		return a.GetLogcatBuffering(), fmt.Errorf("settings are not available")
+	}
+
+	a.cacheService.SetLogcatBuffering(cache.LogcatBuffering{
+		MaxChunk:        maxChunk,
+		FlushIntervalMs: flushIntervalMs,
+	})
+	go a.saveSettings()
+
+	b := a.GetLogcatBuffering()
+	a.Log("Logcat buffering set: maxChunk=%d flushInterval=%dms", b.MaxChunk, b.FlushIntervalMs)
+	return b, nil
+}
+
+// StopLogcat stops the logcat stream of a device
+func (a *App) StopLogcat(deviceId string) {
+	a.logcatMu.Lock()
+	stream := a.logcatStreams[deviceId]
+	delete(a.logcatStreams, deviceId)
+	a.logcatMu.Unlock()
+
+	if stream != nil {
+		stream.stop()
+	}
+	unregisterMonitor("logcat", deviceId)
+}
+
+// StopAllLogcat stops the logcat streams of all devices
+func (a *App) StopAllLogcat() {
+	a.logcatMu.Lock()
+	streams := a.logcatStreams
+	a.logcatStreams = make(map[string]*logcatStream)
+	a.logcatMu.Unlock()
+
+	for _, stream := range streams {
+		stream.stop()
+	}
+	unregisterMonitorKind("logcat")
+}
+
+// GetLogcatDevices returns the devices with a running logcat stream
+func (a *App) GetLogcatDevices() []string {
+	a.logcatMu.Lock()
+	defer a.logcatMu.Unlock()
+	ids := make([]string, 0, len(a.logcatStreams))
+	for id := range a.logcatStreams {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// getForegroundPackage returns the package owning the focused window, or "" if unknown
+func (a *App) getForegroundPackage(ctx context.Context, deviceId string) string {
+	output, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "dumpsys window displays | grep mCurrentFocus")
+	if err != nil {
+		return ""
+	}
+	return parseForegroundPackage(string(output))
+}
+
+// ExportLogcatForForegroundApp captures logcat for whichever app is in the foreground
+// for durationSec seconds and writes it to savePath (defaults to Downloads). If the
+// foreground app changes mid-capture, the capture follows the new app's PIDs.
+func (a *App) ExportLogcatForForegroundApp(deviceId, savePath string, durationSec int) (string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return "", err
+	}
+	if durationSec <= 0 {
+		durationSec = 30
 	}
-	if a.logcatCmd != nil && a.logcatCmd.Process != nil {
-		_ = a.logcatCmd.Process.Kill()
+	if durationSec > 600 {
+		durationSec = 600
 	}
-	a.logcatCmd = nil
-	a.logcatCancel = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(durationSec)*time.Second)
+	defer cancel()
+
+	packageName := a.getForegroundPackage(ctx, deviceId)
+	if packageName == "" {
+		return "", fmt.Errorf("could not determine the foreground app")
+	}
+
+	if savePath == "" {
+		savePath = a.GetOutputPath(OutputLogs, fmt.Sprintf("logcat_%s_%s.txt", packageName, time.Now().Format("20060102_150405")))
+	}
+
+	f, err := os.Create(savePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+
+	// The follower writes to w too, so it's stopped and joined before the final flush
+	var mu sync.RWMutex
+	var follower sync.WaitGroup
+	defer func() {
+		cancel()
+		follower.Wait()
+		w.Flush()
+	}()
+
+	uid := a.resolvePackageUid(deviceId, packageName)
+	pids := a.resolvePackagePids(ctx, deviceId, packageName)
+	fmt.Fprintf(w, "--- Capturing %s (UID: %s, PIDs: %s) ---\n", packageName, uid, strings.Join(pids, ", "))
+
+	// Follow foreground changes and process restarts
+	follower.Add(1)
+	go func() {
+		defer follower.Done()
+		ticker := time.NewTicker(2 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fg := a.getForegroundPackage(ctx, deviceId)
+				mu.RLock()
+				current := packageName
+				mu.RUnlock()
+
+				if fg != "" && fg != current {
+					newUid := a.resolvePackageUid(deviceId, fg)
+					newPids := a.resolvePackagePids(ctx, deviceId, fg)
+					mu.Lock()
+					packageName, uid, pids = fg, newUid, newPids
+					fmt.Fprintf(w, "--- Foreground changed to %s (UID: %s, PIDs: %s) ---\n", fg, newUid, strings.Join(newPids, ", "))
+					mu.Unlock()
+					continue
+				}
+
+				newPids := a.resolvePackagePids(ctx, deviceId, current)
+				mu.Lock()
+				pids = newPids
+				mu.Unlock()
+			}
+		}
+	}()
+
+	cmd := a.newAdbCommand(ctx, "-s", deviceId, "logcat", "-v", "time", "-T", "1")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", fmt.Errorf("failed to get stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("failed to start logcat: %w", err)
+	}
+
+	lines := 0
+	reader := bufio.NewReader(stdout)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			break
+		}
+		mu.Lock()
+		if logcatLineMatchesProcess(line, pids, uid) {
+			w.WriteString(line)
+			lines++
+		}
+		mu.Unlock()
+	}
+	_ = cmd.Wait()
+
+	a.Log("Exported %d foreground logcat lines to %s", lines, savePath)
+	return savePath, nil
 }
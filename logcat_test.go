@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopLogcatPerDevice(t *testing.T) {
+	a := newTestApp(nil)
+	a.logcatStreams = make(map[string]*logcatStream)
+
+	ctxs := make(map[string]context.Context)
+	for _, id := range []string{"emulator-5554", "R5CT1234ABC", "192.168.1.20:5555"} {
+		ctx, cancel := context.WithCancel(context.Background())
+		ctxs[id] = ctx
+		a.logcatStreams[id] = &logcatStream{cmd: &exec.Cmd{}, cancel: cancel}
+	}
+
+	a.StopLogcat("R5CT1234ABC")
+	if ctxs["R5CT1234ABC"].Err() == nil {
+		t.Error("stopped stream should be cancelled")
+	}
+	if ctxs["emulator-5554"].Err() != nil || ctxs["192.168.1.20:5555"].Err() != nil {
+		t.Error("streams of other devices must keep running")
+	}
+	if got := strings.Join(a.GetLogcatDevices(), ","); got != "192.168.1.20:5555,emulator-5554" {
+		t.Errorf("GetLogcatDevices = %s", got)
+	}
+
+	// Stopping a device without a stream is a no-op
+	a.StopLogcat("R5CT1234ABC")
+
+	a.StopAllLogcat()
+	for id, ctx := range ctxs {
+		if ctx.Err() == nil {
+			t.Errorf("%s still running after StopAllLogcat", id)
+		}
+	}
+	if n := len(a.GetLogcatDevices()); n != 0 {
+		t.Errorf("%d streams left after StopAllLogcat", n)
+	}
+}
+
+func TestSetLogcatBuffering(t *testing.T) {
+	app := newOutputTestApp(t)
+
+	if got := app.GetLogcatBuffering(); got.MaxChunk != defaultLogcatMaxChunk || got.FlushIntervalMs != defaultLogcatFlushIntervalMs {
+		t.Errorf("defaults = %+v", got)
+	}
+
+	got, err := app.SetLogcatBuffering(500, 20)
+	if err != nil {
+		t.Fatalf("SetLogcatBuffering: %v", err)
+	}
+	if got.MaxChunk != 500 || got.FlushIntervalMs != 20 {
+		t.Errorf("after set = %+v, want 500 and 20", got)
+	}
+
+	if _, err := app.SetLogcatBuffering(-1, 0); err == nil {
+		t.Error("expected error for a negative chunk size")
+	}
+	if _, err := app.SetLogcatBuffering(0, 1); err == nil {
+		t.Error("expected error for a flush interval below the minimum")
+	}
+
+	got, _ = app.SetLogcatBuffering(0, 0)
+	if got.MaxChunk != defaultLogcatMaxChunk || got.FlushIntervalMs != defaultLogcatFlushIntervalMs {
+		t.Errorf("zero should restore defaults, got %+v", got)
+	}
+}
+
+func TestLogcatFlushTimings(t *testing.T) {
+	// The defaults keep the aggregator's original 300ms tick and 100ms quiet time
+	tick, idle := logcatFlushTimings(LogcatBufferingSettings{MaxChunk: defaultLogcatMaxChunk, FlushIntervalMs: defaultLogcatFlushIntervalMs})
+	if tick != 300*time.Millisecond || idle != 100*time.Millisecond {
+		t.Errorf("default timings = %v tick, %v idle; want 300ms and 100ms", tick, idle)
+	}
+
+	tick, idle = logcatFlushTimings(LogcatBufferingSettings{FlushIntervalMs: 20})
+	if tick != 60*time.Millisecond || idle != 20*time.Millisecond {
+		t.Errorf("20ms timings = %v tick, %v idle; want 60ms and 20ms", tick, idle)
+	}
+}
+
+func TestLogcatLineMatchesProcess(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		pids []string
+		uid  string
+		want bool
+	}{
+		{"time format pid", "01-02 03:04:05.678 I/ActivityManager( 1234): Start proc", []string{"1234"}, "", true},
+		{"threadtime format pid", "01-02 03:04:05.678  1234  1250 I Tag: hello", []string{"1234"}, "", true},
+		{"other pid", "01-02 03:04:05.678 I/Tag( 4321): hello", []string{"1234"}, "", false},
+		{"pid prefix is not a match", "01-02 03:04:05.678 I/Tag(12345): hello", []string{"1234"}, "", false},
+		{"uid fallback", "01-02 03:04:05.678 10234 999 I Tag: hello", []string{"1"}, "10234", true},
+		{"process not running", "01-02 03:04:05.678 I/Tag( 1234): hello", nil, "10234", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := logcatLineMatchesProcess(tt.line, tt.pids, tt.uid); got != tt.want {
+				t.Errorf("logcatLineMatchesProcess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForegroundPackageResolution(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"-s dev1 shell dumpsys window displays | grep mCurrentFocus": "  mCurrentFocus=Window{8f2c1d u0 com.example.app/com.example.app.MainActivity}\n",
+		"-s dev1 shell pm list packages -U com.example.app":          "package:com.example.app uid:10234\n",
+		"-s dev1 shell pgrep -f com.example.app":                     "",
+		"-s dev1 shell pidof com.example.app":                        "4321 4400\n",
+	})
+
+	if got := app.getForegroundPackage(context.Background(), "dev1"); got != "com.example.app" {
+		t.Errorf("getForegroundPackage() = %q", got)
+	}
+	if got := app.getForegroundPackage(context.Background(), "dev2"); got != "" {
+		t.Errorf("expected no package when dumpsys fails, got %q", got)
+	}
+	if got := app.resolvePackageUid("dev1", "com.example.app"); got != "10234" {
+		t.Errorf("resolvePackageUid() = %q", got)
+	}
+	// pgrep finds nothing, so pidof is used
+	if got := app.resolvePackagePids(context.Background(), "dev1", "com.example.app"); strings.Join(got, ",") != "4321,4400" {
+		t.Errorf("resolvePackagePids() = %v", got)
+	}
+}
+
+func TestExportLogcatForForegroundApp_Errors(t *testing.T) {
+	app := newTestApp(nil)
+	if _, err := app.ExportLogcatForForegroundApp("bad id;", "", 1); err == nil {
+		t.Error("expected error for an invalid device ID")
+	}
+	if _, err := app.ExportLogcatForForegroundApp("dev1", "", 1); err == nil || !strings.Contains(err.Error(), "foreground") {
+		t.Errorf("expected a foreground app error, got %v", err)
+	}
+}
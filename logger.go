@@ -388,6 +388,7 @@ const (
 	ActionDeviceConnect    UserAction = "device_connect"
 	ActionDeviceDisconnect UserAction = "device_disconnect"
 	ActionDeviceSelect     UserAction = "device_select"
+	ActionDeviceReboot     UserAction = "device_reboot"
 
 	// Session 相关
 	ActionSessionStart UserAction = "session_start"
@@ -424,6 +425,7 @@ const (
 
 	// Shell 相关
 	ActionShellCommand UserAction = "shell_command"
+	ActionShellBlocked UserAction = "shell_blocked"
 
 	// 设置相关
 	ActionSettingsChange UserAction = "settings_change"
@@ -321,13 +321,8 @@ func updateTrayMenu(ctx context.Context, app *App) {
 			mRecordTop := systray.AddMenuItem("  Start Recording", "")
 			mRecordTop.Click(func() {
 				go func() {
-					home, _ := os.UserHomeDir()
-					saveDir := filepath.Join(home, "Downloads")
-					if _, err := os.Stat(saveDir); err != nil {
-						saveDir = home
-					}
 					filename := fmt.Sprintf("Gaze_record_%s_%s.mp4", strings.ReplaceAll(d.Model, " ", "_"), time.Now().Format("20060102_150405"))
-					savePath := filepath.Join(saveDir, filename)
+					savePath := app.GetOutputPath(OutputRecordings, filename)
 					config := ScrcpyConfig{RecordPath: savePath, MaxSize: 0, BitRate: 8, MaxFps: 60, VideoCodec: "h264", NoAudio: false}
 					app.StartRecording(d.ID, config)
 				}()
@@ -461,17 +456,10 @@ func updateTrayMenu(ctx context.Context, app *App) {
 			mRecord := devItem.AddSubMenuItem("Start Recording", "")
 			mRecord.Click(func() {
 				go func() {
-					home, _ := os.UserHomeDir()
-					// Default to Downloads
-					saveDir := filepath.Join(home, "Downloads")
-					if _, err := os.Stat(saveDir); err != nil {
-						saveDir = home // Fallback
-					}
-
 					filename := fmt.Sprintf("Gaze_record_%s_%s.mp4",
 						strings.ReplaceAll(d.Model, " ", "_"),
 						time.Now().Format("20060102_150405"))
-					savePath := filepath.Join(saveDir, filename)
+					savePath := app.GetOutputPath(OutputRecordings, filename)
 
 					// Use default nice settings
 					config := ScrcpyConfig{
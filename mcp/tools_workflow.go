@@ -51,7 +51,7 @@ Step JSON format (V2):
   "tap": { "x": 540, "y": 960 },
   "swipe": { "x": 540, "y": 1800, "x2": 540, "y2": 600 } or { "direction": "up", "distance": 500 },
   "element": { "selector": {"type":"id","value":"com.app:id/btn"}, "action": "click" },
-  "app": { "packageName": "com.example.app", "action": "launch" },
+  "app": { "packageName": "com.example.app", "action": "launch" } (install_if_missing also needs "apkPath"),
   "branch": { "condition": "exists", "selector": {...} },
   "wait": { "durationMs": 2000 },
   "adb": { "command": "shell input keyevent 4" },
@@ -82,7 +82,7 @@ Branch conditions: exists, not_exists, text_equals, text_contains, variable_equa
 Step types:
 - COORDINATE: tap, swipe
 - ELEMENT: click_element, long_click_element, input_text, swipe_element, wait_element, wait_gone, assert_element
-- APP: launch_app, stop_app, clear_app, open_settings
+- APP: launch_app, stop_app, clear_app, open_settings, install_if_missing
 - KEYS: key_back, key_home, key_recent, key_power, key_volume_up, key_volume_down
 - SCREEN: screen_on, screen_off
 - CONTROL: wait, adb, set_variable, read_to_variable, branch, run_workflow, script
@@ -287,7 +287,7 @@ RETURNS:
 Step types:
 - Coordinate: tap (x,y), swipe (x,y,x2,y2 or direction+distance)
 - Element: click_element, long_click_element, input_text, swipe_element, wait_element, wait_gone, assert_element
-- App: launch_app, stop_app, clear_app, open_settings
+- App: launch_app, stop_app, clear_app, open_settings, install_if_missing (value=package, apk_path)
 - Keys: key_back, key_home, key_recent, key_power, key_volume_up, key_volume_down
 - Screen: screen_on, screen_off
 - Control: wait, adb, set_variable, read_to_variable
@@ -359,6 +359,9 @@ SESSION CONTROL:
 			mcp.WithString("condition_type",
 				mcp.Description("Condition for assert/branch: exists, not_exists, text_equals, text_contains"),
 			),
+			mcp.WithString("apk_path",
+				mcp.Description("Local APK path for install_if_missing"),
+			),
 			// Session params (for start_session / end_session)
 			mcp.WithString("session_name",
 				mcp.Description("Session name for start_session"),
@@ -1205,6 +1208,7 @@ func (s *MCPServer) handleWorkflowExecuteStep(ctx context.Context, request mcp.C
 		"swipe_element": true, "wait_element": true, "wait_gone": true, "assert_element": true,
 		// App operations
 		"launch_app": true, "stop_app": true, "clear_app": true, "open_settings": true,
+		"install_if_missing": true,
 		// Key events
 		"key_back": true, "key_home": true, "key_recent": true, "key_power": true,
 		"key_volume_up": true, "key_volume_down": true,
@@ -1352,6 +1356,13 @@ func (s *MCPServer) handleWorkflowExecuteStep(ctx context.Context, request mcp.C
 		}
 		step.App = &AppParams{PackageName: value, Action: action}
 
+	case "install_if_missing":
+		apkPath, _ := args["apk_path"].(string)
+		if value == "" || apkPath == "" {
+			return nil, fmt.Errorf("value (package name) and apk_path are required for install_if_missing")
+		}
+		step.App = &AppParams{PackageName: value, Action: "install", ApkPath: apkPath}
+
 	case "wait":
 		duration := 1000
 		if value != "" {
@@ -83,7 +83,7 @@ func (b *MCPBridge) GetDeviceIP(deviceId string) (string, error) {
 }
 
 func (b *MCPBridge) ListPackages(deviceId string, packageType string) ([]mcp.AppPackage, error) {
-	packages, err := b.app.ListPackages(deviceId, packageType)
+	packages, err := b.app.ListPackages(deviceId, packageType, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -137,15 +137,15 @@ func (b *MCPBridge) ForceStopApp(deviceId, packageName string) (string, error) {
 }
 
 func (b *MCPBridge) InstallAPK(deviceId string, path string) (string, error) {
-	return b.app.InstallAPK(deviceId, path)
+	return b.app.InstallAPK(deviceId, path, 0)
 }
 
 func (b *MCPBridge) UninstallApp(deviceId, packageName string) (string, error) {
-	return b.app.UninstallApp(deviceId, packageName)
+	return b.app.UninstallApp(deviceId, packageName, "", 0)
 }
 
 func (b *MCPBridge) ClearAppData(deviceId, packageName string) (string, error) {
-	return b.app.ClearAppData(deviceId, packageName)
+	return b.app.ClearAppData(deviceId, packageName, "")
 }
 
 func (b *MCPBridge) IsAppRunning(deviceId, packageName string) (bool, error) {
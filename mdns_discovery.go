@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ========================================
+// mDNS service discovery
+// ========================================
+
+// Service types advertised by Android 11+ wireless debugging
+const (
+	mdnsTypeConnect = "_adb-tls-connect._tcp"
+	mdnsTypePairing = "_adb-tls-pairing._tcp"
+	mdnsTypeLegacy  = "_adb._tcp"
+)
+
+// MdnsService is a wireless debugging endpoint found by `adb mdns services`
+type MdnsService struct {
+	Name    string `json:"name"`    // e.g. "adb-R5CT1234ABC-Xy7Qz1"
+	Type    string `json:"type"`    // e.g. "_adb-tls-connect._tcp"
+	Address string `json:"address"` // host:port
+	Serial  string `json:"serial"`  // Hardware serial from the name, if a pattern matches
+	Pairing bool   `json:"pairing"` // Endpoint for `adb pair` rather than `adb connect`
+}
+
+// ListMdnsServices lists the wireless debugging endpoints adb currently sees on the
+// network, including devices that aren't paired or connected yet
+func (a *App) ListMdnsServices() ([]MdnsService, error) {
+	if a.adbPath == "" {
+		return nil, fmt.Errorf("ADB path is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	output, err := a.runAdbCombined(ctx, "mdns", "services")
+	text := string(output)
+	if err := mdnsUnavailableError(text, err); err != nil {
+		return nil, err
+	}
+	return parseMdnsServices(text, a.mdnsSerialRegexps()), nil
+}
+
+// mdnsUnavailableError turns the ways adb reports missing mDNS support into a clear error
+func mdnsUnavailableError(output string, err error) error {
+	lower := strings.ToLower(output)
+	switch {
+	case strings.Contains(lower, "unknown command"):
+		return fmt.Errorf("this adb does not support mDNS discovery; update platform-tools to 30 or newer")
+	case strings.Contains(lower, "mdns daemon unavailable"), strings.Contains(lower, "discovery disabled"):
+		return fmt.Errorf("mDNS discovery is unavailable: %s", strings.TrimSpace(output))
+	case err != nil:
+		return fmt.Errorf("failed to list mDNS services: %w, output: %s", err, strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// parseMdnsServices parses `adb mdns services` output:
+//
+//	List of discovered mdns services
+//	adb-R5CT1234ABC-Xy7Qz1	_adb-tls-connect._tcp	192.168.1.20:37229
+//
+// Older adb versions print the type with a trailing dot.
+func parseMdnsServices(output string, serialPatterns []*regexp.Regexp) []MdnsService {
+	services := []MdnsService{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || !strings.Contains(fields[2], ":") {
+			continue
+		}
+		svcType := strings.TrimSuffix(fields[1], ".")
+		if svcType != mdnsTypeConnect && svcType != mdnsTypePairing && svcType != mdnsTypeLegacy {
+			continue
+		}
+		services = append(services, MdnsService{
+			Name:    fields[0],
+			Type:    svcType,
+			Address: fields[2],
+			Serial:  extractMDNSSerial(fields[0], serialPatterns),
+			Pairing: svcType == mdnsTypePairing,
+		})
+	}
+	return services
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultMDNSSerialPattern matches the stock service name "adb-SERIAL-xxxxxx._adb-tls-connect._tcp".
+// It is anchored so a non-standard name doesn't yield "tls" from the "_adb-tls-" suffix.
+const defaultMDNSSerialPattern = `^adb-([a-zA-Z0-9]+)-`
+
+// GetMDNSSerialPatterns returns the regexes used to pull a hardware serial out of an
+// mDNS device name, in the order they are tried
+func (a *App) GetMDNSSerialPatterns() []string {
+	if a.cacheService != nil {
+		if patterns := a.cacheService.GetMDNSSerialPatterns(); len(patterns) > 0 {
+			return patterns
+		}
+	}
+	return []string{defaultMDNSSerialPattern}
+}
+
+// AddMDNSSerialPattern appends a pattern for OEMs whose mDNS names don't follow the
+// stock format. The first capture group must be the device serial.
+func (a *App) AddMDNSSerialPattern(pattern string) ([]string, error) {
+	pattern = strings.TrimSpace(pattern)
+	if err := validateMDNSSerialPattern(pattern); err != nil {
+		return a.GetMDNSSerialPatterns(), err
+	}
+	if a.cacheService == nil {
+		return a.GetMDNSSerialPatterns(), fmt.Errorf("settings are not available")
+	}
+
+	patterns := a.GetMDNSSerialPatterns()
+	for _, p := range patterns {
+		if p == pattern {
+			return patterns, nil
+		}
+	}
+	patterns = append(patterns, pattern)
+	a.cacheService.SetMDNSSerialPatterns(patterns)
+	go a.saveSettings()
+
+	a.Log("Added mDNS serial pattern: %s", pattern)
+	return patterns, nil
+}
+
+// RemoveMDNSSerialPattern removes a pattern. Removing every pattern restores the default.
+func (a *App) RemoveMDNSSerialPattern(pattern string) ([]string, error) {
+	if a.cacheService == nil {
+		return a.GetMDNSSerialPatterns(), fmt.Errorf("settings are not available")
+	}
+
+	var patterns []string
+	found := false
+	for _, p := range a.GetMDNSSerialPatterns() {
+		if p == pattern {
+			found = true
+			continue
+		}
+		patterns = append(patterns, p)
+	}
+	if !found {
+		return a.GetMDNSSerialPatterns(), fmt.Errorf("pattern not found: %s", pattern)
+	}
+	a.cacheService.SetMDNSSerialPatterns(patterns)
+	go a.saveSettings()
+
+	return a.GetMDNSSerialPatterns(), nil
+}
+
+func validateMDNSSerialPattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("pattern is empty")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	if re.NumSubexp() < 1 {
+		return fmt.Errorf("pattern must have a capture group for the serial")
+	}
+	return nil
+}
+
+// mdnsSerialRegexps compiles the configured patterns, skipping any that no longer compile
+func (a *App) mdnsSerialRegexps() []*regexp.Regexp {
+	var res []*regexp.Regexp
+	for _, p := range a.GetMDNSSerialPatterns() {
+		if re, err := regexp.Compile(p); err == nil && re.NumSubexp() >= 1 {
+			res = append(res, re)
+		}
+	}
+	return res
+}
+
+// extractMDNSSerial returns the first capture group of the first pattern that matches
+func extractMDNSSerial(id string, patterns []*regexp.Regexp) string {
+	for _, re := range patterns {
+		if m := re.FindStringSubmatch(id); len(m) > 1 && m[1] != "" {
+			return m[1]
+		}
+	}
+	return ""
+}
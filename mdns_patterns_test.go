@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestExtractMDNSSerial(t *testing.T) {
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile(defaultMDNSSerialPattern),
+		regexp.MustCompile(`^([A-Z0-9]{8,})_adb\._adb-tls-connect`),
+	}
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"adb-R5CT1234ABC-Xy7Qz1._adb-tls-connect._tcp", "R5CT1234ABC"},
+		{"adb-1a2b3c4d-abcdef._adb-tls-pairing._tcp.", "1a2b3c4d"},
+		{"HT7A1B2C3D4_adb._adb-tls-connect._tcp", "HT7A1B2C3D4"},
+		{"192.168.1.20:5555", ""},
+		{"Pixel-7._adb-tls-connect._tcp", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := extractMDNSSerial(tt.id, patterns); got != tt.want {
+				t.Errorf("extractMDNSSerial(%q) = %q, want %q", tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMDNSSerialPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{defaultMDNSSerialPattern, false},
+		{"", true},
+		{"adb-[", true},
+		{"adb-[a-z]+-", true},
+	}
+
+	for _, tt := range tests {
+		if err := validateMDNSSerialPattern(tt.pattern); (err != nil) != tt.wantErr {
+			t.Errorf("validateMDNSSerialPattern(%q) error = %v, wantErr %v", tt.pattern, err, tt.wantErr)
+		}
+	}
+}
+
+func TestListMdnsServices(t *testing.T) {
+	a := newTestApp(map[string]string{
+		"mdns services": "List of discovered mdns services\n" +
+			"adb-R5CT1234ABC-Xy7Qz1\t_adb-tls-connect._tcp\t192.168.1.20:37229\n" +
+			"adb-R5CT1234ABC-Xy7Qz1\t_adb-tls-pairing._tcp.\t192.168.1.20:41235\n" +
+			"Pixel-7\t_adb._tcp\t192.168.1.31:5555\n" +
+			"printer\t_ipp._tcp\t192.168.1.9:631\n",
+	})
+
+	services, err := a.ListMdnsServices()
+	if err != nil {
+		t.Fatalf("ListMdnsServices: %v", err)
+	}
+	if len(services) != 3 {
+		t.Fatalf("got %d services, want 3: %+v", len(services), services)
+	}
+	if s := services[0]; s.Type != mdnsTypeConnect || s.Address != "192.168.1.20:37229" || s.Serial != "R5CT1234ABC" || s.Pairing {
+		t.Errorf("connect service = %+v", s)
+	}
+	if s := services[1]; s.Type != mdnsTypePairing || !s.Pairing {
+		t.Errorf("pairing service = %+v", s)
+	}
+	if s := services[2]; s.Name != "Pixel-7" || s.Serial != "" {
+		t.Errorf("legacy service = %+v", s)
+	}
+
+	empty := newTestApp(map[string]string{"mdns services": "List of discovered mdns services\n"})
+	if services, err := empty.ListMdnsServices(); err != nil || services == nil || len(services) != 0 {
+		t.Errorf("no services should give an empty list, got %v, %v", services, err)
+	}
+}
+
+func TestMdnsUnavailableError(t *testing.T) {
+	if err := mdnsUnavailableError("adb: unknown command mdns\n", errors.New("exit status 1")); err == nil || !strings.Contains(err.Error(), "platform-tools") {
+		t.Errorf("old adb error = %v", err)
+	}
+	if err := mdnsUnavailableError("ERROR: mdns discovery disabled\n", nil); err == nil {
+		t.Error("disabled discovery should be an error")
+	}
+	if err := mdnsUnavailableError("List of discovered mdns services\n", nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
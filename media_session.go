@@ -0,0 +1,167 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// ========================================
+// Media sessions - tie mirroring/recording to a session
+// ========================================
+
+// Kinds of device activity that keep a media session open
+const (
+	mediaMirror = "mirror"
+	mediaRecord = "record"
+)
+
+// mediaSessionEntry tracks the session a device's mirror and/or recording is attached to
+type mediaSessionEntry struct {
+	sessionID   string
+	owned       bool // started here, so it's ended here once mirroring and recording stop
+	mirroring   bool
+	recording   bool
+	recordStart time.Time // zero once the session's video file is closed
+	videoOffset int64     // ms from session start to recording start
+}
+
+var (
+	mediaSessions   = make(map[string]*mediaSessionEntry) // deviceId -> entry
+	mediaSessionsMu sync.Mutex
+)
+
+// beginMediaSession attaches mirroring or recording on a device to a session so the
+// timeline can line video up with events. A session already active on the device
+// (manual or auto) is reused; otherwise one is started and later ended by
+// endMediaSession. Returns the session ID, or "" without an event pipeline.
+func (a *App) beginMediaSession(deviceId, kind string) string {
+	if a.eventPipeline == nil {
+		return ""
+	}
+
+	mediaSessionsMu.Lock()
+	defer mediaSessionsMu.Unlock()
+
+	entry := mediaSessions[deviceId]
+	active := a.eventPipeline.GetActiveSessionID(deviceId)
+	if entry == nil || entry.sessionID != active {
+		next := &mediaSessionEntry{sessionID: active}
+		if entry != nil {
+			// The tracked session was ended or replaced; carry what is still running over
+			next.mirroring, next.recording = entry.mirroring, entry.recording
+			next.recordStart, next.videoOffset = entry.recordStart, entry.videoOffset
+		}
+		if active == "" {
+			sessionType, name := "mirror", "Mirror "
+			if kind == mediaRecord {
+				sessionType, name = "recording", "Recording "
+			}
+			next.sessionID = a.eventPipeline.StartSession(deviceId, sessionType, name+time.Now().Format("15:04:05"), nil)
+			next.owned = true
+		}
+		entry = next
+		mediaSessions[deviceId] = entry
+	}
+
+	switch kind {
+	case mediaMirror:
+		entry.mirroring = true
+	case mediaRecord:
+		entry.recording = true
+	}
+	return entry.sessionID
+}
+
+// beginRecordingSession attaches a recording file to the device's media session
+func (a *App) beginRecordingSession(deviceId, videoPath string) {
+	sessionID := a.beginMediaSession(deviceId, mediaRecord)
+	if sessionID == "" {
+		return
+	}
+
+	now := time.Now()
+	var offset int64
+	if session := a.eventPipeline.GetSession(sessionID); session != nil {
+		offset = now.UnixMilli() - session.StartTime
+	}
+
+	mediaSessionsMu.Lock()
+	if entry := mediaSessions[deviceId]; entry != nil && entry.sessionID == sessionID {
+		entry.recordStart = now
+		entry.videoOffset = offset
+	}
+	mediaSessionsMu.Unlock()
+
+	a.eventPipeline.UpdateSessionVideoPath(sessionID, videoPath)
+	a.eventPipeline.UpdateSessionVideoTiming(sessionID, offset, 0)
+}
+
+// finishRecordingVideo saves the duration of the session's video once that file is
+// closed. A segmented recording's session points at segment 1, so this runs when the
+// first segment ends and later segments don't stretch the timeline past that file.
+func (a *App) finishRecordingVideo(deviceId string) {
+	if a.eventPipeline == nil {
+		return
+	}
+	mediaSessionsMu.Lock()
+	defer mediaSessionsMu.Unlock()
+	if entry := mediaSessions[deviceId]; entry != nil {
+		a.finishRecordingVideoLocked(entry)
+	}
+}
+
+// finishRecordingVideoLocked is finishRecordingVideo for a held mediaSessionsMu
+func (a *App) finishRecordingVideoLocked(entry *mediaSessionEntry) {
+	if !entry.recording || entry.recordStart.IsZero() {
+		return
+	}
+	duration := time.Since(entry.recordStart).Milliseconds()
+	a.eventPipeline.UpdateSessionVideoTiming(entry.sessionID, entry.videoOffset, duration)
+	entry.recordStart = time.Time{}
+}
+
+// endMediaSession records that mirroring or recording stopped on a device. A
+// finished recording's duration is saved, and a session started by
+// beginMediaSession is completed once neither is running.
+func (a *App) endMediaSession(deviceId, kind string) {
+	if a.eventPipeline == nil {
+		return
+	}
+
+	mediaSessionsMu.Lock()
+	defer mediaSessionsMu.Unlock()
+
+	entry := mediaSessions[deviceId]
+	if entry == nil {
+		return
+	}
+
+	switch kind {
+	case mediaMirror:
+		entry.mirroring = false
+	case mediaRecord:
+		a.finishRecordingVideoLocked(entry)
+		entry.recording = false
+		entry.recordStart = time.Time{}
+	}
+	if entry.mirroring || entry.recording {
+		return
+	}
+
+	delete(mediaSessions, deviceId)
+	// Leave the session alone if the user ended it or started another meanwhile
+	if entry.owned && a.eventPipeline.GetActiveSessionID(deviceId) == entry.sessionID {
+		a.eventPipeline.EndSession(entry.sessionID, "completed")
+	}
+}
+
+// endMirrorSessionIfIdle ends the mirror's share of the media session unless a
+// mirror was started again in the meantime
+func (a *App) endMirrorSessionIfIdle(deviceId string) {
+	a.scrcpyMu.Lock()
+	_, running := a.scrcpyCmds[deviceId]
+	a.scrcpyMu.Unlock()
+	if !running {
+		a.endMediaSession(deviceId, mediaMirror)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMediaSession_OwnedLifecycle(t *testing.T) {
+	app, _, cleanup := setupTestAppForSession(t)
+	defer cleanup()
+
+	deviceID := "media-dev-1"
+	sessionID := app.beginMediaSession(deviceID, mediaMirror)
+	if sessionID == "" || app.eventPipeline.GetActiveSessionID(deviceID) != sessionID {
+		t.Fatalf("expected mirror to start an active session, got %q", sessionID)
+	}
+	if s := app.eventPipeline.GetSession(sessionID); s == nil || s.Type != "mirror" {
+		t.Fatalf("unexpected session: %+v", s)
+	}
+
+	// Recording while mirroring joins the same session
+	app.beginRecordingSession(deviceID, "/tmp/rec.mp4")
+	time.Sleep(20 * time.Millisecond)
+
+	app.endMediaSession(deviceID, mediaRecord)
+	session := app.eventPipeline.GetSession(sessionID)
+	if session.VideoPath != "/tmp/rec.mp4" || session.VideoDuration <= 0 {
+		t.Errorf("expected video path and duration, got %q %d", session.VideoPath, session.VideoDuration)
+	}
+	if app.eventPipeline.GetActiveSessionID(deviceID) != sessionID {
+		t.Fatal("session should stay open while mirroring")
+	}
+
+	app.endMediaSession(deviceID, mediaMirror)
+	if app.eventPipeline.GetActiveSessionID(deviceID) != "" {
+		t.Error("expected session to end once mirroring and recording stopped")
+	}
+	if session.Status != "completed" || session.EndTime == 0 {
+		t.Errorf("expected completed session, got %s/%d", session.Status, session.EndTime)
+	}
+}
+
+func TestMediaSession_ReusesExistingSession(t *testing.T) {
+	app, _, cleanup := setupTestAppForSession(t)
+	defer cleanup()
+
+	deviceID := "media-dev-2"
+	manual := app.eventPipeline.StartSession(deviceID, "manual", "Manual", nil)
+
+	if got := app.beginMediaSession(deviceID, mediaRecord); got != manual {
+		t.Fatalf("expected recording to join the manual session, got %q", got)
+	}
+	app.endMediaSession(deviceID, mediaRecord)
+
+	if app.eventPipeline.GetActiveSessionID(deviceID) != manual {
+		t.Error("a session the user started must not be ended by recording")
+	}
+}
+
+func TestMediaSession_UserEndedSession(t *testing.T) {
+	app, _, cleanup := setupTestAppForSession(t)
+	defer cleanup()
+
+	deviceID := "media-dev-3"
+	first := app.beginMediaSession(deviceID, mediaMirror)
+	app.eventPipeline.EndSession(first, "completed")
+
+	// A new recording starts a fresh session rather than writing to the ended one
+	second := app.beginMediaSession(deviceID, mediaRecord)
+	if second == "" || second == first {
+		t.Fatalf("expected a new session, got %q", second)
+	}
+
+	app.endMediaSession(deviceID, mediaRecord)
+	if app.eventPipeline.GetActiveSessionID(deviceID) != second {
+		t.Error("session should stay open while the carried-over mirror runs")
+	}
+	app.endMediaSession(deviceID, mediaMirror)
+	if app.eventPipeline.GetActiveSessionID(deviceID) != "" {
+		t.Error("expected session to end")
+	}
+}
+
+func TestMediaSession_SegmentedRecordingKeepsFirstSegment(t *testing.T) {
+	app, _, cleanup := setupTestAppForSession(t)
+	defer cleanup()
+
+	deviceID := "media-dev-4"
+	app.beginRecordingSession(deviceID, "/tmp/rec_001.mp4")
+	sessionID := app.eventPipeline.GetActiveSessionID(deviceID)
+	time.Sleep(20 * time.Millisecond)
+
+	// Segment 1 closes; the next segments keep recording
+	app.finishRecordingVideo(deviceID)
+	first := app.eventPipeline.GetSession(sessionID).VideoDuration
+	if first <= 0 {
+		t.Fatalf("expected segment 1's duration, got %d", first)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	app.endMediaSession(deviceID, mediaRecord)
+	session := app.eventPipeline.GetSession(sessionID)
+	if session.VideoPath != "/tmp/rec_001.mp4" || session.VideoDuration != first {
+		t.Errorf("video = %q/%dms, want segment 1 with %dms", session.VideoPath, session.VideoDuration, first)
+	}
+}
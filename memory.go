@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MemoryBreakdown is a device-wide memory summary from `dumpsys meminfo`
+type MemoryBreakdown struct {
+	TotalKb      int64           `json:"totalKb"`
+	UsedKb       int64           `json:"usedKb"`
+	FreeKb       int64           `json:"freeKb"`
+	CachedKb     int64           `json:"cachedKb"` // Cached PSS + cached kernel (part of FreeKb)
+	LostKb       int64           `json:"lostKb"`
+	TopProcesses []ProcessMemory `json:"topProcesses"` // Sorted by PSS, descending
+}
+
+// ProcessMemory is a single process entry from the "Total PSS by process" section
+type ProcessMemory struct {
+	Name  string `json:"name"`
+	Pid   int    `json:"pid"`
+	PssKb int64  `json:"pssKb"`
+}
+
+const memoryTopProcesses = 15
+
+// GetMemoryBreakdown returns total/used/free/cached RAM and the top processes by PSS
+func (a *App) GetMemoryBreakdown(deviceId string) (MemoryBreakdown, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return MemoryBreakdown{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// Without a process argument dumpsys meminfo prints only the summary sections
+	cmd := a.newAdbCommand(ctx, "-s", deviceId, "shell", "dumpsys", "meminfo")
+	output, err := cmd.Output()
+	if err != nil {
+		return MemoryBreakdown{}, fmt.Errorf("failed to run dumpsys meminfo: %w", err)
+	}
+
+	mb := parseMeminfoSummary(string(output), memoryTopProcesses)
+	if mb.TotalKb == 0 {
+		return mb, fmt.Errorf("unrecognized dumpsys meminfo output")
+	}
+	return mb, nil
+}
+
+var (
+	// "Total RAM: 7,919,072K (status normal)" or "Total RAM: 1900000 kB (status normal)"
+	meminfoRAMLine = regexp.MustCompile(`^\s*(Total|Free|Used|Lost) RAM:\s*([\d,]+)\s*(?:K|kB)\b(.*)$`)
+	// "(   234,560K cached pss + 3,456,789K cached kernel + ..." (older releases omit the unit)
+	meminfoCachedPart = regexp.MustCompile(`([\d,]+)\s*(?:K|kB)? cached (?:pss|kernel)`)
+	// "    456,789K: system (pid 1234)" or "   123456 kB: com.foo (pid 123 / activities)"
+	meminfoProcessLine = regexp.MustCompile(`^\s*([\d,]+)\s*(?:K|kB):\s+(\S+)\s+\(pid\s+(\d+)`)
+)
+
+// parseMeminfoSummary parses the summary of `dumpsys meminfo`. RAM totals are
+// anchored on the "Total RAM"/"Free RAM"/"Used RAM" lines, which are stable across
+// Android versions even though number formatting (commas, K vs kB) is not.
+func parseMeminfoSummary(output string, topN int) MemoryBreakdown {
+	var mb MemoryBreakdown
+	inProcesses := false
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "Total PSS by process") {
+			inProcesses = true
+			continue
+		}
+		if inProcesses {
+			if m := meminfoProcessLine.FindStringSubmatch(line); m != nil {
+				pid, _ := strconv.Atoi(m[3])
+				mb.TopProcesses = append(mb.TopProcesses, ProcessMemory{
+					Name:  m[2],
+					Pid:   pid,
+					PssKb: parseMeminfoKb(m[1]),
+				})
+				continue
+			}
+			if trimmed == "" || strings.HasSuffix(trimmed, ":") {
+				inProcesses = false
+			}
+		}
+
+		m := meminfoRAMLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		value := parseMeminfoKb(m[2])
+		switch m[1] {
+		case "Total":
+			mb.TotalKb = value
+		case "Free":
+			mb.FreeKb = value
+			for _, c := range meminfoCachedPart.FindAllStringSubmatch(m[3], -1) {
+				mb.CachedKb += parseMeminfoKb(c[1])
+			}
+		case "Used":
+			mb.UsedKb = value
+		case "Lost":
+			mb.LostKb = value
+		}
+	}
+
+	sort.SliceStable(mb.TopProcesses, func(i, j int) bool {
+		return mb.TopProcesses[i].PssKb > mb.TopProcesses[j].PssKb
+	})
+	if topN > 0 && len(mb.TopProcesses) > topN {
+		mb.TopProcesses = mb.TopProcesses[:topN]
+	}
+	if mb.UsedKb == 0 && mb.TotalKb > 0 && mb.FreeKb > 0 {
+		mb.UsedKb = mb.TotalKb - mb.FreeKb
+	}
+	return mb
+}
+
+// parseMeminfoKb parses "7,919,072" into 7919072
+func parseMeminfoKb(s string) int64 {
+	v, _ := strconv.ParseInt(strings.ReplaceAll(s, ",", ""), 10, 64)
+	return v
+}
@@ -0,0 +1,102 @@
+package main
+
+import "testing"
+
+// Android 12+ formatting: thousands separators and a "K" suffix
+const dumpsysMeminfoSummaryModern = `Applications Memory Usage (in Kilobytes):
+Uptime: 253447398 Realtime: 600891234
+
+Total PSS by process:
+    412,345K: system (pid 1523)
+    298,112K: com.google.android.gms.persistent (pid 3114)
+    201,004K: com.android.systemui (pid 2011 / activities)
+    655,210K: app.footos (pid 20805 / activities)
+     45,667K: surfaceflinger (pid 881)
+
+Total PSS by OOM adjustment:
+    658,116K: Native
+        45,667K: surfaceflinger (pid 881)
+    412,345K: System
+
+Total PSS by category:
+  1,004,221K: .so mmap
+
+Total RAM: 7,919,072K (status normal)
+ Free RAM: 4,123,456K (  234,560K cached pss + 3,456,789K cached kernel +   432,107K free)
+ DMA-BUF:   234,567K (  123,456K mapped +   111,111K unmapped)
+ Used RAM: 3,456,789K (2,345,678K used pss + 1,111,111K kernel)
+ Lost RAM:   338,827K
+     ZRAM:    12,345K physical used for    45,678K in swap (4,194,300K total swap)
+   Tuning: 256 (large 512), oom   322,560K, restore limit   107,520K (high-end-gfx)
+`
+
+// Android 7/8 formatting: plain numbers with a "kB" suffix
+const dumpsysMeminfoSummaryLegacy = `Applications Memory Usage (in Kilobytes):
+Uptime: 1234567 Realtime: 1234567
+
+Total PSS by process:
+   150000 kB: system (pid 900)
+    90000 kB: com.android.systemui (pid 1200 / activities)
+
+Total PSS by OOM adjustment:
+   150000 kB: System
+
+Total RAM: 1900000 kB (status normal)
+ Free RAM: 900000 kB (500000 cached pss + 300000 cached kernel + 100000 free)
+ Used RAM: 950000 kB (800000 used pss + 150000 kernel)
+ Lost RAM: 50000 kB
+`
+
+func TestParseMeminfoSummary(t *testing.T) {
+	t.Run("modern", func(t *testing.T) {
+		mb := parseMeminfoSummary(dumpsysMeminfoSummaryModern, 3)
+
+		if mb.TotalKb != 7919072 {
+			t.Errorf("TotalKb = %d, want 7919072", mb.TotalKb)
+		}
+		if mb.FreeKb != 4123456 {
+			t.Errorf("FreeKb = %d, want 4123456", mb.FreeKb)
+		}
+		if mb.UsedKb != 3456789 {
+			t.Errorf("UsedKb = %d, want 3456789", mb.UsedKb)
+		}
+		if mb.CachedKb != 234560+3456789 {
+			t.Errorf("CachedKb = %d, want %d", mb.CachedKb, 234560+3456789)
+		}
+		if mb.LostKb != 338827 {
+			t.Errorf("LostKb = %d, want 338827", mb.LostKb)
+		}
+
+		if len(mb.TopProcesses) != 3 {
+			t.Fatalf("expected top 3 processes, got %d", len(mb.TopProcesses))
+		}
+		top := mb.TopProcesses[0]
+		if top.Name != "app.footos" || top.Pid != 20805 || top.PssKb != 655210 {
+			t.Errorf("top process = %+v, want app.footos/20805/655210", top)
+		}
+		if mb.TopProcesses[1].Name != "system" {
+			t.Errorf("second process = %s, want system", mb.TopProcesses[1].Name)
+		}
+	})
+
+	t.Run("legacy kB format", func(t *testing.T) {
+		mb := parseMeminfoSummary(dumpsysMeminfoSummaryLegacy, 0)
+
+		if mb.TotalKb != 1900000 || mb.FreeKb != 900000 || mb.UsedKb != 950000 || mb.LostKb != 50000 {
+			t.Errorf("unexpected totals: %+v", mb)
+		}
+		if mb.CachedKb != 800000 {
+			t.Errorf("CachedKb = %d, want 800000", mb.CachedKb)
+		}
+		if len(mb.TopProcesses) != 2 {
+			t.Errorf("expected 2 processes, got %d", len(mb.TopProcesses))
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		mb := parseMeminfoSummary("", 10)
+		if mb.TotalKb != 0 || len(mb.TopProcesses) != 0 {
+			t.Errorf("expected zero value, got %+v", mb)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// Monitor registry + device watchdog
+// ========================================
+//
+// Each long-running monitor (network, perf, device state, logcat) keeps its own map,
+// but also registers here so a single watchdog can notice when the device drops off
+// without a clean stop, cancel the monitor and tell the UI.
+
+const (
+	monitorWatchdogInterval = 5 * time.Second
+	monitorLostAfterMisses  = 2 // consecutive checks a device must be missing
+)
+
+type registeredMonitor struct {
+	kind     string
+	deviceID string
+	stop     func()
+	misses   int
+}
+
+var (
+	activeMonitors      = make(map[string]*registeredMonitor) // kind|deviceID -> monitor
+	activeMonitorsMu    sync.Mutex
+	monitorWatchdogLive bool
+)
+
+func monitorKey(kind, deviceID string) string {
+	return kind + "|" + deviceID
+}
+
+// registerMonitor records a running monitor and starts the watchdog if needed.
+// stop must cancel the monitor and call unregisterMonitor (directly or via its Stop method).
+func (a *App) registerMonitor(kind, deviceID string, stop func()) {
+	activeMonitorsMu.Lock()
+	defer activeMonitorsMu.Unlock()
+
+	activeMonitors[monitorKey(kind, deviceID)] = &registeredMonitor{kind: kind, deviceID: deviceID, stop: stop}
+	if !monitorWatchdogLive {
+		monitorWatchdogLive = true
+		go a.runMonitorWatchdog()
+	}
+}
+
+// unregisterMonitor forgets a monitor; it is a no-op if it isn't registered
+func unregisterMonitor(kind, deviceID string) {
+	activeMonitorsMu.Lock()
+	delete(activeMonitors, monitorKey(kind, deviceID))
+	activeMonitorsMu.Unlock()
+}
+
+// unregisterMonitorKind forgets every monitor of a kind (for "stop all" paths)
+func unregisterMonitorKind(kind string) {
+	activeMonitorsMu.Lock()
+	for key, m := range activeMonitors {
+		if m.kind == kind {
+			delete(activeMonitors, key)
+		}
+	}
+	activeMonitorsMu.Unlock()
+}
+
+// runMonitorWatchdog polls the attached devices while any monitor is registered
+func (a *App) runMonitorWatchdog() {
+	ticker := time.NewTicker(monitorWatchdogInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		activeMonitorsMu.Lock()
+		if len(activeMonitors) == 0 {
+			monitorWatchdogLive = false
+			activeMonitorsMu.Unlock()
+			return
+		}
+		activeMonitorsMu.Unlock()
+
+		online, err := a.onlineDeviceIDs()
+		if err != nil {
+			// adb itself failed; don't treat that as every device vanishing
+			continue
+		}
+
+		// Stop outside the registry lock; stop functions take their own monitor locks
+		for _, m := range sweepLostMonitors(online) {
+			LogWarn("monitor").Str("deviceId", m.deviceID).Str("monitor", m.kind).Msg("Device lost, stopping monitor")
+			m.stop()
+			if !a.mcpMode {
+				wailsRuntime.EventsEmit(a.ctx, "monitor-device-lost", map[string]string{
+					"deviceId": m.deviceID,
+					"monitor":  m.kind,
+				})
+			}
+		}
+	}
+}
+
+// sweepLostMonitors counts a miss for every monitor whose device isn't online and
+// removes (and returns) those missing for monitorLostAfterMisses checks in a row
+func sweepLostMonitors(online map[string]bool) []*registeredMonitor {
+	var lost []*registeredMonitor
+	activeMonitorsMu.Lock()
+	defer activeMonitorsMu.Unlock()
+
+	for key, m := range activeMonitors {
+		if online[m.deviceID] {
+			m.misses = 0
+			continue
+		}
+		m.misses++
+		if m.misses >= monitorLostAfterMisses {
+			lost = append(lost, m)
+			delete(activeMonitors, key)
+		}
+	}
+	return lost
+}
+
+// onlineDeviceIDs returns the adb ids currently in the "device" state. It uses a bare
+// `adb devices` rather than GetDevices to avoid per-device getprop calls every tick.
+func (a *App) onlineDeviceIDs() (map[string]bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	output, err := a.runAdbCombined(ctx, "devices")
+	if err != nil {
+		return nil, err
+	}
+
+	online := make(map[string]bool)
+	for _, line := range strings.Split(string(output), "\n") {
+		parts := strings.Fields(line)
+		if len(parts) >= 2 && parts[1] == "device" {
+			online[parts[0]] = true
+		}
+	}
+	return online, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+// isolateMonitorRegistry gives the test an empty registry and keeps registerMonitor
+// from starting the real watchdog goroutine
+func isolateMonitorRegistry(t *testing.T) {
+	t.Helper()
+	activeMonitorsMu.Lock()
+	saved, savedLive := activeMonitors, monitorWatchdogLive
+	activeMonitors = make(map[string]*registeredMonitor)
+	monitorWatchdogLive = true
+	activeMonitorsMu.Unlock()
+
+	t.Cleanup(func() {
+		activeMonitorsMu.Lock()
+		activeMonitors, monitorWatchdogLive = saved, savedLive
+		activeMonitorsMu.Unlock()
+	})
+}
+
+func registeredKinds(deviceID string) map[string]bool {
+	activeMonitorsMu.Lock()
+	defer activeMonitorsMu.Unlock()
+	kinds := make(map[string]bool)
+	for _, m := range activeMonitors {
+		if m.deviceID == deviceID {
+			kinds[m.kind] = true
+		}
+	}
+	return kinds
+}
+
+func TestSweepLostMonitors(t *testing.T) {
+	isolateMonitorRegistry(t)
+	app := newTestApp(nil)
+	app.registerMonitor("network", "dev1", func() {})
+	app.registerMonitor("logcat", "dev2", func() {})
+
+	online := map[string]bool{"dev1": true}
+	if lost := sweepLostMonitors(online); len(lost) != 0 {
+		t.Fatalf("one miss must not stop a monitor, lost %d", len(lost))
+	}
+
+	// dev2 comes back before the second miss: its count resets
+	online["dev2"] = true
+	sweepLostMonitors(online)
+	delete(online, "dev2")
+	if lost := sweepLostMonitors(online); len(lost) != 0 {
+		t.Fatalf("misses should reset once the device is seen again, lost %d", len(lost))
+	}
+
+	lost := sweepLostMonitors(online)
+	if len(lost) != 1 || lost[0].kind != "logcat" || lost[0].deviceID != "dev2" {
+		t.Fatalf("expected the dev2 logcat monitor to be lost, got %+v", lost)
+	}
+	if kinds := registeredKinds("dev2"); len(kinds) != 0 {
+		t.Errorf("lost monitor should be unregistered, still have %v", kinds)
+	}
+	if !registeredKinds("dev1")["network"] {
+		t.Error("monitors of online devices must be kept")
+	}
+}
+
+func TestUnregisterMonitor(t *testing.T) {
+	isolateMonitorRegistry(t)
+	app := newTestApp(nil)
+	app.registerMonitor("perf", "dev1", func() {})
+	app.registerMonitor("perf", "dev2", func() {})
+	app.registerMonitor("network", "dev1", func() {})
+
+	unregisterMonitor("perf", "dev2")
+	unregisterMonitor("perf", "missing")
+	if kinds := registeredKinds("dev2"); len(kinds) != 0 {
+		t.Errorf("expected dev2 to have no monitors, got %v", kinds)
+	}
+
+	unregisterMonitorKind("perf")
+	if kinds := registeredKinds("dev1"); len(kinds) != 1 || !kinds["network"] {
+		t.Errorf("expected only the network monitor to remain, got %v", kinds)
+	}
+}
+
+func TestStopAllPerfMonitors_Unregisters(t *testing.T) {
+	isolateMonitorRegistry(t)
+	app := newTestApp(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	perfMonitorsMu.Lock()
+	perfMonitors["dev1"] = &PerfMonitor{app: app, deviceID: "dev1", ctx: ctx, cancel: cancel}
+	perfMonitorsMu.Unlock()
+	app.registerMonitor("perf", "dev1", func() { app.StopPerfMonitor("dev1") })
+	app.registerMonitor("network", "dev1", func() {})
+
+	app.StopAllPerfMonitors()
+
+	if ctx.Err() == nil {
+		t.Error("expected the perf monitor to be cancelled")
+	}
+	if kinds := registeredKinds("dev1"); kinds["perf"] || !kinds["network"] {
+		t.Errorf("expected only the perf monitor to be unregistered, got %v", kinds)
+	}
+}
+
+func TestOnlineDeviceIDs(t *testing.T) {
+	app := newTestApp(map[string]string{"devices": "List of devices attached\nR5CT1234ABC\tdevice\nemulator-5554\toffline\n192.168.1.5:5555\tdevice\n\n"})
+	online, err := app.onlineDeviceIDs()
+	if err != nil {
+		t.Fatalf("onlineDeviceIDs: %v", err)
+	}
+	if len(online) != 2 || !online["R5CT1234ABC"] || !online["192.168.1.5:5555"] {
+		t.Errorf("unexpected online devices: %v", online)
+	}
+
+	if _, err := newTestApp(nil).onlineDeviceIDs(); err == nil {
+		t.Error("expected error when adb fails")
+	}
+}
@@ -24,6 +24,7 @@ func (a *App) StartNetworkMonitor(deviceId string) {
 	ctx, cancel := context.WithCancel(a.ctx)
 	monitorCancels[deviceId] = cancel
 	monitorMu.Unlock()
+	a.registerMonitor("network", deviceId, func() { a.StopNetworkMonitor(deviceId) })
 
 	go func() {
 		var lastStats NetworkStats
@@ -70,6 +71,7 @@ func (a *App) StopNetworkMonitor(deviceId string) {
 		cancel()
 		delete(monitorCancels, deviceId)
 	}
+	unregisterMonitor("network", deviceId)
 }
 
 // StopAllNetworkMonitors stops all network monitoring
@@ -80,6 +82,7 @@ func (a *App) StopAllNetworkMonitors() {
 		cancel()
 		delete(monitorCancels, id)
 	}
+	unregisterMonitorKind("network")
 }
 
 // SetDeviceNetworkLimit sets the ingress rate limit (Android 13+)
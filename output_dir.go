@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"Gaze/pkg/cache"
+)
+
+// Output categories for GetOutputPath and per-category overrides
+const (
+	OutputScreenshots = "screenshots"
+	OutputRecordings  = "recordings"
+	OutputExports     = "exports"   // APKs, sessions, reports, scripts
+	OutputDownloads   = "downloads" // Files pulled from the device
+	OutputLogs        = "logs"
+)
+
+var outputCategories = []string{OutputScreenshots, OutputRecordings, OutputExports, OutputDownloads, OutputLogs}
+
+// OutputSettings describes where generated files are saved
+type OutputSettings struct {
+	Directory  string            `json:"directory"`  // Empty = Downloads
+	Overrides  map[string]string `json:"overrides"`  // category -> directory
+	Categories []string          `json:"categories"` // Known categories, for the UI
+}
+
+// GetOutputSettings returns the configured output directories
+func (a *App) GetOutputSettings() OutputSettings {
+	o := OutputSettings{
+		Overrides:  map[string]string{},
+		Categories: append([]string(nil), outputCategories...),
+	}
+	if a.cacheService == nil {
+		return o
+	}
+	stored := a.cacheService.GetOutput()
+	o.Directory = stored.Directory
+	for k, v := range stored.Overrides {
+		o.Overrides[k] = v
+	}
+	return o
+}
+
+// SetOutputSettings sets the default output directory and per-category overrides.
+// Empty values restore the Downloads default. Directories must be absolute.
+func (a *App) SetOutputSettings(directory string, overrides map[string]string) (OutputSettings, error) {
+	if directory != "" && !filepath.IsAbs(directory) {
+		return a.GetOutputSettings(), fmt.Errorf("output directory must be an absolute path: %s", directory)
+	}
+	clean := make(map[string]string, len(overrides))
+	for category, dir := range overrides {
+		if !isOutputCategory(category) {
+			return a.GetOutputSettings(), fmt.Errorf("unknown output category %q", category)
+		}
+		if dir == "" {
+			continue
+		}
+		if !filepath.IsAbs(dir) {
+			return a.GetOutputSettings(), fmt.Errorf("%s directory must be an absolute path: %s", category, dir)
+		}
+		clean[category] = filepath.Clean(dir)
+	}
+	if directory != "" {
+		directory = filepath.Clean(directory)
+	}
+	if a.cacheService == nil {
+		return a.GetOutputSettings(), fmt.Errorf("settings are not available")
+	}
+
+	a.cacheService.SetOutput(cache.Output{Directory: directory, Overrides: clean})
+	go a.saveSettings()
+
+	categories := make([]string, 0, len(clean))
+	for c := range clean {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
+	a.Log("Output directory set: %q, overrides for %v", directory, categories)
+	return a.GetOutputSettings(), nil
+}
+
+// GetOutputPath returns where a generated file of the given category should be saved
+func (a *App) GetOutputPath(category, filename string) string {
+	return filepath.Join(a.outputDir(category), filename)
+}
+
+// outputDir resolves a category's directory: its override, then the default output
+// directory, then ~/Downloads, then home. Configured directories are created on demand
+// and skipped if that fails.
+func (a *App) outputDir(category string) string {
+	if a.cacheService != nil {
+		o := a.cacheService.GetOutput()
+		for _, dir := range []string{o.Overrides[category], o.Directory} {
+			if dir == "" {
+				continue
+			}
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				LogWarn("output").Err(err).Str("dir", dir).Str("category", category).Msg("Output directory unavailable, skipping")
+				continue
+			}
+			return dir
+		}
+	}
+
+	home, _ := os.UserHomeDir()
+	downloadsDir := filepath.Join(home, "Downloads")
+	if _, err := os.Stat(downloadsDir); err == nil {
+		return downloadsDir
+	}
+	return home
+}
+
+func isOutputCategory(category string) bool {
+	for _, c := range outputCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
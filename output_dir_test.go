@@ -0,0 +1,59 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"Gaze/pkg/cache"
+)
+
+func newOutputTestApp(t *testing.T) *App {
+	t.Helper()
+	svc, err := cache.New(cache.Config{ConfigDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	app := newTestApp(nil)
+	app.cacheService = svc
+	return app
+}
+
+func TestGetOutputPath_OverridesAndDefault(t *testing.T) {
+	app := newOutputTestApp(t)
+	base := t.TempDir()
+	shots := filepath.Join(base, "shots")
+
+	if _, err := app.SetOutputSettings(base, map[string]string{OutputScreenshots: shots}); err != nil {
+		t.Fatalf("SetOutputSettings: %v", err)
+	}
+
+	if got, want := app.GetOutputPath(OutputScreenshots, "a.png"), filepath.Join(shots, "a.png"); got != want {
+		t.Errorf("screenshots path = %q, want %q", got, want)
+	}
+	if got, want := app.GetOutputPath(OutputLogs, "log.txt"), filepath.Join(base, "log.txt"); got != want {
+		t.Errorf("logs path = %q, want %q", got, want)
+	}
+}
+
+func TestSetOutputSettings_Validation(t *testing.T) {
+	app := newOutputTestApp(t)
+
+	if _, err := app.SetOutputSettings("relative/dir", nil); err == nil {
+		t.Error("expected error for relative directory")
+	}
+	if _, err := app.SetOutputSettings("", map[string]string{"videos": t.TempDir()}); err == nil {
+		t.Error("expected error for unknown category")
+	}
+	if _, err := app.SetOutputSettings("", map[string]string{OutputLogs: "logs"}); err == nil {
+		t.Error("expected error for relative override")
+	}
+
+	// Empty overrides are dropped rather than stored
+	got, err := app.SetOutputSettings("", map[string]string{OutputLogs: ""})
+	if err != nil {
+		t.Fatalf("SetOutputSettings: %v", err)
+	}
+	if len(got.Overrides) != 0 || got.Directory != "" {
+		t.Errorf("expected defaults, got %+v", got)
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// Package List Export
+// ========================================
+
+// PackageListEntry is one row of an exported package list
+type PackageListEntry struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Type        string `json:"type"`
+	State       string `json:"state"`
+	VersionName string `json:"versionName"`
+	VersionCode string `json:"versionCode"`
+	TargetSdk   string `json:"targetSdk"`
+}
+
+var packageListColumns = []string{"name", "label", "type", "state", "versionName", "versionCode", "targetSdk"}
+
+// ExportPackageList writes the packages of a device as CSV or JSON, with versions read
+// from the device through dumpsys.
+// format is "csv" or "json"; when empty it is taken from the file extension. If
+// savePath is empty, a save dialog is shown; cancelling it returns an empty path.
+func (a *App) ExportPackageList(deviceId, packageType, format, savePath string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" && savePath != "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(savePath)), ".")
+	}
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		return "", fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	packages, err := a.devicePackageVersions(deviceId, packageType)
+	if err != nil {
+		return "", err
+	}
+
+	if savePath == "" {
+		if a.ctx == nil || a.mcpMode {
+			return "", fmt.Errorf("save path is required")
+		}
+		filter := wailsRuntime.FileFilter{DisplayName: "CSV (*.csv)", Pattern: "*.csv"}
+		if format == "json" {
+			filter = wailsRuntime.FileFilter{DisplayName: "JSON (*.json)", Pattern: "*.json"}
+		}
+		safeID := strings.NewReplacer(":", "_", "/", "_", ".", "_").Replace(deviceId)
+		savePath, err = wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
+			DefaultFilename:  fmt.Sprintf("packages_%s_%s.%s", safeID, time.Now().Format("20060102_150405"), format),
+			Title:            "Export Package List",
+			Filters:          []wailsRuntime.FileFilter{filter},
+			DefaultDirectory: a.outputDir(OutputExports),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to open save dialog: %w", err)
+		}
+		if savePath == "" {
+			return "", nil // User cancelled
+		}
+	}
+
+	if strings.ToLower(filepath.Ext(savePath)) != "."+format {
+		savePath += "." + format
+	}
+
+	data, err := renderPackageList(packageListEntries(packages), format)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(savePath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(savePath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write package list: %w", err)
+	}
+
+	a.Log("Exported %d packages of %s to %s", len(packages), deviceId, savePath)
+	return savePath, nil
+}
+
+// packageListEntries keeps the exported columns of each package
+func packageListEntries(packages []AppPackage) []PackageListEntry {
+	entries := make([]PackageListEntry, 0, len(packages))
+	for _, p := range packages {
+		entries = append(entries, PackageListEntry{
+			Name:        p.Name,
+			Label:       p.Label,
+			Type:        p.Type,
+			State:       p.State,
+			VersionName: p.VersionName,
+			VersionCode: p.VersionCode,
+			TargetSdk:   p.TargetSdkVersion,
+		})
+	}
+	return entries
+}
+
+// renderPackageList encodes entries as "csv" (with a header row) or indented "json"
+func renderPackageList(entries []PackageListEntry, format string) ([]byte, error) {
+	if format == "json" {
+		return json.MarshalIndent(entries, "", "  ")
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(packageListColumns)
+	for _, e := range entries {
+		_ = w.Write([]string{e.Name, e.Label, e.Type, e.State, e.VersionName, e.VersionCode, e.TargetSdk})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to encode package list: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ========================================
+// Package List Diff
+// ========================================
+
+// PackageVersionDiff is a package installed on both devices at different versions
+type PackageVersionDiff struct {
+	Name         string `json:"name"`
+	Label        string `json:"label"`
+	VersionCodeA string `json:"versionCodeA"`
+	VersionCodeB string `json:"versionCodeB"`
+}
+
+// PackageListDiff compares the package inventories of two devices
+type PackageListDiff struct {
+	DeviceA   string               `json:"deviceA"`
+	DeviceB   string               `json:"deviceB"`
+	OnlyA     []PackageListEntry   `json:"onlyA"`
+	OnlyB     []PackageListEntry   `json:"onlyB"`
+	Changed   []PackageVersionDiff `json:"changed"`
+	Unchanged int                  `json:"unchangedCount"` // Common packages at the same version
+}
+
+// DiffPackageLists lists the packages only on deviceA, only on deviceB, and those on
+// both whose version codes differ. Both devices are listed with packageType "all".
+func (a *App) DiffPackageLists(deviceA, deviceB string) (PackageListDiff, error) {
+	if deviceA == deviceB {
+		return PackageListDiff{}, fmt.Errorf("pick two different devices")
+	}
+
+	var listA, listB []AppPackage
+	var errA, errB error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		listA, errA = a.devicePackageVersions(deviceA, "all")
+	}()
+	go func() {
+		defer wg.Done()
+		listB, errB = a.devicePackageVersions(deviceB, "all")
+	}()
+	wg.Wait()
+	if errA != nil {
+		return PackageListDiff{}, fmt.Errorf("failed to list packages on %s: %w", deviceA, errA)
+	}
+	if errB != nil {
+		return PackageListDiff{}, fmt.Errorf("failed to list packages on %s: %w", deviceB, errB)
+	}
+
+	diff := diffPackageLists(listA, listB)
+	diff.DeviceA, diff.DeviceB = deviceA, deviceB
+	return diff, nil
+}
+
+// devicePackageVersions lists a device's packages with their versions read from the
+// device itself. ListPackages fills versions from the package cache, which is keyed by
+// name only and may hold another device's build, so those fields are always replaced.
+func (a *App) devicePackageVersions(deviceId, packageType string) ([]AppPackage, error) {
+	packages, err := a.ListPackages(deviceId, packageType, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	out, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "dumpsys", "package", "packages")
+	var versions map[string]packageVersionInfo
+	if err != nil {
+		LogWarn("apps").Err(err).Str("deviceId", deviceId).Msg("Could not read package versions; leaving them empty")
+	} else {
+		versions = parsePackageVersionDump(string(out))
+	}
+
+	for i := range packages {
+		v := versions[packages[i].Name]
+		packages[i].VersionName = v.versionName
+		packages[i].VersionCode = v.versionCode
+		packages[i].MinSdkVersion = v.minSdk
+		packages[i].TargetSdkVersion = v.targetSdk
+	}
+	return packages, nil
+}
+
+// packageVersionInfo is the version block of one package in `dumpsys package packages`
+type packageVersionInfo struct {
+	versionName string
+	versionCode string
+	minSdk      string
+	targetSdk   string
+}
+
+var (
+	dumpsysPackageHeaderRegex = regexp.MustCompile(`^\s*Package \[([^\]]+)\]`)
+	minSdkRegex               = regexp.MustCompile(`\bminSdk=(\d+)`)
+	targetSdkRegex            = regexp.MustCompile(`\btargetSdk=(\d+)`)
+)
+
+// parsePackageVersionDump reads each "Package [name]" block of `dumpsys package packages`.
+// Only the first block per name is used: the "Hidden system packages" section that
+// follows repeats updated system apps with their factory versions.
+func parsePackageVersionDump(output string) map[string]packageVersionInfo {
+	versions := make(map[string]packageVersionInfo)
+	var name string
+	var info packageVersionInfo
+	flush := func() {
+		if _, seen := versions[name]; name != "" && !seen {
+			versions[name] = info
+		}
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if m := dumpsysPackageHeaderRegex.FindStringSubmatch(line); m != nil {
+			flush()
+			name, info = m[1], packageVersionInfo{}
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		if m := versionCodeRegex.FindStringSubmatch(line); m != nil && info.versionCode == "" {
+			info.versionCode = m[1]
+			if m := minSdkRegex.FindStringSubmatch(line); m != nil {
+				info.minSdk = m[1]
+			}
+			if m := targetSdkRegex.FindStringSubmatch(line); m != nil {
+				info.targetSdk = m[1]
+			}
+		}
+		if m := versionNameRegex.FindStringSubmatch(line); m != nil && info.versionName == "" {
+			info.versionName = m[1]
+		}
+	}
+	flush()
+	return versions
+}
+
+// diffPackageLists computes the three diff columns, each sorted by package name
+func diffPackageLists(listA, listB []AppPackage) PackageListDiff {
+	byName := make(map[string]AppPackage, len(listB))
+	for _, p := range listB {
+		byName[p.Name] = p
+	}
+
+	diff := PackageListDiff{
+		OnlyA:   []PackageListEntry{},
+		OnlyB:   []PackageListEntry{},
+		Changed: []PackageVersionDiff{},
+	}
+	seen := make(map[string]bool, len(listA))
+	for _, pa := range listA {
+		seen[pa.Name] = true
+		pb, ok := byName[pa.Name]
+		if !ok {
+			diff.OnlyA = append(diff.OnlyA, packageListEntries([]AppPackage{pa})...)
+			continue
+		}
+		if pa.VersionCode != pb.VersionCode {
+			label := pa.Label
+			if label == "" {
+				label = pb.Label
+			}
+			diff.Changed = append(diff.Changed, PackageVersionDiff{
+				Name:         pa.Name,
+				Label:        label,
+				VersionCodeA: pa.VersionCode,
+				VersionCodeB: pb.VersionCode,
+			})
+		} else {
+			diff.Unchanged++
+		}
+	}
+	for _, pb := range listB {
+		if !seen[pb.Name] {
+			diff.OnlyB = append(diff.OnlyB, packageListEntries([]AppPackage{pb})...)
+		}
+	}
+
+	sort.Slice(diff.OnlyA, func(i, j int) bool { return diff.OnlyA[i].Name < diff.OnlyA[j].Name })
+	sort.Slice(diff.OnlyB, func(i, j int) bool { return diff.OnlyB[i].Name < diff.OnlyB[j].Name })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Name < diff.Changed[j].Name })
+	return diff
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"Gaze/pkg/cache"
+)
+
+func TestRenderPackageListCSV(t *testing.T) {
+	entries := packageListEntries([]AppPackage{
+		{Name: "com.example.app", Label: "Example, Inc", Type: "user", State: "enabled", VersionName: "1.2", VersionCode: "12", TargetSdkVersion: "34", Icon: "iVBOR"},
+	})
+	data, err := renderPackageList(entries, "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "name,label,type,state,versionName,versionCode,targetSdk\n" +
+		"com.example.app,\"Example, Inc\",user,enabled,1.2,12,34\n"
+	if string(data) != want {
+		t.Errorf("csv =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestRenderPackageListJSON(t *testing.T) {
+	entries := packageListEntries([]AppPackage{{Name: "com.android.settings", Type: "system", State: "enabled"}})
+	data, err := renderPackageList(entries, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []PackageListEntry
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(got) != 1 || got[0] != entries[0] {
+		t.Errorf("round trip = %+v", got)
+	}
+}
+
+func TestExportPackageListRejectsUnknownFormat(t *testing.T) {
+	app := &App{mcpMode: true}
+	if _, err := app.ExportPackageList("R5CT1234ABC", "all", "xml", "/tmp/packages.xml"); err == nil {
+		t.Error("expected an error for xml")
+	}
+}
+
+const dumpsysPackagesOutput = `Packages:
+  Package [com.android.chrome] (a1b2c3):
+    userId=10123
+    versionCode=619715433 minSdk=29 targetSdk=34
+    versionName=120.0.6099.144
+    flags=[ SYSTEM HAS_CODE UPDATED_SYSTEM_APP ]
+  Package [com.example.app] (d4e5f6):
+    userId=10200
+    versionCode=12 targetSdk=33
+    versionName=1.2
+    usesLibraries:
+      versionCode=99
+
+Hidden system packages:
+  Package [com.android.chrome] (0f0f0f):
+    versionCode=572814033 minSdk=29 targetSdk=33
+    versionName=113.0.5672.136
+`
+
+func TestParsePackageVersionDump(t *testing.T) {
+	got := parsePackageVersionDump(dumpsysPackagesOutput)
+	if len(got) != 2 {
+		t.Fatalf("got %d packages: %+v", len(got), got)
+	}
+	want := packageVersionInfo{versionName: "120.0.6099.144", versionCode: "619715433", minSdk: "29", targetSdk: "34"}
+	if got["com.android.chrome"] != want {
+		t.Errorf("chrome = %+v, want the installed update %+v", got["com.android.chrome"], want)
+	}
+	want = packageVersionInfo{versionName: "1.2", versionCode: "12", targetSdk: "33"}
+	if got["com.example.app"] != want {
+		t.Errorf("example = %+v, want %+v", got["com.example.app"], want)
+	}
+}
+
+func TestDiffPackageLists(t *testing.T) {
+	a := []AppPackage{
+		{Name: "com.old.only", VersionCode: "1"},
+		{Name: "com.same", VersionCode: "5"},
+		{Name: "com.updated", Label: "Updated", VersionCode: "10"},
+	}
+	b := []AppPackage{
+		{Name: "com.updated", VersionCode: "11"},
+		{Name: "com.same", VersionCode: "5"},
+		{Name: "com.new.only", VersionCode: "3"},
+	}
+	diff := diffPackageLists(a, b)
+	if len(diff.OnlyA) != 1 || diff.OnlyA[0].Name != "com.old.only" {
+		t.Errorf("OnlyA = %+v", diff.OnlyA)
+	}
+	if len(diff.OnlyB) != 1 || diff.OnlyB[0].Name != "com.new.only" {
+		t.Errorf("OnlyB = %+v", diff.OnlyB)
+	}
+	want := PackageVersionDiff{Name: "com.updated", Label: "Updated", VersionCodeA: "10", VersionCodeB: "11"}
+	if len(diff.Changed) != 1 || diff.Changed[0] != want {
+		t.Errorf("Changed = %+v", diff.Changed)
+	}
+	if diff.Unchanged != 1 {
+		t.Errorf("Unchanged = %d", diff.Unchanged)
+	}
+}
+
+func TestDevicePackageVersionsIgnoresCache(t *testing.T) {
+	app := newOutputTestApp(t)
+	app.runner = &fakeRunner{responses: map[string]string{
+		"-s dev1 shell pm list packages -d":      "",
+		"-s dev1 shell pm list packages -3":      "package:com.example.app\npackage:com.other.app\n",
+		"-s dev1 shell dumpsys package packages": dumpsysPackagesOutput,
+	}}
+	// Cached from another device running a newer build
+	app.cacheService.SetCachedPackage("com.example.app", cache.AppPackage{Name: "com.example.app", Label: "Example", VersionName: "2.0", VersionCode: "20", TargetSdkVersion: "34"})
+	app.cacheService.SetCachedPackage("com.other.app", cache.AppPackage{Name: "com.other.app", VersionName: "9.9", VersionCode: "99"})
+
+	packages, err := app.devicePackageVersions("dev1", "user")
+	if err != nil {
+		t.Fatalf("devicePackageVersions: %v", err)
+	}
+	byName := map[string]AppPackage{}
+	for _, p := range packages {
+		byName[p.Name] = p
+	}
+	if p := byName["com.example.app"]; p.Label != "Example" || p.VersionName != "1.2" || p.VersionCode != "12" || p.TargetSdkVersion != "33" {
+		t.Errorf("com.example.app = %+v, want the device's 1.2 (12), targetSdk 33", p)
+	}
+	if p := byName["com.other.app"]; p.VersionName != "" || p.VersionCode != "" {
+		t.Errorf("versions missing from dumpsys must not come from the cache: %+v", p)
+	}
+}
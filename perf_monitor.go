@@ -149,6 +149,7 @@ func (a *App) StartPerfMonitor(deviceID string, config PerfMonitorConfig) string
 	perfMonitors[deviceID] = monitor
 	go monitor.Start()
 
+	a.registerMonitor("perf", deviceID, func() { a.StopPerfMonitor(deviceID) })
 	LogInfo("perf_monitor").Str("device", deviceID).Str("package", config.PackageName).Msg("Performance monitor started")
 	return "started"
 }
@@ -161,6 +162,7 @@ func (a *App) StopPerfMonitor(deviceID string) string {
 	if m, ok := perfMonitors[deviceID]; ok {
 		m.Stop()
 		delete(perfMonitors, deviceID)
+		unregisterMonitor("perf", deviceID)
 		LogInfo("perf_monitor").Str("device", deviceID).Msg("Performance monitor stopped")
 		return "stopped"
 	}
@@ -246,6 +248,7 @@ func (a *App) StopAllPerfMonitors() {
 		LogInfo("perf_monitor").Str("device", id).Msg("Stopped perf monitor on shutdown")
 	}
 	perfMonitors = make(map[string]*PerfMonitor)
+	unregisterMonitorKind("perf")
 }
 
 // ========================================
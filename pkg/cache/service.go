@@ -24,10 +24,68 @@ type AppPackage struct {
 	LaunchableActivities []string `json:"launchableActivities"`
 }
 
+// Concurrency holds the worker pool sizes used for background device operations.
+// A zero value means "use the built-in default".
+type Concurrency struct {
+	Thumbnails int `json:"thumbnails"`
+	Aapt       int `json:"aapt"`
+	Shell      int `json:"shell"`
+}
+
+// LogcatBuffering holds how logcat lines are batched before emission. 0 = default.
+type LogcatBuffering struct {
+	MaxChunk        int `json:"maxChunk,omitempty"`
+	FlushIntervalMs int `json:"flushIntervalMs,omitempty"`
+}
+
+// AdbServer is the adb server adb commands talk to. Empty = local default.
+type AdbServer struct {
+	Host string `json:"host,omitempty"`
+	Port int    `json:"port,omitempty"`
+}
+
+// Reconnect holds the wireless auto-reconnect tuning.
+// A zero value means "use the built-in default".
+type Reconnect struct {
+	CooldownSec int `json:"cooldownSec"`
+	TimeoutSec  int `json:"timeoutSec"`
+	MaxAttempts int `json:"maxAttempts"`
+}
+
+// Output holds where generated files are saved. Empty values fall back to Downloads.
+type Output struct {
+	Directory string            `json:"directory,omitempty"`
+	Overrides map[string]string `json:"overrides,omitempty"` // category -> directory
+}
+
+// ConnectStats counts manual wireless connect attempts to one address (Unix seconds)
+type ConnectStats struct {
+	Successes   int   `json:"successes"`
+	Failures    int   `json:"failures"`
+	LastSuccess int64 `json:"lastSuccess,omitempty"`
+	LastFailure int64 `json:"lastFailure,omitempty"`
+}
+
 // Settings represents persistent application settings
 type Settings struct {
-	LastActive   map[string]int64 `json:"lastActive"`
-	PinnedSerial string           `json:"pinnedSerial"`
+	LastActive     map[string]int64        `json:"lastActive"`
+	PinnedSerial   string                  `json:"pinnedSerial"`
+	Concurrency    Concurrency             `json:"concurrency"`
+	Reconnect      Reconnect               `json:"reconnect"`
+	Output         Output                  `json:"output"`
+	TraceWindowMs  *int                    `json:"traceWindowMs,omitempty"`  // nil = default, 0 = off
+	SourceSampling map[string]int          `json:"sourceSampling,omitempty"` // event source -> max events/s
+	ConnectStats   map[string]ConnectStats `json:"connectStats,omitempty"`   // address -> attempts
+	AdbRetries     int                     `json:"adbRetries,omitempty"`     // 0 = default
+	Logcat         LogcatBuffering         `json:"logcat"`
+	AdbServer      AdbServer               `json:"adbServer"`
+	AutoSessions   bool                    `json:"autoSessions"`
+	SafeMode       *bool                   `json:"safeMode,omitempty"` // nil = saved before safe mode existed (off)
+
+	MDNSSerialPatterns       []string        `json:"mdnsSerialPatterns,omitempty"`       // empty = built-in default
+	RestartMirrorOnReconnect map[string]bool `json:"restartMirrorOnReconnect,omitempty"` // device ID -> enabled
+
+	Values map[string]string `json:"values,omitempty"` // Free-form key-value settings
 }
 
 // Service manages application cache and settings persistence
@@ -49,6 +107,48 @@ type Service struct {
 	pinnedSerial string
 	pinnedMu     sync.RWMutex
 
+	concurrency   Concurrency
+	concurrencyMu sync.RWMutex
+
+	reconnect   Reconnect
+	reconnectMu sync.RWMutex
+
+	output   Output
+	outputMu sync.RWMutex
+
+	traceWindowMs   int
+	traceWindowMsMu sync.RWMutex
+
+	sourceSampling   map[string]int
+	sourceSamplingMu sync.RWMutex
+
+	connectStats   map[string]ConnectStats
+	connectStatsMu sync.RWMutex
+
+	adbRetries   int
+	adbRetriesMu sync.RWMutex
+
+	logcatBuffering   LogcatBuffering
+	logcatBufferingMu sync.RWMutex
+
+	adbServer   AdbServer
+	adbServerMu sync.RWMutex
+
+	restartMirror   map[string]bool
+	restartMirrorMu sync.RWMutex
+
+	autoSessions   bool
+	autoSessionsMu sync.RWMutex
+
+	safeMode   bool
+	safeModeMu sync.RWMutex
+
+	mdnsSerialPatterns   []string
+	mdnsSerialPatternsMu sync.RWMutex
+
+	values   map[string]string
+	valuesMu sync.RWMutex
+
 	// History
 	historyMu sync.Mutex
 
@@ -85,7 +185,10 @@ func New(cfg Config) (*Service, error) {
 		settingsPath: filepath.Join(configDir, "settings.json"),
 		aaptCache:    make(map[string]AppPackage),
 		lastActive:   make(map[string]int64),
+		safeMode:     true, // New installs; loadSettings turns it off for upgrades
 		logFunc:      cfg.LogFunc,
+
+		traceWindowMs: TraceWindowDefault,
 	}
 
 	// Load persisted data
@@ -196,6 +299,276 @@ func (s *Service) SetPinnedSerial(serial string) {
 	s.pinnedMu.Unlock()
 }
 
+// GetConcurrency returns the configured worker pool sizes
+func (s *Service) GetConcurrency() Concurrency {
+	s.concurrencyMu.RLock()
+	defer s.concurrencyMu.RUnlock()
+	return s.concurrency
+}
+
+// SetConcurrency updates the configured worker pool sizes
+func (s *Service) SetConcurrency(c Concurrency) {
+	s.concurrencyMu.Lock()
+	s.concurrency = c
+	s.concurrencyMu.Unlock()
+}
+
+// GetReconnect returns the configured wireless reconnect tuning
+func (s *Service) GetReconnect() Reconnect {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+	return s.reconnect
+}
+
+// SetReconnect updates the wireless reconnect tuning
+func (s *Service) SetReconnect(r Reconnect) {
+	s.reconnectMu.Lock()
+	s.reconnect = r
+	s.reconnectMu.Unlock()
+}
+
+// GetOutput returns the configured output directories
+func (s *Service) GetOutput() Output {
+	s.outputMu.RLock()
+	defer s.outputMu.RUnlock()
+	o := Output{Directory: s.output.Directory}
+	if len(s.output.Overrides) > 0 {
+		o.Overrides = make(map[string]string, len(s.output.Overrides))
+		for k, v := range s.output.Overrides {
+			o.Overrides[k] = v
+		}
+	}
+	return o
+}
+
+// SetOutput replaces the configured output directories
+func (s *Service) SetOutput(o Output) {
+	s.outputMu.Lock()
+	s.output = o
+	s.outputMu.Unlock()
+}
+
+// TraceWindowDefault is the stored trace window when the user hasn't chosen one
+const TraceWindowDefault = -1
+
+// GetTraceWindowMs returns how long after a touch related events share its trace_id.
+// 0 means correlation is off; TraceWindowDefault means no value was set.
+func (s *Service) GetTraceWindowMs() int {
+	s.traceWindowMsMu.RLock()
+	defer s.traceWindowMsMu.RUnlock()
+	return s.traceWindowMs
+}
+
+// SetTraceWindowMs updates the touch trace correlation window
+func (s *Service) SetTraceWindowMs(ms int) {
+	s.traceWindowMsMu.Lock()
+	s.traceWindowMs = ms
+	s.traceWindowMsMu.Unlock()
+}
+
+// GetSourceSampling returns a copy of the per-source ingestion limits (events/s)
+func (s *Service) GetSourceSampling() map[string]int {
+	s.sourceSamplingMu.RLock()
+	defer s.sourceSamplingMu.RUnlock()
+	limits := make(map[string]int, len(s.sourceSampling))
+	for k, v := range s.sourceSampling {
+		limits[k] = v
+	}
+	return limits
+}
+
+// SetSourceSampling sets the ingestion limit of one event source; 0 removes it
+func (s *Service) SetSourceSampling(source string, maxPerSecond int) {
+	s.sourceSamplingMu.Lock()
+	defer s.sourceSamplingMu.Unlock()
+	if maxPerSecond <= 0 {
+		delete(s.sourceSampling, source)
+		return
+	}
+	if s.sourceSampling == nil {
+		s.sourceSampling = make(map[string]int)
+	}
+	s.sourceSampling[source] = maxPerSecond
+}
+
+// GetConnectStats returns a copy of the wireless connect counters by address
+func (s *Service) GetConnectStats() map[string]ConnectStats {
+	s.connectStatsMu.RLock()
+	defer s.connectStatsMu.RUnlock()
+	stats := make(map[string]ConnectStats, len(s.connectStats))
+	for k, v := range s.connectStats {
+		stats[k] = v
+	}
+	return stats
+}
+
+// RecordConnect counts a connect attempt to address made at the given Unix time
+func (s *Service) RecordConnect(address string, success bool, at int64) {
+	s.connectStatsMu.Lock()
+	defer s.connectStatsMu.Unlock()
+	if s.connectStats == nil {
+		s.connectStats = make(map[string]ConnectStats)
+	}
+	st := s.connectStats[address]
+	if success {
+		st.Successes++
+		st.LastSuccess = at
+	} else {
+		st.Failures++
+		st.LastFailure = at
+	}
+	s.connectStats[address] = st
+}
+
+// GetAdbRetries returns how many attempts flaky adb commands get
+func (s *Service) GetAdbRetries() int {
+	s.adbRetriesMu.RLock()
+	defer s.adbRetriesMu.RUnlock()
+	return s.adbRetries
+}
+
+// SetAdbRetries updates the attempt count of flaky adb commands
+func (s *Service) SetAdbRetries(n int) {
+	s.adbRetriesMu.Lock()
+	s.adbRetries = n
+	s.adbRetriesMu.Unlock()
+}
+
+// GetLogcatBuffering returns the configured logcat batching
+func (s *Service) GetLogcatBuffering() LogcatBuffering {
+	s.logcatBufferingMu.RLock()
+	defer s.logcatBufferingMu.RUnlock()
+	return s.logcatBuffering
+}
+
+// SetLogcatBuffering updates the logcat batching
+func (s *Service) SetLogcatBuffering(b LogcatBuffering) {
+	s.logcatBufferingMu.Lock()
+	s.logcatBuffering = b
+	s.logcatBufferingMu.Unlock()
+}
+
+// GetAdbServer returns the configured adb server address
+func (s *Service) GetAdbServer() AdbServer {
+	s.adbServerMu.RLock()
+	defer s.adbServerMu.RUnlock()
+	return s.adbServer
+}
+
+// SetAdbServer updates the adb server address
+func (s *Service) SetAdbServer(server AdbServer) {
+	s.adbServerMu.Lock()
+	s.adbServer = server
+	s.adbServerMu.Unlock()
+}
+
+// GetRestartMirrorOnReconnect reports whether mirroring of a device is relaunched after it reconnects
+func (s *Service) GetRestartMirrorOnReconnect(deviceID string) bool {
+	s.restartMirrorMu.RLock()
+	defer s.restartMirrorMu.RUnlock()
+	return s.restartMirror[deviceID]
+}
+
+// SetRestartMirrorOnReconnect turns mirror auto-restart on or off for a device
+func (s *Service) SetRestartMirrorOnReconnect(deviceID string, enabled bool) {
+	s.restartMirrorMu.Lock()
+	defer s.restartMirrorMu.Unlock()
+	if !enabled {
+		delete(s.restartMirror, deviceID)
+		return
+	}
+	if s.restartMirror == nil {
+		s.restartMirror = make(map[string]bool)
+	}
+	s.restartMirror[deviceID] = true
+}
+
+// GetAllRestartMirrorOnReconnect returns a copy of the devices with mirror auto-restart on
+func (s *Service) GetAllRestartMirrorOnReconnect() map[string]bool {
+	s.restartMirrorMu.RLock()
+	defer s.restartMirrorMu.RUnlock()
+	all := make(map[string]bool, len(s.restartMirror))
+	for k, v := range s.restartMirror {
+		all[k] = v
+	}
+	return all
+}
+
+// GetAutoSessions reports whether sessions are opened automatically on device connect
+func (s *Service) GetAutoSessions() bool {
+	s.autoSessionsMu.RLock()
+	defer s.autoSessionsMu.RUnlock()
+	return s.autoSessions
+}
+
+// SetAutoSessions enables or disables automatic sessions
+func (s *Service) SetAutoSessions(enabled bool) {
+	s.autoSessionsMu.Lock()
+	s.autoSessions = enabled
+	s.autoSessionsMu.Unlock()
+}
+
+// GetSafeMode reports whether destructive shell commands are blocked
+func (s *Service) GetSafeMode() bool {
+	s.safeModeMu.RLock()
+	defer s.safeModeMu.RUnlock()
+	return s.safeMode
+}
+
+// SetSafeMode enables or disables blocking of destructive shell commands
+func (s *Service) SetSafeMode(enabled bool) {
+	s.safeModeMu.Lock()
+	s.safeMode = enabled
+	s.safeModeMu.Unlock()
+}
+
+// GetMDNSSerialPatterns returns the user-configured mDNS serial patterns
+func (s *Service) GetMDNSSerialPatterns() []string {
+	s.mdnsSerialPatternsMu.RLock()
+	defer s.mdnsSerialPatternsMu.RUnlock()
+	return append([]string(nil), s.mdnsSerialPatterns...)
+}
+
+// SetMDNSSerialPatterns replaces the mDNS serial patterns
+func (s *Service) SetMDNSSerialPatterns(patterns []string) {
+	s.mdnsSerialPatternsMu.Lock()
+	s.mdnsSerialPatterns = append([]string(nil), patterns...)
+	s.mdnsSerialPatternsMu.Unlock()
+}
+
+// GetValue returns a free-form setting and whether it is set
+func (s *Service) GetValue(key string) (string, bool) {
+	s.valuesMu.RLock()
+	defer s.valuesMu.RUnlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// SetValue stores a free-form setting; an empty value removes the key
+func (s *Service) SetValue(key, value string) {
+	s.valuesMu.Lock()
+	defer s.valuesMu.Unlock()
+	if value == "" {
+		delete(s.values, key)
+		return
+	}
+	if s.values == nil {
+		s.values = make(map[string]string)
+	}
+	s.values[key] = value
+}
+
+// GetValues returns a copy of all free-form settings
+func (s *Service) GetValues() map[string]string {
+	s.valuesMu.RLock()
+	defer s.valuesMu.RUnlock()
+	all := make(map[string]string, len(s.values))
+	for k, v := range s.values {
+		all[k] = v
+	}
+	return all
+}
+
 // SaveSettings persists settings to disk
 func (s *Service) SaveSettings() error {
 	s.lastActiveMu.RLock()
@@ -210,8 +583,26 @@ func (s *Service) SaveSettings() error {
 	s.pinnedMu.RUnlock()
 
 	settings := Settings{
-		LastActive:   lastActive,
-		PinnedSerial: pinnedSerial,
+		LastActive:         lastActive,
+		PinnedSerial:       pinnedSerial,
+		Concurrency:        s.GetConcurrency(),
+		Reconnect:          s.GetReconnect(),
+		Output:             s.GetOutput(),
+		SourceSampling:     s.GetSourceSampling(),
+		ConnectStats:       s.GetConnectStats(),
+		AdbRetries:         s.GetAdbRetries(),
+		Logcat:             s.GetLogcatBuffering(),
+		AdbServer:          s.GetAdbServer(),
+		AutoSessions:       s.GetAutoSessions(),
+		MDNSSerialPatterns: s.GetMDNSSerialPatterns(),
+
+		RestartMirrorOnReconnect: s.GetAllRestartMirrorOnReconnect(),
+		Values:                   s.GetValues(),
+	}
+	safeMode := s.GetSafeMode()
+	settings.SafeMode = &safeMode
+	if ms := s.GetTraceWindowMs(); ms != TraceWindowDefault {
+		settings.TraceWindowMs = &ms
 	}
 
 	data, err := json.Marshal(settings)
@@ -243,6 +634,67 @@ func (s *Service) loadSettings() {
 	s.pinnedMu.Lock()
 	s.pinnedSerial = settings.PinnedSerial
 	s.pinnedMu.Unlock()
+
+	s.concurrencyMu.Lock()
+	s.concurrency = settings.Concurrency
+	s.concurrencyMu.Unlock()
+
+	s.reconnectMu.Lock()
+	s.reconnect = settings.Reconnect
+	s.reconnectMu.Unlock()
+
+	s.outputMu.Lock()
+	s.output = settings.Output
+	s.outputMu.Unlock()
+
+	s.traceWindowMsMu.Lock()
+	s.traceWindowMs = TraceWindowDefault
+	if settings.TraceWindowMs != nil {
+		s.traceWindowMs = *settings.TraceWindowMs
+	}
+	s.traceWindowMsMu.Unlock()
+
+	s.sourceSamplingMu.Lock()
+	s.sourceSampling = settings.SourceSampling
+	s.sourceSamplingMu.Unlock()
+
+	s.connectStatsMu.Lock()
+	s.connectStats = settings.ConnectStats
+	s.connectStatsMu.Unlock()
+
+	s.adbRetriesMu.Lock()
+	s.adbRetries = settings.AdbRetries
+	s.adbRetriesMu.Unlock()
+
+	s.logcatBufferingMu.Lock()
+	s.logcatBuffering = settings.Logcat
+	s.logcatBufferingMu.Unlock()
+
+	s.adbServerMu.Lock()
+	s.adbServer = settings.AdbServer
+	s.adbServerMu.Unlock()
+
+	s.restartMirrorMu.Lock()
+	s.restartMirror = settings.RestartMirrorOnReconnect
+	s.restartMirrorMu.Unlock()
+
+	s.autoSessionsMu.Lock()
+	s.autoSessions = settings.AutoSessions
+	s.autoSessionsMu.Unlock()
+
+	// Safe mode is on for new installs only; settings saved by a version without it
+	// keep the old unrestricted shell behavior
+	s.safeModeMu.Lock()
+	s.safeMode = settings.SafeMode != nil && *settings.SafeMode
+	s.safeModeMu.Unlock()
+
+	s.mdnsSerialPatternsMu.Lock()
+	s.mdnsSerialPatterns = settings.MDNSSerialPatterns
+	s.mdnsSerialPatternsMu.Unlock()
+
+	s.valuesMu.Lock()
+	s.values = settings.Values
+	s.valuesMu.Unlock()
 }
 
 // ========================================
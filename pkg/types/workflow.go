@@ -97,7 +97,8 @@ type ElementParams struct {
 // AppParams for app operations
 type AppParams struct {
 	PackageName string `json:"packageName"`
-	Action      string `json:"action"` // launch/stop/clear/settings
+	Action      string `json:"action"`            // launch/stop/clear/settings/install
+	ApkPath     string `json:"apkPath,omitempty"` // Local APK for install_if_missing
 }
 
 // BranchParams for conditional branching
@@ -283,6 +284,17 @@ func (s *WorkflowStep) Validate() error {
 			return ValidationError{StepID: s.ID, Field: "app.packageName", Message: "packageName is required"}
 		}
 
+	case "install_if_missing":
+		if s.App == nil {
+			return ValidationError{StepID: s.ID, Field: "app", Message: "app params required for install_if_missing step"}
+		}
+		if s.App.PackageName == "" {
+			return ValidationError{StepID: s.ID, Field: "app.packageName", Message: "packageName is required"}
+		}
+		if s.App.ApkPath == "" {
+			return ValidationError{StepID: s.ID, Field: "app.apkPath", Message: "apkPath is required"}
+		}
+
 	case "branch":
 		if s.Branch == nil {
 			return ValidationError{StepID: s.ID, Field: "branch", Message: "branch params required for branch step"}
@@ -0,0 +1,113 @@
+package proxy
+
+import (
+	"net/url"
+	"strings"
+)
+
+// CaptureFilter limits which proxied requests are logged. Requests that don't
+// match are still forwarded, they just aren't emitted or retained. Host patterns
+// support * wildcards ("*.example.com"); empty lists match everything and an
+// exclude match always wins over an include match.
+type CaptureFilter struct {
+	IncludeHosts []string `json:"includeHosts"`
+	ExcludeHosts []string `json:"excludeHosts"`
+	Methods      []string `json:"methods"`
+}
+
+// normalizeCaptureFilter lowercases host patterns, uppercases methods and drops
+// blank entries so matching can compare directly.
+func normalizeCaptureFilter(f CaptureFilter) CaptureFilter {
+	clean := func(values []string, transform func(string) string) []string {
+		out := []string{}
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				out = append(out, transform(v))
+			}
+		}
+		return out
+	}
+	return CaptureFilter{
+		IncludeHosts: clean(f.IncludeHosts, strings.ToLower),
+		ExcludeHosts: clean(f.ExcludeHosts, strings.ToLower),
+		Methods:      clean(f.Methods, strings.ToUpper),
+	}
+}
+
+// IsEmpty reports whether the filter lets every request through
+func (f CaptureFilter) IsEmpty() bool {
+	return len(f.IncludeHosts) == 0 && len(f.ExcludeHosts) == 0 && len(f.Methods) == 0
+}
+
+// Matches reports whether a request should be logged. f must be normalized.
+func (f CaptureFilter) Matches(method, rawURL string) bool {
+	if len(f.Methods) > 0 && !containsString(f.Methods, strings.ToUpper(method)) {
+		return false
+	}
+
+	host := requestHost(rawURL)
+	for _, pattern := range f.ExcludeHosts {
+		if MatchPattern(host, pattern) {
+			return false
+		}
+	}
+	if len(f.IncludeHosts) == 0 {
+		return true
+	}
+	for _, pattern := range f.IncludeHosts {
+		if MatchPattern(host, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestHost extracts the lowercased host (without port) from a logged URL
+func requestHost(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Hostname() != "" {
+		return strings.ToLower(u.Hostname())
+	}
+	// CONNECT logs may carry a bare "host:port"
+	host := rawURL
+	if idx := strings.LastIndex(host, ":"); idx > 0 && !strings.Contains(host, "/") {
+		host = host[:idx]
+	}
+	return strings.ToLower(host)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// SetCaptureFilter replaces the active capture filter
+func (p *ProxyServer) SetCaptureFilter(f CaptureFilter) {
+	f = normalizeCaptureFilter(f)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.captureFilter = f
+	p.debugLog("PROXY Capture Filter Updated: %+v", f)
+}
+
+// GetCaptureFilter returns the active capture filter
+func (p *ProxyServer) GetCaptureFilter() CaptureFilter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return normalizeCaptureFilter(p.captureFilter)
+}
+
+// shouldCapture applies the capture filter to a request log. Partial updates
+// carry only an ID and are always passed through.
+func (p *ProxyServer) shouldCapture(req RequestLog) bool {
+	if req.PartialUpdate {
+		return true
+	}
+	p.mu.Lock()
+	f := p.captureFilter
+	p.mu.Unlock()
+	return f.IsEmpty() || f.Matches(req.Method, req.URL)
+}
@@ -0,0 +1,48 @@
+package proxy
+
+import "testing"
+
+func TestCaptureFilter_Matches(t *testing.T) {
+	f := normalizeCaptureFilter(CaptureFilter{
+		IncludeHosts: []string{"*.Example.com", "api.test.io", " "},
+		ExcludeHosts: []string{"tracking.example.com"},
+		Methods:      []string{"get", "post"},
+	})
+
+	tests := []struct {
+		method, url string
+		want        bool
+	}{
+		{"GET", "https://www.example.com/index.html", true},
+		{"post", "https://API.test.io:8443/v1/items", true},
+		{"GET", "https://tracking.example.com/pixel", false}, // excluded
+		{"DELETE", "https://www.example.com/item/1", false},  // method not allowed
+		{"GET", "https://other.org/", false},                 // host not included
+		{"GET", "example.com:443", false},                    // no subdomain for *.example.com
+	}
+	for _, tt := range tests {
+		if got := f.Matches(tt.method, tt.url); got != tt.want {
+			t.Errorf("Matches(%s, %s) = %v, want %v", tt.method, tt.url, got, tt.want)
+		}
+	}
+
+	if len(f.IncludeHosts) != 2 || f.IncludeHosts[0] != "*.example.com" || f.Methods[1] != "POST" {
+		t.Errorf("unexpected normalized filter: %+v", f)
+	}
+}
+
+func TestCaptureFilter_ExcludeOnly(t *testing.T) {
+	f := normalizeCaptureFilter(CaptureFilter{ExcludeHosts: []string{"*cdn*"}})
+	if f.IsEmpty() {
+		t.Fatal("expected non-empty filter")
+	}
+	if f.Matches("GET", "https://img.cdn.example.com/a.png") {
+		t.Error("expected CDN host to be excluded")
+	}
+	if !f.Matches("CONNECT", "api.example.com:443") {
+		t.Error("expected other hosts and methods to match")
+	}
+	if !normalizeCaptureFilter(CaptureFilter{Methods: []string{""}}).IsEmpty() {
+		t.Error("expected blank entries to be dropped")
+	}
+}
@@ -0,0 +1,196 @@
+package proxy
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// HAR 1.2 document types (http://www.softwareishard.com/blog/har-12-spec/)
+
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type HARContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// HARTimings only carries the total wait: the proxy doesn't measure the
+// individual connection phases, which HAR allows to be reported as -1.
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// BuildHAR converts retained requests into a HAR 1.2 document
+func BuildHAR(entries []HistoryEntry, creatorName, creatorVersion string) HAR {
+	har := HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: creatorName, Version: creatorVersion},
+		Entries: make([]HAREntry, 0, len(entries)),
+	}}
+	for _, e := range entries {
+		har.Log.Entries = append(har.Log.Entries, harEntry(e))
+	}
+	return har
+}
+
+func harEntry(e HistoryEntry) HAREntry {
+	started := e.StartedAt
+	if started.IsZero() {
+		started = e.CompletedAt
+	}
+	total := float64(e.Duration().Microseconds()) / 1000
+
+	req := HARRequest{
+		Method:      e.Method,
+		URL:         e.URL,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []HARNameValue{},
+		Headers:     harHeaders(e.Headers),
+		QueryString: harQuery(e.URL),
+		HeadersSize: -1,
+		BodySize:    len(e.Body),
+	}
+	if e.Body != "" {
+		req.PostData = &HARPostData{
+			MimeType: http.Header(e.Headers).Get("Content-Type"),
+			Text:     e.Body,
+		}
+	}
+
+	content := HARContent{
+		Size:     e.BodySize,
+		MimeType: e.ContentType,
+		Text:     e.RespBody,
+	}
+	if len(e.RespBodyRaw) > 0 {
+		content.Text = base64.StdEncoding.EncodeToString(e.RespBodyRaw)
+		content.Encoding = "base64"
+	}
+	if content.Size < 0 {
+		content.Size = int64(len(e.RespBodyRaw))
+		if content.Size == 0 {
+			content.Size = int64(len(e.RespBody))
+		}
+	}
+
+	resp := HARResponse{
+		Status:      e.StatusCode,
+		StatusText:  http.StatusText(e.StatusCode),
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []HARNameValue{},
+		Headers:     harHeaders(e.RespHeaders),
+		Content:     content,
+		RedirectURL: http.Header(e.RespHeaders).Get("Location"),
+		HeadersSize: -1,
+		BodySize:    e.BodySize,
+	}
+
+	entry := HAREntry{
+		StartedDateTime: started.UTC().Format(time.RFC3339Nano),
+		Time:            total,
+		Request:         req,
+		Response:        resp,
+		Timings: HARTimings{
+			Blocked: -1, DNS: -1, Connect: -1, SSL: -1,
+			Wait: total,
+		},
+	}
+	if e.Mocked {
+		entry.Comment = "mocked"
+	}
+	return entry
+}
+
+// harHeaders flattens a header map into name/value pairs, sorted by name
+func harHeaders(h map[string][]string) []HARNameValue {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]HARNameValue, 0, len(h))
+	for _, name := range names {
+		for _, v := range h[name] {
+			out = append(out, HARNameValue{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+func harQuery(rawURL string) []HARNameValue {
+	out := []HARNameValue{}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return out
+	}
+	return append(out, harHeaders(u.Query())...)
+}
@@ -0,0 +1,146 @@
+package proxy
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultHistorySize is how many completed requests the proxy retains when no
+// explicit buffer size has been configured.
+const DefaultHistorySize = 1000
+
+// HistoryEntry is a completed request retained for export, with the wall-clock
+// times it started and finished.
+type HistoryEntry struct {
+	RequestLog
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
+
+// Duration returns how long the request took, or 0 if the start is unknown
+func (e HistoryEntry) Duration() time.Duration {
+	if e.StartedAt.IsZero() || e.CompletedAt.Before(e.StartedAt) {
+		return 0
+	}
+	return e.CompletedAt.Sub(e.StartedAt)
+}
+
+// requestHistory is a fixed-size ring buffer of completed requests. The zero
+// value is ready to use and holds DefaultHistorySize entries.
+type requestHistory struct {
+	mu      sync.Mutex
+	size    int
+	entries []HistoryEntry // ring storage, len(entries) <= capacity
+	head    int            // index of the oldest entry once the ring is full
+	ids     map[string]struct{}
+}
+
+func (h *requestHistory) capacity() int {
+	if h.size <= 0 {
+		return DefaultHistorySize
+	}
+	return h.size
+}
+
+// record stores a final request log. Partial updates, pending requests and
+// duplicate final callbacks for the same ID are ignored.
+func (h *requestHistory) record(req RequestLog, now time.Time) {
+	if req.PartialUpdate || req.StatusCode == 0 || req.Id == "" {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.ids == nil {
+		h.ids = make(map[string]struct{})
+	}
+	if _, ok := h.ids[req.Id]; ok {
+		return
+	}
+
+	entry := HistoryEntry{RequestLog: req, StartedAt: requestStartTime(req.Id), CompletedAt: now}
+	if len(h.entries) < h.capacity() {
+		h.entries = append(h.entries, entry)
+	} else {
+		delete(h.ids, h.entries[h.head].Id)
+		h.entries[h.head] = entry
+		h.head = (h.head + 1) % len(h.entries)
+	}
+	h.ids[req.Id] = struct{}{}
+}
+
+// snapshot returns the retained entries, oldest first
+func (h *requestHistory) snapshot() []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	out := make([]HistoryEntry, 0, len(h.entries))
+	out = append(out, h.entries[h.head:]...)
+	out = append(out, h.entries[:h.head]...)
+	return out
+}
+
+func (h *requestHistory) clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = nil
+	h.head = 0
+	h.ids = nil
+}
+
+// resize changes the buffer size, keeping the most recent entries that fit
+func (h *requestHistory) resize(size int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ordered := append(append([]HistoryEntry(nil), h.entries[h.head:]...), h.entries[:h.head]...)
+	h.size = size
+	if limit := h.capacity(); len(ordered) > limit {
+		for _, e := range ordered[:len(ordered)-limit] {
+			delete(h.ids, e.Id)
+		}
+		ordered = ordered[len(ordered)-limit:]
+	}
+	h.entries = ordered
+	h.head = 0
+}
+
+// requestStartTime recovers the start time encoded in a request ID
+// ("<session>-<unix nano>").
+func requestStartTime(id string) time.Time {
+	idx := strings.LastIndex(id, "-")
+	if idx < 0 {
+		return time.Time{}
+	}
+	nano, err := strconv.ParseInt(id[idx+1:], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, nano)
+}
+
+// History returns the retained completed requests, oldest first
+func (p *ProxyServer) History() []HistoryEntry {
+	return p.history.snapshot()
+}
+
+// ClearHistory drops all retained requests
+func (p *ProxyServer) ClearHistory() {
+	p.history.clear()
+}
+
+// SetHistorySize bounds how many completed requests are retained. A size of 0
+// or less restores DefaultHistorySize.
+func (p *ProxyServer) SetHistorySize(size int) {
+	p.history.resize(size)
+}
+
+// HistorySize returns the current request buffer size
+func (p *ProxyServer) HistorySize() int {
+	p.history.mu.Lock()
+	defer p.history.mu.Unlock()
+	return p.history.capacity()
+}
@@ -0,0 +1,129 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func historyLog(n int) RequestLog {
+	return RequestLog{
+		Id:         fmt.Sprintf("1-%d", int64(n)*int64(time.Millisecond)),
+		Method:     "GET",
+		URL:        fmt.Sprintf("https://example.com/item/%d?q=%d", n, n),
+		StatusCode: 200,
+	}
+}
+
+func TestRequestHistory_RingBuffer(t *testing.T) {
+	h := &requestHistory{size: 3}
+	now := time.Now()
+	for i := 1; i <= 5; i++ {
+		h.record(historyLog(i), now)
+	}
+	// Duplicate final callbacks, partial updates and pending requests are skipped
+	h.record(historyLog(5), now)
+	h.record(RequestLog{Id: "1-99", PartialUpdate: true, StatusCode: 200}, now)
+	h.record(RequestLog{Id: "1-100"}, now)
+
+	got := h.snapshot()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(got))
+	}
+	for i, want := range []int{3, 4, 5} {
+		if got[i].Id != historyLog(want).Id {
+			t.Errorf("entry %d = %s, want %s", i, got[i].Id, historyLog(want).Id)
+		}
+	}
+
+	// An evicted ID can be recorded again
+	h.record(historyLog(1), now)
+	if got := h.snapshot(); got[2].Id != historyLog(1).Id {
+		t.Errorf("expected re-recorded entry last, got %s", got[2].Id)
+	}
+}
+
+func TestRequestHistory_Resize(t *testing.T) {
+	h := &requestHistory{size: 4}
+	now := time.Now()
+	for i := 1; i <= 6; i++ {
+		h.record(historyLog(i), now)
+	}
+
+	h.resize(2)
+	got := h.snapshot()
+	if len(got) != 2 || got[0].Id != historyLog(5).Id || got[1].Id != historyLog(6).Id {
+		t.Fatalf("unexpected entries after shrink: %+v", got)
+	}
+
+	h.resize(0)
+	if h.capacity() != DefaultHistorySize {
+		t.Errorf("expected default capacity, got %d", h.capacity())
+	}
+	h.record(historyLog(7), now)
+	if got := h.snapshot(); len(got) != 3 || got[2].Id != historyLog(7).Id {
+		t.Fatalf("unexpected entries after grow: %+v", got)
+	}
+
+	h.clear()
+	if got := h.snapshot(); len(got) != 0 {
+		t.Errorf("expected empty history after clear, got %d", len(got))
+	}
+}
+
+func TestBuildHAR(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	entry := HistoryEntry{
+		RequestLog: RequestLog{
+			Method:      "POST",
+			URL:         "https://api.example.com/v1/items?page=2&sort=name",
+			Headers:     map[string][]string{"Content-Type": {"application/json"}, "Accept": {"*/*"}},
+			Body:        `{"name":"x"}`,
+			RespHeaders: map[string][]string{"Content-Type": {"application/x-protobuf"}},
+			RespBodyRaw: []byte{0x08, 0x01},
+			StatusCode:  201,
+			ContentType: "application/x-protobuf",
+			BodySize:    2,
+		},
+		StartedAt:   start,
+		CompletedAt: start.Add(250 * time.Millisecond),
+	}
+
+	har := BuildHAR([]HistoryEntry{entry}, "Gaze", "1.0.0")
+	if har.Log.Version != "1.2" || len(har.Log.Entries) != 1 {
+		t.Fatalf("unexpected HAR log: %+v", har.Log)
+	}
+
+	e := har.Log.Entries[0]
+	if e.StartedDateTime != "2024-05-01T12:00:00Z" || e.Time != 250 || e.Timings.Wait != 250 {
+		t.Errorf("unexpected timing: %s %v %+v", e.StartedDateTime, e.Time, e.Timings)
+	}
+	if len(e.Request.Headers) != 2 || e.Request.Headers[0].Name != "Accept" {
+		t.Errorf("expected headers sorted by name, got %+v", e.Request.Headers)
+	}
+	if len(e.Request.QueryString) != 2 || e.Request.QueryString[0].Name != "page" || e.Request.QueryString[0].Value != "2" {
+		t.Errorf("unexpected query string: %+v", e.Request.QueryString)
+	}
+	if e.Request.PostData == nil || e.Request.PostData.MimeType != "application/json" {
+		t.Errorf("unexpected post data: %+v", e.Request.PostData)
+	}
+	if e.Response.Status != 201 || e.Response.StatusText != "Created" {
+		t.Errorf("unexpected status: %d %s", e.Response.Status, e.Response.StatusText)
+	}
+	if e.Response.Content.Encoding != "base64" || e.Response.Content.Text != "CAE=" {
+		t.Errorf("expected base64 binary body, got %+v", e.Response.Content)
+	}
+
+	data, err := json.Marshal(har)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := decoded["log"].(map[string]interface{})["entries"]; !ok {
+		t.Error("expected log.entries in encoded HAR")
+	}
+}
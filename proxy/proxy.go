@@ -94,6 +94,7 @@ type ProxyServer struct {
 	mitmEnabled        bool             // HTTPS Decrypt
 	wsEnabled          bool             // WebSocket support
 	mitmBypassPatterns []string
+	captureFilter      CaptureFilter // Which requests are logged/emitted
 	certMgr            *CertManager
 
 	upLimiter   *rate.Limiter
@@ -109,6 +110,9 @@ type ProxyServer struct {
 	// Breakpoint interception
 	bp breakpointState
 
+	// history retains recently completed requests for export
+	history requestHistory
+
 	// reqBodyCache stores captured request bodies keyed by request ID.
 	// Written by the request TransparentReadCloser, read by the response one.
 	reqBodyCache   map[string]cachedReqBody
@@ -624,7 +628,15 @@ func (p *ProxyServer) Start(port int, onRequest func(RequestLog)) error {
 		p.mu.Unlock()
 		return fmt.Errorf("proxy already running")
 	}
-	p.OnRequest = onRequest
+	p.OnRequest = func(req RequestLog) {
+		if !p.shouldCapture(req) {
+			return
+		}
+		p.history.record(req, time.Now())
+		if onRequest != nil {
+			onRequest(req)
+		}
+	}
 	p.port = port
 	p.hasDecryptedHTTPS = false // Reset on start
 
@@ -0,0 +1,177 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// maxReplayBodySize caps how much of a replayed response is read
+const maxReplayBodySize = 32 * 1024 * 1024
+
+// replayDropHeaders are recomputed by the client for the new request
+var replayDropHeaders = []string{
+	"Content-Length", "Transfer-Encoding", "Connection", "Proxy-Connection",
+	"Keep-Alive", "Upgrade", "Te", "Trailer", "Host",
+}
+
+// Replay re-issues a captured request, possibly after its headers or body were
+// edited, and returns the new exchange as a RequestLog. The body is taken from
+// ReqBodyRaw when present and Body otherwise; it is re-compressed to match the
+// request's Content-Encoding and Content-Length is recalculated. Redirects are
+// returned as-is, like the proxy shows them.
+func (p *ProxyServer) Replay(ctx context.Context, req RequestLog) (RequestLog, error) {
+	method := strings.ToUpper(strings.TrimSpace(req.Method))
+	if method == "" {
+		method = http.MethodGet
+	}
+	if method == "CONNECT" || method == "WS" || req.IsWs {
+		return RequestLog{}, fmt.Errorf("%s requests cannot be replayed", method)
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return RequestLog{}, fmt.Errorf("invalid request URL: %q", req.URL)
+	}
+
+	headers := http.Header(copyHeader(req.Headers))
+	if headers == nil {
+		headers = http.Header{}
+	}
+	host := headers.Get("Host")
+	for _, h := range replayDropHeaders {
+		headers.Del(h)
+	}
+
+	body := []byte(req.Body)
+	if len(req.ReqBodyRaw) > 0 {
+		body = req.ReqBodyRaw
+	}
+	if len(body) > 0 {
+		if encoding := headers.Get("Content-Encoding"); encoding != "" {
+			encoded, err := encodeBody(body, encoding)
+			if err != nil {
+				// Send the body as plain bytes rather than mislabelled
+				headers.Del("Content-Encoding")
+			} else {
+				body = encoded
+			}
+		}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return RequestLog{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header = headers
+	if len(body) == 0 {
+		httpReq.Body = http.NoBody
+		httpReq.ContentLength = 0
+	}
+	if host != "" {
+		httpReq.Host = host
+	}
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			DisableCompression:  true, // Keep the response encoding as the app would see it
+			ForceAttemptHTTP2:   true,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	start := time.Now()
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return RequestLog{}, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxReplayBodySize))
+	if err != nil {
+		return RequestLog{}, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	sentHeaders := copyHeader(httpReq.Header)
+	if httpReq.ContentLength > 0 {
+		sentHeaders.Set("Content-Length", fmt.Sprint(httpReq.ContentLength))
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	result := RequestLog{
+		Id:          fmt.Sprintf("replay-%d", start.UnixNano()),
+		Time:        start.Format("2006-01-02 15:04:05"),
+		Method:      method,
+		URL:         u.String(),
+		IsHTTPS:     u.Scheme == "https",
+		Headers:     sentHeaders,
+		Body:        req.Body,
+		ReqBodyRaw:  req.ReqBodyRaw,
+		RespHeaders: copyHeader(resp.Header),
+		StatusCode:  resp.StatusCode,
+		ContentType: contentType,
+		BodySize:    int64(len(data)),
+	}
+	analyzed := p.analyzeBodyFull(data, resp.Header.Get("Content-Encoding"), contentType)
+	result.RespBody = analyzed.Text
+	if analyzed.IsBinary {
+		result.RespBodyRaw = analyzed.RawBytes
+	}
+	return result, nil
+}
+
+// encodeBody compresses a body for the given Content-Encoding
+func encodeBody(body []byte, encoding string) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		if err != nil {
+			return nil, err
+		}
+		w = fw
+	case "br":
+		w = brotli.NewWriter(&buf)
+	case "zstd":
+		zw, err := zstd.NewWriter(&buf)
+		if err != nil {
+			return nil, err
+		}
+		w = zw
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+
+	if _, err := w.Write(body); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
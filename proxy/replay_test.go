@@ -0,0 +1,100 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestReplay_ReencodesBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("expected gzip request, got %q", r.Header.Get("Content-Encoding"))
+		}
+		if r.ContentLength != int64(len(raw)) {
+			t.Errorf("Content-Length %d does not match body size %d", r.ContentLength, len(raw))
+		}
+		gr, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			t.Errorf("request body is not gzip: %v", err)
+			return
+		}
+		body, _ := io.ReadAll(gr)
+		if string(body) != `{"name":"edited"}` {
+			t.Errorf("unexpected request body %q", body)
+		}
+		if r.Header.Get("X-Token") != "abc" {
+			t.Errorf("expected custom header to be forwarded")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusCreated)
+		gw := gzip.NewWriter(w)
+		gw.Write([]byte(`{"ok":true}`))
+		gw.Close()
+	}))
+	defer server.Close()
+
+	p := &ProxyServer{}
+	result, err := p.Replay(context.Background(), RequestLog{
+		Method: "post",
+		URL:    server.URL + "/items",
+		Headers: map[string][]string{
+			"Content-Encoding": {"gzip"},
+			"Content-Length":   {"999"}, // stale length from the original capture
+			"Content-Type":     {"application/json"},
+			"X-Token":          {"abc"},
+		},
+		Body: `{"name":"edited"}`,
+	})
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if result.StatusCode != http.StatusCreated || result.RespBody != `{"ok":true}` {
+		t.Errorf("unexpected response: %d %q", result.StatusCode, result.RespBody)
+	}
+	if result.Method != "POST" || result.Id == "" {
+		t.Errorf("unexpected log: %+v", result)
+	}
+	if n, _ := strconv.Atoi(http.Header(result.Headers).Get("Content-Length")); n == 0 || n == 999 {
+		t.Errorf("expected recalculated Content-Length, got %v", result.Headers["Content-Length"])
+	}
+}
+
+func TestReplay_Rejects(t *testing.T) {
+	p := &ProxyServer{}
+	for _, req := range []RequestLog{
+		{Method: "CONNECT", URL: "https://example.com:443"},
+		{Method: "WS", URL: "wss://example.com/socket"},
+		{Method: "GET", URL: "ftp://example.com/file"},
+		{Method: "GET", URL: "/relative"},
+	} {
+		if _, err := p.Replay(context.Background(), req); err == nil {
+			t.Errorf("expected error replaying %s %s", req.Method, req.URL)
+		}
+	}
+}
+
+func TestEncodeBody_RoundTrip(t *testing.T) {
+	p := &ProxyServer{}
+	body := []byte("hello replay hello replay hello replay")
+	for _, enc := range []string{"gzip", "deflate", "br", "zstd"} {
+		encoded, err := encodeBody(body, enc)
+		if err != nil {
+			t.Fatalf("%s: %v", enc, err)
+		}
+		if got := p.analyzeBody(encoded, enc, "text/plain"); got != string(body) {
+			t.Errorf("%s round trip = %q", enc, got)
+		}
+	}
+	if _, err := encodeBody(body, "compress"); err == nil {
+		t.Error("expected error for unsupported encoding")
+	}
+}
@@ -1,10 +1,9 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	net_url "net/url"
 	"os"
@@ -348,18 +347,20 @@ func (a *App) SetupProxyForDevice(deviceId string, port int) error {
 		return fmt.Errorf("no device specified")
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
 	// 1. Setup adb reverse: device's localhost:port -> host's localhost:port
-	reverseCmd := a.newAdbCommand(nil, "-s", deviceId, "reverse", "tcp:"+strconv.Itoa(port), "tcp:"+strconv.Itoa(port))
-	if out, err := reverseCmd.CombinedOutput(); err != nil {
+	tcp := "tcp:" + strconv.Itoa(port)
+	if out, err := a.runAdbCombined(ctx, "-s", deviceId, "reverse", tcp, tcp); err != nil {
 		return fmt.Errorf("adb reverse failed: %v, output: %s", err, string(out))
 	}
 
 	// 2. Set device proxy to localhost (traffic goes through adb tunnel)
-	proxyCmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "settings", "put", "global", "http_proxy", fmt.Sprintf("127.0.0.1:%d", port))
-	if out, err := proxyCmd.CombinedOutput(); err != nil {
+	if _, err := a.putDeviceProxy(ctx, deviceId, fmt.Sprintf("127.0.0.1:%d", port)); err != nil {
 		// Try to clean up reverse on failure
-		a.newAdbCommand(nil, "-s", deviceId, "reverse", "--remove", "tcp:"+strconv.Itoa(port)).Run()
-		return fmt.Errorf("set proxy failed: %v, output: %s", err, string(out))
+		_, _ = a.runAdbCombined(ctx, "-s", deviceId, "reverse", "--remove", tcp)
+		return err
 	}
 
 	return nil
@@ -371,11 +372,16 @@ func (a *App) CleanupProxyForDevice(deviceId string, port int) error {
 		return nil
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
 	// 1. Clear device proxy
-	a.newAdbCommand(nil, "-s", deviceId, "shell", "settings", "put", "global", "http_proxy", ":0").Run()
+	if _, err := a.putDeviceProxy(ctx, deviceId, ":0"); err != nil {
+		a.Log("Failed to clear device proxy on %s: %v", deviceId, err)
+	}
 
 	// 2. Remove adb reverse
-	a.newAdbCommand(nil, "-s", deviceId, "reverse", "--remove", "tcp:"+strconv.Itoa(port)).Run()
+	_, _ = a.runAdbCombined(ctx, "-s", deviceId, "reverse", "--remove", "tcp:"+strconv.Itoa(port))
 
 	return nil
 }
@@ -445,12 +451,28 @@ func (a *App) GetMITMBypassPatterns() []string {
 	return proxy.GetProxy().GetMITMBypassPatterns()
 }
 
+// SetProxyCaptureFilter limits which requests are logged by host pattern and
+// method. Non-matching requests are still proxied, just not emitted.
+func (a *App) SetProxyCaptureFilter(includeHosts, excludeHosts, methods []string) {
+	proxy.GetProxy().SetCaptureFilter(proxy.CaptureFilter{
+		IncludeHosts: includeHosts,
+		ExcludeHosts: excludeHosts,
+		Methods:      methods,
+	})
+}
+
+// GetProxyCaptureFilter returns the active capture filter
+func (a *App) GetProxyCaptureFilter() proxy.CaptureFilter {
+	return proxy.GetProxy().GetCaptureFilter()
+}
+
 // GetProxySettings returns the current proxy settings
 func (a *App) GetProxySettings() map[string]interface{} {
 	return map[string]interface{}{
 		"wsEnabled":      proxy.GetProxy().IsWSEnabled(),
 		"mitmEnabled":    proxy.GetProxy().IsMITMEnabled(),
 		"bypassPatterns": proxy.GetProxy().GetMITMBypassPatterns(),
+		"logBufferSize":  proxy.GetProxy().HistorySize(),
 	}
 }
 
@@ -536,7 +558,7 @@ func evaluateConditionsLocal(conditions []MockCondition, headers map[string]stri
 	for _, cond := range conditions {
 		switch cond.Type {
 		case "header":
-			val, exists := headers[cond.Key]
+			val, exists := lookupHeaderLocal(headers, cond.Key)
 			if !matchOperatorLocal(cond.Operator, val, cond.Value, exists) {
 				return false
 			}
@@ -570,6 +592,19 @@ func evaluateConditionsLocal(conditions []MockCondition, headers map[string]stri
 	return true
 }
 
+// lookupHeaderLocal finds a header case-insensitively, preferring an exact key match
+func lookupHeaderLocal(headers map[string]string, key string) (string, bool) {
+	if val, ok := headers[key]; ok {
+		return val, true
+	}
+	for k, v := range headers {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
 // matchOperatorLocal applies a match operator to compare values.
 func matchOperatorLocal(operator, actual, expected string, exists bool) bool {
 	switch operator {
@@ -617,86 +652,119 @@ func getCachedRegexpLocal(pattern string) (*regexp.Regexp, error) {
 
 // ResendRequest sends an HTTP request with optional modifications
 // Returns the response status, headers, and body
-// Checks mock rules first, then sends actual request if no match
+// Checks mock rules first, then sends actual request if no match.
+// It is a thin wrapper over ReplayProxyRequest with flattened headers.
 func (a *App) ResendRequest(method, url string, headers map[string]string, body string) (map[string]interface{}, error) {
-	// Check for matching mock rule first (works without proxy running)
-	if mockRule := matchMockRuleLocal(method, url, headers, body); mockRule != nil {
-		// Apply mock delay
-		if mockRule.Delay > 0 {
-			time.Sleep(time.Duration(mockRule.Delay) * time.Millisecond)
-		}
-
-		// Build mock response headers
-		respHeaders := make(map[string]string)
-		for k, v := range mockRule.Headers {
-			respHeaders[k] = v
-		}
-		if respHeaders["Content-Type"] == "" {
-			respHeaders["Content-Type"] = "application/json"
-		}
-
-		return map[string]interface{}{
-			"statusCode":  mockRule.StatusCode,
-			"status":      fmt.Sprintf("%d %s", mockRule.StatusCode, http.StatusText(mockRule.StatusCode)),
-			"headers":     respHeaders,
-			"body":        mockRule.Body,
-			"bodySize":    len(mockRule.Body),
-			"duration":    int64(mockRule.Delay),
-			"contentType": respHeaders["Content-Type"],
-			"mocked":      true,
-		}, nil
+	req := proxy.RequestLog{
+		Method:  method,
+		URL:     url,
+		Headers: make(map[string][]string, len(headers)),
+		Body:    body,
+	}
+	for k, v := range headers {
+		req.Headers[http.CanonicalHeaderKey(k)] = []string{v}
 	}
 
-	// No mock match, send actual request
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+	startTime := time.Now()
+	result, err := a.ReplayProxyRequest(req)
+	if err != nil {
+		return nil, err
 	}
+	duration := time.Since(startTime).Milliseconds()
 
-	var reqBody io.Reader
-	if body != "" {
-		reqBody = bytes.NewBufferString(body)
+	// Build response headers map
+	respHeaders := make(map[string]string, len(result.RespHeaders))
+	for k, v := range result.RespHeaders {
+		respHeaders[k] = strings.Join(v, ", ")
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+	resp := map[string]interface{}{
+		"statusCode":  result.StatusCode,
+		"status":      fmt.Sprintf("%d %s", result.StatusCode, http.StatusText(result.StatusCode)),
+		"headers":     respHeaders,
+		"body":        result.RespBody,
+		"bodySize":    result.BodySize,
+		"duration":    duration,
+		"contentType": result.ContentType,
+	}
+	if result.Mocked {
+		resp["mocked"] = true
 	}
+	return resp, nil
+}
 
-	// Set headers
-	for k, v := range headers {
-		req.Header.Set(k, v)
+// serveMockRuleLocal waits out a mock rule's delay and returns the response headers it
+// answers with, defaulting Content-Type to JSON
+func serveMockRuleLocal(rule *MockRule) map[string]string {
+	if rule.Delay > 0 {
+		time.Sleep(time.Duration(rule.Delay) * time.Millisecond)
+	}
+	respHeaders := make(map[string]string)
+	for k, v := range rule.Headers {
+		respHeaders[k] = v
 	}
+	if respHeaders["Content-Type"] == "" {
+		respHeaders["Content-Type"] = "application/json"
+	}
+	return respHeaders
+}
 
-	startTime := time.Now()
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %v", err)
+// ReplayProxyRequest re-issues a captured request, with any edits the user made
+// to its headers or body, and returns the new request/response pair. A matching
+// mock rule answers instead of the real server. ResendRequest goes through here too.
+func (a *App) ReplayProxyRequest(req proxy.RequestLog) (proxy.RequestLog, error) {
+	if mocked, ok := replayFromMockRule(req); ok {
+		a.Log("Replayed %s %s -> %d (mocked)", mocked.Method, mocked.URL, mocked.StatusCode)
+		return mocked, nil
 	}
-	defer resp.Body.Close()
 
-	duration := time.Since(startTime).Milliseconds()
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
 
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
+	result, err := proxy.GetProxy().Replay(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %v", err)
+		return proxy.RequestLog{}, err
 	}
+	a.Log("Replayed %s %s -> %d", result.Method, result.URL, result.StatusCode)
+	return result, nil
+}
 
-	// Build response headers map
-	respHeaders := make(map[string]string)
-	for k, v := range resp.Header {
-		respHeaders[k] = strings.Join(v, ", ")
+// replayFromMockRule answers a replayed request from the first matching mock rule
+func replayFromMockRule(req proxy.RequestLog) (proxy.RequestLog, bool) {
+	method := strings.ToUpper(strings.TrimSpace(req.Method))
+	if method == "" {
+		method = http.MethodGet
+	}
+	headers := make(map[string]string, len(req.Headers))
+	for k, v := range req.Headers {
+		headers[k] = strings.Join(v, ", ")
+	}
+	rule := matchMockRuleLocal(method, req.URL, headers, req.Body)
+	if rule == nil {
+		return proxy.RequestLog{}, false
 	}
 
-	return map[string]interface{}{
-		"statusCode":  resp.StatusCode,
-		"status":      resp.Status,
-		"headers":     respHeaders,
-		"body":        string(respBody),
-		"bodySize":    len(respBody),
-		"duration":    duration,
-		"contentType": resp.Header.Get("Content-Type"),
-	}, nil
+	start := time.Now()
+	respHeaders := make(map[string][]string)
+	for k, v := range serveMockRuleLocal(rule) {
+		respHeaders[k] = []string{v}
+	}
+	return proxy.RequestLog{
+		Id:          fmt.Sprintf("replay-%d", start.UnixNano()),
+		Time:        start.Format("2006-01-02 15:04:05"),
+		Method:      method,
+		URL:         req.URL,
+		IsHTTPS:     strings.HasPrefix(req.URL, "https://"),
+		Headers:     req.Headers,
+		Body:        req.Body,
+		ReqBodyRaw:  req.ReqBodyRaw,
+		RespHeaders: respHeaders,
+		RespBody:    rule.Body,
+		StatusCode:  rule.StatusCode,
+		ContentType: respHeaders["Content-Type"][0],
+		BodySize:    int64(len(rule.Body)),
+		Mocked:      true,
+	}, true
 }
 
 // MockCondition defines an additional match condition for mock rules.
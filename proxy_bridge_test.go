@@ -0,0 +1,113 @@
+package main
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"Gaze/proxy"
+)
+
+// withMockRules swaps in the given mock rules for the duration of a test
+func withMockRules(t *testing.T, rules ...*MockRule) {
+	t.Helper()
+	mockRulesMu.Lock()
+	saved := mockRules
+	mockRules = make(map[string]*MockRule)
+	for _, r := range rules {
+		mockRules[r.ID] = r
+	}
+	mockRulesMu.Unlock()
+	t.Cleanup(func() {
+		mockRulesMu.Lock()
+		mockRules = saved
+		mockRulesMu.Unlock()
+	})
+}
+
+func TestReplayProxyRequest_MockRules(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer srv.Close()
+
+	withMockRules(t, &MockRule{
+		ID:         "m1",
+		URLPattern: srv.URL + "/api/*",
+		Method:     "POST",
+		StatusCode: 201,
+		Body:       `{"ok":true}`,
+		Enabled:    true,
+		Conditions: []MockCondition{{Type: "header", Key: "x-env", Operator: "equals", Value: "test"}},
+	})
+	a := newTestApp(nil)
+
+	mocked, err := a.ReplayProxyRequest(proxy.RequestLog{
+		Method:  "post",
+		URL:     srv.URL + "/api/items",
+		Headers: map[string][]string{"X-Env": {"test"}},
+		Body:    `{"name":"a"}`,
+	})
+	if err != nil {
+		t.Fatalf("ReplayProxyRequest (mocked): %v", err)
+	}
+	if !mocked.Mocked || mocked.StatusCode != 201 || mocked.RespBody != `{"ok":true}` || mocked.ContentType != "application/json" {
+		t.Errorf("mocked replay = %+v", mocked)
+	}
+	if hits.Load() != 0 {
+		t.Errorf("mocked replay reached the server %d times", hits.Load())
+	}
+
+	// A request the rule's condition rejects goes to the real server
+	real, err := a.ReplayProxyRequest(proxy.RequestLog{
+		Method:  "POST",
+		URL:     srv.URL + "/api/items",
+		Headers: map[string][]string{"X-Env": {"prod"}},
+	})
+	if err != nil {
+		t.Fatalf("ReplayProxyRequest (real): %v", err)
+	}
+	if real.Mocked || real.StatusCode != http.StatusTeapot || hits.Load() != 1 {
+		t.Errorf("real replay = %+v, hits = %d", real, hits.Load())
+	}
+
+	// ResendRequest answers from the same rule
+	resp, err := a.ResendRequest("POST", srv.URL+"/api/items", map[string]string{"X-Env": "test"}, "")
+	if err != nil || resp["mocked"] != true || resp["statusCode"] != 201 {
+		t.Errorf("ResendRequest = %v, %v", resp, err)
+	}
+}
+
+func TestResendRequestUsesReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte("echo:" + string(body)))
+		gz.Close()
+	}))
+	defer srv.Close()
+	withMockRules(t)
+	a := newTestApp(nil)
+
+	resp, err := a.ResendRequest("put", srv.URL+"/echo", map[string]string{"x-token": "abc"}, "hi")
+	if err != nil {
+		t.Fatalf("ResendRequest: %v", err)
+	}
+	if resp["statusCode"] != 200 || resp["status"] != "200 OK" || resp["body"] != "echo:hi" {
+		t.Errorf("ResendRequest = %v", resp)
+	}
+	if _, mocked := resp["mocked"]; mocked {
+		t.Error("a real response should not be marked as mocked")
+	}
+}
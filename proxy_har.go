@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"Gaze/proxy"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ExportProxyHar writes the proxy's retained requests to a HAR 1.2 file. With an
+// empty savePath a save dialog is shown; the chosen path is returned, or "" if
+// the dialog was cancelled.
+func (a *App) ExportProxyHar(savePath string) (string, error) {
+	entries := proxy.GetProxy().History()
+	if len(entries) == 0 {
+		return "", fmt.Errorf("no captured requests to export")
+	}
+
+	if savePath == "" {
+		if a.ctx == nil || a.mcpMode {
+			return "", fmt.Errorf("save path is required")
+		}
+		var err error
+		savePath, err = wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
+			DefaultFilename:  fmt.Sprintf("proxy_%s.har", time.Now().Format("20060102_150405")),
+			Title:            "Export Proxy Log as HAR",
+			Filters:          []wailsRuntime.FileFilter{{DisplayName: "HAR (*.har)", Pattern: "*.har"}},
+			DefaultDirectory: a.outputDir(OutputExports),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to open save dialog: %w", err)
+		}
+		if savePath == "" {
+			return "", nil // User cancelled
+		}
+	}
+
+	if strings.ToLower(filepath.Ext(savePath)) != ".har" {
+		savePath += ".har"
+	}
+
+	data, err := json.MarshalIndent(proxy.BuildHAR(entries, "Gaze", a.GetAppVersion()), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode HAR: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(savePath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(savePath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write HAR file: %w", err)
+	}
+
+	a.Log("Exported %d proxy requests to %s", len(entries), savePath)
+	return savePath, nil
+}
+
+// ClearProxyLog drops the requests retained for HAR export
+func (a *App) ClearProxyLog() {
+	proxy.GetProxy().ClearHistory()
+}
+
+// SetProxyLogBufferSize bounds how many completed requests the proxy retains.
+// Zero restores the default size.
+func (a *App) SetProxyLogBufferSize(size int) error {
+	if size < 0 {
+		return fmt.Errorf("buffer size must not be negative")
+	}
+	proxy.GetProxy().SetHistorySize(size)
+	return nil
+}
+
+// GetProxyLogBufferSize returns how many completed requests the proxy retains
+func (a *App) GetProxyLogBufferSize() int {
+	return proxy.GetProxy().HistorySize()
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"Gaze/pkg/cache"
+)
+
+// ========================================
+// Quick connect
+// ========================================
+
+const (
+	quickConnectMaxTargets = 10
+	// Recency weight halves every this many days since the device was last seen
+	quickConnectHalfLifeDays = 7.0
+)
+
+// QuickTarget is a wireless address worth offering for one-tap reconnect
+type QuickTarget struct {
+	Address   string  `json:"address"`
+	Serial    string  `json:"serial"`
+	Model     string  `json:"model"`
+	Brand     string  `json:"brand"`
+	LastSeen  int64   `json:"lastSeen"` // Unix seconds
+	Successes int     `json:"successes"`
+	Failures  int     `json:"failures"`
+	Score     float64 `json:"score"` // Higher is more likely the device the user wants
+}
+
+// GetQuickConnectTargets returns wireless addresses from history, most likely first.
+// Ranking favours devices seen recently and addresses that connected reliably.
+func (a *App) GetQuickConnectTargets() ([]QuickTarget, error) {
+	history := a.GetHistoryDevices()
+	var stats map[string]cache.ConnectStats
+	if a.cacheService != nil {
+		stats = a.cacheService.GetConnectStats()
+	}
+	return rankQuickTargets(history, stats, time.Now()), nil
+}
+
+// recordConnectResult counts the outcome of a manual connect for quick connect ranking
+func (a *App) recordConnectResult(address string, success bool) {
+	if a.cacheService == nil {
+		return
+	}
+	a.cacheService.RecordConnect(address, success, time.Now().Unix())
+	go a.saveSettings()
+}
+
+// rankQuickTargets merges history with connect counters and sorts by score. Only
+// host:port addresses are returned; mDNS service names can't be typed into connect.
+func rankQuickTargets(history []HistoryDevice, stats map[string]cache.ConnectStats, now time.Time) []QuickTarget {
+	byAddr := make(map[string]*QuickTarget)
+	for _, hd := range history {
+		addr := hd.WifiAddr
+		if !strings.Contains(addr, ":") {
+			addr = hd.ID
+		}
+		if !strings.Contains(addr, ":") {
+			continue
+		}
+		t, ok := byAddr[addr]
+		if !ok {
+			t = &QuickTarget{Address: addr}
+			byAddr[addr] = t
+		}
+		if hd.LastSeen >= t.LastSeen {
+			t.Serial, t.Model, t.Brand, t.LastSeen = hd.Serial, hd.Model, hd.Brand, hd.LastSeen
+		}
+	}
+	for addr, st := range stats {
+		t, ok := byAddr[addr]
+		if !ok {
+			t = &QuickTarget{Address: addr}
+			byAddr[addr] = t
+		}
+		t.Successes, t.Failures = st.Successes, st.Failures
+		if st.LastSuccess > t.LastSeen {
+			t.LastSeen = st.LastSuccess
+		}
+	}
+
+	targets := make([]QuickTarget, 0, len(byAddr))
+	for _, t := range byAddr {
+		t.Score = quickTargetScore(t, now)
+		targets = append(targets, *t)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Score != targets[j].Score {
+			return targets[i].Score > targets[j].Score
+		}
+		return targets[i].Address < targets[j].Address
+	})
+	if len(targets) > quickConnectMaxTargets {
+		targets = targets[:quickConnectMaxTargets]
+	}
+	return targets
+}
+
+// quickTargetScore is recency (1 when seen now, halving every half-life) times the
+// smoothed success rate, boosted logarithmically by how often the address worked
+func quickTargetScore(t *QuickTarget, now time.Time) float64 {
+	recency := 0.0
+	if t.LastSeen > 0 {
+		ageDays := math.Max(0, now.Sub(time.Unix(t.LastSeen, 0)).Hours()/24)
+		recency = math.Pow(0.5, ageDays/quickConnectHalfLifeDays)
+	}
+	rate := float64(t.Successes+1) / float64(t.Successes+t.Failures+2)
+	return recency * rate * math.Log2(2+float64(t.Successes))
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"Gaze/pkg/cache"
+)
+
+func TestRankQuickTargets(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	day := int64(24 * 60 * 60)
+	history := []HistoryDevice{
+		{ID: "R5CT1234ABC", Serial: "R5CT1234ABC", Model: "SM-G991B", WifiAddr: "192.168.1.20:5555", LastSeen: now.Unix() - day},
+		{ID: "192.168.1.30:5555", Serial: "PIXEL7", Model: "Pixel 7", LastSeen: now.Unix() - 30*day},
+		{ID: "emulator-5554", Serial: "emulator-5554", LastSeen: now.Unix()},
+		{ID: "adb-PIXEL8-abc._adb-tls-connect._tcp", Serial: "PIXEL8", WifiAddr: "adb-PIXEL8-abc._adb-tls-connect._tcp", LastSeen: now.Unix()},
+	}
+	stats := map[string]cache.ConnectStats{
+		"192.168.1.20:5555": {Successes: 8, Failures: 1},
+		"192.168.1.30:5555": {Successes: 1, Failures: 5},
+		"10.0.0.5:5555":     {Failures: 3, LastFailure: now.Unix()},
+	}
+
+	targets := rankQuickTargets(history, stats, now)
+
+	if len(targets) != 3 {
+		t.Fatalf("got %d targets, want 3 (USB and mDNS entries skipped): %+v", len(targets), targets)
+	}
+	if got := targets[0]; got.Address != "192.168.1.20:5555" || got.Model != "SM-G991B" || got.Successes != 8 {
+		t.Errorf("top target = %+v, want the reliable, recently seen SM-G991B", got)
+	}
+	if targets[1].Address != "192.168.1.30:5555" {
+		t.Errorf("second target = %s, want 192.168.1.30:5555", targets[1].Address)
+	}
+	// Never connected and never seen ranks last
+	if got := targets[2]; got.Address != "10.0.0.5:5555" || got.Score != 0 {
+		t.Errorf("last target = %+v, want 10.0.0.5:5555 with score 0", got)
+	}
+}
+
+func TestQuickTargetScore_RecencyAndReliability(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	fresh := &QuickTarget{LastSeen: now.Unix(), Successes: 2}
+	stale := &QuickTarget{LastSeen: now.Add(-14 * 24 * time.Hour).Unix(), Successes: 2}
+	flaky := &QuickTarget{LastSeen: now.Unix(), Successes: 2, Failures: 6}
+
+	if s := quickTargetScore(stale, now) / quickTargetScore(fresh, now); s < 0.24 || s > 0.26 {
+		t.Errorf("two half-lives should quarter the score, ratio = %v", s)
+	}
+	if quickTargetScore(flaky, now) >= quickTargetScore(fresh, now) {
+		t.Error("failures should lower the score")
+	}
+}
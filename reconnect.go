@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Gaze/pkg/cache"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	defaultReconnectCooldownSec = 30
+	defaultReconnectTimeoutSec  = 5
+	defaultReconnectMaxAttempts = 10
+
+	maxReconnectCooldownSec = 3600
+	maxReconnectTimeoutSec  = 120
+	maxReconnectAttempts    = 1000
+
+	// Backoff never waits longer than this, however many failures have piled up
+	maxReconnectBackoff = 30 * time.Minute
+)
+
+// GetReconnectSettings returns the effective wireless reconnect tuning
+func (a *App) GetReconnectSettings() ReconnectSettings {
+	r := ReconnectSettings{
+		CooldownSec: defaultReconnectCooldownSec,
+		TimeoutSec:  defaultReconnectTimeoutSec,
+		MaxAttempts: defaultReconnectMaxAttempts,
+	}
+	if a.cacheService == nil {
+		return r
+	}
+
+	stored := a.cacheService.GetReconnect()
+	if stored.CooldownSec > 0 {
+		r.CooldownSec = stored.CooldownSec
+	}
+	if stored.TimeoutSec > 0 {
+		r.TimeoutSec = stored.TimeoutSec
+	}
+	if stored.MaxAttempts > 0 {
+		r.MaxAttempts = stored.MaxAttempts
+	}
+	return r
+}
+
+// SetReconnectSettings sets the base cooldown between reconnect attempts, the timeout
+// of a single attempt and how many consecutive failures are tolerated before giving up.
+// Passing 0 for a value restores its default.
+func (a *App) SetReconnectSettings(cooldownSec, timeoutSec, maxAttempts int) (ReconnectSettings, error) {
+	limits := []struct {
+		name  string
+		value int
+		max   int
+	}{
+		{"cooldown", cooldownSec, maxReconnectCooldownSec},
+		{"timeout", timeoutSec, maxReconnectTimeoutSec},
+		{"max attempts", maxAttempts, maxReconnectAttempts},
+	}
+	for _, l := range limits {
+		if l.value < 0 || l.value > l.max {
+			return a.GetReconnectSettings(), fmt.Errorf("%s must be between 0 and %d, got %d", l.name, l.max, l.value)
+		}
+	}
+	if a.cacheService == nil {
+		return a.GetReconnectSettings(), fmt.Errorf("settings are not available")
+	}
+
+	a.cacheService.SetReconnect(cache.Reconnect{
+		CooldownSec: cooldownSec,
+		TimeoutSec:  timeoutSec,
+		MaxAttempts: maxAttempts,
+	})
+	go a.saveSettings()
+
+	r := a.GetReconnectSettings()
+	a.Log("Reconnect settings: cooldown=%ds timeout=%ds maxAttempts=%d", r.CooldownSec, r.TimeoutSec, r.MaxAttempts)
+	return r, nil
+}
+
+// GetReconnectStats returns auto-reconnect progress for a wireless address.
+// Addresses that were never retried report zero attempts.
+func (a *App) GetReconnectStats(address string) ReconnectStats {
+	a.reconnectMu.Lock()
+	defer a.reconnectMu.Unlock()
+
+	if st, ok := a.reconnectStates[address]; ok {
+		return *st
+	}
+	return ReconnectStats{Address: address}
+}
+
+// tryAutoReconnect attempts to reconnect to a wireless device if it's offline.
+// Attempts back off exponentially and stop after MaxAttempts consecutive failures.
+func (a *App) tryAutoReconnect(address string) {
+	if address == "" || (!strings.Contains(address, ":") && !strings.Contains(address, "._tcp")) {
+		return
+	}
+
+	settings := a.GetReconnectSettings()
+	if !a.claimReconnectAttempt(address, time.Now(), settings) {
+		return
+	}
+
+	go a.runReconnectAttempt(address, settings)
+}
+
+// claimReconnectAttempt reports whether an attempt is due and, if so, records it
+func (a *App) claimReconnectAttempt(address string, now time.Time, settings ReconnectSettings) bool {
+	a.reconnectMu.Lock()
+	defer a.reconnectMu.Unlock()
+
+	if a.reconnectStates == nil {
+		a.reconnectStates = make(map[string]*ReconnectStats)
+	}
+	st, ok := a.reconnectStates[address]
+	if !ok {
+		st = &ReconnectStats{Address: address}
+		a.reconnectStates[address] = st
+	}
+	if st.GaveUp || (st.NextAttempt > 0 && now.UnixMilli() < st.NextAttempt) {
+		return false
+	}
+
+	st.Attempts++
+	st.LastAttempt = now.UnixMilli()
+	// Provisional: keeps concurrent polls from retrying while this attempt runs
+	st.NextAttempt = now.Add(reconnectBackoff(settings.CooldownSec, st.Failures)).UnixMilli()
+	return true
+}
+
+func (a *App) runReconnectAttempt(address string, settings ReconnectSettings) {
+	a.Log("Auto-reconnecting to wireless device: %s", address)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(settings.TimeoutSec)*time.Second)
+	defer cancel()
+
+	out, err := a.runAdbCombined(ctx, "connect", address)
+	output := strings.TrimSpace(string(out))
+	if err == nil && !strings.Contains(output, "connected to") {
+		// adb connect exits 0 on "failed to connect" / "cannot connect"
+		err = fmt.Errorf("%s", output)
+	}
+	a.recordReconnectResult(address, time.Now(), settings, err)
+}
+
+// recordReconnectResult schedules the next attempt, or gives up after too many failures
+func (a *App) recordReconnectResult(address string, now time.Time, settings ReconnectSettings, err error) {
+	a.reconnectMu.Lock()
+	st, ok := a.reconnectStates[address]
+	if !ok {
+		a.reconnectMu.Unlock()
+		return
+	}
+
+	if err == nil {
+		st.Failures = 0
+		st.LastError = ""
+		st.NextAttempt = now.Add(reconnectBackoff(settings.CooldownSec, 0)).UnixMilli()
+		a.reconnectMu.Unlock()
+		return
+	}
+
+	st.Failures++
+	st.LastError = err.Error()
+	gaveUp := false
+	if st.Failures >= settings.MaxAttempts {
+		st.GaveUp = true
+		st.NextAttempt = 0
+		gaveUp = true
+	} else {
+		st.NextAttempt = now.Add(reconnectBackoff(settings.CooldownSec, st.Failures)).UnixMilli()
+	}
+	stats := *st
+	a.reconnectMu.Unlock()
+
+	if !gaveUp {
+		LogDebug("device").Str("address", address).Int("failures", stats.Failures).Err(err).Msg("Auto-reconnect failed")
+		return
+	}
+
+	LogWarn("device").Str("address", address).Int("failures", stats.Failures).Str("lastError", stats.LastError).Msg("Giving up auto-reconnect")
+	if !a.mcpMode && a.ctx != nil {
+		wailsRuntime.EventsEmit(a.ctx, "wireless-give-up", stats)
+	}
+}
+
+// resetReconnectState clears backoff and give-up state, e.g. after a manual connect
+func (a *App) resetReconnectState(address string) {
+	a.reconnectMu.Lock()
+	delete(a.reconnectStates, address)
+	a.reconnectMu.Unlock()
+}
+
+// reconnectBackoff is cooldown * 2^failures, capped at maxReconnectBackoff
+func reconnectBackoff(cooldownSec, failures int) time.Duration {
+	d := time.Duration(cooldownSec) * time.Second
+	for i := 0; i < failures && d < maxReconnectBackoff; i++ {
+		d *= 2
+	}
+	if d > maxReconnectBackoff {
+		d = maxReconnectBackoff
+	}
+	return d
+}
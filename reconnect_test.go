@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReconnectBackoff(t *testing.T) {
+	if got := reconnectBackoff(30, 0); got != 30*time.Second {
+		t.Errorf("no failures: got %v", got)
+	}
+	if got := reconnectBackoff(30, 3); got != 240*time.Second {
+		t.Errorf("3 failures: got %v", got)
+	}
+	if got := reconnectBackoff(30, 100); got != maxReconnectBackoff {
+		t.Errorf("backoff should be capped, got %v", got)
+	}
+}
+
+func TestReconnectGivesUpAfterMaxAttempts(t *testing.T) {
+	app := newTestApp(nil)
+	settings := ReconnectSettings{CooldownSec: 10, TimeoutSec: 1, MaxAttempts: 3}
+	addr := "192.168.1.20:5555"
+	now := time.Now()
+
+	for i := 0; i < settings.MaxAttempts; i++ {
+		if !app.claimReconnectAttempt(addr, now, settings) {
+			t.Fatalf("attempt %d should be due", i+1)
+		}
+		if app.claimReconnectAttempt(addr, now, settings) {
+			t.Fatalf("attempt %d: a second claim inside the cooldown should be refused", i+1)
+		}
+		app.recordReconnectResult(addr, now, settings, errors.New("failed to connect"))
+
+		stats := app.GetReconnectStats(addr)
+		if i < settings.MaxAttempts-1 {
+			wantNext := now.Add(reconnectBackoff(10, i+1)).UnixMilli()
+			if stats.NextAttempt != wantNext {
+				t.Errorf("attempt %d: next = %d, want %d", i+1, stats.NextAttempt, wantNext)
+			}
+			now = time.UnixMilli(stats.NextAttempt)
+		}
+	}
+
+	stats := app.GetReconnectStats(addr)
+	if !stats.GaveUp || stats.Attempts != 3 || stats.Failures != 3 || stats.LastError != "failed to connect" {
+		t.Errorf("unexpected stats after giving up: %+v", stats)
+	}
+	if app.claimReconnectAttempt(addr, now.Add(24*time.Hour), settings) {
+		t.Error("no attempts should be made after giving up")
+	}
+
+	app.resetReconnectState(addr)
+	if !app.claimReconnectAttempt(addr, now, settings) {
+		t.Error("reset should allow attempts again")
+	}
+}
+
+func TestReconnectSuccessResetsFailures(t *testing.T) {
+	app := newTestApp(nil)
+	settings := ReconnectSettings{CooldownSec: 10, TimeoutSec: 1, MaxAttempts: 5}
+	addr := "192.168.1.20:5555"
+	now := time.Now()
+
+	app.claimReconnectAttempt(addr, now, settings)
+	app.recordReconnectResult(addr, now, settings, errors.New("timeout"))
+	now = now.Add(time.Hour)
+	app.claimReconnectAttempt(addr, now, settings)
+	app.recordReconnectResult(addr, now, settings, nil)
+
+	stats := app.GetReconnectStats(addr)
+	if stats.Failures != 0 || stats.LastError != "" || stats.Attempts != 2 {
+		t.Errorf("unexpected stats after success: %+v", stats)
+	}
+}
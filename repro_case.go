@@ -0,0 +1,548 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// Reproduction Case Export
+// ========================================
+
+// Limits that keep a reproduction case small enough to attach to a bug report
+const (
+	reproMaxPackages     = 20
+	reproMaxScreenshots  = 30
+	reproMaxErrors       = 50
+	reproScreenshotWidth = 720
+)
+
+// ReproAppVersion is the installed version of a package seen during the session
+type ReproAppVersion struct {
+	Package     string `json:"package"`
+	VersionName string `json:"versionName,omitempty"`
+	VersionCode string `json:"versionCode,omitempty"`
+}
+
+// ExportReproductionCase bundles everything needed to reproduce a session into one zip:
+// the touch script (JSON plus a standalone shell script), a device spec sheet, installed
+// versions of the apps involved, the session's logcat, screenshots at bookmarks and a
+// README. If savePath is empty, a save dialog is shown; cancelling it returns an empty path.
+func (a *App) ExportReproductionCase(sessionID, savePath string) (string, error) {
+	if a.eventStore == nil {
+		return "", fmt.Errorf("event store not initialized")
+	}
+	session, err := a.eventStore.GetSession(sessionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return "", fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	if savePath == "" {
+		if a.ctx == nil || a.mcpMode {
+			return "", fmt.Errorf("save path is required")
+		}
+
+		safeName := strings.ReplaceAll(session.Name, " ", "_")
+		safeName = strings.ReplaceAll(safeName, "/", "_")
+		if safeName == "" {
+			safeName = "session"
+		}
+		ts := time.UnixMilli(session.StartTime).Format("2006-01-02")
+
+		savePath, err = wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
+			DefaultFilename: fmt.Sprintf("%s_%s_repro.zip", safeName, ts),
+			Title:           "Export Reproduction Case",
+			Filters: []wailsRuntime.FileFilter{
+				{DisplayName: "Zip Archive (*.zip)", Pattern: "*.zip"},
+			},
+			DefaultDirectory: a.outputDir(OutputExports),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to open save dialog: %w", err)
+		}
+		if savePath == "" {
+			return "", nil // User cancelled
+		}
+	}
+
+	if strings.ToLower(filepath.Ext(savePath)) != ".zip" {
+		savePath += ".zip"
+	}
+	if err := os.MkdirAll(filepath.Dir(savePath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := a.writeReproductionCase(session, savePath); err != nil {
+		os.Remove(savePath)
+		return "", err
+	}
+	return savePath, nil
+}
+
+// reproCase collects the parts of a reproduction case before they are written out
+type reproCase struct {
+	session     *DeviceSession
+	events      []UnifiedEvent
+	bookmarks   []Bookmark
+	script      TouchScript
+	device      *DeviceInfo
+	apps        []ReproAppVersion
+	screenshots []string // Archive names, in bookmark order
+	logLines    int
+	notes       []string // Things that could not be included
+}
+
+func (a *App) writeReproductionCase(session *DeviceSession, outputPath string) error {
+	LogInfo("repro_case").Str("sessionId", session.ID).Str("path", outputPath).Msg("Starting reproduction case export")
+
+	a.eventStore.Flush()
+
+	events, err := a.eventStore.ExportSessionEvents(session.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get events: %w", err)
+	}
+	bookmarks, err := a.eventStore.GetBookmarks(session.ID)
+	if err != nil {
+		LogWarn("repro_case").Err(err).Msg("Failed to get bookmarks, skipping")
+	}
+
+	rc := &reproCase{session: session, events: events, bookmarks: bookmarks}
+	rc.script = touchScriptFromEvents(session, events)
+
+	online := a.isDeviceOnline(session.DeviceID)
+	if online {
+		info, err := a.GetDeviceInfo(session.DeviceID)
+		if err != nil {
+			rc.notes = append(rc.notes, fmt.Sprintf("Device spec unavailable: %v", err))
+		} else {
+			rc.device = &info
+			rc.script.DeviceModel = info.Model
+			rc.script.Resolution = info.Resolution
+		}
+	} else {
+		rc.notes = append(rc.notes, fmt.Sprintf("Device %s was not connected at export time; device spec and app versions are missing.", session.DeviceID))
+	}
+
+	packages := reproPackages(events)
+	if len(packages) > reproMaxPackages {
+		rc.notes = append(rc.notes, fmt.Sprintf("Only the first %d of %d packages were looked up.", reproMaxPackages, len(packages)))
+		packages = packages[:reproMaxPackages]
+	}
+	for _, pkg := range packages {
+		v := ReproAppVersion{Package: pkg}
+		if online {
+			if out, _, err := a.runAdb(nil, "-s", session.DeviceID, "shell", "dumpsys", "package", pkg); err == nil {
+				v.VersionName, v.VersionCode = parsePackageVersion(string(out))
+			}
+		}
+		rc.apps = append(rc.apps, v)
+	}
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+
+	if err := writeZipJSON(w, "script.json", rc.script); err != nil {
+		return err
+	}
+	if err := writeZipBytes(w, "script.sh", []byte(renderTouchScriptShell(rc.script))); err != nil {
+		return err
+	}
+	if rc.device != nil {
+		if err := writeZipJSON(w, "device.json", rc.device); err != nil {
+			return err
+		}
+	}
+	if err := writeZipJSON(w, "apps.json", rc.apps); err != nil {
+		return err
+	}
+
+	logcat, lines := reproLogcat(events)
+	rc.logLines = lines
+	if err := writeZipBytes(w, "logcat.txt", logcat); err != nil {
+		return err
+	}
+
+	if err := a.writeReproScreenshots(w, rc); err != nil {
+		return err
+	}
+
+	if err := writeZipBytes(w, "README.md", []byte(rc.readme())); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to finalize zip: %w", err)
+	}
+
+	LogInfo("repro_case").
+		Str("sessionId", session.ID).
+		Int("touchEvents", len(rc.script.Events)).
+		Int("logLines", rc.logLines).
+		Int("screenshots", len(rc.screenshots)).
+		Msg("Reproduction case exported")
+	return nil
+}
+
+// isDeviceOnline reports whether the device is connected and authorized
+func (a *App) isDeviceOnline(deviceId string) bool {
+	d, err := a.GetDevice(deviceId)
+	return err == nil && d.State == "device"
+}
+
+// writeReproScreenshots stores one image per bookmark. Frames come from the session
+// video when ffmpeg is available, otherwise the latest screenshot referenced by an
+// event at or before the bookmark is used.
+func (a *App) writeReproScreenshots(w *zip.Writer, rc *reproCase) error {
+	if len(rc.bookmarks) == 0 {
+		return nil
+	}
+
+	type shot struct {
+		offset int64
+		path   string
+	}
+	var eventShots []shot
+	for i := range rc.events {
+		if path := reportScreenshotPath(rc.events[i].Data); path != "" {
+			eventShots = append(eventShots, shot{rc.events[i].RelativeTime, path})
+		}
+	}
+
+	var video *VideoService
+	if rc.session.VideoPath != "" {
+		if svc := a.getVideoService(); svc != nil && svc.IsAvailable() {
+			video = svc
+		}
+	}
+
+	missing := 0
+	for i, bm := range rc.bookmarks {
+		if len(rc.screenshots) >= reproMaxScreenshots {
+			rc.notes = append(rc.notes, fmt.Sprintf("Only the first %d bookmark screenshots were included.", reproMaxScreenshots))
+			break
+		}
+
+		var data []byte
+		ext := ".jpg"
+		if video != nil {
+			if t := bm.RelativeTime - rc.session.VideoOffset; t >= 0 {
+				if frame, err := video.ExtractFrame(rc.session.VideoPath, t, reproScreenshotWidth); err == nil {
+					data = frame.Data
+				} else {
+					LogWarn("repro_case").Err(err).Str("bookmark", bm.ID).Msg("Failed to extract video frame")
+				}
+			}
+		}
+		if data == nil {
+			idx := sort.Search(len(eventShots), func(k int) bool { return eventShots[k].offset > bm.RelativeTime }) - 1
+			if idx >= 0 {
+				if b, err := os.ReadFile(eventShots[idx].path); err == nil {
+					data = b
+					ext = strings.ToLower(filepath.Ext(eventShots[idx].path))
+				}
+			}
+		}
+		if data == nil {
+			missing++
+			continue
+		}
+
+		name := fmt.Sprintf("screenshots/bookmark_%02d_%dms%s", i+1, bm.RelativeTime, ext)
+		if err := writeZipBytes(w, name, data); err != nil {
+			return err
+		}
+		rc.screenshots = append(rc.screenshots, name)
+	}
+	if missing > 0 {
+		rc.notes = append(rc.notes, fmt.Sprintf("%d bookmark(s) have no screenshot (no session video or event screenshot).", missing))
+	}
+	return nil
+}
+
+// touchScriptFromEvents converts the session's recorded taps, long presses and swipes
+// into a TouchScript. Timestamps are relative to the first touch so playback starts
+// right away; key presses are left out because scripts cannot replay them.
+func touchScriptFromEvents(session *DeviceSession, events []UnifiedEvent) TouchScript {
+	script := TouchScript{
+		Name:      session.Name,
+		DeviceID:  session.DeviceID,
+		CreatedAt: time.Now().Format(time.RFC3339),
+		Events:    []TouchEvent{},
+	}
+
+	var first int64 = -1
+	for i := range events {
+		e := &events[i]
+		if e.Source != SourceTouch || (e.Type != "touch" && e.Type != "gesture") {
+			continue
+		}
+		var data struct {
+			Action   string `json:"action"`
+			X        int    `json:"x"`
+			Y        int    `json:"y"`
+			X2       int    `json:"x2"`
+			Y2       int    `json:"y2"`
+			Duration int64  `json:"duration"`
+		}
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			continue
+		}
+
+		te := TouchEvent{X: data.X, Y: data.Y, Duration: int(data.Duration)}
+		switch data.Action {
+		case "tap", "":
+			te.Type = "tap"
+			te.Duration = 0
+		case "long_press":
+			te.Type = "long_press"
+		case "swipe":
+			te.Type = "swipe"
+			te.X2, te.Y2 = data.X2, data.Y2
+		default:
+			continue
+		}
+
+		if first < 0 {
+			first = e.RelativeTime
+		}
+		te.Timestamp = e.RelativeTime - first
+		script.Events = append(script.Events, te)
+	}
+	return script
+}
+
+// reproPackages returns the packages referenced by event data, sorted
+func reproPackages(events []UnifiedEvent) []string {
+	seen := make(map[string]bool)
+	add := func(fields map[string]interface{}) {
+		if pkg, ok := fields["packageName"].(string); ok && pkg != "" {
+			seen[pkg] = true
+		}
+	}
+	for i := range events {
+		if len(events[i].Data) == 0 {
+			continue
+		}
+		var obj map[string]interface{}
+		if err := json.Unmarshal(events[i].Data, &obj); err == nil {
+			add(obj)
+			continue
+		}
+		// Aggregated logcat events carry an array of entries
+		var arr []map[string]interface{}
+		if err := json.Unmarshal(events[i].Data, &arr); err == nil {
+			for _, entry := range arr {
+				add(entry)
+			}
+		}
+	}
+
+	packages := make([]string, 0, len(seen))
+	for pkg := range seen {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+	return packages
+}
+
+var (
+	versionNameRegex = regexp.MustCompile(`\bversionName=(\S+)`)
+	versionCodeRegex = regexp.MustCompile(`\bversionCode=(\d+)`)
+)
+
+// parsePackageVersion extracts versionName and versionCode from dumpsys package output
+func parsePackageVersion(output string) (versionName, versionCode string) {
+	if m := versionNameRegex.FindStringSubmatch(output); m != nil {
+		versionName = m[1]
+	}
+	if m := versionCodeRegex.FindStringSubmatch(output); m != nil {
+		versionCode = m[1]
+	}
+	return versionName, versionCode
+}
+
+// reproLogcat renders the session's logcat events as raw lines prefixed with their offset
+func reproLogcat(events []UnifiedEvent) ([]byte, int) {
+	var b strings.Builder
+	lines := 0
+	for i := range events {
+		e := &events[i]
+		if e.Source != SourceLogcat {
+			continue
+		}
+		offset := formatReportOffset(e.RelativeTime)
+
+		var entries []LogcatData
+		if err := json.Unmarshal(e.Data, &entries); err != nil {
+			var single LogcatData
+			if json.Unmarshal(e.Data, &single) == nil && single.Raw != "" {
+				entries = []LogcatData{single}
+			}
+		}
+		written := false
+		for _, entry := range entries {
+			if entry.Raw == "" {
+				continue
+			}
+			fmt.Fprintf(&b, "[%s] %s\n", offset, entry.Raw)
+			lines++
+			written = true
+		}
+		if !written {
+			fmt.Fprintf(&b, "[%s] %s\n", offset, e.Title)
+			lines++
+		}
+	}
+	return []byte(b.String()), lines
+}
+
+func (rc *reproCase) readme() string {
+	var b strings.Builder
+	s := rc.session
+
+	name := s.Name
+	if name == "" {
+		name = s.ID
+	}
+	fmt.Fprintf(&b, "# Reproduction case: %s\n\n", name)
+	fmt.Fprintf(&b, "- Session: `%s`\n", s.ID)
+	fmt.Fprintf(&b, "- Device: `%s`\n", s.DeviceID)
+	fmt.Fprintf(&b, "- Started: %s\n", time.UnixMilli(s.StartTime).Format("2006-01-02 15:04:05"))
+	if s.EndTime > 0 {
+		fmt.Fprintf(&b, "- Duration: %s\n", formatReportOffset(s.EndTime-s.StartTime))
+	}
+	fmt.Fprintf(&b, "- Status: %s\n", s.Status)
+	fmt.Fprintf(&b, "- Events: %d\n", len(rc.events))
+
+	if d := rc.device; d != nil {
+		b.WriteString("\n## Device\n\n")
+		fmt.Fprintf(&b, "| Property | Value |\n|---|---|\n")
+		for _, row := range [][2]string{
+			{"Model", d.Model},
+			{"Brand", d.Brand},
+			{"Manufacturer", d.Manufacturer},
+			{"Android", d.AndroidVer},
+			{"SDK", d.SDK},
+			{"ABI", d.ABI},
+			{"Resolution", d.Resolution},
+			{"Density", d.Density},
+			{"CPU", d.CPU},
+			{"Memory", d.Memory},
+		} {
+			if row[1] != "" {
+				fmt.Fprintf(&b, "| %s | %s |\n", row[0], row[1])
+			}
+		}
+	}
+
+	if len(rc.apps) > 0 {
+		b.WriteString("\n## Apps\n\n")
+		for _, app := range rc.apps {
+			version := app.VersionName
+			if app.VersionCode != "" {
+				version = fmt.Sprintf("%s (%s)", version, app.VersionCode)
+			}
+			if strings.TrimSpace(version) == "" {
+				version = "unknown"
+			}
+			fmt.Fprintf(&b, "- `%s` %s\n", app.Package, version)
+		}
+	}
+
+	b.WriteString("\n## Steps\n\n")
+	if len(rc.script.Events) == 0 {
+		b.WriteString("No touch input was recorded in this session.\n")
+	} else {
+		b.WriteString("Replay with `./script.sh <device serial>` or import `script.json` into Gaze.\n\n")
+		for i, e := range rc.script.Events {
+			fmt.Fprintf(&b, "%d. %s ", i+1, formatReportOffset(e.Timestamp))
+			switch e.Type {
+			case "swipe":
+				fmt.Fprintf(&b, "swipe (%d, %d) → (%d, %d)\n", e.X, e.Y, e.X2, e.Y2)
+			case "long_press":
+				fmt.Fprintf(&b, "long press (%d, %d) for %dms\n", e.X, e.Y, e.Duration)
+			default:
+				fmt.Fprintf(&b, "tap (%d, %d)\n", e.X, e.Y)
+			}
+		}
+	}
+
+	var errors []*UnifiedEvent
+	for i := range rc.events {
+		if lvl := rc.events[i].Level; lvl == LevelError || lvl == LevelFatal {
+			errors = append(errors, &rc.events[i])
+		}
+	}
+	if len(errors) > 0 {
+		b.WriteString("\n## Errors\n\n")
+		for i, e := range errors {
+			if i >= reproMaxErrors {
+				fmt.Fprintf(&b, "- … and %d more\n", len(errors)-reproMaxErrors)
+				break
+			}
+			fmt.Fprintf(&b, "- %s [%s] %s\n", formatReportOffset(e.RelativeTime), e.Type, e.Title)
+		}
+	}
+
+	if len(rc.bookmarks) > 0 {
+		b.WriteString("\n## Bookmarks\n\n")
+		for _, bm := range rc.bookmarks {
+			fmt.Fprintf(&b, "- %s %s\n", formatReportOffset(bm.RelativeTime), bm.Label)
+		}
+	}
+
+	b.WriteString("\n## Contents\n\n")
+	b.WriteString("- `script.json` – touch script (Gaze format)\n")
+	b.WriteString("- `script.sh` – the same script as plain adb commands\n")
+	if rc.device != nil {
+		b.WriteString("- `device.json` – full device spec\n")
+	}
+	b.WriteString("- `apps.json` – versions of the apps involved\n")
+	fmt.Fprintf(&b, "- `logcat.txt` – %d logcat line(s) from the session\n", rc.logLines)
+	for _, name := range rc.screenshots {
+		fmt.Fprintf(&b, "- `%s`\n", name)
+	}
+
+	if len(rc.notes) > 0 {
+		b.WriteString("\n## Notes\n\n")
+		for _, note := range rc.notes {
+			fmt.Fprintf(&b, "- %s\n", note)
+		}
+	}
+	return b.String()
+}
+
+func writeZipBytes(w *zip.Writer, name string, data []byte) error {
+	fw, err := w.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s in zip: %w", name, err)
+	}
+	if _, err := fw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func writeZipJSON(w *zip.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+	return writeZipBytes(w, name, data)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func reproTouchEvent(offset int64, data map[string]interface{}) UnifiedEvent {
+	raw, _ := json.Marshal(data)
+	return UnifiedEvent{Source: SourceTouch, Type: "touch", RelativeTime: offset, Data: raw}
+}
+
+func TestTouchScriptFromEvents(t *testing.T) {
+	session := &DeviceSession{ID: "s1", DeviceID: "emulator-5554", Name: "Login crash"}
+	swipe := reproTouchEvent(3500, map[string]interface{}{"action": "swipe", "x": 500, "y": 1500, "x2": 500, "y2": 400, "duration": 300})
+	swipe.Type = "gesture"
+	events := []UnifiedEvent{
+		{Source: SourceLogcat, Type: "logcat", RelativeTime: 100},
+		reproTouchEvent(1000, map[string]interface{}{"action": "tap", "x": 10, "y": 20}),
+		reproTouchEvent(2000, map[string]interface{}{"action": "long_press", "x": 30, "y": 40, "duration": 800}),
+		swipe,
+		{Source: SourceTouch, Type: "key", RelativeTime: 4000, Data: json.RawMessage(`{"action":"key","key":"KEY_BACK"}`)},
+	}
+
+	script := touchScriptFromEvents(session, events)
+
+	if script.Name != "Login crash" || script.DeviceID != "emulator-5554" {
+		t.Errorf("unexpected script header: %+v", script)
+	}
+	if len(script.Events) != 3 {
+		t.Fatalf("got %d events, want 3: %+v", len(script.Events), script.Events)
+	}
+	if e := script.Events[0]; e.Type != "tap" || e.Timestamp != 0 || e.X != 10 || e.Y != 20 {
+		t.Errorf("tap = %+v", e)
+	}
+	if e := script.Events[1]; e.Type != "long_press" || e.Timestamp != 1000 || e.Duration != 800 {
+		t.Errorf("long press = %+v", e)
+	}
+	if e := script.Events[2]; e.Type != "swipe" || e.Timestamp != 2500 || e.Y2 != 400 || e.Duration != 300 {
+		t.Errorf("swipe = %+v", e)
+	}
+}
+
+func TestParsePackageVersion(t *testing.T) {
+	name, code := parsePackageVersion(dumpsysPackageSuspended)
+	if name != "1.4.2" || code != "42" {
+		t.Errorf("parsePackageVersion = %q, %q; want 1.4.2, 42", name, code)
+	}
+	if name, code := parsePackageVersion(""); name != "" || code != "" {
+		t.Errorf("expected empty version for empty output, got %q, %q", name, code)
+	}
+}
+
+func TestReproPackagesAndLogcat(t *testing.T) {
+	events := []UnifiedEvent{
+		{Source: SourceLogcat, Type: "logcat", RelativeTime: 1500, Title: "Logcat Output (2 entries) - App",
+			Data: json.RawMessage(`[{"raw":"E App: boom","packageName":"com.example.app"},{"raw":"E App: again","packageName":"com.example.app"}]`)},
+		{Source: SourceApp, Type: "app_crash", RelativeTime: 2000, Title: "Crash",
+			Data: json.RawMessage(`{"packageName":"com.example.other"}`)},
+		{Source: SourceLogcat, Type: "logcat", RelativeTime: 61000, Title: "[Tag] no raw data"},
+	}
+
+	if got := reproPackages(events); strings.Join(got, ",") != "com.example.app,com.example.other" {
+		t.Errorf("reproPackages = %v", got)
+	}
+
+	logcat, lines := reproLogcat(events)
+	want := "[00:01.500] E App: boom\n[00:01.500] E App: again\n[01:01.000] [Tag] no raw data\n"
+	if lines != 3 || string(logcat) != want {
+		t.Errorf("reproLogcat = %d lines:\n%s\nwant:\n%s", lines, logcat, want)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ========================================
+// Safe mode - refuse obviously destructive shell commands
+// ========================================
+
+// dangerousCommandRule is a denylist entry checked against user-entered commands
+type dangerousCommandRule struct {
+	pattern *regexp.Regexp
+	reason  string
+}
+
+var dangerousCommandRules = []dangerousCommandRule{
+	{
+		// rm -r on /, /*, or a top-level system/data mount
+		pattern: regexp.MustCompile(`\brm\s+(?:-\S*\s+)*-\S*[rR]\S*\s+(?:-\S+\s+)*["']?(?:/\*?|/(?:system|vendor|product|data|sdcard|storage|boot|cache|efs|persist)(?:/\*?)?)["']?(?:\s|;|&|\||$)`),
+		reason:  "recursive delete of a root or system directory",
+	},
+	{
+		pattern: regexp.MustCompile(`\bdd\b[^;&|]*\bof=/dev/block`),
+		reason:  "raw write to a block device",
+	},
+	{
+		pattern: regexp.MustCompile(`>\s*/dev/block/`),
+		reason:  "redirect into a block device",
+	},
+	{
+		pattern: regexp.MustCompile(`\b(?:mkfs(?:\.\w+)?|make_ext4fs|flash_image|erase_image)\b`),
+		reason:  "formatting or flashing a partition",
+	},
+	{
+		pattern: regexp.MustCompile(`(?:^|[\s;&|])(?:wipe\s+(?:data|all|system|cache)|recovery\s+--wipe_data)\b|--wipe_data\b|android\.intent\.action\.(?:MASTER_CLEAR|FACTORY_RESET)`),
+		reason:  "factory reset / wipe",
+	},
+}
+
+// pmUninstallPattern captures the package of `pm uninstall [flags] pkg` or `adb uninstall [flags] pkg`
+var pmUninstallPattern = regexp.MustCompile(`(?:^|[\s;&|])(?:pm\s+uninstall|uninstall)\s+(?:-\S+\s+(?:\d+\s+)?)*([a-zA-Z][\w.]*)`)
+
+// matchDangerousCommand returns the reason a command is considered destructive, or ""
+func matchDangerousCommand(command string) string {
+	for _, rule := range dangerousCommandRules {
+		if rule.pattern.MatchString(command) {
+			return rule.reason
+		}
+	}
+	return ""
+}
+
+// IsSafeModeEnabled reports whether destructive shell commands are blocked (default on
+// for new installs, off for installs upgraded from a version without safe mode)
+func (a *App) IsSafeModeEnabled() bool {
+	if a.cacheService == nil {
+		return true
+	}
+	return a.cacheService.GetSafeMode()
+}
+
+// SetSafeMode enables or disables safe mode. Disabling it allows RunAdbCommand and
+// batch shell operations to run commands matching the destructive-command denylist.
+func (a *App) SetSafeMode(enabled bool) {
+	if a.cacheService == nil {
+		return
+	}
+	a.cacheService.SetSafeMode(enabled)
+	go a.saveSettings()
+	a.Log("Safe mode %s", map[bool]string{true: "enabled", false: "disabled"}[enabled])
+}
+
+// checkSafeMode returns an error if safe mode is on and the command is destructive
+func (a *App) checkSafeMode(deviceId, command string) error {
+	if !a.IsSafeModeEnabled() {
+		return nil
+	}
+
+	reason := matchDangerousCommand(command)
+	if reason == "" {
+		if m := pmUninstallPattern.FindStringSubmatch(command); m != nil && a.isSystemPackage(deviceId, m[1]) {
+			reason = "uninstalling system package " + m[1]
+		}
+	}
+	if reason == "" {
+		return nil
+	}
+
+	LogUserAction(ActionShellBlocked, deviceId, map[string]interface{}{
+		"command": command,
+		"reason":  reason,
+	})
+	return fmt.Errorf("blocked by safe mode (%s); disable safe mode to run this command", reason)
+}
+
+// isSystemPackage reports whether the package is part of the system image
+func (a *App) isSystemPackage(deviceId, packageName string) bool {
+	out, err := a.newAdbCommand(nil, "-s", deviceId, "shell", "pm", "list", "packages", "-s", packageName).Output()
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.TrimSpace(line) == "package:"+packageName {
+			return true
+		}
+	}
+	return false
+}
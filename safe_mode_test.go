@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"Gaze/pkg/cache"
+)
+
+func TestMatchDangerousCommand(t *testing.T) {
+	tests := []struct {
+		command   string
+		dangerous bool
+	}{
+		{"shell rm -rf /", true},
+		{"shell rm -rf /*", true},
+		{"shell rm -r -f /sdcard", true},
+		{"shell rm -fr /data/", true},
+		{"shell su -c 'rm -rf /system'", true},
+		{"shell rm -rf / ; echo done", true},
+		{"shell dd if=/sdcard/boot.img of=/dev/block/by-name/boot", true},
+		{"shell cat x > /dev/block/mmcblk0", true},
+		{"shell mkfs.ext4 /dev/block/sda1", true},
+		{"shell recovery --wipe_data", true},
+		{"shell am broadcast -a android.intent.action.MASTER_CLEAR", true},
+
+		{"shell rm -rf /sdcard/Download/tmp", false},
+		{"shell rm /sdcard/a.txt", false},
+		{"shell rm -rf /data/local/tmp/test", false},
+		{"shell ls -la /", false},
+		{"shell dd if=/dev/zero of=/sdcard/blob bs=1M count=1", false},
+		{"shell pm list packages", false},
+		{"shell dumpsys wifi", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			reason := matchDangerousCommand(tt.command)
+			if (reason != "") != tt.dangerous {
+				t.Errorf("matchDangerousCommand(%q) = %q, want dangerous=%v", tt.command, reason, tt.dangerous)
+			}
+		})
+	}
+}
+
+func TestPmUninstallPattern(t *testing.T) {
+	tests := []struct {
+		command string
+		pkg     string
+	}{
+		{"shell pm uninstall com.android.chrome", "com.android.chrome"},
+		{"shell pm uninstall -k --user 0 com.google.android.youtube", "com.google.android.youtube"},
+		{"uninstall com.example.app", "com.example.app"},
+		{"shell pm list packages", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			m := pmUninstallPattern.FindStringSubmatch(tt.command)
+			got := ""
+			if m != nil {
+				got = m[1]
+			}
+			if got != tt.pkg {
+				t.Errorf("pmUninstallPattern(%q) = %q, want %q", tt.command, got, tt.pkg)
+			}
+		})
+	}
+}
+
+func TestSafeModeDefaults(t *testing.T) {
+	// New install: no settings file yet
+	fresh, err := cache.New(cache.Config{ConfigDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	if !fresh.GetSafeMode() {
+		t.Error("safe mode should default on for new installs")
+	}
+
+	// Upgrade: settings saved by a version without safe mode
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "settings.json"), []byte(`{"pinnedSerial":"R5CT1234ABC"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	upgraded, err := cache.New(cache.Config{ConfigDir: dir})
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	if upgraded.GetSafeMode() {
+		t.Error("safe mode should stay off for existing installs")
+	}
+
+	// An explicit choice survives a save and reload
+	upgraded.SetSafeMode(true)
+	if err := upgraded.SaveSettings(); err != nil {
+		t.Fatalf("SaveSettings: %v", err)
+	}
+	reloaded, err := cache.New(cache.Config{ConfigDir: dir})
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	if !reloaded.GetSafeMode() {
+		t.Error("expected saved safe mode to be restored")
+	}
+}
@@ -3,13 +3,16 @@ package main
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"image/png"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,122 +29,45 @@ func (a *App) StartScrcpy(deviceId string, config ScrcpyConfig) error {
 		return err
 	}
 
+	// An empty config means "same as last time"; otherwise remember this one
+	if config == (ScrcpyConfig{}) {
+		if saved, err := a.GetScrcpyConfig(deviceId); err == nil {
+			config = saved
+		}
+	} else if err := a.SaveScrcpyConfig(deviceId, config); err != nil {
+		LogWarn("scrcpy").Err(err).Str("device", deviceId).Msg("Failed to save scrcpy config")
+	}
+
 	LogUserAction(ActionScrcpyStart, deviceId, map[string]interface{}{
 		"video_source": config.VideoSource,
 		"max_fps":      config.MaxFps,
 		"bit_rate":     config.BitRate,
+		"audio_only":   config.AudioOnly,
 	})
 
+	if config.AudioOnly && config.NoAudio {
+		err := fmt.Errorf("audio-only mode requires audio to be enabled")
+		timer.EndWithError(err)
+		return err
+	}
+
 	a.scrcpyMu.Lock()
 	if cmd, exists := a.scrcpyCmds[deviceId]; exists && cmd.Process != nil {
 		_ = cmd.Process.Kill()
 	}
 	a.scrcpyMu.Unlock()
 
-	args := []string{"-s", deviceId}
-
-	if config.MaxSize > 0 {
-		args = append(args, "--max-size", fmt.Sprintf("%d", config.MaxSize))
-	}
-	if config.BitRate > 0 {
-		args = append(args, "--video-bit-rate", fmt.Sprintf("%d", config.BitRate))
-	}
-	if config.MaxFps > 0 {
-		args = append(args, "--max-fps", fmt.Sprintf("%d", config.MaxFps))
-	}
-	isCamera := config.VideoSource == "camera"
+	args := buildScrcpyArgs(deviceId, config, scrcpyModeMirror)
 
-	if config.StayAwake && !isCamera {
-		args = append(args, "--stay-awake")
-	}
-	if config.TurnScreenOff && !isCamera {
-		args = append(args, "--turn-screen-off")
-	}
-	if config.VideoCodec != "" {
-		args = append(args, "--video-codec", config.VideoCodec)
-	}
-
-	if config.NoAudio {
-		args = append(args, "--no-audio")
-	} else if config.AudioCodec != "" {
-		args = append(args, "--audio-codec", config.AudioCodec)
-	}
-
-	if config.AlwaysOnTop {
-		args = append(args, "--always-on-top")
-	}
-	if config.ShowTouches && !isCamera {
-		args = append(args, "--show-touches")
-		go a.RunAdbCommand(deviceId, "shell settings put system show_touches 1")
-	} else if !isCamera {
-		go a.RunAdbCommand(deviceId, "shell settings put system show_touches 0")
-	}
-	if config.Fullscreen {
-		args = append(args, "--fullscreen")
-	}
-	if config.ReadOnly {
-		args = append(args, "--no-control")
-	}
-	if config.PowerOffOnClose && !isCamera {
-		args = append(args, "--power-off-on-close")
-	}
-	if config.WindowBorderless {
-		args = append(args, "--window-borderless")
-	}
-
-	if config.VideoSource == "camera" {
-		args = append(args, "--video-source", "camera")
-		if config.CameraId != "" {
-			args = append(args, "--camera-id", config.CameraId)
-		}
-		if config.CameraSize != "" {
-			args = append(args, "--camera-size", config.CameraSize)
-		}
-	} else {
-		if config.VideoSource == "display" {
-			args = append(args, "--video-source", "display")
-		}
-		if config.DisplayId > 0 {
-			args = append(args, "--display-id", fmt.Sprintf("%d", config.DisplayId))
-		}
-	}
-	if config.DisplayOrientation != "" && config.DisplayOrientation != "0" {
-		args = append(args, "--display-orientation", config.DisplayOrientation)
-	}
-	if config.CaptureOrientation != "" && config.CaptureOrientation != "0" {
-		args = append(args, "--capture-orientation", config.CaptureOrientation)
-	}
-	if config.KeyboardMode != "" && config.KeyboardMode != "sdk" {
-		args = append(args, "--keyboard", config.KeyboardMode)
-	}
-	if config.MouseMode != "" && config.MouseMode != "sdk" {
-		args = append(args, "--mouse", config.MouseMode)
-	}
-	if config.NoClipboardSync {
-		args = append(args, "--no-clipboard-autosync")
-	}
-	if config.ShowFps {
-		args = append(args, "--print-fps")
-	}
-	if config.NoPowerOn {
-		args = append(args, "--no-power-on")
-	}
-
-	if config.VideoSource == "camera" {
-		foundNoAudio := false
-		for _, arg := range args {
-			if arg == "--no-audio" {
-				foundNoAudio = true
-				break
-			}
-		}
-		if !foundNoAudio {
-			args = append(args, "--no-audio")
+	// Keep the device's show_touches setting in sync with the mirror option
+	if !config.AudioOnly && config.VideoSource != "camera" {
+		if config.ShowTouches {
+			go a.RunAdbCommand(deviceId, "shell settings put system show_touches 1")
+		} else {
+			go a.RunAdbCommand(deviceId, "shell settings put system show_touches 0")
 		}
 	}
 
-	args = append(args, "--window-title", "ADB GUI - "+deviceId)
-
 	cmd := a.newScrcpyCommand(args...)
 
 	var stderrBuf bytes.Buffer
@@ -156,8 +82,13 @@ func (a *App) StartScrcpy(deviceId string, config ScrcpyConfig) error {
 
 	a.scrcpyMu.Lock()
 	a.scrcpyCmds[deviceId] = cmd
+	if a.scrcpyConfigs != nil {
+		a.scrcpyConfigs[deviceId] = config
+	}
+	a.syncSleepInhibitLocked()
 	a.scrcpyMu.Unlock()
 
+	a.beginMediaSession(deviceId, mediaMirror)
 	timer.End()
 
 	startTime := time.Now()
@@ -166,6 +97,7 @@ func (a *App) StartScrcpy(deviceId string, config ScrcpyConfig) error {
 		wailsRuntime.EventsEmit(a.ctx, "scrcpy-started", map[string]interface{}{
 			"deviceId":  deviceId,
 			"startTime": startTime.Unix(),
+			"audioOnly": config.AudioOnly,
 		})
 	}
 
@@ -178,17 +110,35 @@ func (a *App) StartScrcpy(deviceId string, config ScrcpyConfig) error {
 
 		if a.scrcpyCmds[deviceId] == cmd {
 			delete(a.scrcpyCmds, deviceId)
+			a.syncSleepInhibitLocked()
+
+			errorMsg := stderrBuf.String()
+			if err != nil && errorMsg == "" {
+				errorMsg = err.Error()
+			}
+			var failure ScrcpyFailure
+			if err != nil {
+				failure = classifyScrcpyError(errorMsg)
+			}
+
+			// The entry is gone if the user stopped the mirror; otherwise keep it while
+			// waiting for the device to come back
+			if _, wanted := a.scrcpyConfigs[deviceId]; wanted && err != nil && a.shouldRestartMirror(deviceId, failure, duration) {
+				go a.restartMirrorOnReconnect(deviceId, time.Now())
+			} else {
+				delete(a.scrcpyConfigs, deviceId)
+				a.endMediaSession(deviceId, mediaMirror)
+			}
 
 			if err != nil && duration < 5*time.Second {
-				errorMsg := stderrBuf.String()
-				if errorMsg == "" {
-					errorMsg = err.Error()
-				}
-				a.Log("Scrcpy failed quickly (%v): %s", duration, errorMsg)
+				a.Log("Scrcpy failed quickly (%v) [%s]: %s", duration, failure.Code, errorMsg)
 				if !a.mcpMode {
 					wailsRuntime.EventsEmit(a.ctx, "scrcpy-failed", map[string]interface{}{
-						"deviceId": deviceId,
-						"error":    errorMsg,
+						"deviceId":   deviceId,
+						"error":      errorMsg,
+						"code":       failure.Code,
+						"message":    failure.Message,
+						"suggestion": failure.Suggestion,
 					})
 				}
 			} else {
@@ -202,40 +152,36 @@ func (a *App) StartScrcpy(deviceId string, config ScrcpyConfig) error {
 	return nil
 }
 
-// StopScrcpy stops scrcpy for the given device
-func (a *App) StopScrcpy(deviceId string) error {
-	a.scrcpyMu.Lock()
-	defer a.scrcpyMu.Unlock()
+// Modes of buildScrcpyArgs
+const (
+	scrcpyModeMirror = "mirror" // Interactive mirror window
+	scrcpyModeRecord = "record" // Headless recording to config.RecordPath
+)
 
-	if cmd, exists := a.scrcpyCmds[deviceId]; exists && cmd.Process != nil {
-		err := cmd.Process.Kill()
-		if err != nil && (strings.Contains(err.Error(), "process already finished") || strings.Contains(err.Error(), "already finished")) {
-			return nil
+// buildScrcpyArgs turns a config into scrcpy arguments for a mirror or a recording.
+// Video, audio and source options are shared so both modes interpret a config the same
+// way; window, control and device power options only apply to mirrors. Audio-only
+// mirrors get --no-video and none of the video, window or display options.
+func buildScrcpyArgs(deviceId string, config ScrcpyConfig, mode string) []string {
+	record := mode == scrcpyModeRecord
+	if config.AudioOnly && !record {
+		args := []string{"-s", deviceId, "--no-video"}
+		if config.AudioCodec != "" {
+			args = append(args, "--audio-codec", config.AudioCodec)
 		}
-		return err
-	}
-	return nil
-}
-
-// StartRecording starts a separate scrcpy process just for recording without a window
-func (a *App) StartRecording(deviceId string, config ScrcpyConfig) error {
-	a.updateLastActive(deviceId)
-	if deviceId == "" {
-		return fmt.Errorf("no device specified")
-	}
-
-	if config.RecordPath == "" {
-		return fmt.Errorf("no record path specified")
+		if config.StayAwake {
+			args = append(args, "--stay-awake")
+		}
+		return args
 	}
 
-	a.scrcpyMu.Lock()
-	if cmd, exists := a.scrcpyRecordCmd[deviceId]; exists && cmd.Process != nil {
-		_ = cmd.Process.Kill()
+	args := []string{"-s", deviceId}
+	if record {
+		args = append(args, "--no-window", "--no-audio-playback", "--record", config.RecordPath)
 	}
-	a.scrcpyMu.Unlock()
-
-	args := []string{"-s", deviceId, "--no-window", "--no-audio-playback", "--record", config.RecordPath}
+	isCamera := config.VideoSource == "camera"
 
+	// Video encoding
 	if config.MaxSize > 0 {
 		args = append(args, "--max-size", fmt.Sprintf("%d", config.MaxSize))
 	}
@@ -249,13 +195,15 @@ func (a *App) StartRecording(deviceId string, config ScrcpyConfig) error {
 		args = append(args, "--video-codec", config.VideoCodec)
 	}
 
-	if config.NoAudio {
+	// Audio; camera sources are captured without it
+	if config.NoAudio || isCamera {
 		args = append(args, "--no-audio")
 	} else if config.AudioCodec != "" {
 		args = append(args, "--audio-codec", config.AudioCodec)
 	}
 
-	if config.VideoSource == "camera" {
+	// Source and orientation
+	if isCamera {
 		args = append(args, "--video-source", "camera")
 		if config.CameraId != "" {
 			args = append(args, "--camera-id", config.CameraId)
@@ -274,36 +222,156 @@ func (a *App) StartRecording(deviceId string, config ScrcpyConfig) error {
 	if config.DisplayOrientation != "" && config.DisplayOrientation != "0" {
 		args = append(args, "--display-orientation", config.DisplayOrientation)
 	}
+	if config.CaptureOrientation != "" && config.CaptureOrientation != "0" {
+		args = append(args, "--capture-orientation", config.CaptureOrientation)
+	}
 
-	cmd := a.newScrcpyCommand(args...)
+	if record {
+		return args
+	}
 
-	a.Log("Starting recording process: %s %v", a.scrcpyPath, cmd.Args)
+	// Device power and touches (not meaningful for a camera)
+	if !isCamera {
+		if config.StayAwake {
+			args = append(args, "--stay-awake")
+		}
+		if config.TurnScreenOff {
+			args = append(args, "--turn-screen-off")
+		}
+		if config.ShowTouches {
+			args = append(args, "--show-touches")
+		}
+		if config.PowerOffOnClose {
+			args = append(args, "--power-off-on-close")
+		}
+	}
+	if config.NoPowerOn {
+		args = append(args, "--no-power-on")
+	}
 
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start recording: %w", err)
+	// Control
+	if config.ReadOnly {
+		args = append(args, "--no-control")
+	}
+	if config.KeyboardMode != "" && config.KeyboardMode != "sdk" {
+		args = append(args, "--keyboard", config.KeyboardMode)
+	}
+	if config.MouseMode != "" && config.MouseMode != "sdk" {
+		args = append(args, "--mouse", config.MouseMode)
+	}
+	if config.NoClipboardSync {
+		args = append(args, "--no-clipboard-autosync")
 	}
 
+	// Window
+	if config.AlwaysOnTop {
+		args = append(args, "--always-on-top")
+	}
+	if config.Fullscreen {
+		args = append(args, "--fullscreen")
+	}
+	if config.WindowBorderless {
+		args = append(args, "--window-borderless")
+	}
+	if config.ShowFps {
+		args = append(args, "--print-fps")
+	}
+
+	args = append(args, "--window-title", "ADB GUI - "+deviceId)
+	return args
+}
+
+// GetScrcpyArgsPreview returns the command StartScrcpy (mode "mirror" or "") or
+// StartRecording (mode "record") would run for a config, scrcpy binary first
+func (a *App) GetScrcpyArgsPreview(deviceId string, config ScrcpyConfig, mode string) ([]string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return nil, err
+	}
+	switch mode {
+	case "", scrcpyModeMirror:
+		mode = scrcpyModeMirror
+		if config.AudioOnly && config.NoAudio {
+			return nil, fmt.Errorf("audio-only mode requires audio to be enabled")
+		}
+	case scrcpyModeRecord:
+		if config.RecordPath == "" {
+			return nil, fmt.Errorf("no record path specified")
+		}
+	default:
+		return nil, fmt.Errorf("unknown scrcpy mode: %q", mode)
+	}
+
+	bin := a.scrcpyPath
+	if bin == "" {
+		bin = "scrcpy"
+	}
+	return append([]string{bin}, buildScrcpyArgs(deviceId, config, mode)...), nil
+}
+
+// StopScrcpy stops scrcpy for the given device
+func (a *App) StopScrcpy(deviceId string) error {
+	a.scrcpyMu.Lock()
+	defer a.scrcpyMu.Unlock()
+
+	delete(a.scrcpyConfigs, deviceId)
+	if cmd, exists := a.scrcpyCmds[deviceId]; exists && cmd.Process != nil {
+		err := cmd.Process.Kill()
+		if err != nil && (strings.Contains(err.Error(), "process already finished") || strings.Contains(err.Error(), "already finished")) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// StartRecording starts a separate scrcpy process just for recording without a window
+func (a *App) StartRecording(deviceId string, config ScrcpyConfig) error {
+	a.updateLastActive(deviceId)
+	if deviceId == "" {
+		return fmt.Errorf("no device specified")
+	}
+
+	if config.RecordPath == "" {
+		return fmt.Errorf("no record path specified")
+	}
+	if config.MaxDuration < 0 || config.SegmentDuration < 0 {
+		return fmt.Errorf("recording durations must not be negative")
+	}
+
+	a.scrcpyMu.Lock()
+	if cmd, exists := a.scrcpyRecordCmd[deviceId]; exists && cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+	a.closeRecordStopLocked(deviceId)
+	a.scrcpyMu.Unlock()
+
+	segment := config
+	if config.SegmentDuration > 0 {
+		segment.RecordPath = recordSegmentPath(config.RecordPath, 1)
+	}
+	cmd, err := a.startRecordProcess(deviceId, segment, recordSegmentLimit(config, 1))
+	if err != nil {
+		return err
+	}
+
+	stop := make(chan struct{})
 	a.scrcpyMu.Lock()
 	a.scrcpyRecordCmd[deviceId] = cmd
+	a.scrcpyRecordStop[deviceId] = stop
+	a.syncSleepInhibitLocked()
 	a.scrcpyMu.Unlock()
 
+	a.beginRecordingSession(deviceId, segment.RecordPath)
+
 	if !a.mcpMode {
 		wailsRuntime.EventsEmit(a.ctx, "scrcpy-record-started", map[string]interface{}{
 			"deviceId":   deviceId,
-			"recordPath": config.RecordPath,
+			"recordPath": segment.RecordPath,
 			"startTime":  time.Now().Unix(),
 		})
 	}
 
-	go func() {
-		_ = cmd.Wait()
-		a.scrcpyMu.Lock()
-		delete(a.scrcpyRecordCmd, deviceId)
-		a.scrcpyMu.Unlock()
-		if !a.mcpMode {
-			wailsRuntime.EventsEmit(a.ctx, "scrcpy-record-stopped", deviceId)
-		}
-	}()
+	go a.superviseRecording(deviceId, config, cmd, stop)
 
 	return nil
 }
@@ -313,18 +381,9 @@ func (a *App) StopRecording(deviceId string) error {
 	a.scrcpyMu.Lock()
 	defer a.scrcpyMu.Unlock()
 
+	a.closeRecordStopLocked(deviceId)
 	if cmd, exists := a.scrcpyRecordCmd[deviceId]; exists && cmd.Process != nil {
-		var err error
-		if runtime.GOOS != "windows" {
-			err = cmd.Process.Signal(os.Interrupt)
-		} else {
-			err = cmd.Process.Kill()
-		}
-
-		if err != nil && (strings.Contains(err.Error(), "process already finished") || strings.Contains(err.Error(), "already finished")) {
-			return nil
-		}
-		return err
+		return interruptRecording(cmd)
 	}
 	return nil
 }
@@ -383,13 +442,9 @@ func (a *App) ListDisplays(deviceId string) ([]string, error) {
 	return displays, nil
 }
 
-// SelectRecordPath returns a default recording path in the Downloads folder
+// SelectRecordPath returns a default recording path in the recordings output directory
 func (a *App) SelectRecordPath(deviceModel string) (string, error) {
-	defaultDir, _ := os.UserHomeDir()
-	downloadsDir := filepath.Join(defaultDir, "Downloads")
-	if _, err := os.Stat(downloadsDir); err == nil {
-		defaultDir = downloadsDir
-	}
+	defaultDir := a.outputDir(OutputRecordings)
 
 	cleanModel := "Device"
 	if deviceModel != "" {
@@ -403,13 +458,9 @@ func (a *App) SelectRecordPath(deviceModel string) (string, error) {
 	return fullPath, nil
 }
 
-// SelectScreenshotPath returns a default screenshot path in the Downloads folder
+// SelectScreenshotPath returns a default screenshot path in the screenshots output directory
 func (a *App) SelectScreenshotPath(deviceModel string) (string, error) {
-	defaultDir, _ := os.UserHomeDir()
-	downloadsDir := filepath.Join(defaultDir, "Downloads")
-	if _, err := os.Stat(downloadsDir); err == nil {
-		defaultDir = downloadsDir
-	}
+	defaultDir := a.outputDir(OutputScreenshots)
 
 	cleanModel := "Device"
 	if deviceModel != "" {
@@ -423,8 +474,125 @@ func (a *App) SelectScreenshotPath(deviceModel string) (string, error) {
 	return fullPath, nil
 }
 
+var (
+	screenOnRegex          = regexp.MustCompile(`(?i)wakefulness=Awake|state=ON|mDisplayState=ON`)
+	screenInteractiveRegex = regexp.MustCompile(`(?i)wakefulness=Awake`)
+	screenLockedRegex      = regexp.MustCompile(`(?i)(keyguardShowing|showingLockscreen).*true`)
+)
+
+// GetScreenState reports whether the display is on, the keyguard is showing, and the
+// device is interactive. Brightness and orientation are left unset.
+func (a *App) GetScreenState(deviceId string) (ScreenState, error) {
+	if deviceId == "" {
+		return ScreenState{}, fmt.Errorf("no device specified")
+	}
+	return a.getScreenState(nil, deviceId)
+}
+
+// getScreenState is GetScreenState with a context; ctx may be nil
+func (a *App) getScreenState(ctx context.Context, deviceId string) (ScreenState, error) {
+	out, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "dumpsys power | grep -iE 'state=|wakefulness=' ; dumpsys window | grep -iE 'keyguardShowing|showingLockscreen'")
+	if err != nil && len(out) == 0 {
+		return ScreenState{}, fmt.Errorf("failed to query screen state: %w", err)
+	}
+	return parseScreenPowerState(string(out)), nil
+}
+
+// parseScreenPowerState parses the grep'd dumpsys power/window output used by GetScreenState
+func parseScreenPowerState(output string) ScreenState {
+	output = strings.TrimSpace(output)
+	return ScreenState{
+		On:          screenOnRegex.MatchString(output),
+		Locked:      screenLockedRegex.MatchString(output),
+		Interactive: screenInteractiveRegex.MatchString(output),
+	}
+}
+
+var (
+	// ErrScreenOff is returned when a screenshot is requested while the screen is off or locked
+	ErrScreenOff = errors.New("SCREEN_OFF")
+	// ErrSecureWindow is returned (wrapped) when the focused window sets FLAG_SECURE, which
+	// makes screencap return a black image. Nothing is captured or saved.
+	ErrSecureWindow = errors.New("SECURE_WINDOW")
+)
+
+var (
+	focusedWindowRegex = regexp.MustCompile(`mCurrentFocus=Window\{(\S+) `)
+	windowHeaderRegex  = regexp.MustCompile(`^\s*Window #\d+ Window\{(\S+) `)
+)
+
+// WindowManager.LayoutParams.FLAG_SECURE, as printed in hex by older dumpsys versions
+const windowFlagSecure = 0x2000
+
+// focusedWindowSecure reports whether the focused window sets FLAG_SECURE; ctx may be nil
+func (a *App) focusedWindowSecure(ctx context.Context, deviceId string) (bool, error) {
+	out, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", "dumpsys", "window", "windows")
+	if err != nil {
+		return false, fmt.Errorf("failed to dump windows: %w", err)
+	}
+	return parseFocusedWindowSecure(string(out)), nil
+}
+
+// parseFocusedWindowSecure finds the mCurrentFocus window in `dumpsys window windows`
+// output and checks its flags: "fl=... SECURE ..." on Android 10+, "fl=#81812100" before
+func parseFocusedWindowSecure(output string) bool {
+	m := focusedWindowRegex.FindStringSubmatch(output)
+	if m == nil {
+		return false
+	}
+	focused := m[1]
+
+	inWindow := false
+	for _, line := range strings.Split(output, "\n") {
+		if h := windowHeaderRegex.FindStringSubmatch(line); h != nil {
+			inWindow = h[1] == focused
+			continue
+		}
+		if !inWindow {
+			continue
+		}
+		i := strings.Index(line, "fl=")
+		if i < 0 || (i > 0 && line[i-1] != ' ' && line[i-1] != '\t') {
+			continue
+		}
+		flags := line[i+len("fl="):]
+		if strings.HasPrefix(flags, "#") {
+			hex := strings.Fields(flags[1:])
+			if len(hex) == 0 {
+				continue
+			}
+			v, err := strconv.ParseUint(hex[0], 16, 32)
+			return err == nil && v&windowFlagSecure != 0
+		}
+		for _, f := range strings.Fields(flags) {
+			if strings.Contains(f, "=") {
+				break
+			}
+			if strings.TrimSuffix(f, "}") == "SECURE" {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
 // TakeScreenshot captures a screenshot of the device and saves it to the host
 func (a *App) TakeScreenshot(deviceId, savePath string) (string, error) {
+	return a.takeScreenshot(deviceId, savePath, false)
+}
+
+// TakeScreenshotDirect is TakeScreenshot with a choice of capture method. With useExecOut
+// the PNG is streamed from `exec-out screencap -p` straight into savePath, so nothing is
+// written to /sdcard; the temp-file method is only used if exec-out keeps returning
+// corrupt data.
+func (a *App) TakeScreenshotDirect(deviceId, savePath string, useExecOut bool) (string, error) {
+	return a.takeScreenshot(deviceId, savePath, useExecOut)
+}
+
+// takeScreenshot validates the arguments and runs captureScreenshot, reporting
+// progress to the frontend
+func (a *App) takeScreenshot(deviceId, savePath string, useExecOut bool) (string, error) {
 	if deviceId == "" {
 		return "", fmt.Errorf("no device specified")
 	}
@@ -433,62 +601,234 @@ func (a *App) TakeScreenshot(deviceId, savePath string) (string, error) {
 		return "", fmt.Errorf("no save path specified")
 	}
 
-	a.updateLastActive(deviceId)
+	progress := func(step string, data ...interface{}) {
+		if !a.mcpMode {
+			wailsRuntime.EventsEmit(a.ctx, "screenshot-progress", append([]interface{}{step}, data...)...)
+		}
+	}
+	if err := a.captureScreenshot(deviceId, savePath, useExecOut, progress); err != nil {
+		return "", err
+	}
+	progress("screenshot_success", savePath)
+	return savePath, nil
+}
 
-	checkCmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "dumpsys power | grep -iE 'state=|wakefulness=' ; dumpsys window | grep -iE 'keyguardShowing|showingLockscreen'")
-	out, _ := checkCmd.CombinedOutput()
-	outStr := strings.TrimSpace(string(out))
+// captureScreenshot takes a screenshot into savePath, reporting each step through progress.
+// useExecOut streams it through exec-out, falling back to screencap/pull on failure.
+// Returns ErrScreenOff or a wrapped ErrSecureWindow, without saving anything, when the
+// screen can't be captured.
+func (a *App) captureScreenshot(deviceId, savePath string, useExecOut bool, progress func(step string, data ...interface{})) error {
+	a.updateLastActive(deviceId)
 
-	reOn := regexp.MustCompile(`(?i)wakefulness=Awake|state=ON|mDisplayState=ON`)
-	isOn := reOn.MatchString(outStr)
-	isOff := !isOn
+	screen, _ := a.GetScreenState(deviceId)
+	if !screen.On || screen.Locked {
+		progress("screenshot_off")
+		return ErrScreenOff
+	}
 
-	reLocked := regexp.MustCompile(`(?i)(keyguardShowing|showingLockscreen).*true`)
-	isLocked := reLocked.MatchString(outStr)
+	// FLAG_SECURE windows come back black from screencap; say so instead of saving a black image
+	if secure, err := a.focusedWindowSecure(nil, deviceId); err != nil {
+		LogDebug("screenshot").Err(err).Str("deviceId", deviceId).Msg("Could not check for a secure window")
+	} else if secure {
+		progress("screenshot_secure")
+		return fmt.Errorf("%w: the foreground app blocks screenshots", ErrSecureWindow)
+	}
 
-	if isOff || isLocked {
-		if !a.mcpMode {
-			wailsRuntime.EventsEmit(a.ctx, "screenshot-progress", "screenshot_off")
+	progress("screenshot_capturing")
+	captured := false
+	if useExecOut {
+		if err := a.saveScreencapExecOut(deviceId, savePath); err != nil {
+			LogWarn("screenshot").Err(err).Str("deviceId", deviceId).Msg("exec-out screenshot failed, falling back to temp file")
+		} else {
+			captured = true
 		}
-		return "", fmt.Errorf("SCREEN_OFF")
 	}
+	if !captured {
+		if err := a.captureScreenshotViaTempFile(deviceId, savePath, progress); err != nil {
+			return err
+		}
+	}
+	a.emitScreenshotEvent(deviceId, savePath)
+	return nil
+}
 
-	if !a.mcpMode {
-		wailsRuntime.EventsEmit(a.ctx, "screenshot-progress", "screenshot_capturing")
+// emitScreenshotEvent records a saved screenshot in the device's session so session
+// reports can embed it. MCP screenshots are temp files deleted once returned, so skipped.
+func (a *App) emitScreenshotEvent(deviceId, savePath string) {
+	if a.eventPipeline == nil || a.mcpMode {
+		return
 	}
+	a.eventPipeline.EmitRaw(deviceId, SourceSystem, "screenshot", LevelInfo,
+		"Screenshot "+filepath.Base(savePath),
+		map[string]string{screenshotEventPathKey: savePath})
+}
+
+// captureScreenshotViaTempFile writes the screenshot to /sdcard and pulls it
+func (a *App) captureScreenshotViaTempFile(deviceId, savePath string, progress func(step string, data ...interface{})) error {
 	// Use unique remote path to avoid race conditions with concurrent/rapid calls
 	remotePath := fmt.Sprintf("/sdcard/screenshot_%d.png", time.Now().UnixNano())
-	capCmd := a.newAdbCommand(nil, "-s", deviceId, "shell", "screencap", "-p", remotePath)
-	if out, err := capCmd.CombinedOutput(); err != nil {
-		return "", fmt.Errorf("failed to capture screenshot on device: %w, output: %s", err, string(out))
+	out, err := runWithRetry(nil, a.GetAdbRetries(), adbRetryBackoff, func() ([]byte, error) {
+		return a.newAdbCommand(nil, "-s", deviceId, "shell", "screencap", "-p", remotePath).CombinedOutput()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to capture screenshot on device: %w, output: %s", err, string(out))
 	}
 	defer a.newAdbCommand(nil, "-s", deviceId, "shell", "rm", remotePath).Run()
 
 	// Force filesystem sync to ensure screenshot is fully written before pulling
 	a.newAdbCommand(nil, "-s", deviceId, "shell", "sync").Run()
 
-	if !a.mcpMode {
-		wailsRuntime.EventsEmit(a.ctx, "screenshot-progress", "screenshot_pulling")
-	}
+	progress("screenshot_pulling")
 	pullCmd := a.newAdbCommand(nil, "-s", deviceId, "pull", remotePath, savePath)
 	if out, err := pullCmd.CombinedOutput(); err != nil {
-		if !a.mcpMode {
-			wailsRuntime.EventsEmit(a.ctx, "screenshot-progress", "screenshot_error", err.Error())
+		progress("screenshot_error", err.Error())
+		return fmt.Errorf("failed to pull screenshot: %w, output: %s", err, string(out))
+	}
+
+	if err := a.ensureValidScreenshot(deviceId, savePath); err != nil {
+		os.Remove(savePath)
+		progress("screenshot_error", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Corrupt exec-out output is often transient, so it's retried before giving up
+const screencapExecOutAttempts = 2
+
+// saveScreencapExecOut streams a screenshot into savePath without touching the device
+// filesystem. CRLF-mangled output is repaired; other corrupt data is retried.
+func (a *App) saveScreencapExecOut(deviceId, savePath string) error {
+	var data []byte
+	var err error
+	for attempt := 1; attempt <= screencapExecOutAttempts; attempt++ {
+		if data, err = a.GetScreencapPNG(deviceId); err == nil {
+			break
 		}
-		return "", fmt.Errorf("failed to pull screenshot: %w, output: %s", err, string(out))
+		LogDebug("screenshot").Err(err).Int("attempt", attempt).Str("deviceId", deviceId).Msg("exec-out screencap attempt failed")
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(savePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write screenshot: %w", err)
 	}
+	return nil
+}
 
-	if !a.mcpMode {
-		wailsRuntime.EventsEmit(a.ctx, "screenshot-progress", "screenshot_success", savePath)
+// CaptureScreenshotOfPinnedDevice takes a screenshot of the pinned device straight into
+// the screenshots output directory, without a dialog. Meant for hotkeys and tray actions.
+func (a *App) CaptureScreenshotOfPinnedDevice() (string, error) {
+	d, err := a.pinnedDevice()
+	if err != nil {
+		return "", err
 	}
-	return savePath, nil
+	savePath, err := a.SelectScreenshotPath(d.Model)
+	if err != nil {
+		return "", err
+	}
+	return a.TakeScreenshot(d.ID, savePath)
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// GetScreencapPNG streams a screenshot straight into memory with `adb exec-out screencap -p`:
+// no temp file on the device and no pull. Returns the raw PNG bytes.
+func (a *App) GetScreencapPNG(deviceId string) ([]byte, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return nil, fmt.Errorf("invalid device ID: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	// exec-out is binary safe, unlike shell, which may translate LF to CRLF
+	stdout, stderr, err := a.runAdbWithRetry(ctx, "-s", deviceId, "exec-out", "screencap", "-p")
+	if err != nil {
+		return nil, fmt.Errorf("screencap failed: %w (%s)", err, strings.TrimSpace(string(stderr)))
+	}
+	data, corrected, err := repairScreencapPNG(stdout)
+	if err != nil {
+		return nil, err
+	}
+	if corrected {
+		a.warnScreenshotCorrected(deviceId, "crlf")
+	}
+	return data, nil
+}
+
+// ensureValidScreenshot checks that a pulled screenshot decodes. A CRLF-mangled file is
+// repaired in place; anything else is replaced by a fresh exec-out capture.
+func (a *App) ensureValidScreenshot(deviceId, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read screenshot: %w", err)
+	}
+	fixed, corrected, repairErr := repairScreencapPNG(data)
+	if repairErr == nil {
+		if !corrected {
+			return nil
+		}
+		if err := os.WriteFile(path, fixed, 0644); err != nil {
+			return fmt.Errorf("failed to write repaired screenshot: %w", err)
+		}
+		a.warnScreenshotCorrected(deviceId, "crlf")
+		return nil
+	}
+
+	LogWarn("screenshot").Err(repairErr).Str("deviceId", deviceId).Msg("Pulled screenshot is corrupt, retrying with exec-out")
+	data, err = a.GetScreencapPNG(deviceId)
+	if err != nil {
+		return fmt.Errorf("screenshot is corrupt and exec-out capture failed: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write screenshot: %w", err)
+	}
+	a.warnScreenshotCorrected(deviceId, "exec-out")
+	return nil
+}
+
+// warnScreenshotCorrected logs and surfaces that a corrupt screenshot had to be fixed up
+func (a *App) warnScreenshotCorrected(deviceId, method string) {
+	LogWarn("screenshot").Str("deviceId", deviceId).Str("method", method).Msg("Corrected corrupt screencap output")
+	if !a.mcpMode && a.ctx != nil {
+		wailsRuntime.EventsEmit(a.ctx, "screenshot-corrected", map[string]string{
+			"deviceId": deviceId,
+			"method":   method,
+		})
+	}
+}
+
+// repairScreencapPNG checks that data decodes as a PNG. If not, it undoes the LF -> CRLF
+// translation older adb builds apply to shell output on Windows (the PNG signature itself
+// contains "\r\n", so mangled output starts with "\x89PNG\r\r\n"). corrected reports
+// whether the repair was needed.
+func repairScreencapPNG(data []byte) (fixed []byte, corrected bool, err error) {
+	if len(data) == 0 {
+		return nil, false, fmt.Errorf("screencap returned no data")
+	}
+	if _, err := png.Decode(bytes.NewReader(data)); err == nil {
+		return data, false, nil
+	}
+	if bytes.HasPrefix(data, []byte("\x89PNG\r\r\n")) {
+		fixed = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+		if _, err := png.Decode(bytes.NewReader(fixed)); err == nil {
+			return fixed, true, nil
+		}
+	}
+	if !bytes.HasPrefix(data, pngSignature) {
+		head := data
+		if len(head) > 64 {
+			head = head[:64]
+		}
+		return nil, false, fmt.Errorf("screencap did not return a PNG: %q", strings.TrimSpace(string(head)))
+	}
+	return nil, false, fmt.Errorf("screencap returned a corrupt PNG")
 }
 
 // OpenPath opens a file or directory in the default system browser
 func (a *App) OpenPath(path string) error {
 	if path == "::recordings::" {
-		home, _ := os.UserHomeDir()
-		path = filepath.Join(home, "Downloads")
+		path = a.outputDir(OutputRecordings)
 	}
 
 	info, err := os.Stat(path)
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ========================================
+// Saved Scrcpy Configs
+// ========================================
+
+// savedScrcpyConfigs is the on-disk layout of scrcpy_configs.json
+type savedScrcpyConfigs struct {
+	Default *ScrcpyConfig           `json:"default,omitempty"`
+	Devices map[string]ScrcpyConfig `json:"devices"` // serial -> last used config
+}
+
+var scrcpyConfigFileMu sync.Mutex
+
+// scrcpyConfigsPath returns the file saved mirror configs are kept in
+func (a *App) scrcpyConfigsPath() string {
+	if a.cacheService != nil {
+		return filepath.Join(a.cacheService.ConfigDir(), "scrcpy_configs.json")
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = os.TempDir()
+	}
+	return filepath.Join(configDir, "Gaze", "scrcpy_configs.json")
+}
+
+// serialFor resolves a device ID (which may be a transport address) to its hardware
+// serial, so settings follow a device across USB and wireless connections
+func (a *App) serialFor(deviceId string) string {
+	a.idToSerialMu.RLock()
+	defer a.idToSerialMu.RUnlock()
+	if s, ok := a.idToSerial[deviceId]; ok && s != "" {
+		return s
+	}
+	return deviceId
+}
+
+func (a *App) loadScrcpyConfigs() (savedScrcpyConfigs, error) {
+	saved := savedScrcpyConfigs{Devices: make(map[string]ScrcpyConfig)}
+	data, err := os.ReadFile(a.scrcpyConfigsPath())
+	if os.IsNotExist(err) {
+		return saved, nil
+	}
+	if err != nil {
+		return saved, fmt.Errorf("failed to read saved scrcpy configs: %w", err)
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return saved, fmt.Errorf("failed to parse saved scrcpy configs: %w", err)
+	}
+	if saved.Devices == nil {
+		saved.Devices = make(map[string]ScrcpyConfig)
+	}
+	return saved, nil
+}
+
+// SaveScrcpyConfig remembers a mirror config for a device. An empty deviceId saves the
+// global default used for devices without a config of their own.
+func (a *App) SaveScrcpyConfig(deviceId string, config ScrcpyConfig) error {
+	scrcpyConfigFileMu.Lock()
+	defer scrcpyConfigFileMu.Unlock()
+
+	saved, err := a.loadScrcpyConfigs()
+	if err != nil {
+		return err
+	}
+	if deviceId == "" {
+		saved.Default = &config
+	} else {
+		saved.Devices[a.serialFor(deviceId)] = config
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal scrcpy configs: %w", err)
+	}
+	path := a.scrcpyConfigsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write scrcpy configs: %w", err)
+	}
+	return nil
+}
+
+// GetScrcpyConfig returns the last config used to mirror a device, falling back to the
+// global default and then to an empty config (scrcpy's own defaults)
+func (a *App) GetScrcpyConfig(deviceId string) (ScrcpyConfig, error) {
+	scrcpyConfigFileMu.Lock()
+	defer scrcpyConfigFileMu.Unlock()
+
+	saved, err := a.loadScrcpyConfigs()
+	if err != nil {
+		return ScrcpyConfig{}, err
+	}
+	if deviceId != "" {
+		if config, ok := saved.Devices[a.serialFor(deviceId)]; ok {
+			return config, nil
+		}
+	}
+	if saved.Default != nil {
+		return *saved.Default, nil
+	}
+	return ScrcpyConfig{}, nil
+}
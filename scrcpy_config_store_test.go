@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestScrcpyConfigPerDeviceAndDefault(t *testing.T) {
+	app := newOutputTestApp(t)
+	app.idToSerial = map[string]string{"192.168.1.20:5555": "R5CT1234ABC"}
+
+	if got, err := app.GetScrcpyConfig("R5CT1234ABC"); err != nil || got != (ScrcpyConfig{}) {
+		t.Fatalf("nothing saved yet: got %+v, %v", got, err)
+	}
+
+	if err := app.SaveScrcpyConfig("", ScrcpyConfig{MaxSize: 1024}); err != nil {
+		t.Fatalf("save default: %v", err)
+	}
+	// Saved under the wireless ID, found again through the USB serial
+	if err := app.SaveScrcpyConfig("192.168.1.20:5555", ScrcpyConfig{BitRate: 8, MaxFps: 60}); err != nil {
+		t.Fatalf("save device: %v", err)
+	}
+
+	got, err := app.GetScrcpyConfig("R5CT1234ABC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.BitRate != 8 || got.MaxFps != 60 {
+		t.Errorf("device config = %+v", got)
+	}
+
+	other, err := app.GetScrcpyConfig("emulator-5554")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.MaxSize != 1024 {
+		t.Errorf("expected the global default, got %+v", other)
+	}
+}
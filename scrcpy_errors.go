@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+)
+
+// Codes reported in the "code" field of the scrcpy-failed event
+const (
+	ScrcpyErrDeviceDisconnected = "DEVICE_DISCONNECTED"
+	ScrcpyErrDeviceNotFound     = "DEVICE_NOT_FOUND"
+	ScrcpyErrUnauthorized       = "DEVICE_UNAUTHORIZED"
+	ScrcpyErrCodecUnsupported   = "CODEC_UNSUPPORTED"
+	ScrcpyErrServerConnection   = "SERVER_CONNECTION_FAILED"
+	ScrcpyErrPermission         = "PERMISSION_DENIED"
+	ScrcpyErrUnknown            = "UNKNOWN"
+)
+
+// ScrcpyFailure is an actionable description of why scrcpy exited
+type ScrcpyFailure struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion"`
+}
+
+// scrcpyErrorPatterns are matched in order against lowercased stderr; the first hit wins.
+// More specific patterns come before generic ones: "could not find adb device" before
+// "device disconnected", "device unauthorized" before "adb server" (adb's unauthorized
+// message mentions the server), and everything before the bare "codec"/"encoder" needles.
+var scrcpyErrorPatterns = []struct {
+	needles []string
+	failure ScrcpyFailure
+}{
+	{
+		needles: []string{"could not find adb device", "device not found", "no devices/emulators found", "device offline"},
+		failure: ScrcpyFailure{
+			Code:       ScrcpyErrDeviceNotFound,
+			Message:    "scrcpy could not find the device",
+			Suggestion: "Reconnect the device (replug the cable or reconnect wireless ADB) and make sure it shows up as \"device\" in the device list.",
+		},
+	},
+	{
+		needles: []string{"device unauthorized", "device is unauthorized", "unauthorized device"},
+		failure: ScrcpyFailure{
+			Code:       ScrcpyErrUnauthorized,
+			Message:    "The device has not authorized this computer for USB debugging",
+			Suggestion: "Unlock the device and accept the \"Allow USB debugging\" prompt. If no prompt appears, revoke USB debugging authorizations in Developer options and reconnect.",
+		},
+	},
+	{
+		needles: []string{"device disconnected", "connection reset", "broken pipe"},
+		failure: ScrcpyFailure{
+			Code:       ScrcpyErrDeviceDisconnected,
+			Message:    "The device disconnected while mirroring was starting",
+			Suggestion: "Check the cable or Wi-Fi connection, unlock the device and accept any USB debugging prompt, then try again.",
+		},
+	},
+	{
+		needles: []string{"server connection failed", "could not connect to server", "server exited", "failed to push", "could not push", "adb server"},
+		failure: ScrcpyFailure{
+			Code:       ScrcpyErrServerConnection,
+			Message:    "scrcpy could not talk to its server on the device",
+			Suggestion: "Restart ADB (adb kill-server && adb start-server) and try again.",
+		},
+	},
+	{
+		needles: []string{"permission denied", "libusb_error_access", "access denied", "could not open usb device", "could not find any usb device"},
+		failure: ScrcpyFailure{
+			Code:       ScrcpyErrPermission,
+			Message:    "scrcpy was denied access to the device",
+			Suggestion: "Grant USB access (udev rules on Linux), close other tools using the device, and for OTG mode make sure ADB is not holding the device.",
+		},
+	},
+	{
+		needles: []string{"codec", "encoder", "mediacodec", "could not open video stream", "could not open audio stream"},
+		failure: ScrcpyFailure{
+			Code:       ScrcpyErrCodecUnsupported,
+			Message:    "The device does not support the selected codec or encoder",
+			Suggestion: "Pick a different video codec (h264 is the most compatible) or encoder in the mirror settings, or lower the resolution and bitrate.",
+		},
+	},
+}
+
+// classifyScrcpyError maps scrcpy stderr output onto an actionable failure
+func classifyScrcpyError(stderr string) ScrcpyFailure {
+	lower := strings.ToLower(stderr)
+	for _, p := range scrcpyErrorPatterns {
+		for _, needle := range p.needles {
+			if strings.Contains(lower, needle) {
+				return p.failure
+			}
+		}
+	}
+	return ScrcpyFailure{
+		Code:       ScrcpyErrUnknown,
+		Message:    "scrcpy exited unexpectedly",
+		Suggestion: "Check the scrcpy output below; reconnecting the device or restarting ADB often helps.",
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// Recording time limit and segmenting
+// ========================================
+
+// startRecordProcess launches a headless scrcpy recording to config.RecordPath. With a
+// timeLimit (seconds) scrcpy stops on its own and finalizes the file, which is the only
+// graceful stop on Windows where the process can't be interrupted.
+func (a *App) startRecordProcess(deviceId string, config ScrcpyConfig, timeLimit int) (*exec.Cmd, error) {
+	args := buildScrcpyArgs(deviceId, config, scrcpyModeRecord)
+	if timeLimit > 0 {
+		args = append(args, "--time-limit", strconv.Itoa(timeLimit))
+	}
+	cmd := a.newScrcpyCommand(args...)
+
+	a.Log("Starting recording process: %s %v", a.scrcpyPath, cmd.Args)
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start recording: %w", err)
+	}
+	return cmd, nil
+}
+
+// recordSegmentLimit returns the --time-limit in seconds for the index-th (1-based)
+// scrcpy run of a recording: a full segment, or whatever MaxDuration leaves of it.
+// 0 means no limit; a negative value means MaxDuration is already used up.
+func recordSegmentLimit(config ScrcpyConfig, index int) int {
+	if config.SegmentDuration <= 0 {
+		return config.MaxDuration
+	}
+	if config.MaxDuration <= 0 {
+		return config.SegmentDuration
+	}
+	remaining := config.MaxDuration - (index-1)*config.SegmentDuration
+	if remaining <= 0 {
+		return -1
+	}
+	if remaining < config.SegmentDuration {
+		return remaining
+	}
+	return config.SegmentDuration
+}
+
+// interruptRecording asks scrcpy to stop so it finalizes the container. Windows
+// has no SIGINT for child processes, so the process is killed there; time limits
+// and segment rotation go through --time-limit instead and never need this.
+func interruptRecording(cmd *exec.Cmd) error {
+	var err error
+	if runtime.GOOS != "windows" {
+		err = cmd.Process.Signal(os.Interrupt)
+	} else {
+		err = cmd.Process.Kill()
+	}
+
+	if err != nil && strings.Contains(err.Error(), "already finished") {
+		return nil
+	}
+	return err
+}
+
+// closeRecordStopLocked ends segment rotation for a device; caller holds scrcpyMu
+func (a *App) closeRecordStopLocked(deviceId string) {
+	if stop, ok := a.scrcpyRecordStop[deviceId]; ok {
+		close(stop)
+		delete(a.scrcpyRecordStop, deviceId)
+	}
+}
+
+// recordSegmentPath numbers a recording path: "rec.mp4" becomes "rec_001.mp4"
+func recordSegmentPath(path string, index int) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s_%03d%s", strings.TrimSuffix(path, ext), index, ext)
+}
+
+// superviseRecording waits on the recording process and, with a SegmentDuration set,
+// starts scrcpy on the next numbered file each time a segment ends at its time limit.
+// Every scrcpy run gets a --time-limit (see recordSegmentLimit), so segment ends and
+// MaxDuration are graceful stops by scrcpy itself. Every finished segment is reported
+// through "scrcpy-record-segment"; "scrcpy-record-stopped" is emitted once at the end.
+func (a *App) superviseRecording(deviceId string, config ScrcpyConfig, cmd *exec.Cmd, stop <-chan struct{}) {
+	index := 1
+	for {
+		limit := recordSegmentLimit(config, index)
+		started := time.Now()
+		done := make(chan struct{})
+		go func(c *exec.Cmd) {
+			_ = c.Wait()
+			close(done)
+		}(cmd)
+
+		stopped := false
+		select {
+		case <-done:
+		case <-stop:
+			<-done
+		}
+		select {
+		case <-stop:
+			stopped = true
+		default:
+		}
+		// scrcpy ended by itself; only a run that lasted its full limit ended on time
+		// (a device disconnect ends it early)
+		reachedLimit := limit > 0 && time.Since(started) >= time.Duration(limit)*time.Second
+
+		if config.SegmentDuration > 0 {
+			a.emitRecordSegment(deviceId, recordSegmentPath(config.RecordPath, index), index)
+			if index == 1 {
+				a.finishRecordingVideo(deviceId)
+			}
+		}
+		if stopped || !reachedLimit {
+			break
+		}
+		if config.SegmentDuration <= 0 || recordSegmentLimit(config, index+1) < 0 {
+			a.Log("Recording for %s reached its %ds limit", deviceId, config.MaxDuration)
+			break
+		}
+
+		// Only rotate if the recording wasn't stopped or replaced while this segment closed
+		index++
+		a.scrcpyMu.Lock()
+		current := a.scrcpyRecordCmd[deviceId] == cmd && a.scrcpyRecordStop[deviceId] == stop
+		a.scrcpyMu.Unlock()
+		if !current {
+			break
+		}
+
+		segment := config
+		segment.RecordPath = recordSegmentPath(config.RecordPath, index)
+		nextCmd, err := a.startRecordProcess(deviceId, segment, recordSegmentLimit(config, index))
+		if err != nil {
+			LogWarn("scrcpy").Err(err).Str("device", deviceId).Int("segment", index).Msg("Failed to start next recording segment")
+			break
+		}
+
+		a.scrcpyMu.Lock()
+		if a.scrcpyRecordCmd[deviceId] != cmd || a.scrcpyRecordStop[deviceId] != stop {
+			// Stopped while the new segment was starting
+			a.scrcpyMu.Unlock()
+			_ = interruptRecording(nextCmd)
+			_ = nextCmd.Wait()
+			_ = os.Remove(segment.RecordPath)
+			break
+		}
+		a.scrcpyRecordCmd[deviceId] = nextCmd
+		a.scrcpyMu.Unlock()
+		cmd = nextCmd
+	}
+
+	a.scrcpyMu.Lock()
+	current, running := a.scrcpyRecordCmd[deviceId]
+	if current == cmd {
+		delete(a.scrcpyRecordCmd, deviceId)
+		if a.scrcpyRecordStop[deviceId] == stop {
+			delete(a.scrcpyRecordStop, deviceId)
+		}
+	}
+	a.syncSleepInhibitLocked()
+	a.scrcpyMu.Unlock()
+	// A new StartRecording took over the device; its own supervisor reports the stop
+	replaced := running && current != cmd
+	if replaced {
+		return
+	}
+	a.endMediaSession(deviceId, mediaRecord)
+	if !a.mcpMode {
+		wailsRuntime.EventsEmit(a.ctx, "scrcpy-record-stopped", deviceId)
+	}
+}
+
+// emitRecordSegment reports a completed segment file
+func (a *App) emitRecordSegment(deviceId, path string, index int) {
+	if a.mcpMode {
+		return
+	}
+	wailsRuntime.EventsEmit(a.ctx, "scrcpy-record-segment", map[string]interface{}{
+		"deviceId": deviceId,
+		"path":     path,
+		"index":    index,
+	})
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestRecordSegmentPath(t *testing.T) {
+	cases := map[string]string{
+		"/tmp/rec.mp4":      "/tmp/rec_002.mp4",
+		"/tmp/a.b/rec.mkv":  "/tmp/a.b/rec_002.mkv",
+		"/tmp/no_extension": "/tmp/no_extension_002",
+	}
+	for in, want := range cases {
+		if got := recordSegmentPath(in, 2); got != want {
+			t.Errorf("recordSegmentPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// fakeScrcpyScript touches the --record file and runs until interrupted or, like
+// scrcpy, until its --time-limit has passed
+const fakeScrcpyScript = `#!/bin/sh
+limit=0
+while [ $# -gt 0 ]; do
+	if [ "$1" = "--record" ]; then touch "$2"; fi
+	if [ "$1" = "--time-limit" ]; then limit=$2; fi
+	shift
+done
+trap 'exit 0' INT
+ticks=0
+while :; do
+	sleep 0.1
+	ticks=$((ticks + 1))
+	if [ "$limit" -gt 0 ] && [ "$ticks" -ge $((limit * 10)) ]; then exit 0; fi
+done
+`
+
+func TestRecordSegmentLimit(t *testing.T) {
+	cases := []struct {
+		segment, max, index, want int
+	}{
+		{0, 0, 1, 0},
+		{0, 90, 1, 90},
+		{60, 0, 5, 60},
+		{60, 150, 1, 60},
+		{60, 150, 3, 30},
+		{60, 120, 3, -1},
+	}
+	for _, c := range cases {
+		got := recordSegmentLimit(ScrcpyConfig{SegmentDuration: c.segment, MaxDuration: c.max}, c.index)
+		if got != c.want {
+			t.Errorf("segment=%d max=%d index=%d: got %d, want %d", c.segment, c.max, c.index, got, c.want)
+		}
+	}
+}
+
+func TestStartRecordingSegmentsAndLimit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "scrcpy")
+	if err := os.WriteFile(bin, []byte(fakeScrcpyScript), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	app := &App{
+		mcpMode:          true,
+		scrcpyPath:       bin,
+		scrcpyRecordCmd:  make(map[string]*exec.Cmd),
+		scrcpyRecordStop: make(map[string]chan struct{}),
+	}
+	app.sleepInhibit.start = func() (func(), error) { return func() {}, nil }
+
+	recordPath := filepath.Join(dir, "rec.mp4")
+	err := app.StartRecording("R5CT1234ABC", ScrcpyConfig{RecordPath: recordPath, SegmentDuration: 1, MaxDuration: 2})
+	if err != nil {
+		t.Fatalf("StartRecording: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for app.IsRecording("R5CT1234ABC") {
+		if time.Now().After(deadline) {
+			_ = app.StopRecording("R5CT1234ABC")
+			t.Fatal("recording didn't stop at MaxDuration")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	for _, i := range []int{1, 2} {
+		if _, err := os.Stat(recordSegmentPath(recordPath, i)); err != nil {
+			t.Errorf("segment %d missing: %v", i, err)
+		}
+	}
+	if _, err := os.Stat(recordSegmentPath(recordPath, 3)); err == nil {
+		t.Error("no segment should start once MaxDuration is used up")
+	}
+	if _, err := os.Stat(recordPath); err == nil {
+		t.Error("unsegmented path should not be written")
+	}
+}
+
+func TestStartRecordingRejectsNegativeDurations(t *testing.T) {
+	app := &App{mcpMode: true}
+	if err := app.StartRecording("R5CT1234ABC", ScrcpyConfig{RecordPath: "/tmp/rec.mp4", MaxDuration: -1}); err == nil {
+		t.Error("expected an error for a negative MaxDuration")
+	}
+}
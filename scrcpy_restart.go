@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// Mirror auto-restart on reconnect
+// ========================================
+
+const (
+	// How long after a disconnect the device may take to come back before we give up
+	mirrorRestartWindow = 60 * time.Second
+	mirrorRestartPoll   = 2 * time.Second
+	// Mirrors that die sooner than this failed to start rather than lost their device,
+	// and are reported through scrcpy-failed instead of being relaunched
+	mirrorMinRunTime = 5 * time.Second
+)
+
+// GetRestartMirrorOnReconnect reports whether mirroring of a device is relaunched
+// automatically after the device drops and reconnects
+func (a *App) GetRestartMirrorOnReconnect(deviceId string) bool {
+	if a.cacheService == nil {
+		return false
+	}
+	return a.cacheService.GetRestartMirrorOnReconnect(deviceId)
+}
+
+// SetRestartMirrorOnReconnect turns mirror auto-restart on or off for a device. When on,
+// a mirror that exits because the device disconnected is relaunched with the same
+// config once the device is back online.
+func (a *App) SetRestartMirrorOnReconnect(deviceId string, enabled bool) error {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return err
+	}
+	if a.cacheService == nil {
+		return fmt.Errorf("settings are not available")
+	}
+
+	a.cacheService.SetRestartMirrorOnReconnect(deviceId, enabled)
+	go a.saveSettings()
+
+	a.Log("Mirror auto-restart for %s set to %v", deviceId, enabled)
+	return nil
+}
+
+// shouldRestartMirror reports whether a mirror that exited with failure after running for
+// ran should be relaunched once its device reconnects
+func (a *App) shouldRestartMirror(deviceId string, failure ScrcpyFailure, ran time.Duration) bool {
+	if ran < mirrorMinRunTime {
+		return false
+	}
+	// A dropped wireless link surfaces either as a disconnect or, when adb already
+	// marked the device offline, as "device not found"
+	if failure.Code != ScrcpyErrDeviceDisconnected && failure.Code != ScrcpyErrDeviceNotFound {
+		return false
+	}
+	return a.GetRestartMirrorOnReconnect(deviceId)
+}
+
+// restartMirrorOnReconnect waits for the device of a mirror lost at lostAt to come back
+// and relaunches scrcpy with the config it last ran with. It gives up when the window
+// passes, or when the user stops or restarts the mirror in the meantime.
+func (a *App) restartMirrorOnReconnect(deviceId string, lostAt time.Time) {
+	a.Log("Mirror of %s lost its device, waiting up to %v for it to reconnect", deviceId, mirrorRestartWindow)
+
+	for time.Since(lostAt) < mirrorRestartWindow {
+		time.Sleep(mirrorRestartPoll)
+
+		config, ok := a.pendingMirrorRestart(deviceId)
+		if !ok {
+			a.endMirrorSessionIfIdle(deviceId)
+			return
+		}
+		if !a.isMirrorDeviceOnline(deviceId) {
+			continue
+		}
+
+		if err := a.StartScrcpy(deviceId, config); err != nil {
+			LogWarn("scrcpy").Str("device", deviceId).Err(err).Msg("Failed to restart mirror after reconnect")
+			break
+		}
+
+		downtime := time.Since(lostAt)
+		a.Log("Mirror of %s restarted after %v", deviceId, downtime.Round(time.Second))
+		if !a.mcpMode && a.ctx != nil {
+			wailsRuntime.EventsEmit(a.ctx, "scrcpy-auto-restarted", map[string]interface{}{
+				"deviceId":   deviceId,
+				"downtimeMs": downtime.Milliseconds(),
+				"audioOnly":  config.AudioOnly,
+			})
+		}
+		return
+	}
+
+	a.scrcpyMu.Lock()
+	if _, running := a.scrcpyCmds[deviceId]; !running {
+		delete(a.scrcpyConfigs, deviceId)
+	}
+	a.scrcpyMu.Unlock()
+	a.endMirrorSessionIfIdle(deviceId)
+	a.Log("Gave up restarting mirror of %s: device did not come back", deviceId)
+}
+
+// pendingMirrorRestart returns the config to relaunch with, or false once the mirror was
+// stopped, started again by the user, or the app is shutting down
+func (a *App) pendingMirrorRestart(deviceId string) (ScrcpyConfig, bool) {
+	a.scrcpyMu.Lock()
+	defer a.scrcpyMu.Unlock()
+	if _, running := a.scrcpyCmds[deviceId]; running {
+		return ScrcpyConfig{}, false
+	}
+	config, ok := a.scrcpyConfigs[deviceId]
+	return config, ok
+}
+
+// isMirrorDeviceOnline reports whether deviceId is listed and ready under that same ID,
+// which scrcpy needs to be relaunched with -s
+func (a *App) isMirrorDeviceOnline(deviceId string) bool {
+	dev, err := a.GetDevice(deviceId)
+	if err != nil || dev.State != "device" {
+		return false
+	}
+	return dev.ID == deviceId || slices.Contains(dev.IDs, deviceId)
+}
@@ -0,0 +1,463 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"Gaze/pkg/cache"
+)
+
+func TestParseScreenPowerState(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   ScreenState
+	}{
+		{
+			name: "awake and unlocked",
+			output: `  mWakefulness=Awake
+Display Power: state=ON
+    mDreamingLockscreen=false mShowingLockscreen=false`,
+			want: ScreenState{On: true, Interactive: true},
+		},
+		{
+			name: "awake on keyguard",
+			output: `  mWakefulness=Awake
+Display Power: state=ON
+  isStatusBarKeyguard=true mKeyguardShowing=true`,
+			want: ScreenState{On: true, Locked: true, Interactive: true},
+		},
+		{
+			name: "dozing with always-on display",
+			output: `  mWakefulness=Dozing
+Display Power: state=DOZE
+  mDisplayState=ON`,
+			want: ScreenState{On: true},
+		},
+		{
+			name: "asleep",
+			output: `  mWakefulness=Asleep
+Display Power: state=OFF
+    mShowingLockscreen=true`,
+			want: ScreenState{Locked: true},
+		},
+		{
+			name:   "empty",
+			output: "",
+			want:   ScreenState{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseScreenPowerState(tt.output); got != tt.want {
+				t.Errorf("parseScreenPowerState() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyScrcpyError(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		want   string
+	}{
+		{"missing device", "ERROR: Could not find ADB device 1234:\n", ScrcpyErrDeviceNotFound},
+		{"disconnect", "WARN: Device disconnected\n", ScrcpyErrDeviceDisconnected},
+		{"unauthorized", "ERROR: Device unauthorized\n", ScrcpyErrUnauthorized},
+		{"adb unauthorized mentions server", "adb: device unauthorized.\nThis adb server's $ADB_VENDOR_KEYS is not set", ScrcpyErrUnauthorized},
+		{"server before encoder", "ERROR: Server connection failed\nINFO: use --list-encoders to list encoders", ScrcpyErrServerConnection},
+		{"push before encoder", "ERROR: Could not push scrcpy-server (encoder probe skipped)", ScrcpyErrServerConnection},
+		{"codec", "ERROR: Could not open video stream\nERROR: Encoder 'c2.foo' for 'h265' not found", ScrcpyErrCodecUnsupported},
+		{"server", "ERROR: Server connection failed", ScrcpyErrServerConnection},
+		{"usb access", "ERROR: LIBUSB_ERROR_ACCESS: Access denied (insufficient permissions)", ScrcpyErrPermission},
+		{"unknown", "Segmentation fault", ScrcpyErrUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyScrcpyError(tt.stderr)
+			if got.Code != tt.want {
+				t.Errorf("code = %s, want %s", got.Code, tt.want)
+			}
+			if got.Message == "" || got.Suggestion == "" {
+				t.Errorf("expected message and suggestion, got %+v", got)
+			}
+		})
+	}
+}
+
+const secureWindowsDump = `WINDOW MANAGER WINDOWS (dumpsys window windows)
+  Window #0 Window{5e1f2a u0 com.android.systemui.ImageWallpaper}:
+    mAttrs={(0,0)(fillxfill) ty=WALLPAPER fmt=RGBX_8888
+      fl=NOT_FOCUSABLE NOT_TOUCHABLE SECURE}
+  Window #1 Window{a1b2c3 u0 com.bank.app/com.bank.app.MainActivity}:
+    mDisplayId=0 rootTaskId=12
+    mAttrs={(0,0)(fillxfill) sim={adjust=pan} ty=BASE_APPLICATION fmt=TRANSPARENT wanim=0x10302f8
+      fl=LAYOUT_IN_SCREEN LAYOUT_INSET_DECOR SECURE SPLIT_TOUCH HARDWARE_ACCELERATED
+      pfl=FORCE_DRAW_STATUS_BAR_BACKGROUND}
+  Window #2 Window{d4e5f6 u0 com.example.notes/com.example.notes.Editor}:
+    mAttrs={(0,0)(fillxfill) ty=BASE_APPLICATION fmt=TRANSPARENT
+      fl=LAYOUT_IN_SCREEN SPLIT_TOUCH HARDWARE_ACCELERATED
+      pfl=SECURE_LOOKING}
+`
+
+func TestParseFocusedWindowSecure(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{"secure focused window", secureWindowsDump + "  mCurrentFocus=Window{a1b2c3 u0 com.bank.app/com.bank.app.MainActivity}\n", true},
+		{"other window is secure", secureWindowsDump + "  mCurrentFocus=Window{d4e5f6 u0 com.example.notes/com.example.notes.Editor}\n", false},
+		{"no focus", secureWindowsDump + "  mCurrentFocus=null\n", false},
+		{"hex flags secure", "  Window #0 Window{42 u0 com.bank.app/.Main}:\n    mAttrs=WM.LayoutParams{(0,0)(fillxfill) sim=#120 ty=1 fl=#81812100 wanim=0x1030465}\n  mCurrentFocus=Window{42 u0 com.bank.app/.Main}\n", true},
+		{"hex flags plain", "  Window #0 Window{42 u0 com.app/.Main}:\n    mAttrs=WM.LayoutParams{(0,0)(fillxfill) sim=#120 ty=1 fl=#81810100 wanim=0x1030465}\n  mCurrentFocus=Window{42 u0 com.app/.Main}\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseFocusedWindowSecure(tt.output); got != tt.want {
+				t.Errorf("parseFocusedWindowSecure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaptureScreenshot_SentinelErrors(t *testing.T) {
+	const stateCmd = "-s dev1 shell dumpsys power | grep -iE 'state=|wakefulness=' ; dumpsys window | grep -iE 'keyguardShowing|showingLockscreen'"
+	const windowsCmd = "-s dev1 shell dumpsys window windows"
+	const awake = "mWakefulness=Awake\nDisplay Power: state=ON\nmShowingLockscreen=false\n"
+	noop := func(string, ...interface{}) {}
+
+	// A dark-themed screen is a valid screenshot
+	var dark bytes.Buffer
+	if err := png.Encode(&dark, image.NewRGBA(image.Rect(0, 0, 108, 240))); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("screen off", func(t *testing.T) {
+		a := newTestApp(map[string]string{stateCmd: "mWakefulness=Asleep\nDisplay Power: state=OFF\n"})
+		path := filepath.Join(t.TempDir(), "off.png")
+		if err := a.captureScreenshot("dev1", path, true, noop); !errors.Is(err, ErrScreenOff) {
+			t.Fatalf("captureScreenshot() error = %v, want ErrScreenOff", err)
+		}
+	})
+
+	t.Run("secure window saves nothing", func(t *testing.T) {
+		a := newTestApp(map[string]string{
+			stateCmd:   awake,
+			windowsCmd: secureWindowsDump + "  mCurrentFocus=Window{a1b2c3 u0 com.bank.app/com.bank.app.MainActivity}\n",
+		})
+		path := filepath.Join(t.TempDir(), "secure.png")
+		var steps []string
+		err := a.captureScreenshot("dev1", path, true, func(step string, _ ...interface{}) { steps = append(steps, step) })
+		if !errors.Is(err, ErrSecureWindow) {
+			t.Fatalf("captureScreenshot() error = %v, want ErrSecureWindow", err)
+		}
+		if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+			t.Errorf("no file should be written for a secure window, stat error = %v", statErr)
+		}
+		if len(steps) == 0 || steps[len(steps)-1] != "screenshot_secure" {
+			t.Errorf("progress steps = %v, want screenshot_secure last", steps)
+		}
+		for _, call := range a.runner.(*fakeRunner).calls {
+			if strings.Contains(call, "screencap") {
+				t.Errorf("screencap should not run for a secure window: %q", call)
+			}
+		}
+	})
+
+	t.Run("dark screen", func(t *testing.T) {
+		a := newTestApp(map[string]string{
+			stateCmd:                        awake,
+			windowsCmd:                      secureWindowsDump + "  mCurrentFocus=Window{d4e5f6 u0 com.example.notes/com.example.notes.Editor}\n",
+			"-s dev1 exec-out screencap -p": dark.String(),
+		})
+		path := filepath.Join(t.TempDir(), "ok.png")
+		if err := a.captureScreenshot("dev1", path, true, noop); err != nil {
+			t.Fatalf("captureScreenshot() error = %v", err)
+		}
+	})
+}
+
+func TestPinnedDevice(t *testing.T) {
+	svc, err := cache.New(cache.Config{ConfigDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	a := newTestApp(map[string]string{
+		"devices -l": adbDevicesOutput,
+		"-s R5CT1234ABC shell getprop ro.serialno":                                       "R5CT1234ABC\n",
+		"-s R5CT1234ABC shell getprop ro.product.manufacturer; getprop ro.product.model": "samsung\nSM-G991B\n",
+	})
+	a.cacheService = svc
+
+	if _, err := a.pinnedDevice(); err == nil || !strings.Contains(err.Error(), "no device is pinned") {
+		t.Errorf("expected no-pin error, got %v", err)
+	}
+
+	svc.SetPinnedSerial("R5CT1234ABC")
+	d, err := a.pinnedDevice()
+	if err != nil {
+		t.Fatalf("pinnedDevice: %v", err)
+	}
+	if d.ID != "R5CT1234ABC" || !d.IsPinned {
+		t.Errorf("unexpected pinned device: %+v", d)
+	}
+
+	svc.SetPinnedSerial("emulator-5554")
+	if _, err := a.pinnedDevice(); err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+
+	svc.SetPinnedSerial("GONE123")
+	if _, err := a.pinnedDevice(); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected not connected error, got %v", err)
+	}
+}
+
+func encodeTestPNG(t *testing.T) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for i := range img.Pix {
+		// Plenty of 0x0a bytes so the CRLF translation really mangles the data
+		img.Pix[i] = byte(10 + i%3)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return buf.String()
+}
+
+func TestGetScreencapPNG(t *testing.T) {
+	valid := encodeTestPNG(t)
+	mangled := strings.ReplaceAll(valid, "\n", "\r\n")
+
+	tests := []struct {
+		name    string
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{name: "binary safe", output: valid, want: valid},
+		{name: "crlf mangled", output: mangled, want: valid},
+		{name: "truncated", output: valid[:len(valid)/2], wantErr: true},
+		{name: "empty", output: "", wantErr: true},
+		{name: "error text", output: "Error: could not take screenshot", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(map[string]string{"-s R5CT1234ABC exec-out screencap -p": tt.output})
+			a.mcpMode = true
+			got, err := a.GetScreencapPNG("R5CT1234ABC")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("got %d bytes, want the original %d", len(got), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestEnsureValidScreenshot(t *testing.T) {
+	valid := encodeTestPNG(t)
+	dir := t.TempDir()
+
+	// A mangled file on disk is repaired in place without touching the device
+	mangledPath := filepath.Join(dir, "mangled.png")
+	os.WriteFile(mangledPath, []byte(strings.ReplaceAll(valid, "\n", "\r\n")), 0644)
+	a := newTestApp(nil)
+	a.mcpMode = true
+	if err := a.ensureValidScreenshot("R5CT1234ABC", mangledPath); err != nil {
+		t.Fatalf("ensureValidScreenshot: %v", err)
+	}
+	if got, _ := os.ReadFile(mangledPath); string(got) != valid {
+		t.Error("mangled screenshot was not repaired")
+	}
+
+	// Unrepairable files are recaptured through exec-out
+	brokenPath := filepath.Join(dir, "broken.png")
+	os.WriteFile(brokenPath, []byte(valid[:40]), 0644)
+	a = newTestApp(map[string]string{"-s R5CT1234ABC exec-out screencap -p": valid})
+	a.mcpMode = true
+	if err := a.ensureValidScreenshot("R5CT1234ABC", brokenPath); err != nil {
+		t.Fatalf("ensureValidScreenshot: %v", err)
+	}
+	if got, _ := os.ReadFile(brokenPath); string(got) != valid {
+		t.Error("broken screenshot was not recaptured")
+	}
+}
+
+func TestSaveScreencapExecOut(t *testing.T) {
+	const key = "-s R5CT1234ABC exec-out screencap -p"
+	valid := encodeTestPNG(t)
+	dir := t.TempDir()
+
+	// CRLF-mangled output is repaired and written straight to the host file
+	a := newTestApp(map[string]string{key: strings.ReplaceAll(valid, "\n", "\r\n")})
+	a.mcpMode = true
+	path := filepath.Join(dir, "direct.png")
+	if err := a.saveScreencapExecOut("R5CT1234ABC", path); err != nil {
+		t.Fatalf("saveScreencapExecOut: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != valid {
+		t.Error("saved screenshot does not match the repaired PNG")
+	}
+
+	// Corrupt output is retried, then reported so the caller can fall back
+	a = newTestApp(map[string]string{key: valid[:40]})
+	a.mcpMode = true
+	path = filepath.Join(dir, "corrupt.png")
+	if err := a.saveScreencapExecOut("R5CT1234ABC", path); err == nil {
+		t.Fatal("expected error for corrupt exec-out output")
+	}
+	if calls := len(a.runner.(*fakeRunner).calls); calls != screencapExecOutAttempts {
+		t.Errorf("exec-out ran %d times, want %d", calls, screencapExecOutAttempts)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("corrupt screenshot should not be written")
+	}
+}
+
+func TestBuildScrcpyArgs_AudioOnly(t *testing.T) {
+	config := ScrcpyConfig{
+		AudioOnly:   true,
+		AudioCodec:  "aac",
+		StayAwake:   true,
+		MaxSize:     1024,
+		VideoCodec:  "h265",
+		VideoSource: "camera",
+		DisplayId:   2,
+		ShowTouches: true,
+	}
+	got := strings.Join(buildScrcpyArgs("R5CT1234ABC", config, scrcpyModeMirror), " ")
+	if want := "-s R5CT1234ABC --no-video --audio-codec aac --stay-awake"; got != want {
+		t.Errorf("audio-only args = %q, want %q", got, want)
+	}
+
+	config.AudioOnly = false
+	got = strings.Join(buildScrcpyArgs("R5CT1234ABC", config, scrcpyModeMirror), " ")
+	for _, want := range []string{"--video-source camera", "--no-audio", "--window-title"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("mirror args %q missing %q", got, want)
+		}
+	}
+}
+
+func TestMirrorRestartDecision(t *testing.T) {
+	svc, err := cache.New(cache.Config{ConfigDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	a := newTestApp(map[string]string{
+		"devices -l": adbDevicesOutput,
+		"-s R5CT1234ABC shell getprop ro.serialno":                                       "R5CT1234ABC\n",
+		"-s R5CT1234ABC shell getprop ro.product.manufacturer; getprop ro.product.model": "samsung\nSM-G991B\n",
+	})
+	a.cacheService = svc
+	a.scrcpyCmds = make(map[string]*exec.Cmd)
+	a.scrcpyConfigs = make(map[string]ScrcpyConfig)
+
+	disconnected := classifyScrcpyError("ERROR: Device disconnected")
+	if a.shouldRestartMirror("R5CT1234ABC", disconnected, time.Minute) {
+		t.Error("mirror should not restart while the option is off")
+	}
+	if err := a.SetRestartMirrorOnReconnect("R5CT1234ABC", true); err != nil {
+		t.Fatalf("SetRestartMirrorOnReconnect: %v", err)
+	}
+	if !a.shouldRestartMirror("R5CT1234ABC", disconnected, time.Minute) {
+		t.Error("disconnect after running should restart")
+	}
+	if a.shouldRestartMirror("R5CT1234ABC", disconnected, time.Second) {
+		t.Error("a mirror that failed to start should not restart")
+	}
+	if a.shouldRestartMirror("R5CT1234ABC", classifyScrcpyError("ERROR: codec unsupported"), time.Minute) {
+		t.Error("non-disconnect failures should not restart")
+	}
+	if a.shouldRestartMirror("R5CT1234ABC", classifyScrcpyError("ERROR: Device unauthorized"), time.Minute) {
+		t.Error("an unauthorized device should not restart until the prompt is accepted")
+	}
+
+	if !a.isMirrorDeviceOnline("R5CT1234ABC") {
+		t.Error("R5CT1234ABC should be online")
+	}
+	if a.isMirrorDeviceOnline("emulator-5554") || a.isMirrorDeviceOnline("10.0.0.9:5555") {
+		t.Error("unauthorized and missing devices are not online")
+	}
+
+	if _, ok := a.pendingMirrorRestart("R5CT1234ABC"); ok {
+		t.Error("nothing to restart without a saved config")
+	}
+	a.scrcpyConfigs["R5CT1234ABC"] = ScrcpyConfig{MaxFps: 30}
+	if cfg, ok := a.pendingMirrorRestart("R5CT1234ABC"); !ok || cfg.MaxFps != 30 {
+		t.Errorf("pendingMirrorRestart = %+v, %v", cfg, ok)
+	}
+	a.scrcpyCmds["R5CT1234ABC"] = &exec.Cmd{}
+	if _, ok := a.pendingMirrorRestart("R5CT1234ABC"); ok {
+		t.Error("a mirror started again by the user must not be restarted")
+	}
+}
+
+func TestBuildScrcpyArgs_RecordSharesVideoOptions(t *testing.T) {
+	config := ScrcpyConfig{
+		MaxSize:            1280,
+		BitRate:            8000000,
+		VideoCodec:         "h265",
+		VideoSource:        "camera",
+		CameraId:           "1",
+		CaptureOrientation: "90",
+		RecordPath:         "/tmp/rec.mp4",
+		AlwaysOnTop:        true,
+		ShowTouches:        true,
+	}
+	mirror := strings.Join(buildScrcpyArgs("R5CT1234ABC", config, scrcpyModeMirror), " ")
+	record := strings.Join(buildScrcpyArgs("R5CT1234ABC", config, scrcpyModeRecord), " ")
+
+	if !strings.HasPrefix(record, "-s R5CT1234ABC --no-window --no-audio-playback --record /tmp/rec.mp4 ") {
+		t.Errorf("record args = %q", record)
+	}
+	for _, shared := range []string{"--max-size 1280", "--video-bit-rate 8000000", "--video-codec h265",
+		"--no-audio", "--video-source camera --camera-id 1", "--capture-orientation 90"} {
+		if !strings.Contains(mirror, shared) || !strings.Contains(record, shared) {
+			t.Errorf("%q should be in both modes:\nmirror: %s\nrecord: %s", shared, mirror, record)
+		}
+	}
+	for _, mirrorOnly := range []string{"--always-on-top", "--window-title"} {
+		if strings.Contains(record, mirrorOnly) {
+			t.Errorf("record args should not contain %q: %s", mirrorOnly, record)
+		}
+	}
+	if strings.Contains(mirror, "--show-touches") {
+		t.Errorf("camera mirror should not show touches: %s", mirror)
+	}
+}
+
+func TestGetScrcpyArgsPreview(t *testing.T) {
+	a := newTestApp(nil)
+	a.scrcpyPath = "/opt/gaze/bin/scrcpy"
+
+	got, err := a.GetScrcpyArgsPreview("R5CT1234ABC", ScrcpyConfig{MaxFps: 30}, "")
+	if err != nil {
+		t.Fatalf("GetScrcpyArgsPreview: %v", err)
+	}
+	if want := "/opt/gaze/bin/scrcpy -s R5CT1234ABC --max-fps 30 --window-title ADB GUI - R5CT1234ABC"; strings.Join(got, " ") != want {
+		t.Errorf("preview = %q, want %q", strings.Join(got, " "), want)
+	}
+
+	if _, err := a.GetScrcpyArgsPreview("R5CT1234ABC", ScrcpyConfig{}, scrcpyModeRecord); err == nil {
+		t.Error("record preview without a path should fail")
+	}
+	if _, err := a.GetScrcpyArgsPreview("R5CT1234ABC", ScrcpyConfig{}, "stream"); err == nil {
+		t.Error("unknown mode should fail")
+	}
+}
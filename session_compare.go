@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ========================================
+// Session Comparison
+// ========================================
+
+const (
+	// Alignment is O(n*m), so each side is capped; the rest of a long session is ignored
+	compareMaxEvents = 2000
+	// Matched events whose duration or preceding gap differ by less than this are not reported
+	compareTimingThresholdMs = 100
+	compareMaxTimingDiffs    = 100
+)
+
+// Levels that take part in a comparison; verbose/debug noise would drown the alignment
+var compareLevels = []EventLevel{LevelInfo, LevelWarn, LevelError, LevelFatal}
+
+// SessionDiffEvent is an event that only appears in one of the compared sessions
+type SessionDiffEvent struct {
+	ID           string      `json:"id"`
+	Source       EventSource `json:"source"`
+	Type         string      `json:"type"`
+	Title        string      `json:"title"`
+	RelativeTime int64       `json:"relativeTime"`
+}
+
+// SessionTimingDiff is a pair of matching events whose timing differs between sessions.
+// Deltas are B minus A, so positive means slower in B.
+type SessionTimingDiff struct {
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	IDA           string `json:"idA"`
+	IDB           string `json:"idB"`
+	OffsetA       int64  `json:"offsetA"`
+	OffsetB       int64  `json:"offsetB"`
+	DurationA     int64  `json:"durationA"`
+	DurationB     int64  `json:"durationB"`
+	DurationDelta int64  `json:"durationDelta"`
+	GapDelta      int64  `json:"gapDelta"` // Time since the previous matched event
+}
+
+// SessionDiff is the result of aligning two session timelines
+type SessionDiff struct {
+	SessionA    string              `json:"sessionA"`
+	SessionB    string              `json:"sessionB"`
+	EventsA     int                 `json:"eventsA"`
+	EventsB     int                 `json:"eventsB"`
+	Matched     int                 `json:"matched"`
+	Similarity  float64             `json:"similarity"` // 0..1, matched share of the longer timeline
+	Truncated   bool                `json:"truncated"`  // true if a session exceeded compareMaxEvents
+	OnlyInA     []SessionDiffEvent  `json:"onlyInA"`
+	OnlyInB     []SessionDiffEvent  `json:"onlyInB"`
+	TimingDiffs []SessionTimingDiff `json:"timingDiffs"` // Largest differences first
+}
+
+// CompareSessions aligns the event timelines of two sessions by type and title and reports
+// events missing from either side plus timing differences between matching events.
+// Only info level and above are compared.
+func (a *App) CompareSessions(sessionA, sessionB string) (SessionDiff, error) {
+	if a.eventStore == nil {
+		return SessionDiff{}, fmt.Errorf("event store not initialized")
+	}
+	a.eventStore.Flush()
+
+	eventsA, truncA, err := a.loadCompareEvents(sessionA)
+	if err != nil {
+		return SessionDiff{}, err
+	}
+	eventsB, truncB, err := a.loadCompareEvents(sessionB)
+	if err != nil {
+		return SessionDiff{}, err
+	}
+
+	diff := diffEventTimelines(eventsA, eventsB)
+	diff.SessionA, diff.SessionB = sessionA, sessionB
+	diff.Truncated = truncA || truncB
+	return diff, nil
+}
+
+func (a *App) loadCompareEvents(sessionID string) ([]UnifiedEvent, bool, error) {
+	session, err := a.eventStore.GetSession(sessionID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, false, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	result, err := a.eventStore.QueryEvents(EventQuery{
+		SessionID: sessionID,
+		Levels:    compareLevels,
+		Limit:     compareMaxEvents,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query events of %s: %w", sessionID, err)
+	}
+	return result.Events, result.HasMore, nil
+}
+
+// diffEventTimelines aligns two timelines with a longest common subsequence over
+// "type|title" keys. Both inputs must be in chronological order.
+func diffEventTimelines(a, b []UnifiedEvent) SessionDiff {
+	diff := SessionDiff{
+		EventsA:     len(a),
+		EventsB:     len(b),
+		OnlyInA:     []SessionDiffEvent{},
+		OnlyInB:     []SessionDiffEvent{},
+		TimingDiffs: []SessionTimingDiff{},
+	}
+
+	key := func(e *UnifiedEvent) string { return e.Type + "|" + e.Title }
+	keysA := make([]string, len(a))
+	for i := range a {
+		keysA[i] = key(&a[i])
+	}
+	keysB := make([]string, len(b))
+	for j := range b {
+		keysB[j] = key(&b[j])
+	}
+
+	// lcs[i][j] = LCS length of a[i:] and b[j:]; uint16 is enough for compareMaxEvents
+	cols := len(b) + 1
+	lcs := make([]uint16, (len(a)+1)*cols)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case keysA[i] == keysB[j]:
+				lcs[i*cols+j] = lcs[(i+1)*cols+j+1] + 1
+			case lcs[(i+1)*cols+j] >= lcs[i*cols+j+1]:
+				lcs[i*cols+j] = lcs[(i+1)*cols+j]
+			default:
+				lcs[i*cols+j] = lcs[i*cols+j+1]
+			}
+		}
+	}
+
+	var prevA, prevB int64
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && keysA[i] == keysB[j]:
+			ea, eb := &a[i], &b[j]
+			diff.Matched++
+			t := SessionTimingDiff{
+				Type:          ea.Type,
+				Title:         ea.Title,
+				IDA:           ea.ID,
+				IDB:           eb.ID,
+				OffsetA:       ea.RelativeTime,
+				OffsetB:       eb.RelativeTime,
+				DurationA:     ea.Duration,
+				DurationB:     eb.Duration,
+				DurationDelta: eb.Duration - ea.Duration,
+				GapDelta:      (eb.RelativeTime - prevB) - (ea.RelativeTime - prevA),
+			}
+			if abs64(t.DurationDelta) >= compareTimingThresholdMs || abs64(t.GapDelta) >= compareTimingThresholdMs {
+				diff.TimingDiffs = append(diff.TimingDiffs, t)
+			}
+			prevA, prevB = ea.RelativeTime, eb.RelativeTime
+			i++
+			j++
+		case j >= len(b) || (i < len(a) && lcs[(i+1)*cols+j] >= lcs[i*cols+j+1]):
+			diff.OnlyInA = append(diff.OnlyInA, newSessionDiffEvent(&a[i]))
+			i++
+		default:
+			diff.OnlyInB = append(diff.OnlyInB, newSessionDiffEvent(&b[j]))
+			j++
+		}
+	}
+
+	sort.SliceStable(diff.TimingDiffs, func(x, y int) bool {
+		return timingDiffMagnitude(diff.TimingDiffs[x]) > timingDiffMagnitude(diff.TimingDiffs[y])
+	})
+	if len(diff.TimingDiffs) > compareMaxTimingDiffs {
+		diff.TimingDiffs = diff.TimingDiffs[:compareMaxTimingDiffs]
+	}
+
+	if longest := max(len(a), len(b)); longest > 0 {
+		diff.Similarity = float64(diff.Matched) / float64(longest)
+	} else {
+		diff.Similarity = 1
+	}
+	return diff
+}
+
+func newSessionDiffEvent(e *UnifiedEvent) SessionDiffEvent {
+	return SessionDiffEvent{
+		ID:           e.ID,
+		Source:       e.Source,
+		Type:         e.Type,
+		Title:        e.Title,
+		RelativeTime: e.RelativeTime,
+	}
+}
+
+func timingDiffMagnitude(t SessionTimingDiff) int64 {
+	return max(abs64(t.DurationDelta), abs64(t.GapDelta))
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
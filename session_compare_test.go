@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func compareEvent(id, typ, title string, offset, duration int64) UnifiedEvent {
+	return UnifiedEvent{ID: id, Type: typ, Title: title, RelativeTime: offset, Duration: duration}
+}
+
+func TestDiffEventTimelines(t *testing.T) {
+	a := []UnifiedEvent{
+		compareEvent("a1", "activity_start", "LoginActivity", 0, 0),
+		compareEvent("a2", "http_request", "POST /login", 500, 200),
+		compareEvent("a3", "activity_start", "HomeActivity", 800, 0),
+		compareEvent("a4", "toast", "Welcome", 900, 0),
+	}
+	b := []UnifiedEvent{
+		compareEvent("b1", "activity_start", "LoginActivity", 0, 0),
+		compareEvent("b2", "http_request", "POST /login", 520, 500),
+		compareEvent("b3", "app_crash", "NullPointerException", 600, 0),
+		compareEvent("b4", "activity_start", "HomeActivity", 1400, 0),
+	}
+
+	diff := diffEventTimelines(a, b)
+
+	if diff.Matched != 3 {
+		t.Errorf("Matched = %d, want 3", diff.Matched)
+	}
+	if len(diff.OnlyInA) != 1 || diff.OnlyInA[0].ID != "a4" {
+		t.Errorf("OnlyInA = %+v, want [a4]", diff.OnlyInA)
+	}
+	if len(diff.OnlyInB) != 1 || diff.OnlyInB[0].ID != "b3" {
+		t.Errorf("OnlyInB = %+v, want [b3]", diff.OnlyInB)
+	}
+	if diff.Similarity != 0.75 {
+		t.Errorf("Similarity = %v, want 0.75", diff.Similarity)
+	}
+
+	// Login request got 300ms slower, home screen appeared 580ms later
+	if len(diff.TimingDiffs) != 2 {
+		t.Fatalf("TimingDiffs = %+v, want 2 entries", diff.TimingDiffs)
+	}
+	if d := diff.TimingDiffs[0]; d.IDA != "a3" || d.GapDelta != 580 {
+		t.Errorf("largest diff = %+v, want HomeActivity with gap delta 580", d)
+	}
+	if d := diff.TimingDiffs[1]; d.IDA != "a2" || d.DurationDelta != 300 {
+		t.Errorf("second diff = %+v, want login request with duration delta 300", d)
+	}
+}
+
+func TestDiffEventTimelines_Empty(t *testing.T) {
+	diff := diffEventTimelines(nil, nil)
+	if diff.Similarity != 1 || diff.Matched != 0 || len(diff.OnlyInA) != 0 {
+		t.Errorf("unexpected diff for empty sessions: %+v", diff)
+	}
+
+	diff = diffEventTimelines(nil, []UnifiedEvent{compareEvent("b1", "touch", "Tap", 0, 0)})
+	if diff.Similarity != 0 || len(diff.OnlyInB) != 1 {
+		t.Errorf("unexpected diff against empty session: %+v", diff)
+	}
+}
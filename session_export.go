@@ -53,11 +53,7 @@ func (a *App) ExportSession(sessionID string) (string, error) {
 	defaultFilename := fmt.Sprintf("%s_%s.gaze", safeName, ts)
 
 	// Default save directory
-	defaultDir, _ := os.UserHomeDir()
-	downloadsDir := filepath.Join(defaultDir, "Downloads")
-	if _, err := os.Stat(downloadsDir); err == nil {
-		defaultDir = downloadsDir
-	}
+	defaultDir := a.outputDir(OutputExports)
 
 	// Show save dialog (only for GUI mode)
 	if a.ctx == nil || a.mcpMode {
@@ -236,11 +232,7 @@ func (a *App) ImportSession() (string, error) {
 		return "", fmt.Errorf("ImportSession requires GUI mode, use ImportSessionFromPath for MCP")
 	}
 
-	defaultDir, _ := os.UserHomeDir()
-	downloadsDir := filepath.Join(defaultDir, "Downloads")
-	if _, err := os.Stat(downloadsDir); err == nil {
-		defaultDir = downloadsDir
-	}
+	defaultDir := a.outputDir(OutputExports)
 
 	openPath, err := wailsRuntime.OpenFileDialog(a.ctx, wailsRuntime.OpenDialogOptions{
 		Title: "Import Session",
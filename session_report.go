@@ -0,0 +1,327 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// Session HTML Report
+// ========================================
+
+// Limits that keep reports for huge sessions bounded. Events are written page by
+// page; only screenshots (the bulk of the file) and raw event data are capped.
+const (
+	reportEventPageSize      = 500
+	reportMaxEventDataBytes  = 4 * 1024
+	reportMaxScreenshots     = 50
+	reportMaxScreenshotBytes = 2 * 1024 * 1024
+	reportMaxScreenshotTotal = 40 * 1024 * 1024
+)
+
+// screenshotEventPathKey is the "screenshot" event data field holding the saved image
+const screenshotEventPathKey = "screenshotPath"
+
+var reportImageMIME = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".webp": "image/webp",
+}
+
+// ExportSessionReport writes a self-contained HTML report of a session: event timeline,
+// bookmarks, assertion results and inlined screenshots. If savePath is empty, a save
+// dialog is shown; cancelling it returns an empty path.
+func (a *App) ExportSessionReport(sessionID, savePath string) (string, error) {
+	session, err := a.eventStore.GetSession(sessionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return "", fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	if savePath == "" {
+		if a.ctx == nil || a.mcpMode {
+			return "", fmt.Errorf("save path is required")
+		}
+
+		safeName := strings.ReplaceAll(session.Name, " ", "_")
+		safeName = strings.ReplaceAll(safeName, "/", "_")
+		if safeName == "" {
+			safeName = "session"
+		}
+		ts := time.UnixMilli(session.StartTime).Format("2006-01-02")
+		defaultDir := a.outputDir(OutputExports)
+
+		savePath, err = wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
+			DefaultFilename: fmt.Sprintf("%s_%s_report.html", safeName, ts),
+			Title:           "Export Session Report",
+			Filters: []wailsRuntime.FileFilter{
+				{DisplayName: "HTML Report (*.html)", Pattern: "*.html"},
+			},
+			DefaultDirectory: defaultDir,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to open save dialog: %w", err)
+		}
+		if savePath == "" {
+			return "", nil // User cancelled
+		}
+	}
+
+	if ext := strings.ToLower(filepath.Ext(savePath)); ext != ".html" && ext != ".htm" {
+		savePath += ".html"
+	}
+	if err := os.MkdirAll(filepath.Dir(savePath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := a.writeSessionReport(session, savePath); err != nil {
+		os.Remove(savePath)
+		return "", err
+	}
+	return savePath, nil
+}
+
+// sessionReportWriter tracks screenshot budget while a report is streamed out
+type sessionReportWriter struct {
+	w               *bufio.Writer
+	screenshots     int
+	screenshotBytes int64
+	skipped         int
+}
+
+func (a *App) writeSessionReport(session *DeviceSession, outputPath string) error {
+	LogInfo("session_report").Str("sessionId", session.ID).Str("path", outputPath).Msg("Starting session report export")
+
+	a.eventStore.Flush()
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	rw := &sessionReportWriter{w: bufio.NewWriterSize(f, 64*1024)}
+	rw.writeHeader(session)
+
+	bookmarks, err := a.eventStore.GetBookmarks(session.ID)
+	if err != nil {
+		LogWarn("session_report").Err(err).Msg("Failed to get bookmarks, skipping")
+	}
+	rw.writeBookmarks(bookmarks)
+
+	results, err := a.eventStore.ListAssertionResults(session.ID, "", 0)
+	if err != nil {
+		LogWarn("session_report").Err(err).Msg("Failed to get assertion results, skipping")
+	}
+	rw.writeAssertionResults(results)
+
+	io.WriteString(rw.w, "<h2>Timeline</h2>\n<table class=\"events\">\n<tr><th>Time</th><th>Level</th><th>Source</th><th>Type</th><th>Event</th></tr>\n")
+	written := 0
+	for offset := 0; ; offset += reportEventPageSize {
+		page, err := a.eventStore.QueryEvents(EventQuery{
+			SessionID:   session.ID,
+			Limit:       reportEventPageSize,
+			Offset:      offset,
+			IncludeData: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to query events: %w", err)
+		}
+		for i := range page.Events {
+			rw.writeEvent(&page.Events[i])
+		}
+		written += len(page.Events)
+		if !page.HasMore || len(page.Events) == 0 {
+			break
+		}
+	}
+	io.WriteString(rw.w, "</table>\n")
+	if written == 0 {
+		io.WriteString(rw.w, "<p class=\"muted\">No events recorded.</p>\n")
+	}
+	if rw.skipped > 0 {
+		fmt.Fprintf(rw.w, "<p class=\"muted\">%d screenshot(s) were not embedded to keep the report size bounded.</p>\n", rw.skipped)
+	}
+	io.WriteString(rw.w, "</body>\n</html>\n")
+
+	// bufio.Writer keeps the first write error, so checking Flush covers every write above
+	if err := rw.w.Flush(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	LogInfo("session_report").
+		Str("sessionId", session.ID).
+		Int("events", written).
+		Int("screenshots", rw.screenshots).
+		Int("skippedScreenshots", rw.skipped).
+		Msg("Session report exported")
+	return nil
+}
+
+func (rw *sessionReportWriter) writeHeader(session *DeviceSession) {
+	name := session.Name
+	if name == "" {
+		name = session.ID
+	}
+	esc := html.EscapeString
+
+	io.WriteString(rw.w, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(rw.w, "<title>%s - Gaze Session Report</title>\n", esc(name))
+	io.WriteString(rw.w, `<style>
+body{font-family:-apple-system,Segoe UI,Roboto,sans-serif;margin:24px;color:#222}
+table{border-collapse:collapse;width:100%;margin-bottom:24px;font-size:13px}
+th,td{border-bottom:1px solid #eee;padding:4px 8px;text-align:left;vertical-align:top}
+th{background:#fafafa}
+.muted{color:#888}
+.level-error,.level-fatal,.fail{color:#cf1322}
+.level-warn{color:#d48806}
+.pass{color:#389e0d}
+pre{white-space:pre-wrap;word-break:break-all;margin:4px 0;font-size:12px;background:#f6f6f6;padding:6px}
+img.shot{max-width:320px;border:1px solid #ddd;margin-top:4px}
+</style>
+</head>
+<body>
+`)
+	fmt.Fprintf(rw.w, "<h1>%s</h1>\n<table>\n", esc(name))
+	fmt.Fprintf(rw.w, "<tr><th>Session ID</th><td>%s</td></tr>\n", esc(session.ID))
+	fmt.Fprintf(rw.w, "<tr><th>Device</th><td>%s</td></tr>\n", esc(session.DeviceID))
+	fmt.Fprintf(rw.w, "<tr><th>Type</th><td>%s</td></tr>\n", esc(session.Type))
+	fmt.Fprintf(rw.w, "<tr><th>Status</th><td>%s</td></tr>\n", esc(session.Status))
+	fmt.Fprintf(rw.w, "<tr><th>Started</th><td>%s</td></tr>\n", time.UnixMilli(session.StartTime).Format("2006-01-02 15:04:05"))
+	if session.EndTime > 0 {
+		fmt.Fprintf(rw.w, "<tr><th>Duration</th><td>%s</td></tr>\n", time.Duration(session.EndTime-session.StartTime)*time.Millisecond)
+	}
+	fmt.Fprintf(rw.w, "<tr><th>Events</th><td>%d</td></tr>\n", session.EventCount)
+	fmt.Fprintf(rw.w, "<tr><th>Generated</th><td>%s</td></tr>\n</table>\n", time.Now().Format("2006-01-02 15:04:05"))
+}
+
+func (rw *sessionReportWriter) writeBookmarks(bookmarks []Bookmark) {
+	io.WriteString(rw.w, "<h2>Bookmarks</h2>\n")
+	if len(bookmarks) == 0 {
+		io.WriteString(rw.w, "<p class=\"muted\">No bookmarks.</p>\n")
+		return
+	}
+	io.WriteString(rw.w, "<table>\n<tr><th>Time</th><th>Type</th><th>Label</th></tr>\n")
+	for _, b := range bookmarks {
+		fmt.Fprintf(rw.w, "<tr><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			formatReportOffset(b.RelativeTime), html.EscapeString(b.Type), html.EscapeString(b.Label))
+	}
+	io.WriteString(rw.w, "</table>\n")
+}
+
+func (rw *sessionReportWriter) writeAssertionResults(results []StoredAssertionResult) {
+	io.WriteString(rw.w, "<h2>Assertions</h2>\n")
+	if len(results) == 0 {
+		io.WriteString(rw.w, "<p class=\"muted\">No assertions were run for this session.</p>\n")
+		return
+	}
+	io.WriteString(rw.w, "<table>\n<tr><th>Result</th><th>Assertion</th><th>Message</th><th>Executed</th></tr>\n")
+	for _, r := range results {
+		status, class := "PASS", "pass"
+		if !r.Passed {
+			status, class = "FAIL", "fail"
+		}
+		fmt.Fprintf(rw.w, "<tr><td class=\"%s\">%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			class, status, html.EscapeString(r.AssertionName), html.EscapeString(r.Message),
+			time.UnixMilli(r.ExecutedAt).Format("2006-01-02 15:04:05"))
+	}
+	io.WriteString(rw.w, "</table>\n")
+}
+
+func (rw *sessionReportWriter) writeEvent(e *UnifiedEvent) {
+	esc := html.EscapeString
+	fmt.Fprintf(rw.w, "<tr><td>%s</td><td class=\"level-%s\">%s</td><td>%s</td><td>%s</td><td>",
+		formatReportOffset(e.RelativeTime), esc(string(e.Level)), esc(string(e.Level)),
+		esc(string(e.Source)), esc(e.Type))
+
+	io.WriteString(rw.w, esc(e.Title))
+	if e.Summary != "" && e.Summary != e.Title {
+		fmt.Fprintf(rw.w, "<div class=\"muted\">%s</div>", esc(e.Summary))
+	}
+
+	if len(e.Data) > 0 && string(e.Data) != "null" {
+		data := string(e.Data)
+		if len(data) > reportMaxEventDataBytes {
+			data = data[:reportMaxEventDataBytes] + "... (truncated)"
+		}
+		fmt.Fprintf(rw.w, "<details><summary>data</summary><pre>%s</pre></details>", esc(data))
+		if path := reportScreenshotPath(e.Data); path != "" {
+			rw.writeScreenshot(path)
+		}
+	}
+	io.WriteString(rw.w, "</td></tr>\n")
+}
+
+// writeScreenshot inlines an image as a data URI, or leaves a note when it is
+// missing or over budget. The file is base64-encoded straight into the output.
+func (rw *sessionReportWriter) writeScreenshot(path string) {
+	esc := html.EscapeString
+	info, err := os.Stat(path)
+	if err != nil {
+		fmt.Fprintf(rw.w, "<div class=\"muted\">Screenshot not found: %s</div>", esc(path))
+		return
+	}
+	if rw.screenshots >= reportMaxScreenshots ||
+		info.Size() > reportMaxScreenshotBytes ||
+		rw.screenshotBytes+info.Size() > reportMaxScreenshotTotal {
+		rw.skipped++
+		fmt.Fprintf(rw.w, "<div class=\"muted\">Screenshot not embedded: %s</div>", esc(path))
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(rw.w, "<div class=\"muted\">Screenshot not readable: %s</div>", esc(path))
+		return
+	}
+	defer f.Close()
+
+	mime := reportImageMIME[strings.ToLower(filepath.Ext(path))]
+	fmt.Fprintf(rw.w, "<div><img class=\"shot\" alt=\"%s\" src=\"data:%s;base64,", esc(filepath.Base(path)), mime)
+	enc := base64.NewEncoder(base64.StdEncoding, rw.w)
+	if _, err := io.Copy(enc, f); err != nil {
+		LogWarn("session_report").Err(err).Str("path", path).Msg("Failed to embed screenshot")
+	}
+	enc.Close()
+	io.WriteString(rw.w, "\"></div>")
+
+	rw.screenshots++
+	rw.screenshotBytes += info.Size()
+}
+
+// reportScreenshotPath returns the image file referenced by event data, if any
+func reportScreenshotPath(data json.RawMessage) string {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return ""
+	}
+	path, ok := fields[screenshotEventPathKey].(string)
+	if !ok || path == "" {
+		return ""
+	}
+	if _, ok := reportImageMIME[strings.ToLower(filepath.Ext(path))]; !ok {
+		return ""
+	}
+	return path
+}
+
+// formatReportOffset renders a session-relative time as mm:ss.mmm
+func formatReportOffset(ms int64) string {
+	if ms < 0 {
+		ms = 0
+	}
+	return fmt.Sprintf("%02d:%02d.%03d", ms/60000, (ms/1000)%60, ms%1000)
+}
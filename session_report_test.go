@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestExportSessionReport_EmbedsTimelineAndScreenshot(t *testing.T) {
+	app, tempDir, cleanup := setupTestAppForExport(t)
+	defer cleanup()
+
+	session := createTestSession(t, app.eventStore, "Report <Test>")
+	createTestEvents(t, app.eventStore, session.ID, session.DeviceID, reportEventPageSize+5)
+	createTestBookmarks(t, app.eventStore, session.ID, 2)
+
+	shotPath := filepath.Join(tempDir, "shot.png")
+	if err := os.WriteFile(shotPath, []byte("fake-png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(map[string]string{"screenshotPath": shotPath})
+	if err := app.eventStore.WriteEventDirect(UnifiedEvent{
+		ID:           uuid.New().String(),
+		SessionID:    session.ID,
+		DeviceID:     session.DeviceID,
+		Timestamp:    time.Now().UnixMilli(),
+		RelativeTime: 999999,
+		Source:       SourceWorkflow,
+		Category:     CategoryAutomation,
+		Type:         "workflow_step_end",
+		Level:        LevelError,
+		Title:        "Step failed",
+		Data:         data,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := app.ExportSessionReport(session.ID, filepath.Join(tempDir, "out", "report"))
+	if err != nil {
+		t.Fatalf("ExportSessionReport failed: %v", err)
+	}
+	if !strings.HasSuffix(result, "report.html") {
+		t.Errorf("expected .html to be appended, got %s", result)
+	}
+
+	content, err := os.ReadFile(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(content)
+
+	if !strings.Contains(html, "Report &lt;Test&gt;") {
+		t.Error("session name should be HTML-escaped")
+	}
+	// Every page of events is written
+	if got := strings.Count(html, "Test log "); got != reportEventPageSize+5 {
+		t.Errorf("expected %d events in report, got %d", reportEventPageSize+5, got)
+	}
+	if strings.Count(html, "Bookmark ") != 2 {
+		t.Error("expected both bookmarks in report")
+	}
+	if !strings.Contains(html, "data:image/png;base64,ZmFrZS1wbmc=") {
+		t.Error("expected screenshot to be inlined as base64")
+	}
+	if !strings.HasSuffix(html, "</html>\n") {
+		t.Error("report should be a complete document")
+	}
+}
+
+func TestExportSessionReport_SessionNotFound(t *testing.T) {
+	app, tempDir, cleanup := setupTestAppForExport(t)
+	defer cleanup()
+
+	if _, err := app.ExportSessionReport("missing", filepath.Join(tempDir, "r.html")); err == nil {
+		t.Fatal("expected error for missing session")
+	}
+}
+
+func TestReportScreenshotPath(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{`{"screenshotPath":"/tmp/a.png"}`, "/tmp/a.png"},
+		{`{"screenshotPath":"/tmp/b.JPG"}`, "/tmp/b.JPG"},
+		{`{"screenshotPath":"/tmp/c.txt"}`, ""},
+		{`{"imagePath":"/tmp/e.png"}`, ""},
+		{`{"path":"/tmp/d.png"}`, ""},
+		{`[1,2]`, ""},
+	}
+	for _, tt := range tests {
+		if got := reportScreenshotPath(json.RawMessage(tt.data)); got != tt.want {
+			t.Errorf("reportScreenshotPath(%s) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestExportSessionReport_EmbedsCapturedScreenshot(t *testing.T) {
+	app, tempDir, cleanup := setupTestAppForSession(t)
+	defer cleanup()
+
+	const deviceID = "report-shot-device"
+	sessionID := app.eventPipeline.EnsureActiveSession(deviceID)
+
+	shotPath := filepath.Join(tempDir, "capture.png")
+	if err := os.WriteFile(shotPath, []byte("fake-png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app.mcpMode = false // MCP screenshots are not recorded
+	app.emitScreenshotEvent(deviceID, shotPath)
+	app.mcpMode = true
+	waitForPipeline()
+
+	result, err := app.ExportSessionReport(sessionID, filepath.Join(tempDir, "report.html"))
+	if err != nil {
+		t.Fatalf("ExportSessionReport failed: %v", err)
+	}
+	content, err := os.ReadFile(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "data:image/png;base64,ZmFrZS1wbmc=") {
+		t.Error("expected the captured screenshot to be inlined")
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Gaze/pkg/cache"
+)
+
+// ========================================
+// Key-Value Settings
+// ========================================
+
+const (
+	maxSettingKeyLen   = 128
+	maxSettingValueLen = 64 * 1024
+)
+
+// legacySetting maps a key onto one of the typed fields settings.json had before the
+// key-value store, so existing settings stay readable and writable under that key
+type legacySetting struct {
+	get func(*cache.Service) string
+	set func(*cache.Service, string) error
+}
+
+var legacySettings = map[string]legacySetting{
+	"pinnedSerial": {
+		get: func(s *cache.Service) string { return s.GetPinnedSerial() },
+		set: func(s *cache.Service, v string) error { s.SetPinnedSerial(v); return nil },
+	},
+	"autoSessions": {
+		get: func(s *cache.Service) string { return strconv.FormatBool(s.GetAutoSessions()) },
+		set: func(s *cache.Service, v string) error {
+			enabled, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("autoSessions must be true or false")
+			}
+			s.SetAutoSessions(enabled)
+			return nil
+		},
+	},
+	"safeMode": {
+		get: func(s *cache.Service) string { return strconv.FormatBool(s.GetSafeMode()) },
+		set: func(s *cache.Service, v string) error {
+			enabled, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("safeMode must be true or false")
+			}
+			s.SetSafeMode(enabled)
+			return nil
+		},
+	},
+}
+
+func validateSettingKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("setting key is required")
+	}
+	if len(key) > maxSettingKeyLen {
+		return fmt.Errorf("setting key is longer than %d characters", maxSettingKeyLen)
+	}
+	return nil
+}
+
+// GetSetting returns a stored setting, or "" when it was never set
+func (a *App) GetSetting(key string) (string, error) {
+	if err := validateSettingKey(key); err != nil {
+		return "", err
+	}
+	if a.cacheService == nil {
+		return "", fmt.Errorf("settings are not available")
+	}
+	if legacy, ok := legacySettings[key]; ok {
+		return legacy.get(a.cacheService), nil
+	}
+	value, _ := a.cacheService.GetValue(key)
+	return value, nil
+}
+
+// SetSetting stores a setting in settings.json. An empty value removes the key.
+func (a *App) SetSetting(key, value string) error {
+	if err := validateSettingKey(key); err != nil {
+		return err
+	}
+	if len(value) > maxSettingValueLen {
+		return fmt.Errorf("setting value is larger than %d bytes", maxSettingValueLen)
+	}
+	if a.cacheService == nil {
+		return fmt.Errorf("settings are not available")
+	}
+
+	if legacy, ok := legacySettings[key]; ok {
+		if err := legacy.set(a.cacheService, value); err != nil {
+			return err
+		}
+	} else {
+		a.cacheService.SetValue(key, value)
+	}
+	go a.saveSettings()
+	return nil
+}
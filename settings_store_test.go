@@ -0,0 +1,103 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"Gaze/pkg/cache"
+)
+
+func TestSettingRoundTripPersists(t *testing.T) {
+	dir := t.TempDir()
+	svc, err := cache.New(cache.Config{ConfigDir: dir})
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	app := newTestApp(nil)
+	app.cacheService = svc
+
+	if err := app.SetSetting("theme", "dark"); err != nil {
+		t.Fatalf("SetSetting: %v", err)
+	}
+	if err := app.SetSetting("pinnedSerial", "R5CT1234ABC"); err != nil {
+		t.Fatalf("SetSetting legacy: %v", err)
+	}
+	if err := svc.SaveSettings(); err != nil {
+		t.Fatalf("SaveSettings: %v", err)
+	}
+
+	reloaded, err := cache.New(cache.Config{ConfigDir: dir})
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	reloadedApp := newTestApp(nil)
+	reloadedApp.cacheService = reloaded
+	if got, _ := reloadedApp.GetSetting("theme"); got != "dark" {
+		t.Errorf("theme = %q, want dark", got)
+	}
+	if got := reloaded.GetPinnedSerial(); got != "R5CT1234ABC" {
+		t.Errorf("pinnedSerial should stay in its typed field, got %q", got)
+	}
+	if _, ok := reloaded.GetValue("pinnedSerial"); ok {
+		t.Error("legacy key was duplicated into the key-value map")
+	}
+	if got, _ := reloadedApp.GetSetting("missing"); got != "" {
+		t.Errorf("unset key = %q", got)
+	}
+}
+
+func TestSetSettingValidation(t *testing.T) {
+	app := newOutputTestApp(t)
+	if err := app.SetSetting("", "x"); err == nil {
+		t.Error("expected error for an empty key")
+	}
+	if err := app.SetSetting(strings.Repeat("k", maxSettingKeyLen+1), "x"); err == nil {
+		t.Error("expected error for a long key")
+	}
+	if err := app.SetSetting("safeMode", "maybe"); err == nil {
+		t.Error("expected error for a non-boolean safeMode")
+	}
+	if err := app.SetSetting("theme", "dark"); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.SetSetting("theme", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := app.cacheService.GetValue("theme"); ok {
+		t.Error("empty value should remove the key")
+	}
+}
+
+func TestRetentionPolicyUsesSettings(t *testing.T) {
+	app := newOutputTestApp(t)
+	if got := app.GetRetentionPolicy(); got != (RetentionPolicy{}) {
+		t.Fatalf("default policy = %+v, want no limits", got)
+	}
+
+	if err := app.SetRetentionPolicy(14, 512); err != nil {
+		t.Fatalf("SetRetentionPolicy: %v", err)
+	}
+	if got, _ := app.GetSetting(retentionMaxAgeDaysKey); got != "14" {
+		t.Errorf("%s = %q, want 14", retentionMaxAgeDaysKey, got)
+	}
+	if got := app.GetRetentionPolicy(); got != (RetentionPolicy{MaxAgeDays: 14, MaxTotalSizeMB: 512}) {
+		t.Errorf("policy = %+v", got)
+	}
+
+	if err := app.SetRetentionPolicy(0, 512); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := app.cacheService.GetValue(retentionMaxAgeDaysKey); ok {
+		t.Error("a zero limit should remove its key")
+	}
+	if err := app.SetRetentionPolicy(-1, 0); err == nil {
+		t.Error("expected error for a negative limit")
+	}
+
+	if err := app.SetSetting(retentionMaxTotalSizeMBKey, "lots"); err != nil {
+		t.Fatal(err)
+	}
+	if got := app.GetRetentionPolicy().MaxTotalSizeMB; got != 0 {
+		t.Errorf("invalid setting should disable the limit, got %d", got)
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// shellSubcommands is the built-in completion table for common device tools. It is
+// merged with whatever the tool's own help lists, which varies by Android release.
+var shellSubcommands = map[string][]string{
+	"pm": {
+		"list", "path", "dump", "install", "install-existing", "uninstall", "clear",
+		"enable", "disable", "disable-user", "disable-until-used", "suspend", "unsuspend",
+		"grant", "revoke", "reset-permissions", "set-installer", "trim-caches",
+		"create-user", "remove-user", "get-max-users", "resolve-activity", "query-activities",
+		"query-services", "query-receivers", "compile", "force-dex-opt", "help",
+	},
+	"am": {
+		"start", "start-activity", "start-service", "start-foreground-service", "stop-service",
+		"broadcast", "instrument", "force-stop", "kill", "kill-all", "profile", "dumpheap",
+		"set-debug-app", "clear-debug-app", "monitor", "stack", "task", "get-config",
+		"switch-user", "get-current-user", "help",
+	},
+	"settings": {"get", "put", "delete", "list", "reset", "help"},
+	"dumpsys": {
+		"activity", "battery", "batterystats", "connectivity", "cpuinfo", "display", "gfxinfo",
+		"input", "meminfo", "netstats", "notification", "package", "power", "procstats",
+		"SurfaceFlinger", "usagestats", "window", "wifi",
+	},
+	"input": {"text", "keyevent", "tap", "swipe", "draganddrop", "press", "roll", "motionevent", "keycombination"},
+	"wm":    {"size", "density", "overscan", "scaling", "dismiss-keyguard", "user-rotation", "fixed-to-user-rotation", "help"},
+	"svc":   {"power", "data", "wifi", "usb", "nfc", "bluetooth"},
+	"cmd": {
+		"package", "activity", "settings", "statusbar", "notification", "wifi", "connectivity",
+		"appops", "battery", "jobscheduler", "overlay", "uimode", "role", "device_config",
+	},
+}
+
+// helpCommands lists, per tool, the device command whose output enumerates more subcommands
+var helpCommands = map[string]string{
+	"pm":      "pm help",
+	"am":      "am help",
+	"wm":      "wm help",
+	"dumpsys": "dumpsys -l",
+	"cmd":     "cmd -l",
+}
+
+var (
+	shellHelpCache   = make(map[string][]string) // deviceId + "|" + tool
+	shellHelpCacheMu sync.Mutex
+)
+
+// GetShellCommandCompletions returns candidates for the last word of a partial device
+// shell command (without the leading "shell"). With no tool typed yet it completes
+// tool names, after a known tool it completes subcommands, and for a word starting
+// with "/" it completes paths on the device. Candidates replace the last word; directory
+// candidates end with "/".
+func (a *App) GetShellCommandCompletions(deviceId, partial string) ([]string, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return nil, err
+	}
+
+	words := strings.Fields(partial)
+	current := ""
+	if len(words) > 0 && !strings.HasSuffix(partial, " ") {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	if strings.HasPrefix(current, "/") {
+		return a.completeDevicePath(deviceId, current)
+	}
+
+	if len(words) == 0 {
+		tools := make([]string, 0, len(shellSubcommands))
+		for tool := range shellSubcommands {
+			tools = append(tools, tool)
+		}
+		return filterCompletions(tools, current), nil
+	}
+
+	if len(words) == 1 {
+		tool := words[0]
+		candidates := append([]string(nil), shellSubcommands[tool]...)
+		candidates = append(candidates, a.shellHelpSubcommands(deviceId, tool)...)
+		return filterCompletions(candidates, current), nil
+	}
+
+	return []string{}, nil
+}
+
+// completeDevicePath lists the directory part of a partial path and returns matching entries
+func (a *App) completeDevicePath(deviceId, partialPath string) ([]string, error) {
+	dir, prefix := path.Split(partialPath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	files, err := a.listDir(ctx, deviceId, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []string
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name, prefix) {
+			continue
+		}
+		candidate := dir + f.Name
+		if f.IsDir {
+			candidate += "/"
+		}
+		candidates = append(candidates, candidate)
+	}
+	sort.Strings(candidates)
+	return candidates, nil
+}
+
+// shellHelpSubcommands runs the tool's help listing once per device and caches it
+func (a *App) shellHelpSubcommands(deviceId, tool string) []string {
+	helpCmd, ok := helpCommands[tool]
+	if !ok {
+		return nil
+	}
+
+	key := deviceId + "|" + tool
+	shellHelpCacheMu.Lock()
+	cached, ok := shellHelpCache[key]
+	shellHelpCacheMu.Unlock()
+	if ok {
+		return cached
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Help text goes to stdout or stderr depending on the tool and release
+	output, err := a.runAdbCombined(ctx, "-s", deviceId, "shell", helpCmd)
+	if err != nil && len(output) == 0 {
+		return nil
+	}
+
+	var subcommands []string
+	if tool == "cmd" {
+		subcommands = parseServiceList(string(output))
+	} else {
+		subcommands = parseHelpSubcommands(string(output))
+	}
+
+	shellHelpCacheMu.Lock()
+	shellHelpCache[key] = subcommands
+	shellHelpCacheMu.Unlock()
+	return subcommands
+}
+
+// Subcommands in pm/am/wm help and services in `dumpsys -l` are indented by exactly
+// two spaces; descriptions and continuation lines are indented further.
+var helpSubcommandRegex = regexp.MustCompile(`^  ([A-Za-z][\w.-]*)(?:\s|$)`)
+
+func parseHelpSubcommands(output string) []string {
+	var subcommands []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if m := helpSubcommandRegex.FindStringSubmatch(line); m != nil {
+			subcommands = append(subcommands, m[1])
+		}
+	}
+	return subcommands
+}
+
+// parseServiceList parses `cmd -l`, which prints one service name per line
+func parseServiceList(output string) []string {
+	var services []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasSuffix(line, ":") || strings.Contains(line, " ") {
+			continue
+		}
+		services = append(services, line)
+	}
+	return services
+}
+
+// filterCompletions returns the sorted, de-duplicated candidates starting with prefix
+func filterCompletions(candidates []string, prefix string) []string {
+	seen := make(map[string]bool)
+	result := []string{}
+	for _, c := range candidates {
+		if !strings.HasPrefix(c, prefix) || seen[c] {
+			continue
+		}
+		seen[c] = true
+		result = append(result, c)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+const pmHelpOutput = `Package manager (package) commands:
+  help
+    Print this help text.
+
+  path [--user USER_ID] PACKAGE
+    Print the path to the .apk of the given PACKAGE.
+
+  list packages [-f] [-d] [-e] [-s] [-3] [-i] [-l] [-u] [-U]
+      [--show-versioncode] [--apex-only] [--uid UID] [--user USER_ID] [FILTER]
+    Prints all packages; optionally only those whose name contains
+    the text in FILTER.  Options are:
+      -f: see their associated file
+
+  get-app-links [--user <USER_ID>] [<PACKAGE>]
+`
+
+const dumpsysListOutput = `Currently running services:
+  DockObserver
+  SurfaceFlinger
+  accessibility
+  activity
+`
+
+func TestParseHelpSubcommands(t *testing.T) {
+	if got, want := parseHelpSubcommands(pmHelpOutput), []string{"help", "path", "list", "get-app-links"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pm help = %v, want %v", got, want)
+	}
+	if got, want := parseHelpSubcommands(dumpsysListOutput), []string{"DockObserver", "SurfaceFlinger", "accessibility", "activity"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dumpsys -l = %v, want %v", got, want)
+	}
+}
+
+func TestGetShellCommandCompletions(t *testing.T) {
+	a := newTestApp(map[string]string{
+		"-s dev1 shell pm help": pmHelpOutput,
+		"-s dev1 shell ls -la '/sdcard/'": `total 8
+drwxrwx--x  5 root sdcard_rw 4096 2024-03-02 18:40 DCIM
+drwxrwx--x  2 root sdcard_rw 4096 2024-03-01 10:12 Download
+-rw-rw----  1 root sdcard_rw 1832 2024-03-05 09:01 dump.txt
+`,
+	})
+
+	tests := []struct {
+		partial string
+		want    []string
+	}{
+		{"p", []string{"pm"}},
+		{"pm li", []string{"list"}},
+		{"pm ge", []string{"get-app-links", "get-max-users"}},
+		{"settings ", []string{"delete", "get", "help", "list", "put", "reset"}},
+		{"ls /sdcard/D", []string{"/sdcard/DCIM/", "/sdcard/Download/"}},
+		{"pm list packages -", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.partial, func(t *testing.T) {
+			got, err := a.GetShellCommandCompletions("dev1", tt.partial)
+			if err != nil {
+				t.Fatalf("GetShellCommandCompletions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetShellCommandCompletions(%q) = %v, want %v", tt.partial, got, tt.want)
+			}
+		})
+	}
+}
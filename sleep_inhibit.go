@@ -0,0 +1,59 @@
+package main
+
+import "sync"
+
+// ========================================
+// Host sleep inhibition
+// ========================================
+
+// sleepInhibitor keeps the host machine awake while mirroring or recording runs.
+// A laptop going to sleep halfway through a long recording kills the scrcpy process.
+type sleepInhibitor struct {
+	mu      sync.Mutex
+	release func()
+
+	// start takes the OS-level inhibition; nil uses startSleepInhibit (tests inject a fake)
+	start func() (func(), error)
+}
+
+// update takes the inhibition when active > 0 and drops it once nothing is active
+func (s *sleepInhibitor) update(active int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if active > 0 && s.release == nil {
+		start := s.start
+		if start == nil {
+			start = startSleepInhibit
+		}
+		release, err := start()
+		if err != nil {
+			LogWarn("scrcpy").Err(err).Msg("Failed to keep host awake")
+			return
+		}
+		s.release = release
+		LogDebug("scrcpy").Int("active", active).Msg("Host sleep inhibited")
+	} else if active == 0 && s.release != nil {
+		s.release()
+		s.release = nil
+		LogDebug("scrcpy").Msg("Host sleep inhibition released")
+	}
+}
+
+// active reports whether the inhibition is currently held
+func (s *sleepInhibitor) active() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.release != nil
+}
+
+// syncSleepInhibitLocked counts running mirrors and recordings into the sleep inhibitor.
+// Callers must hold scrcpyMu and call it after every change to the scrcpy process maps.
+func (a *App) syncSleepInhibitLocked() {
+	a.sleepInhibit.update(len(a.scrcpyCmds) + len(a.scrcpyRecordCmd))
+}
+
+// IsHostSleepInhibited reports whether the app is currently keeping the computer awake
+func (a *App) IsHostSleepInhibited() bool {
+	return a.sleepInhibit.active()
+}
@@ -0,0 +1,23 @@
+//go:build darwin
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// startSleepInhibit runs caffeinate to prevent idle sleep. -w ties it to this process,
+// so the assertion also goes away if the app exits without releasing it.
+func startSleepInhibit() (func(), error) {
+	cmd := exec.Command("caffeinate", "-i", "-w", strconv.Itoa(os.Getpid()))
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start caffeinate: %w", err)
+	}
+	return func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}, nil
+}
@@ -0,0 +1,35 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// startSleepInhibit takes a systemd-logind sleep/idle lock. The lock is held by
+// systemd-inhibit for as long as its child runs; the child is cat reading our pipe,
+// so it exits on release and also when the app dies and the pipe closes.
+func startSleepInhibit() (func(), error) {
+	path, err := exec.LookPath("systemd-inhibit")
+	if err != nil {
+		return nil, fmt.Errorf("systemd-inhibit not available: %w", err)
+	}
+	cmd := exec.Command(path,
+		"--what=sleep:idle",
+		"--who=Gaze",
+		"--why=Android device mirroring or recording in progress",
+		"--mode=block",
+		"cat")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start systemd-inhibit: %w", err)
+	}
+	return func() {
+		_ = stdin.Close()
+		_ = cmd.Wait()
+	}, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestSleepInhibitorRefcount(t *testing.T) {
+	starts, releases := 0, 0
+	s := &sleepInhibitor{start: func() (func(), error) {
+		starts++
+		return func() { releases++ }, nil
+	}}
+
+	s.update(1)
+	s.update(2)
+	if starts != 1 || !s.active() {
+		t.Fatalf("after two holders: starts=%d active=%v, want 1 and true", starts, s.active())
+	}
+	s.update(1)
+	if releases != 0 {
+		t.Fatalf("released with a holder left")
+	}
+	s.update(0)
+	if releases != 1 || s.active() {
+		t.Fatalf("after last holder: releases=%d active=%v, want 1 and false", releases, s.active())
+	}
+	s.update(0)
+	if releases != 1 {
+		t.Errorf("released twice")
+	}
+}
+
+func TestSleepInhibitorStartFailure(t *testing.T) {
+	s := &sleepInhibitor{start: func() (func(), error) {
+		return nil, errors.New("no inhibitor")
+	}}
+	s.update(1)
+	if s.active() {
+		t.Error("inhibitor active after a failed start")
+	}
+}
+
+func TestSyncSleepInhibitCountsMirrorsAndRecordings(t *testing.T) {
+	app := &App{
+		scrcpyCmds:      make(map[string]*exec.Cmd),
+		scrcpyRecordCmd: make(map[string]*exec.Cmd),
+	}
+	app.sleepInhibit.start = func() (func(), error) { return func() {}, nil }
+
+	app.scrcpyMu.Lock()
+	app.scrcpyCmds["dev1"] = &exec.Cmd{}
+	app.syncSleepInhibitLocked()
+	app.scrcpyRecordCmd["dev2"] = &exec.Cmd{}
+	delete(app.scrcpyCmds, "dev1")
+	app.syncSleepInhibitLocked()
+	app.scrcpyMu.Unlock()
+	if !app.IsHostSleepInhibited() {
+		t.Fatal("expected inhibition while a recording runs")
+	}
+
+	app.scrcpyMu.Lock()
+	delete(app.scrcpyRecordCmd, "dev2")
+	app.syncSleepInhibitLocked()
+	app.scrcpyMu.Unlock()
+	if app.IsHostSleepInhibited() {
+		t.Error("expected inhibition released with nothing running")
+	}
+}
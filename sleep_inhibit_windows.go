@@ -0,0 +1,42 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+)
+
+var procSetThreadExecutionState = syscall.NewLazyDLL("kernel32.dll").NewProc("SetThreadExecutionState")
+
+const (
+	esSystemRequired = 0x00000001
+	esContinuous     = 0x80000000
+)
+
+// startSleepInhibit marks the system as required via SetThreadExecutionState. The state
+// belongs to the calling thread, so a locked goroutine holds it until release.
+func startSleepInhibit() (func(), error) {
+	started := make(chan error, 1)
+	done := make(chan struct{})
+
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		if r, _, err := procSetThreadExecutionState.Call(uintptr(esContinuous | esSystemRequired)); r == 0 {
+			started <- fmt.Errorf("SetThreadExecutionState failed: %w", err)
+			return
+		}
+		started <- nil
+
+		<-done
+		_, _, _ = procSetThreadExecutionState.Call(uintptr(esContinuous))
+	}()
+
+	if err := <-started; err != nil {
+		return nil, err
+	}
+	return func() { close(done) }, nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ========================================
+// Split APK bundling
+// ========================================
+
+// SplitAPKBundle describes the file MergeSplitAPKs wrote
+type SplitAPKBundle struct {
+	Path   string   `json:"path"`   // Where the file was written; the extension may differ from the requested one
+	Format string   `json:"format"` // "apk" for apps installed as a single APK, "apks" for split installs
+	Files  []string `json:"files"`  // APK file names inside the bundle, base first
+	Merged bool     `json:"merged"` // Whether the result is one plain APK that installs anywhere
+	Note   string   `json:"note,omitempty"`
+}
+
+// splitBundleNote explains why a split install is bundled rather than merged: merging
+// splits means rebuilding the resource table and re-signing the APK, which Gaze can't do
+const splitBundleNote = "The app is installed as split APKs. They were bundled into an .apks archive, " +
+	"not merged into one APK; install it with Gaze (drag and drop) or any installer that supports " +
+	"split APKs, such as adb install-multiple or SAI."
+
+// MergeSplitAPKs pulls every APK of an installed package into savePath so the app can be
+// installed on another device. Apps installed as a single APK are copied as-is. Split
+// installs are stored as an .apks archive that InstallPackage reinstalls with
+// install-multiple; the returned bundle reports this in Merged and Note.
+func (a *App) MergeSplitAPKs(deviceId, packageName, savePath string) (SplitAPKBundle, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return SplitAPKBundle{}, err
+	}
+	if packageName == "" {
+		return SplitAPKBundle{}, fmt.Errorf("package name is required")
+	}
+	if savePath == "" {
+		return SplitAPKBundle{}, fmt.Errorf("save path is required")
+	}
+	a.updateLastActive(deviceId)
+
+	paths, err := a.packageAPKPaths(deviceId, packageName)
+	if err != nil {
+		return SplitAPKBundle{}, err
+	}
+	bundle := newSplitAPKBundle(paths, savePath)
+
+	if err := os.MkdirAll(filepath.Dir(bundle.Path), 0755); err != nil {
+		return SplitAPKBundle{}, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if bundle.Merged {
+		pullCmd := a.newAdbCommand(nil, "-s", deviceId, "pull", paths[0], bundle.Path)
+		if output, err := pullCmd.CombinedOutput(); err != nil {
+			return SplitAPKBundle{}, fmt.Errorf("failed to pull APK: %w (output: %s)", err, string(output))
+		}
+	} else if err := a.exportSplitAPKs(deviceId, orderBaseFirst(paths), bundle.Path); err != nil {
+		return SplitAPKBundle{}, err
+	}
+
+	a.Log("Saved %d APK(s) of %s to %s", len(bundle.Files), packageName, bundle.Path)
+	return bundle, nil
+}
+
+// newSplitAPKBundle describes the output for the APK paths of a package, fixing the
+// extension of savePath to match what will be written
+func newSplitAPKBundle(paths []string, savePath string) SplitAPKBundle {
+	ordered := orderBaseFirst(paths)
+	files := make([]string, len(ordered))
+	for i, p := range ordered {
+		files[i] = path.Base(p)
+	}
+
+	format := "apks"
+	if len(paths) == 1 {
+		format = "apk"
+	}
+	ext := filepath.Ext(savePath)
+	if !strings.EqualFold(ext, "."+format) {
+		savePath = strings.TrimSuffix(savePath, ext) + "." + format
+	}
+
+	bundle := SplitAPKBundle{Path: savePath, Format: format, Files: files, Merged: len(paths) == 1}
+	if !bundle.Merged {
+		bundle.Note = splitBundleNote
+	}
+	return bundle
+}
+
+// orderBaseFirst returns the paths with the base APK moved to the front
+func orderBaseFirst(paths []string) []string {
+	base := baseAPKPath(paths)
+	ordered := []string{base}
+	for _, p := range paths {
+		if p != base {
+			ordered = append(ordered, p)
+		}
+	}
+	return ordered
+}
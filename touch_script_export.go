@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ExportScriptAsShell converts a saved touch script into a standalone bash script of
+// `adb shell input` commands, so a recording can be replayed without Gaze (e.g. in CI).
+// If savePath is empty, a save dialog is shown; cancelling it returns nil.
+func (a *App) ExportScriptAsShell(scriptName, savePath string) error {
+	scripts, err := a.LoadTouchScripts()
+	if err != nil {
+		return err
+	}
+
+	var script *TouchScript
+	for i := range scripts {
+		if scripts[i].Name == scriptName {
+			script = &scripts[i]
+			break
+		}
+	}
+	if script == nil {
+		return fmt.Errorf("script not found: %s", scriptName)
+	}
+
+	if savePath == "" {
+		if a.ctx == nil || a.mcpMode {
+			return fmt.Errorf("save path is required")
+		}
+		safeName := regexp.MustCompile(`[^a-zA-Z0-9_-]`).ReplaceAllString(script.Name, "_")
+		savePath, err = wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
+			DefaultFilename: safeName + ".sh",
+			Title:           "Export Script as Shell",
+			Filters: []wailsRuntime.FileFilter{
+				{DisplayName: "Shell Script (*.sh)", Pattern: "*.sh"},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to open save dialog: %w", err)
+		}
+		if savePath == "" {
+			return nil // User cancelled
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(savePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(savePath, []byte(renderTouchScriptShell(*script)), 0755); err != nil {
+		return fmt.Errorf("failed to write shell script: %w", err)
+	}
+	return nil
+}
+
+// renderTouchScriptShell builds the bash script for a touch script.
+// Timing follows playback: each event waits until its (speed-adjusted) timestamp,
+// and wait events add their own duration on top.
+func renderTouchScriptShell(script TouchScript) string {
+	var b strings.Builder
+
+	speed := script.PlaybackSpeed
+	if speed <= 0 {
+		speed = 1.0
+	}
+
+	b.WriteString("#!/usr/bin/env bash\n")
+	fmt.Fprintf(&b, "# Touch script %q exported from Gaze\n", script.Name)
+	source := script.DeviceModel
+	if source == "" {
+		source = script.DeviceID
+	}
+	if source != "" {
+		fmt.Fprintf(&b, "# Recorded on: %s\n", shellCommentText(source))
+	}
+	if script.CreatedAt != "" {
+		fmt.Fprintf(&b, "# Created: %s\n", shellCommentText(script.CreatedAt))
+	}
+	resolution := shellCommentText(script.Resolution)
+	if resolution == "" {
+		resolution = "unknown"
+	}
+	fmt.Fprintf(&b, "# Source resolution: %s (coordinates are not rescaled for other screens)\n", resolution)
+	if speed != 1.0 {
+		fmt.Fprintf(&b, "# Playback speed: %gx (already applied to the delays below)\n", speed)
+	}
+	b.WriteString("#\n")
+	b.WriteString("# Note: taps replay their recorded coordinates only; selector-based smart\n")
+	b.WriteString("# tapping is not available outside Gaze.\n")
+	b.WriteString("#\n")
+	b.WriteString("# Usage: SERIAL=<device serial> ./script.sh   or   ./script.sh <device serial>\n")
+	b.WriteString("set -euo pipefail\n\n")
+	b.WriteString("SERIAL=\"${1:-${SERIAL:-}}\"\n")
+	b.WriteString("if [ -z \"$SERIAL\" ]; then\n")
+	b.WriteString("  echo \"usage: SERIAL=<device serial> $0  (or pass the serial as the first argument)\" >&2\n")
+	b.WriteString("  exit 1\n")
+	b.WriteString("fi\n")
+	b.WriteString("ADB=\"${ADB:-adb}\"\n\n")
+
+	var last int64
+	for _, event := range script.Events {
+		at := int64(float64(event.Timestamp) / speed)
+		if at > last {
+			writeShellSleep(&b, at-last)
+			last = at
+		}
+
+		switch event.Type {
+		case "tap":
+			if event.Selector != nil && event.Selector.Type != "coordinates" && event.Selector.Value != "" {
+				fmt.Fprintf(&b, "# smart tap %s=%q replayed as coordinates\n", event.Selector.Type, event.Selector.Value)
+			}
+			fmt.Fprintf(&b, "\"$ADB\" -s \"$SERIAL\" shell input tap %d %d\n", event.X, event.Y)
+		case "long_press":
+			duration := event.Duration
+			if duration < 500 {
+				duration = 1000 // Same default as playback
+			}
+			fmt.Fprintf(&b, "\"$ADB\" -s \"$SERIAL\" shell input swipe %d %d %d %d %d\n", event.X, event.Y, event.X, event.Y, duration)
+		case "swipe":
+			fmt.Fprintf(&b, "\"$ADB\" -s \"$SERIAL\" shell input swipe %d %d %d %d %d\n", event.X, event.Y, event.X2, event.Y2, event.Duration)
+		case "wait":
+			// The wait elapses before the next event's timestamp, as during playback
+			wait := int64(float64(event.Duration) / speed)
+			if wait > 0 {
+				writeShellSleep(&b, wait)
+				last += wait
+			}
+		default:
+			fmt.Fprintf(&b, "# skipped unsupported event type %q\n", event.Type)
+		}
+	}
+
+	return b.String()
+}
+
+// shellCommentText keeps recorded metadata on its comment line; a newline in a device
+// model or timestamp would otherwise turn the rest of it into a command
+func shellCommentText(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
+
+func writeShellSleep(b *strings.Builder, ms int64) {
+	if ms <= 0 {
+		return
+	}
+	fmt.Fprintf(b, "sleep %d.%03d\n", ms/1000, ms%1000)
+}
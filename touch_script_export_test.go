@@ -0,0 +1,111 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTouchScriptShell(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  TouchScript
+		want    []string // lines expected in order
+		notWant []string
+	}{
+		{
+			name: "delays follow timestamps",
+			script: TouchScript{Name: "login", Events: []TouchEvent{
+				{Timestamp: 0, Type: "tap", X: 100, Y: 200},
+				{Timestamp: 1500, Type: "swipe", X: 10, Y: 20, X2: 30, Y2: 40, Duration: 300},
+				{Timestamp: 1500, Type: "tap", X: 1, Y: 2},
+			}},
+			want: []string{
+				`"$ADB" -s "$SERIAL" shell input tap 100 200`,
+				"sleep 1.500",
+				`"$ADB" -s "$SERIAL" shell input swipe 10 20 30 40 300`,
+				`"$ADB" -s "$SERIAL" shell input tap 1 2`,
+			},
+			notWant: []string{"sleep 0.000"},
+		},
+		{
+			name: "playback speed scales delays and waits",
+			script: TouchScript{Name: "fast", PlaybackSpeed: 2, Events: []TouchEvent{
+				{Timestamp: 2000, Type: "tap", X: 5, Y: 5},
+				{Timestamp: 2000, Type: "wait", Duration: 1000},
+			}},
+			want: []string{
+				"# Playback speed: 2x (already applied to the delays below)",
+				"sleep 1.000",
+				`"$ADB" -s "$SERIAL" shell input tap 5 5`,
+				"sleep 0.500",
+			},
+		},
+		{
+			name: "a wait counts toward the next event's delay",
+			script: TouchScript{Name: "waits", Events: []TouchEvent{
+				{Timestamp: 1000, Type: "wait", Duration: 2000},
+				{Timestamp: 3500, Type: "tap", X: 7, Y: 8},
+			}},
+			want: []string{
+				"sleep 1.000",
+				"sleep 2.000",
+				"sleep 0.500",
+				`"$ADB" -s "$SERIAL" shell input tap 7 8`,
+			},
+			notWant: []string{"sleep 2.500"},
+		},
+		{
+			name: "long press defaults and unsupported events",
+			script: TouchScript{Name: "hold", Events: []TouchEvent{
+				{Type: "long_press", X: 50, Y: 60, Duration: 100},
+				{Type: "key", X: 0, Y: 0},
+			}},
+			want: []string{
+				`"$ADB" -s "$SERIAL" shell input swipe 50 60 50 60 1000`,
+				`# skipped unsupported event type "key"`,
+			},
+		},
+		{
+			name: "metadata cannot break out of comments",
+			script: TouchScript{
+				Name:        "evil\nrm -rf ~",
+				DeviceModel: "Pixel 8\nrm -rf ~",
+				CreatedAt:   "2026-01-01\r\ncurl x | sh",
+				Resolution:  "1080x2400\nreboot",
+				Events: []TouchEvent{
+					{Type: "tap", X: 1, Y: 1, Selector: &ElementSelector{Type: "text", Value: "OK\"\n; reboot"}},
+				},
+			},
+			want: []string{
+				`# Touch script "evil\nrm -rf ~" exported from Gaze`,
+				"# Recorded on: Pixel 8 rm -rf ~",
+				"# Created: 2026-01-01  curl x | sh",
+				"# Source resolution: 1080x2400 reboot (coordinates are not rescaled for other screens)",
+				`# smart tap text="OK\"\n; reboot" replayed as coordinates`,
+			},
+			notWant: []string{"\nrm -rf ~", "\nreboot", "\ncurl", "\n; reboot"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderTouchScriptShell(tt.script)
+			if !strings.HasPrefix(got, "#!/usr/bin/env bash\n") || !strings.Contains(got, "set -euo pipefail\n") {
+				t.Fatalf("missing script preamble:\n%s", got)
+			}
+			rest := got
+			for _, line := range tt.want {
+				idx := strings.Index(rest, line+"\n")
+				if idx < 0 {
+					t.Fatalf("missing or out-of-order line %q in:\n%s", line, got)
+				}
+				rest = rest[idx+len(line):]
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(got, s) {
+					t.Errorf("unexpected %q in:\n%s", s, got)
+				}
+			}
+		})
+	}
+}
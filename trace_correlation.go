@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+
+	"Gaze/pkg/cache"
+
+	"github.com/google/uuid"
+)
+
+// ========================================
+// Touch -> logcat/network trace correlation
+// ========================================
+
+const (
+	DefaultTraceWindowMs = 2000
+	maxTraceWindowMs     = 60000
+)
+
+// SetTraceWindow sets how long (ms) after a touch logcat and network events are tagged
+// with its trace_id. A value <= 0 turns correlation off.
+func (p *EventPipeline) SetTraceWindow(ms int64) {
+	p.traceWindowMs.Store(ms)
+}
+
+// correlateTrace starts a new trace on touch events and tags logcat/network events that
+// arrive within the window with it. Must be called with sessionMu held.
+func (p *EventPipeline) correlateTrace(state *SessionState, event *UnifiedEvent) {
+	if event.TraceID != "" {
+		return
+	}
+	window := p.traceWindowMs.Load()
+	if window <= 0 {
+		return
+	}
+
+	switch event.Source {
+	case SourceTouch:
+		state.TraceID = uuid.New().String()
+		state.TraceStart = event.Timestamp
+		state.TraceUntil = event.Timestamp + window
+		event.TraceID = state.TraceID
+	case SourceLogcat, SourceNetwork:
+		if state.TraceID != "" && event.Timestamp >= state.TraceStart && event.Timestamp <= state.TraceUntil {
+			event.TraceID = state.TraceID
+		}
+	}
+}
+
+// GetTraceWindowMs returns the touch trace correlation window in milliseconds; 0 means
+// correlation is off
+func (a *App) GetTraceWindowMs() int {
+	if a.cacheService != nil {
+		if ms := a.cacheService.GetTraceWindowMs(); ms >= 0 {
+			return ms
+		}
+	}
+	return DefaultTraceWindowMs
+}
+
+// SetTraceWindowMs sets how long after a touch the resulting logcat lines and network
+// requests share its trace_id. Passing 0 turns correlation off and -1 restores the default.
+func (a *App) SetTraceWindowMs(ms int) (int, error) {
+	if ms < cache.TraceWindowDefault || ms > maxTraceWindowMs {
+		return a.GetTraceWindowMs(), fmt.Errorf("trace window must be -1 (default), 0 (off) or up to %d ms, got %d", maxTraceWindowMs, ms)
+	}
+	if a.cacheService == nil {
+		return a.GetTraceWindowMs(), fmt.Errorf("settings are not available")
+	}
+
+	a.cacheService.SetTraceWindowMs(ms)
+	go a.saveSettings()
+
+	window := a.GetTraceWindowMs()
+	if a.eventPipeline != nil {
+		a.eventPipeline.SetTraceWindow(int64(window))
+	}
+	if window == 0 {
+		a.Log("Trace correlation turned off")
+	} else {
+		a.Log("Trace correlation window set to %dms", window)
+	}
+	return window, nil
+}
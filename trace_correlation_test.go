@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+
+	"Gaze/pkg/cache"
+)
+
+func TestTraceWindowSetting(t *testing.T) {
+	dir := t.TempDir()
+	svc, err := cache.New(cache.Config{ConfigDir: dir})
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	a := newTestApp(nil)
+	a.cacheService = svc
+	a.eventPipeline = &EventPipeline{}
+
+	if got := a.GetTraceWindowMs(); got != DefaultTraceWindowMs {
+		t.Fatalf("default window = %d, want %d", got, DefaultTraceWindowMs)
+	}
+
+	if got, err := a.SetTraceWindowMs(500); err != nil || got != 500 {
+		t.Fatalf("SetTraceWindowMs(500) = %d, %v", got, err)
+	}
+	if got := a.eventPipeline.traceWindowMs.Load(); got != 500 {
+		t.Errorf("pipeline window = %d, want 500", got)
+	}
+
+	// 0 turns correlation off and survives a reload
+	if got, err := a.SetTraceWindowMs(0); err != nil || got != 0 {
+		t.Fatalf("SetTraceWindowMs(0) = %d, %v", got, err)
+	}
+	if got := a.eventPipeline.traceWindowMs.Load(); got != 0 {
+		t.Errorf("pipeline window = %d, want 0 (off)", got)
+	}
+	if err := svc.SaveSettings(); err != nil {
+		t.Fatalf("SaveSettings: %v", err)
+	}
+	reloaded, err := cache.New(cache.Config{ConfigDir: dir})
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	if got := reloaded.GetTraceWindowMs(); got != 0 {
+		t.Errorf("reloaded window = %d, want 0 (off)", got)
+	}
+
+	// -1 restores the default
+	if got, err := a.SetTraceWindowMs(cache.TraceWindowDefault); err != nil || got != DefaultTraceWindowMs {
+		t.Fatalf("SetTraceWindowMs(-1) = %d, %v; want the default", got, err)
+	}
+
+	for _, bad := range []int{-2, maxTraceWindowMs + 1} {
+		if _, err := a.SetTraceWindowMs(bad); err == nil {
+			t.Errorf("SetTraceWindowMs(%d) should fail", bad)
+		}
+	}
+	if got := a.GetTraceWindowMs(); got != DefaultTraceWindowMs {
+		t.Errorf("window after rejected values = %d, want %d", got, DefaultTraceWindowMs)
+	}
+}
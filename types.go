@@ -14,6 +14,7 @@ type Device struct {
 	WifiAddr   string   `json:"wifiAddr"`
 	LastActive int64    `json:"lastActive"`
 	IsPinned   bool     `json:"isPinned"`
+	Hint       string   `json:"hint,omitempty"` // Actionable advice for problem states such as no_permissions
 }
 
 // HistoryDevice represents a device in the connection history
@@ -87,6 +88,7 @@ type ScrcpyConfig struct {
 	StayAwake        bool   `json:"stayAwake"`
 	TurnScreenOff    bool   `json:"turnScreenOff"`
 	NoAudio          bool   `json:"noAudio"`
+	AudioOnly        bool   `json:"audioOnly"` // Stream device audio without video or a window
 	AlwaysOnTop      bool   `json:"alwaysOnTop"`
 	ShowTouches      bool   `json:"showTouches"`
 	Fullscreen       bool   `json:"fullscreen"`
@@ -96,6 +98,8 @@ type ScrcpyConfig struct {
 	VideoCodec       string `json:"videoCodec"`
 	AudioCodec       string `json:"audioCodec"`
 	RecordPath       string `json:"recordPath"`
+	MaxDuration      int    `json:"maxDuration"`     // Seconds; recording stops gracefully after this, 0 = no limit
+	SegmentDuration  int    `json:"segmentDuration"` // Seconds per file when splitting a recording, 0 = single file
 	// Advanced options
 	DisplayId          int    `json:"displayId"`
 	VideoSource        string `json:"videoSource"` // "display" or "camera"
@@ -110,10 +114,41 @@ type ScrcpyConfig struct {
 	NoPowerOn          bool   `json:"noPowerOn"`
 }
 
-// AppSettings contains persistent application settings
-type AppSettings struct {
-	LastActive   map[string]int64 `json:"lastActive"`
-	PinnedSerial string           `json:"pinnedSerial"`
+// ConcurrencySettings controls how many background workers run at once
+type ConcurrencySettings struct {
+	Thumbnails int `json:"thumbnails"` // Label/icon loading for app lists
+	Aapt       int `json:"aapt"`       // APK pulls + aapt parsing
+	Shell      int `json:"shell"`      // Per-device shell fan-out (batch operations)
+}
+
+// LogcatBufferingSettings controls how logcat lines are batched into events
+type LogcatBufferingSettings struct {
+	MaxChunk        int `json:"maxChunk"`        // Lines per event before a forced flush
+	FlushIntervalMs int `json:"flushIntervalMs"` // Quiet time after which a batch is flushed
+}
+
+// AdbServerSettings is the adb server the app's adb commands connect to
+type AdbServerSettings struct {
+	Host string `json:"host"` // Empty = local server
+	Port int    `json:"port"`
+}
+
+// ReconnectSettings tunes how aggressively dropped wireless devices are reconnected
+type ReconnectSettings struct {
+	CooldownSec int `json:"reconnectCooldownSec"` // Base delay between attempts, doubled after each failure
+	TimeoutSec  int `json:"reconnectTimeoutSec"`  // Timeout for a single adb connect
+	MaxAttempts int `json:"maxAttempts"`          // Consecutive failures before giving up
+}
+
+// ReconnectStats reports auto-reconnect progress for one wireless address
+type ReconnectStats struct {
+	Address     string `json:"address"`
+	Attempts    int    `json:"attempts"`    // Total attempts since the app started
+	Failures    int    `json:"failures"`    // Consecutive failures since the last success
+	LastAttempt int64  `json:"lastAttempt"` // Unix ms, 0 = never
+	NextAttempt int64  `json:"nextAttempt"` // Unix ms, 0 = no attempt scheduled
+	LastError   string `json:"lastError,omitempty"`
+	GaveUp      bool   `json:"gaveUp"`
 }
 
 // BatchOperation represents a batch operation to execute on multiple devices
@@ -143,6 +178,15 @@ type BatchOperationResult struct {
 	Results      []BatchResult `json:"results"`
 }
 
+// BatchScreenshotResult lists the screenshots saved by BatchScreenshot. Devices whose
+// screen was off or locked are skipped; other failures, including secure windows that
+// block capture, are reported per device.
+type BatchScreenshotResult struct {
+	Paths   []string          `json:"paths"`
+	Skipped []string          `json:"skipped"`
+	Errors  map[string]string `json:"errors"` // deviceId -> error
+}
+
 // TouchEvent represents a single touch event in an automation script
 type TouchEvent struct {
 	Timestamp int64            `json:"timestamp"` // Relative time in milliseconds from script start
@@ -153,6 +197,15 @@ type TouchEvent struct {
 	Y2        int              `json:"y2,omitempty"`       // End Y for swipe
 	Duration  int              `json:"duration,omitempty"` // Duration in ms for swipe or wait
 	Selector  *ElementSelector `json:"selector,omitempty"` // Unified selector for smart tap
+	// Element expected on screen after this event; verified during playback
+	Checkpoint *PlaybackCheckpoint `json:"checkpoint,omitempty"`
+}
+
+// PlaybackCheckpoint is an element that must appear after a touch event during playback
+type PlaybackCheckpoint struct {
+	Selector  ElementSelector `json:"selector"`
+	TimeoutMs int             `json:"timeoutMs,omitempty"` // How long to wait for the element (default: 3000)
+	OnFailure string          `json:"onFailure,omitempty"` // "stop" (default), "pause", "continue"
 }
 
 // TouchScript represents a recorded touch automation script
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// AndroidUser is a user or profile from `pm list users`
+type AndroidUser struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Flags       int    `json:"flags"`
+	Running     bool   `json:"running"`
+	WorkProfile bool   `json:"workProfile"`
+}
+
+// userFlagManagedProfile is UserInfo.FLAG_MANAGED_PROFILE (work profile)
+const userFlagManagedProfile = 0x20
+
+// ListUsers returns the users and profiles on the device (user 0 is the owner)
+func (a *App) ListUsers(deviceId string) ([]AndroidUser, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	output, _, err := a.runAdb(ctx, "-s", deviceId, "shell", "pm", "list", "users")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := parseUserList(string(output))
+	if len(users) == 0 {
+		return nil, fmt.Errorf("no users found in pm output")
+	}
+	return users, nil
+}
+
+// "UserInfo{0:Owner:c13} running" / "UserInfo{10:Work profile:1030}"
+var userInfoRegex = regexp.MustCompile(`UserInfo\{(\d+):(.*):([0-9a-fA-F]+)\}(\s+running)?`)
+
+func parseUserList(output string) []AndroidUser {
+	var users []AndroidUser
+	for _, m := range userInfoRegex.FindAllStringSubmatch(output, -1) {
+		id, _ := strconv.Atoi(m[1])
+		flags, _ := strconv.ParseInt(m[3], 16, 64)
+		users = append(users, AndroidUser{
+			ID:          id,
+			Name:        m[2],
+			Flags:       int(flags),
+			Running:     m[4] != "",
+			WorkProfile: flags&userFlagManagedProfile != 0,
+		})
+	}
+	return users
+}
+
+// userArgs returns the pm/install "--user N" arguments. User 0 adds nothing so
+// commands stay identical to the single-user behaviour.
+func userArgs(userId int) []string {
+	if userId <= 0 {
+		return nil
+	}
+	return []string{"--user", strconv.Itoa(userId)}
+}
+
+func validateUserID(userId int) error {
+	if userId < 0 {
+		return fmt.Errorf("invalid user id: %d", userId)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseUserList(t *testing.T) {
+	const output = `Users:
+	UserInfo{0:Owner:c13} running
+	UserInfo{10:Work profile:1030} running
+	UserInfo{11:Guest: Visitor:414}
+`
+	want := []AndroidUser{
+		{ID: 0, Name: "Owner", Flags: 0xc13, Running: true},
+		{ID: 10, Name: "Work profile", Flags: 0x1030, Running: true, WorkProfile: true},
+		{ID: 11, Name: "Guest: Visitor", Flags: 0x414},
+	}
+
+	got := parseUserList(output)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseUserList() = %+v, want %+v", got, want)
+	}
+
+	if users := parseUserList(""); len(users) != 0 {
+		t.Errorf("expected no users for empty output, got %+v", users)
+	}
+}
+
+func TestUserArgs(t *testing.T) {
+	if args := userArgs(0); args != nil {
+		t.Errorf("userArgs(0) = %v, want nil", args)
+	}
+	if args := userArgs(10); !reflect.DeepEqual(args, []string{"--user", "10"}) {
+		t.Errorf("userArgs(10) = %v", args)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WirelessInfo describes how a device is reachable over the network
+type WirelessInfo struct {
+	DeviceID string `json:"deviceId"`
+	IP       string `json:"ip"`
+	Port     int    `json:"port"`
+	Address  string `json:"address"`  // ip:port
+	Mode     string `json:"mode"`     // "tcpip" (adb tcpip) or "tls" (Android 11+ wireless debugging)
+	Wireless bool   `json:"wireless"` // false if deviceId is a USB connection
+}
+
+// GetWirelessInfo returns the IP:port a device is reachable on. For wireless connections
+// this is the address adb is connected to; for USB devices it is the device's Wi-Fi IP
+// together with the adb tcpip port, if tcpip mode is enabled.
+func (a *App) GetWirelessInfo(deviceId string) (WirelessInfo, error) {
+	if err := ValidateDeviceID(deviceId); err != nil {
+		return WirelessInfo{}, fmt.Errorf("invalid device ID: %w", err)
+	}
+	info := WirelessInfo{DeviceID: deviceId}
+
+	// Classic "adb connect ip:port"
+	if ip, port, ok := splitWirelessAddress(deviceId); ok {
+		info.IP, info.Port, info.Mode, info.Wireless = ip, port, "tcpip", true
+		info.Address = net.JoinHostPort(ip, strconv.Itoa(port))
+		return info, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Wireless debugging connections are named after their mDNS service
+	if strings.Contains(deviceId, "._tcp") {
+		info.Mode, info.Wireless = "tls", true
+		if out, _, err := a.runAdb(ctx, "mdns", "services"); err == nil {
+			if addr, ok := parseMDNSServiceAddress(string(out), deviceId); ok {
+				info.IP, info.Port, _ = splitWirelessAddress(addr)
+				info.Address = addr
+				return info, nil
+			}
+		}
+		// Fall back to asking the device
+		ip, err := a.GetDeviceIP(deviceId)
+		if err != nil {
+			return info, err
+		}
+		out, _, _ := a.runAdb(ctx, "-s", deviceId, "shell", "getprop", "service.adb.tls.port")
+		port, _ := strconv.Atoi(strings.TrimSpace(string(out)))
+		if port <= 0 {
+			return info, fmt.Errorf("could not determine the wireless debugging port of %s", deviceId)
+		}
+		info.IP, info.Port = ip, port
+		info.Address = net.JoinHostPort(ip, strconv.Itoa(port))
+		return info, nil
+	}
+
+	// USB: report where the device would be reachable once tcpip mode is on
+	ip, err := a.GetDeviceIP(deviceId)
+	if err != nil {
+		return info, err
+	}
+	info.IP, info.Mode = ip, "tcpip"
+	out, _, _ := a.runAdb(ctx, "-s", deviceId, "shell", "getprop", "service.adb.tcp.port")
+	if port, _ := strconv.Atoi(strings.TrimSpace(string(out))); port > 0 {
+		info.Port = port
+		info.Address = net.JoinHostPort(ip, strconv.Itoa(port))
+	}
+	return info, nil
+}
+
+// ChangeWirelessPort moves a wirelessly connected device to another adb tcpip port
+// without a USB cable: it runs `adb tcpip <port>` over the current connection, then
+// connects to the new address. Returns the output of the new connect.
+func (a *App) ChangeWirelessPort(deviceId string, newPort int) (string, error) {
+	if newPort < 1024 || newPort > 65535 {
+		return "", fmt.Errorf("port must be between 1024 and 65535, got %d", newPort)
+	}
+
+	info, err := a.GetWirelessInfo(deviceId)
+	if err != nil {
+		return "", err
+	}
+	if !info.Wireless {
+		return "", fmt.Errorf("%s is not connected wirelessly, use SwitchToWireless instead", deviceId)
+	}
+	if info.IP == "" {
+		return "", fmt.Errorf("could not determine the IP address of %s", deviceId)
+	}
+	if info.Mode == "tcpip" && info.Port == newPort {
+		return "already connected to " + info.Address, nil
+	}
+
+	newAddress := net.JoinHostPort(info.IP, strconv.Itoa(newPort))
+	a.Log("Changing wireless port of %s: %s -> %s", deviceId, info.Address, newAddress)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// adbd restarts on the new port, so the current connection drops right after this
+	if out, err := a.runAdbCombined(ctx, "-s", deviceId, "tcpip", strconv.Itoa(newPort)); err != nil {
+		return string(out), fmt.Errorf("failed to switch tcpip port: %w", err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	if info.Mode == "tcpip" {
+		// The old address is dead; drop it so it doesn't linger as offline
+		_, _ = a.runAdbCombined(ctx, "disconnect", deviceId)
+	}
+	return a.AdbConnect(newAddress)
+}
+
+// splitWirelessAddress parses "ip:port" device IDs
+func splitWirelessAddress(addr string) (string, int, bool) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil || net.ParseIP(host) == nil {
+		return "", 0, false
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port <= 0 || port > 65535 {
+		return "", 0, false
+	}
+	return host, port, true
+}
+
+// parseMDNSServiceAddress finds the ip:port of a service in `adb mdns services` output, e.g.
+// "adb-R5CT1234ABC-Xy7Qz1	_adb-tls-connect._tcp	192.168.1.20:37891"
+func parseMDNSServiceAddress(output, deviceId string) (string, bool) {
+	deviceId = strings.TrimSuffix(deviceId, ".")
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		// adb names the device "<instance>.<service>", mdns services lists them separately
+		if fields[0]+"."+strings.TrimSuffix(fields[1], ".") != deviceId && fields[0] != deviceId {
+			continue
+		}
+		if _, _, ok := splitWirelessAddress(fields[2]); ok {
+			return fields[2], true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ========================================
+// QR code pairing (Android 11+)
+// ========================================
+
+// Scanning the QR under Developer options > Wireless debugging > Pair device with QR
+// code makes the phone advertise a _adb-tls-pairing._tcp service named after the QR's
+// S: field. We watch for that name through adb's mDNS and pair with the P: password.
+var (
+	pairingQRTimeout      = 2 * time.Minute
+	pairingQRPollInterval = time.Second
+	// How long to wait for the connect service after pairing before giving up on it
+	pairingConnectWait = 10 * time.Second
+)
+
+const pairingAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+var (
+	pairingQRCancel context.CancelFunc
+	pairingQRMu     sync.Mutex
+)
+
+// AdbPairingQR is what the frontend needs to render and describe a pairing QR
+type AdbPairingQR struct {
+	Payload   string `json:"payload"` // Text to encode in the QR code
+	Name      string `json:"name"`
+	Password  string `json:"password"`
+	ExpiresAt int64  `json:"expiresAt"` // Unix ms
+}
+
+// GenerateAdbPairingQR creates a pairing name and password and waits in the background
+// for a device to scan the QR. On success "wireless-paired" is emitted and the device
+// is connected; "wireless-pair-failed" is emitted on a pairing error or timeout.
+// Generating a new QR cancels the previous one.
+func (a *App) GenerateAdbPairingQR() (AdbPairingQR, error) {
+	if a.adbPath == "" {
+		return AdbPairingQR{}, fmt.Errorf("ADB path is not initialized")
+	}
+	suffix, err := randomPairingString(6)
+	if err != nil {
+		return AdbPairingQR{}, err
+	}
+	password, err := randomPairingString(10)
+	if err != nil {
+		return AdbPairingQR{}, err
+	}
+	name := "gaze-" + suffix
+
+	pairingQRMu.Lock()
+	if pairingQRCancel != nil {
+		pairingQRCancel()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pairingQRTimeout)
+	pairingQRCancel = cancel
+	pairingQRMu.Unlock()
+
+	go func() {
+		defer cancel()
+		result, err := a.waitForQRPairing(ctx, name, password)
+		if err != nil {
+			LogWarn("wireless").Err(err).Str("name", name).Msg("QR pairing failed")
+			a.emitWirelessEvent("wireless-pair-failed", map[string]string{"name": name, "error": err.Error()})
+			return
+		}
+		a.emitWirelessEvent("wireless-paired", result)
+	}()
+
+	return AdbPairingQR{
+		Payload:   pairingQRPayload(name, password),
+		Name:      name,
+		Password:  password,
+		ExpiresAt: time.Now().Add(pairingQRTimeout).UnixMilli(),
+	}, nil
+}
+
+// CancelAdbPairingQR stops waiting for a device to scan the current pairing QR
+func (a *App) CancelAdbPairingQR() {
+	pairingQRMu.Lock()
+	defer pairingQRMu.Unlock()
+	if pairingQRCancel != nil {
+		pairingQRCancel()
+		pairingQRCancel = nil
+	}
+}
+
+// pairingQRPayload formats the Wi-Fi style payload Android's QR scanner expects
+func pairingQRPayload(name, password string) string {
+	return fmt.Sprintf("WIFI:T:ADB;S:%s;P:%s;;", name, password)
+}
+
+func randomPairingString(n int) (string, error) {
+	b := make([]byte, n)
+	max := big.NewInt(int64(len(pairingAlphabet)))
+	for i := range b {
+		idx, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate pairing secret: %w", err)
+		}
+		b[i] = pairingAlphabet[idx.Int64()]
+	}
+	return string(b), nil
+}
+
+// waitForQRPairing polls mDNS for the pairing service named name, pairs with it and
+// then connects to the device's connect service on the same host when it shows up
+func (a *App) waitForQRPairing(ctx context.Context, name, password string) (map[string]string, error) {
+	pairAddr, err := a.pollMdnsService(ctx, func(s MdnsService) bool {
+		return s.Pairing && strings.TrimSuffix(s.Name, ".") == name
+	})
+	if err != nil {
+		if ctx.Err() == context.Canceled {
+			return nil, fmt.Errorf("pairing cancelled")
+		}
+		return nil, fmt.Errorf("no device scanned the pairing code: %w", err)
+	}
+
+	output, err := a.AdbPair(pairAddr, password)
+	if err != nil {
+		return nil, err
+	}
+	a.Log("Paired with %s via QR: %s", pairAddr, strings.TrimSpace(output))
+
+	result := map[string]string{"name": name, "pairAddress": pairAddr}
+
+	// adb usually auto-connects paired devices it sees over mDNS; connect explicitly
+	// in case it doesn't
+	host, _, _ := net.SplitHostPort(pairAddr)
+	connectCtx, cancel := context.WithTimeout(ctx, pairingConnectWait)
+	defer cancel()
+	connectAddr, err := a.pollMdnsService(connectCtx, func(s MdnsService) bool {
+		h, _, _ := net.SplitHostPort(s.Address)
+		return s.Type == mdnsTypeConnect && h == host
+	})
+	if err == nil {
+		result["address"] = connectAddr
+		if _, err := a.AdbConnect(connectAddr); err != nil {
+			LogWarn("wireless").Err(err).Str("address", connectAddr).Msg("Connect after QR pairing failed")
+		}
+	}
+	return result, nil
+}
+
+// pollMdnsService returns the address of the first mDNS service matching match
+func (a *App) pollMdnsService(ctx context.Context, match func(MdnsService) bool) (string, error) {
+	ticker := time.NewTicker(pairingQRPollInterval)
+	defer ticker.Stop()
+	for {
+		output, err := a.runAdbCombined(ctx, "mdns", "services")
+		if err := mdnsUnavailableError(string(output), err); err != nil && ctx.Err() == nil {
+			return "", err
+		}
+		for _, s := range parseMdnsServices(string(output), nil) {
+			if match(s) {
+				return s.Address, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *App) emitWirelessEvent(name string, data interface{}) {
+	if a.mcpMode || a.ctx == nil {
+		return
+	}
+	wailsRuntime.EventsEmit(a.ctx, name, data)
+}
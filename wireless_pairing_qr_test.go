@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPairingQRPayload(t *testing.T) {
+	if got := pairingQRPayload("gaze-abc123", "s3cretPass"); got != "WIFI:T:ADB;S:gaze-abc123;P:s3cretPass;;" {
+		t.Errorf("payload = %q", got)
+	}
+	s, err := randomPairingString(10)
+	if err != nil || len(s) != 10 || strings.Trim(s, pairingAlphabet) != "" {
+		t.Errorf("randomPairingString = %q, %v", s, err)
+	}
+}
+
+func TestWaitForQRPairing(t *testing.T) {
+	oldPoll, oldWait := pairingQRPollInterval, pairingConnectWait
+	pairingQRPollInterval, pairingConnectWait = 10*time.Millisecond, 30*time.Millisecond
+	defer func() { pairingQRPollInterval, pairingConnectWait = oldPoll, oldWait }()
+
+	app := newTestApp(map[string]string{
+		"mdns services": "List of discovered mdns services\n" +
+			"adb-R5CT1234ABC-Xy7Qz1\t_adb-tls-connect._tcp\t192.168.1.30:41111\n" +
+			"gaze-abc123\t_adb-tls-pairing._tcp\t192.168.1.20:37000\n",
+		"pair 192.168.1.20:37000 s3cretPass": "Successfully paired to 192.168.1.20:37000 [guid=adb-R5CT1234ABC-Xy7Qz1]\n",
+	})
+
+	result, err := app.waitForQRPairing(context.Background(), "gaze-abc123", "s3cretPass")
+	if err != nil {
+		t.Fatalf("waitForQRPairing: %v", err)
+	}
+	if result["pairAddress"] != "192.168.1.20:37000" {
+		t.Errorf("pairAddress = %q", result["pairAddress"])
+	}
+	// The connect service belongs to another host, so nothing is connected
+	if _, ok := result["address"]; ok {
+		t.Errorf("unexpected connect address %q", result["address"])
+	}
+}
+
+func TestWaitForQRPairingTimesOut(t *testing.T) {
+	oldPoll := pairingQRPollInterval
+	pairingQRPollInterval = 10 * time.Millisecond
+	defer func() { pairingQRPollInterval = oldPoll }()
+
+	app := newTestApp(map[string]string{"mdns services": "List of discovered mdns services\n"})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := app.waitForQRPairing(ctx, "gaze-abc123", "s3cretPass"); err == nil {
+		t.Error("expected a timeout error")
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import "testing"
+
+const mdnsServicesOutput = `List of discovered mdns services
+adb-R5CT1234ABC-Xy7Qz1	_adb-tls-connect._tcp	192.168.1.20:37891
+adb-R5CT1234ABC-Xy7Qz1	_adb-tls-pairing._tcp	192.168.1.20:41023
+`
+
+func TestGetWirelessInfo(t *testing.T) {
+	app := newTestApp(map[string]string{
+		"mdns services": mdnsServicesOutput,
+	})
+
+	info, err := app.GetWirelessInfo("192.168.1.20:5555")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Wireless || info.Mode != "tcpip" || info.IP != "192.168.1.20" || info.Port != 5555 {
+		t.Errorf("tcpip info = %+v", info)
+	}
+
+	info, err = app.GetWirelessInfo("adb-R5CT1234ABC-Xy7Qz1._adb-tls-connect._tcp.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode != "tls" || info.Address != "192.168.1.20:37891" || info.Port != 37891 {
+		t.Errorf("tls info = %+v", info)
+	}
+}
+
+func TestChangeWirelessPortRejectsBadInput(t *testing.T) {
+	app := newTestApp(nil)
+	if _, err := app.ChangeWirelessPort("192.168.1.20:5555", 80); err == nil {
+		t.Error("privileged port should be rejected")
+	}
+	if out, err := app.ChangeWirelessPort("192.168.1.20:5555", 5555); err != nil || out != "already connected to 192.168.1.20:5555" {
+		t.Errorf("same port: out=%q err=%v", out, err)
+	}
+}
+
+func TestSplitWirelessAddress(t *testing.T) {
+	if ip, port, ok := splitWirelessAddress("10.0.0.2:40001"); !ok || ip != "10.0.0.2" || port != 40001 {
+		t.Errorf("got %s %d %v", ip, port, ok)
+	}
+	for _, bad := range []string{"R5CT1234ABC", "emulator-5554", "host:5555", "10.0.0.2:0"} {
+		if _, _, ok := splitWirelessAddress(bad); ok {
+			t.Errorf("%q should not parse as a wireless address", bad)
+		}
+	}
+}
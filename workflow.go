@@ -676,6 +676,9 @@ func (a *App) executeStep(ctx context.Context, deviceId string, step *WorkflowSt
 	case "launch_app", "stop_app", "clear_app", "open_settings":
 		return a.executeAppStep(deviceId, step)
 
+	case "install_if_missing":
+		return a.executeInstallIfMissingStep(deviceId, step, vars)
+
 	case "branch":
 		return a.executeBranchStep(deviceId, step, vars)
 
@@ -721,35 +724,35 @@ func (a *App) executeStep(ctx context.Context, deviceId string, step *WorkflowSt
 		return StepResult{Success: err == nil, Error: err}
 
 	case "key_back":
-		_, err := a.RunAdbCommand(deviceId, "shell input keyevent 4")
+		err := a.SendKeyEvent(deviceId, "BACK")
 		return StepResult{Success: err == nil, Error: err}
 
 	case "key_home":
-		_, err := a.RunAdbCommand(deviceId, "shell input keyevent 3")
+		err := a.SendKeyEvent(deviceId, "HOME")
 		return StepResult{Success: err == nil, Error: err}
 
 	case "key_recent":
-		_, err := a.RunAdbCommand(deviceId, "shell input keyevent 187")
+		err := a.SendKeyEvent(deviceId, "APP_SWITCH")
 		return StepResult{Success: err == nil, Error: err}
 
 	case "key_power":
-		_, err := a.RunAdbCommand(deviceId, "shell input keyevent 26")
+		err := a.SendKeyEvent(deviceId, "POWER")
 		return StepResult{Success: err == nil, Error: err}
 
 	case "key_volume_up":
-		_, err := a.RunAdbCommand(deviceId, "shell input keyevent 24")
+		err := a.SendKeyEvent(deviceId, "VOLUME_UP")
 		return StepResult{Success: err == nil, Error: err}
 
 	case "key_volume_down":
-		_, err := a.RunAdbCommand(deviceId, "shell input keyevent 25")
+		err := a.SendKeyEvent(deviceId, "VOLUME_DOWN")
 		return StepResult{Success: err == nil, Error: err}
 
 	case "screen_on":
-		_, err := a.RunAdbCommand(deviceId, "shell input keyevent 224")
+		err := a.SendKeyEvent(deviceId, "WAKEUP")
 		return StepResult{Success: err == nil, Error: err}
 
 	case "screen_off":
-		_, err := a.RunAdbCommand(deviceId, "shell input keyevent 223")
+		err := a.SendKeyEvent(deviceId, "SLEEP")
 		return StepResult{Success: err == nil, Error: err}
 
 	case "start_session":
@@ -870,7 +873,7 @@ func (a *App) executeAppStep(deviceId string, step *WorkflowStep) StepResult {
 	case "stop":
 		_, err = a.ForceStopApp(deviceId, step.App.PackageName)
 	case "clear":
-		_, err = a.ClearAppData(deviceId, step.App.PackageName)
+		_, err = a.ClearAppData(deviceId, step.App.PackageName, "")
 	case "settings":
 		_, err = a.OpenSettings(deviceId, "android.settings.APPLICATION_DETAILS_SETTINGS", "package:"+step.App.PackageName)
 	default:
@@ -880,6 +883,36 @@ func (a *App) executeAppStep(deviceId string, step *WorkflowStep) StepResult {
 	return StepResult{Success: err == nil, Error: err}
 }
 
+// executeInstallIfMissingStep installs the step's APK unless the package is already present
+func (a *App) executeInstallIfMissingStep(deviceId string, step *WorkflowStep, vars map[string]string) StepResult {
+	if step.App == nil {
+		return StepResult{Success: false, Error: fmt.Errorf("app params missing")}
+	}
+	packageName := a.processWorkflowVariables(step.App.PackageName, vars)
+	apkPath := a.processWorkflowVariables(step.App.ApkPath, vars)
+
+	installed, err := a.IsAppInstalled(deviceId, packageName)
+	if err != nil {
+		return StepResult{Success: false, Error: err}
+	}
+	if installed {
+		if !a.mcpMode {
+			wailsRuntime.EventsEmit(a.ctx, "step-skipped", map[string]interface{}{
+				"deviceId": deviceId,
+				"stepId":   step.ID,
+				"stepType": step.Type,
+				"reason":   fmt.Sprintf("%s is already installed", packageName),
+			})
+		}
+		return StepResult{Success: true}
+	}
+
+	if _, err := a.InstallAPK(deviceId, apkPath, 0); err != nil {
+		return StepResult{Success: false, Error: err}
+	}
+	return StepResult{Success: true}
+}
+
 // executeBranchStep handles branch condition evaluation
 func (a *App) executeBranchStep(deviceId string, step *WorkflowStep, vars map[string]string) StepResult {
 	if step.Branch == nil {